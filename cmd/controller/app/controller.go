@@ -27,6 +27,7 @@ import (
 
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/cache"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/leaderelection"
@@ -36,10 +37,13 @@ import (
 
 	"github.com/cert-manager/cert-manager/cmd/controller/app/options"
 	cmdutil "github.com/cert-manager/cert-manager/internal/cmd/util"
+	"github.com/cert-manager/cert-manager/internal/controller/approval"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/internal/credentials"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
+	acmeissuer "github.com/cert-manager/cert-manager/pkg/issuer/acme"
 	dnsutil "github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
@@ -54,6 +58,12 @@ func Run(opts *options.ControllerOptions, stopCh <-chan struct{}) error {
 	log := logf.FromContext(rootCtx)
 	g, rootCtx := errgroup.WithContext(rootCtx)
 
+	keyService, err := credentials.NewKeyService(rootCtx, opts.CredentialsKMSPluginEndpoint, opts.CredentialsEncryptionKeyFile)
+	if err != nil {
+		return fmt.Errorf("error setting up credentials encryption: %v", err)
+	}
+	credentials.SetKeyService(keyService)
+
 	ctxFactory, err := buildControllerContextFactory(rootCtx, opts)
 	if err != nil {
 		return err
@@ -262,6 +272,18 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 	}
 
 	ACMEHTTP01SolverRunAsNonRoot := opts.ACMEHTTP01SolverRunAsNonRoot
+
+	var approvalPolicy *approval.Config
+	if len(opts.ApprovalPolicyFile) > 0 {
+		approvalPolicy, err = approval.LoadConfig(opts.ApprovalPolicyFile)
+		if err != nil {
+			return nil, err
+		}
+		log.V(logf.InfoLevel).WithName("build-context").
+			WithValues("file", opts.ApprovalPolicyFile, "policies", len(approvalPolicy.Policies)).
+			Info("loaded approval policies")
+	}
+
 	acmeAccountRegistry := accounts.NewDefaultRegistry()
 
 	ctxFactory, err := controller.NewContextFactory(ctx, controller.ContextOptions{
@@ -290,6 +312,8 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 			DNS01CheckAuthoritative: !opts.DNS01RecursiveNameserversOnly,
 
 			AccountRegistry: acmeAccountRegistry,
+
+			VerifiedCloudflareTokens: cache.NewLRUExpireCache(acmeissuer.CloudflareTokenCacheSize),
 		},
 
 		SchedulerOptions: controller.SchedulerOptions{
@@ -312,6 +336,28 @@ func buildControllerContextFactory(ctx context.Context, opts *options.Controller
 		CertificateOptions: controller.CertificateOptions{
 			EnableOwnerRef:           opts.EnableCertificateOwnerRef,
 			CopiedAnnotationPrefixes: opts.CopiedAnnotationPrefixes,
+
+			IssuanceReceiptSigningKeySecretNamespace: opts.ClusterResourceNamespace,
+			IssuanceReceiptSigningKeySecretName:      opts.IssuanceReceiptSigningKeySecretName,
+		},
+
+		ApproverOptions: controller.ApproverOptions{
+			ApprovalPolicy: approvalPolicy,
+		},
+
+		VenafiOptions: controller.VenafiOptions{
+			RetireSupersededCertificates: opts.VenafiRetireSupersededCertificates,
+			RetirementSkipZones:          opts.VenafiRetirementSkipZones,
+		},
+		CTMonitorOptions: controller.CTMonitorOptions{
+			LogURLs:      opts.CTMonitorLogURLs,
+			Zones:        opts.CTMonitorZones,
+			PollInterval: opts.CTMonitorPollInterval,
+		},
+		CanaryOptions: controller.CanaryOptions{
+			Interval:            opts.CanaryInterval,
+			Timeout:             opts.CanaryTimeout,
+			CertificateDuration: opts.CanaryCertificateDuration,
 		},
 	})
 	if err != nil {
@@ -29,16 +29,19 @@ import (
 	cmdutil "github.com/cert-manager/cert-manager/internal/cmd/util"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cm "github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	challengescontroller "github.com/cert-manager/cert-manager/pkg/controller/acmechallenges"
 	orderscontroller "github.com/cert-manager/cert-manager/pkg/controller/acmeorders"
 	shimgatewaycontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/gateways"
 	shimingresscontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/ingresses"
+	shimtlsroutecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim/tlsroutes"
 	cracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/acme"
 	crapprovercontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/approver"
 	crcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/ca"
 	crselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/selfsigned"
 	crvaultcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/vault"
 	crvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/venafi"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/ctmonitor"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/keymanager"
 	certificatesmetricscontroller "github.com/cert-manager/cert-manager/pkg/controller/certificates/metrics"
@@ -46,13 +49,17 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/requestmanager"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/revisionmanager"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/trigger"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/venafiretirement"
 	csracmecontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/acme"
+	csrapprovercontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/approver"
 	csrcacontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/ca"
 	csrselfsignedcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/selfsigned"
 	csrvaultcontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/vault"
 	csrvenaficontroller "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/venafi"
 	clusterissuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/clusterissuers"
 	issuerscontroller "github.com/cert-manager/cert-manager/pkg/controller/issuers"
+	issuerscanary "github.com/cert-manager/cert-manager/pkg/controller/issuers/canary"
+	sshcertificatescontroller "github.com/cert-manager/cert-manager/pkg/controller/sshcertificates"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -121,6 +128,53 @@ type ControllerOptions struct {
 	// CertificateRequest -> Order. Slice of string literals that are
 	// treated as prefixes for annotation keys.
 	CopiedAnnotationPrefixes []string
+
+	// IssuanceReceiptSigningKeySecretName is the name of a Secret in the
+	// cluster resource namespace holding the private key used to sign the
+	// issuance receipts of Certificates. Receipts are not created if empty.
+	IssuanceReceiptSigningKeySecretName string
+
+	// CredentialsKMSPluginEndpoint is the endpoint of a KMS v2 plugin used
+	// to decrypt envelope encrypted issuer and DNS provider credentials.
+	CredentialsKMSPluginEndpoint string
+
+	// CredentialsEncryptionKeyFile is the path to a file containing a local
+	// AES-256 key used instead of a KMS plugin to decrypt envelope encrypted
+	// issuer and DNS provider credentials.
+	CredentialsEncryptionKeyFile string
+
+	// ApprovalPolicyFile is the path to a file containing the approval
+	// policies evaluated by the CertificateRequest and
+	// CertificateSigningRequest approver controllers.
+	ApprovalPolicyFile string
+
+	// VenafiRetireSupersededCertificates controls whether the Venafi
+	// retirement controller retires the certificates of previous revisions
+	// once a Certificate has been reissued.
+	VenafiRetireSupersededCertificates bool
+	// VenafiRetirementSkipZones is the list of Venafi zones for which the
+	// Venafi retirement controller never retires certificates.
+	VenafiRetirementSkipZones []string
+
+	// CTMonitorLogURLs is the list of Certificate Transparency logs polled by
+	// the CT monitor controller.
+	CTMonitorLogURLs []string
+	// CTMonitorZones is a list of additional DNS zones monitored by the CT
+	// monitor controller.
+	CTMonitorZones []string
+	// CTMonitorPollInterval is the interval at which the CT monitor
+	// controller polls each log for new entries.
+	CTMonitorPollInterval time.Duration
+
+	// CanaryInterval is the interval at which the issuers canary controller
+	// creates a CertificateRequest for each selected issuer.
+	CanaryInterval time.Duration
+	// CanaryTimeout is the time after which a canary CertificateRequest which
+	// has not been issued is counted as failed.
+	CanaryTimeout time.Duration
+	// CanaryCertificateDuration is the duration requested for canary
+	// certificates.
+	CanaryCertificateDuration time.Duration
 }
 
 const (
@@ -148,6 +202,12 @@ const (
 
 	// default time period to wait between checking DNS01 and HTTP01 challenge propagation
 	defaultDNS01CheckRetryPeriod = 10 * time.Second
+
+	defaultCTMonitorPollInterval = 5 * time.Minute
+
+	defaultCanaryInterval            = 24 * time.Hour
+	defaultCanaryTimeout             = 10 * time.Minute
+	defaultCanaryCertificateDuration = time.Hour
 )
 
 var (
@@ -166,6 +226,7 @@ var (
 		certificatesmetricscontroller.ControllerName,
 		shimingresscontroller.ControllerName,
 		shimgatewaycontroller.ControllerName,
+		shimtlsroutecontroller.ControllerName,
 		orderscontroller.ControllerName,
 		challengescontroller.ControllerName,
 		cracmecontroller.CRControllerName,
@@ -174,6 +235,14 @@ var (
 		crselfsignedcontroller.CRControllerName,
 		crvaultcontroller.CRControllerName,
 		crvenaficontroller.CRControllerName,
+		// certificatesigningrequest controllers, which may be enabled
+		// individually now that their annotations are validated by the webhook
+		csracmecontroller.CSRControllerName,
+		csrapprovercontroller.CSRControllerName,
+		csrcacontroller.CSRControllerName,
+		csrselfsignedcontroller.CSRControllerName,
+		csrvenaficontroller.CSRControllerName,
+		csrvaultcontroller.CSRControllerName,
 		// certificate controllers
 		trigger.ControllerName,
 		issuing.ControllerName,
@@ -181,6 +250,12 @@ var (
 		requestmanager.ControllerName,
 		readiness.ControllerName,
 		revisionmanager.ControllerName,
+		venafiretirement.ControllerName,
+		ctmonitor.ControllerName,
+		// issuer controllers
+		issuerscanary.ControllerName,
+		// sshcertificate controllers
+		sshcertificatescontroller.ControllerName,
 	}
 
 	defaultEnabledControllers = []string{
@@ -207,6 +282,7 @@ var (
 
 	experimentalCertificateSigningRequestControllers = []string{
 		csracmecontroller.CSRControllerName,
+		csrapprovercontroller.CSRControllerName,
 		csrcacontroller.CSRControllerName,
 		csrselfsignedcontroller.CSRControllerName,
 		csrvenaficontroller.CSRControllerName,
@@ -249,6 +325,10 @@ func NewControllerOptions() *ControllerOptions {
 		DNS01CheckRetryPeriod:             defaultDNS01CheckRetryPeriod,
 		EnablePprof:                       cmdutil.DefaultEnableProfiling,
 		PprofAddress:                      cmdutil.DefaultProfilerAddr,
+		CTMonitorPollInterval:             defaultCTMonitorPollInterval,
+		CanaryInterval:                    defaultCanaryInterval,
+		CanaryTimeout:                     defaultCanaryTimeout,
+		CanaryCertificateDuration:         defaultCanaryCertificateDuration,
 	}
 }
 
@@ -357,6 +437,48 @@ func (s *ControllerOptions) AddFlags(fs *pflag.FlagSet) {
 		"from Certificate to CertificateRequest and Order, as well as from CertificateSigningRequest to Order, by passing a list of annotation key prefixes."+
 		"A prefix starting with a dash(-) specifies an annotation that shouldn't be copied. Example: '*,-kubectl.kuberenetes.io/'- all annotations"+
 		"will be copied apart from the ones where the key is prefixed with 'kubectl.kubernetes.io/'.")
+	fs.StringVar(&s.IssuanceReceiptSigningKeySecretName, "issuance-receipt-signing-key-secret-name", "", ""+
+		"Name of a Secret in the cluster resource namespace whose 'tls.key' holds the private key used to sign "+
+		"issuance receipts. When set, a signed receipt is added to the Secret and the CertificateRequest of each "+
+		"issued Certificate revision, which can be verified offline using 'cmctl verify'. If the key cannot be "+
+		"loaded, a warning event is recorded and the revision is issued without a receipt.")
+	fs.StringVar(&s.CredentialsKMSPluginEndpoint, "credentials-kms-plugin-endpoint", "", ""+
+		"Endpoint of a KMS v2 plugin, such as 'unix:///var/run/kms-plugin/socket.sock', used to decrypt "+
+		"envelope encrypted values in the Secrets referenced by issuers and DNS01 providers. "+
+		"Values can be encrypted using 'cmctl encrypt'. "+
+		"ACME account keys generated by cert-manager are stored encrypted when set.")
+	fs.StringVar(&s.CredentialsEncryptionKeyFile, "credentials-encryption-key-file", "", ""+
+		"Path to a file containing a base64 encoded 32 byte AES key, used instead of a KMS plugin to decrypt "+
+		"envelope encrypted values in the Secrets referenced by issuers and DNS01 providers. "+
+		"Intended for testing; may not be used together with --credentials-kms-plugin-endpoint.")
+	fs.StringVar(&s.ApprovalPolicyFile, "approval-policy-file", "", ""+
+		"Path to a file containing approval policies. CertificateRequests and CertificateSigningRequests "+
+		"whose signer name matches a policy are approved or denied by the approver controllers according to "+
+		"that policy. If unset, CertificateRequests are always approved and CertificateSigningRequests are "+
+		"left for manual approval.")
+	fs.BoolVar(&s.VenafiRetireSupersededCertificates, "venafi-retire-superseded-certificates", false, ""+
+		"When the "+venafiretirement.ControllerName+" controller is enabled, also retire the certificates of "+
+		"previous revisions in Venafi once a Certificate has been reissued.")
+	fs.StringSliceVar(&s.VenafiRetirementSkipZones, "venafi-retirement-skip-zones", nil, ""+
+		"A list of Venafi zones for which the "+venafiretirement.ControllerName+" controller never retires certificates.")
+	fs.StringSliceVar(&s.CTMonitorLogURLs, "ct-monitor-log-urls", nil, ""+
+		"A list of Certificate Transparency log URLs which the "+ctmonitor.ControllerName+" controller polls for "+
+		"certificates of monitored domains that were not issued by cert-manager.")
+	fs.StringSliceVar(&s.CTMonitorZones, "ct-monitor-zones", nil, ""+
+		"A list of DNS zones which the "+ctmonitor.ControllerName+" controller monitors in addition to the "+
+		"dnsNames of Certificate resources. Subdomains of each zone are monitored too.")
+	fs.DurationVar(&s.CTMonitorPollInterval, "ct-monitor-poll-interval", defaultCTMonitorPollInterval, ""+
+		"The interval at which the "+ctmonitor.ControllerName+" controller polls each Certificate Transparency log for new entries.")
+	fs.DurationVar(&s.CanaryInterval, "canary-interval", defaultCanaryInterval, ""+
+		"The interval at which the "+issuerscanary.ControllerName+" controller creates a CertificateRequest for each "+
+		"Issuer and ClusterIssuer annotated with "+cmapi.CanaryCommonNameAnnotationKey+" or "+cmapi.CanaryDNSNamesAnnotationKey+". "+
+		"Each canary of an ACME issuer is a new order for the same identifiers, which counts towards the rate limits of the "+
+		"ACME server, such as the duplicate certificate limit of Let's Encrypt (5 per week), and competes with the renewals of "+
+		"Certificates. The canaries of ACME issuers are therefore never created more often than every "+issuerscanary.MinimumACMEInterval.String()+".")
+	fs.DurationVar(&s.CanaryTimeout, "canary-timeout", defaultCanaryTimeout, ""+
+		"The time after which a canary CertificateRequest which has not been issued is counted as failed and deleted.")
+	fs.DurationVar(&s.CanaryCertificateDuration, "canary-certificate-duration", defaultCanaryCertificateDuration, ""+
+		"The duration requested for certificates issued by the "+issuerscanary.ControllerName+" controller.")
 
 	fs.IntVar(&s.MaxConcurrentChallenges, "max-concurrent-challenges", defaultMaxConcurrentChallenges, ""+
 		"The maximum number of challenges that can be scheduled as 'processing' at once.")
@@ -389,6 +511,10 @@ func (o *ControllerOptions) Validate() error {
 		return fmt.Errorf("invalid value for kube-api-burst: %v must be higher or equal to kube-api-qps: %v", o.KubernetesAPIQPS, o.KubernetesAPIQPS)
 	}
 
+	if len(o.CredentialsKMSPluginEndpoint) > 0 && len(o.CredentialsEncryptionKeyFile) > 0 {
+		return errors.New("the --credentials-kms-plugin-endpoint and --credentials-encryption-key-file flags are mutually exclusive")
+	}
+
 	for _, server := range append(o.DNS01RecursiveNameservers, o.ACMEHTTP01SolverNameservers...) {
 		// ensure all servers have a port number
 		_, _, err := net.SplitHostPort(server)
@@ -414,6 +540,22 @@ func (o *ControllerOptions) Validate() error {
 		return fmt.Errorf("validation failed for '--controllers': %v", errs)
 	}
 
+	if o.CTMonitorPollInterval <= 0 {
+		return fmt.Errorf("invalid value for ct-monitor-poll-interval: %v must be higher than 0", o.CTMonitorPollInterval)
+	}
+
+	if o.CanaryInterval <= 0 {
+		return fmt.Errorf("invalid value for canary-interval: %v must be higher than 0", o.CanaryInterval)
+	}
+
+	if o.CanaryTimeout <= 0 {
+		return fmt.Errorf("invalid value for canary-timeout: %v must be higher than 0", o.CanaryTimeout)
+	}
+
+	if o.CanaryCertificateDuration < cmapi.MinimumCertificateDuration {
+		return fmt.Errorf("invalid value for canary-certificate-duration: %v must be at least %v", o.CanaryCertificateDuration, cmapi.MinimumCertificateDuration)
+	}
+
 	return nil
 }
 
@@ -442,6 +584,21 @@ func (o *ControllerOptions) EnabledControllers() sets.String {
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ExperimentalGatewayAPISupport) {
 		logf.Log.Info("enabling the sig-network Gateway API certificate-shim and HTTP-01 solver")
 		enabled = enabled.Insert(shimgatewaycontroller.ControllerName)
+	} else if enabled.Has(shimtlsroutecontroller.ControllerName) {
+		// The Gateway API informers are only started when the Gateway API
+		// support is enabled.
+		logf.Log.Info("not enabling the TLSRoute certificate-shim since the " + string(feature.ExperimentalGatewayAPISupport) + " feature gate is disabled")
+		enabled = enabled.Delete(shimtlsroutecontroller.ControllerName)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.SSHCertificates) {
+		logf.Log.Info("enabling the SSHCertificate controller")
+		enabled = enabled.Insert(sshcertificatescontroller.ControllerName)
+	} else if enabled.Has(sshcertificatescontroller.ControllerName) {
+		// SSHCertificates are an alpha feature, which may only be used once
+		// the feature gate is enabled.
+		logf.Log.Info("not enabling the SSHCertificate controller since the " + string(feature.SSHCertificates) + " feature gate is disabled")
+		enabled = enabled.Delete(sshcertificatescontroller.ControllerName)
 	}
 
 	return enabled
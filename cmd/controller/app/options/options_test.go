@@ -19,6 +19,7 @@ package options
 import (
 	"testing"
 
+	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/sets"
 )
 
@@ -63,3 +64,49 @@ func TestEnabledControllers(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateControllers(t *testing.T) {
+	tests := map[string]struct {
+		args       []string
+		expErr     bool
+		expEnabled sets.String
+	}{
+		"if an optional controller is enabled, it is valid": {
+			args:       []string{"--controllers=issuers-canary"},
+			expEnabled: sets.NewString("issuers-canary"),
+		},
+		"if an optional controller is enabled alongside the defaults, it is valid": {
+			args:       []string{"--controllers=*,issuers-canary,certificates-ct-monitor"},
+			expEnabled: sets.NewString(defaultEnabledControllers...).Insert("issuers-canary", "certificates-ct-monitor"),
+		},
+		"if an unknown controller is enabled, it is invalid": {
+			args:   []string{"--controllers=foo"},
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := NewControllerOptions()
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			o.AddFlags(fs)
+			if err := fs.Parse(test.args); err != nil {
+				t.Fatal(err)
+			}
+
+			err := o.Validate()
+			if test.expErr != (err != nil) {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expErr, err)
+			}
+			if test.expErr {
+				return
+			}
+
+			got := o.EnabledControllers()
+			if !got.Equal(test.expEnabled) {
+				t.Errorf("got unexpected enabled, exp=%s got=%s",
+					test.expEnabled, got)
+			}
+		})
+	}
+}
@@ -29,11 +29,13 @@ import (
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/convert"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/create"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/deny"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/encrypt"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/experimental"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/inspect"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/renew"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/status"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/upgrade"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/verify"
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/version"
 )
 
@@ -54,6 +56,8 @@ func Commands() []RegisterCommandFunc {
 		renew.NewCmdRenew,
 		status.NewCmdStatus,
 		inspect.NewCmdInspect,
+		verify.NewCmdVerify,
+		encrypt.NewCmdEncrypt,
 		approve.NewCmdApprove,
 		deny.NewCmdDeny,
 		check.NewCmdCheck,
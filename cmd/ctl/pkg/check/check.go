@@ -23,12 +23,16 @@ import (
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/check/api"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/check/conversion"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/check/issuer"
 )
 
 // NewCmdCheck returns a cobra command for checking cert-manager components.
 func NewCmdCheck(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
 	cmds := NewCmdCreateBare()
 	cmds.AddCommand(api.NewCmdCheckApi(ctx, ioStreams))
+	cmds.AddCommand(conversion.NewCmdCheckConversion(ctx, ioStreams))
+	cmds.AddCommand(issuer.NewCmdCheckIssuer(ctx, ioStreams))
 
 	return cmds
 }
@@ -0,0 +1,276 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/ctl"
+	"github.com/cert-manager/cert-manager/pkg/webhook/handlers"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Check that every stored cert-manager resource survives a conversion to each
+served API version and back again without losing data.
+
+Every Certificate, CertificateRequest, Issuer, ClusterIssuer, Order and
+Challenge is read from the cluster and converted locally, using the same
+conversion functions as the cert-manager webhook. Any field which is dropped,
+added or changed by a round trip is reported. Run this before removing an old
+API version to make sure no stored resource depends on it.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Check the conversion of all cert-manager resources in all namespaces.
+{{.BuildName}} check conversion
+
+# Check the conversion of all cert-manager resources in the namespace 'sandbox'.
+{{.BuildName}} check conversion --namespace sandbox`)))
+)
+
+// Options is a struct to support check conversion command
+type Options struct {
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCheckConversion returns a cobra command for checking the conversion
+// of stored cert-manager resources
+func NewCmdCheckConversion(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "conversion",
+		Short:   "Check that stored cert-manager resources can be converted between API versions without data loss",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("no arguments are accepted")
+	}
+	return nil
+}
+
+// Run executes check conversion command
+func (o *Options) Run(ctx context.Context) error {
+	// Only restrict the check to a single namespace if one was explicitly
+	// requested, since all stored resources should be checked by default.
+	namespace := metav1.NamespaceAll
+	if o.EnforceNamespace {
+		namespace = o.Namespace
+	}
+
+	objects, err := o.storedObjects(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	served, err := o.servedVersions()
+	if err != nil {
+		return err
+	}
+
+	converter := handlers.NewSchemeBackedConverter(logr.Discard(), ctl.Scheme)
+
+	failed := 0
+	for _, object := range objects {
+		raw, err := json.Marshal(object.obj)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s %s: %w", object.kind, object.name, err)
+		}
+
+		differences, err := converter.VerifyRoundTrip(runtime.RawExtension{Raw: raw}, served[schema.GroupKind{Group: object.group, Kind: object.kind}])
+		if err != nil {
+			failed++
+			fmt.Fprintf(o.Out, "%s %s could not be converted: %v\n", object.kind, object.name, err)
+			continue
+		}
+
+		if len(differences) == 0 {
+			continue
+		}
+
+		failed++
+		fmt.Fprintf(o.Out, "%s %s is not preserved by conversion:\n", object.kind, object.name)
+		for _, difference := range differences {
+			fmt.Fprintf(o.Out, "  %s\n", difference)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d resources are not preserved by conversion", failed, len(objects))
+	}
+
+	fmt.Fprintf(o.Out, "All %d resources are preserved by conversion to every served API version\n", len(objects))
+
+	return nil
+}
+
+// storedObject is a cert-manager resource read from the cluster, with its
+// TypeMeta populated so that it can be decoded by the converter.
+type storedObject struct {
+	group, kind, name string
+	obj               runtime.Object
+}
+
+// storedObjects lists all cert-manager resources in the given namespace.
+func (o *Options) storedObjects(ctx context.Context, namespace string) ([]storedObject, error) {
+	var objects []storedObject
+	add := func(gv schema.GroupVersion, kind string, obj runtime.Object, meta metav1.Object) {
+		// Objects returned by the typed client have no TypeMeta set.
+		obj.GetObjectKind().SetGroupVersionKind(gv.WithKind(kind))
+
+		name := meta.GetName()
+		if len(meta.GetNamespace()) > 0 {
+			name = meta.GetNamespace() + "/" + name
+		}
+		objects = append(objects, storedObject{group: gv.Group, kind: kind, name: name, obj: obj})
+	}
+
+	cmGV := cmapi.SchemeGroupVersion
+	acmeGV := cmacme.SchemeGroupVersion
+
+	crts, err := o.CMClient.CertmanagerV1().Certificates(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Certificates: %w", err)
+	}
+	for i := range crts.Items {
+		add(cmGV, cmapi.CertificateKind, &crts.Items[i], &crts.Items[i])
+	}
+
+	reqs, err := o.CMClient.CertmanagerV1().CertificateRequests(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list CertificateRequests: %w", err)
+	}
+	for i := range reqs.Items {
+		add(cmGV, cmapi.CertificateRequestKind, &reqs.Items[i], &reqs.Items[i])
+	}
+
+	issuers, err := o.CMClient.CertmanagerV1().Issuers(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Issuers: %w", err)
+	}
+	for i := range issuers.Items {
+		add(cmGV, cmapi.IssuerKind, &issuers.Items[i], &issuers.Items[i])
+	}
+
+	// ClusterIssuers are not namespaced, so are only checked if all
+	// namespaces are checked.
+	if namespace == metav1.NamespaceAll {
+		clusterIssuers, err := o.CMClient.CertmanagerV1().ClusterIssuers().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ClusterIssuers: %w", err)
+		}
+		for i := range clusterIssuers.Items {
+			add(cmGV, cmapi.ClusterIssuerKind, &clusterIssuers.Items[i], &clusterIssuers.Items[i])
+		}
+	}
+
+	orders, err := o.CMClient.AcmeV1().Orders(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Orders: %w", err)
+	}
+	for i := range orders.Items {
+		add(acmeGV, cmacme.OrderKind, &orders.Items[i], &orders.Items[i])
+	}
+
+	challenges, err := o.CMClient.AcmeV1().Challenges(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Challenges: %w", err)
+	}
+	for i := range challenges.Items {
+		add(acmeGV, cmacme.ChallengeKind, &challenges.Items[i], &challenges.Items[i])
+	}
+
+	return objects, nil
+}
+
+// servedVersions returns the API versions served by the API server for each
+// cert-manager kind, as reported by discovery. Versions which are not known
+// to the converter are left out, since they cannot be checked locally.
+func (o *Options) servedVersions() (map[schema.GroupKind][]string, error) {
+	groups, err := o.KubeClient.Discovery().ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover served API groups: %w", err)
+	}
+
+	served := make(map[schema.GroupKind][]string)
+	for _, group := range groups.Groups {
+		if group.Name != cmapi.SchemeGroupVersion.Group && group.Name != cmacme.SchemeGroupVersion.Group {
+			continue
+		}
+
+		for _, version := range group.Versions {
+			gv := schema.GroupVersion{Group: group.Name, Version: version.Version}
+			if !ctl.Scheme.IsVersionRegistered(gv) {
+				continue
+			}
+
+			resources, err := o.KubeClient.Discovery().ServerResourcesForGroupVersion(version.GroupVersion)
+			if err != nil {
+				return nil, fmt.Errorf("failed to discover resources served by %s: %w", version.GroupVersion, err)
+			}
+			for _, resource := range resources.APIResources {
+				// Skip subresources such as certificates/status.
+				if strings.Contains(resource.Name, "/") {
+					continue
+				}
+				gk := schema.GroupKind{Group: group.Name, Kind: resource.Kind}
+				served[gk] = append(served[gk], gv.String())
+			}
+		}
+	}
+
+	return served, nil
+}
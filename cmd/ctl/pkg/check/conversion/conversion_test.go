@@ -0,0 +1,175 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRun(t *testing.T) {
+	crt := gen.Certificate("crt",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateURIs("spiffe://example.com/foo"),
+		gen.SetCertificateEmails("foo@example.com"),
+		gen.SetCertificateSecretName("crt-tls"),
+		gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+		gen.SetCertificateKeySize(256),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}),
+	)
+	req := gen.CertificateRequest("req",
+		gen.SetCertificateRequestNamespace("testns"),
+		gen.SetCertificateRequestCSR([]byte("csr")),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "ca"}),
+	)
+	issuer := gen.Issuer("ca",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}),
+	)
+	clusterIssuer := gen.ClusterIssuer("acme",
+		gen.SetIssuerACME(cmacme.ACMEIssuer{Server: "https://acme.example.com", PrivateKey: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "acme"}}}),
+	)
+	otherCrt := gen.Certificate("other",
+		gen.SetCertificateNamespace("otherns"),
+		gen.SetCertificateDNSNames("example.org"),
+		gen.SetCertificateSecretName("other-tls"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"}),
+	)
+
+	tests := map[string]struct {
+		namespace string
+		objects   []runtime.Object
+
+		expectedOutput string
+		expectedErr    string
+	}{
+		"no resources": {
+			expectedOutput: "All 0 resources are preserved by conversion to every served API version\n",
+		},
+		"resources in all namespaces": {
+			objects:        []runtime.Object{crt, req, issuer, clusterIssuer, otherCrt},
+			expectedOutput: "All 5 resources are preserved by conversion to every served API version\n",
+		},
+		"resources in a single namespace should not include ClusterIssuers": {
+			namespace:      "testns",
+			objects:        []runtime.Object{crt, req, issuer, clusterIssuer, otherCrt},
+			expectedOutput: "All 3 resources are preserved by conversion to every served API version\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := NewOptions(streams)
+			o.Factory = &factory.Factory{
+				Namespace:        test.namespace,
+				EnforceNamespace: len(test.namespace) > 0,
+				CMClient:         cmfake.NewSimpleClientset(test.objects...),
+				KubeClient:       kubeClientServing(servedResources()),
+			}
+
+			err := o.Run(context.Background())
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedOutput, out.String())
+		})
+	}
+}
+
+func TestServedVersions(t *testing.T) {
+	resources := append(servedResources(),
+		// Versions unknown to the converter cannot be checked.
+		&metav1.APIResourceList{
+			GroupVersion: "cert-manager.io/v2",
+			APIResources: []metav1.APIResource{{Name: "certificates", Kind: "Certificate"}},
+		},
+		// Other groups are not checked.
+		&metav1.APIResourceList{
+			GroupVersion: "apps/v1",
+			APIResources: []metav1.APIResource{{Name: "deployments", Kind: "Deployment"}},
+		},
+	)
+
+	o := NewOptions(genericclioptions.IOStreams{})
+	o.Factory = &factory.Factory{KubeClient: kubeClientServing(resources)}
+
+	served, err := o.servedVersions()
+	assert.NoError(t, err)
+	assert.Equal(t, map[schema.GroupKind][]string{
+		{Group: "cert-manager.io", Kind: "Certificate"}:        {"cert-manager.io/v1", "cert-manager.io/v1alpha2"},
+		{Group: "cert-manager.io", Kind: "CertificateRequest"}: {"cert-manager.io/v1"},
+		{Group: "cert-manager.io", Kind: "Issuer"}:             {"cert-manager.io/v1"},
+		{Group: "cert-manager.io", Kind: "ClusterIssuer"}:      {"cert-manager.io/v1"},
+		{Group: "acme.cert-manager.io", Kind: "Order"}:         {"acme.cert-manager.io/v1"},
+		{Group: "acme.cert-manager.io", Kind: "Challenge"}:     {"acme.cert-manager.io/v1"},
+	}, served)
+}
+
+// servedResources returns the cert-manager resources served by a cluster
+// where only Certificates are still served at v1alpha2.
+func servedResources() []*metav1.APIResourceList {
+	return []*metav1.APIResourceList{
+		{
+			GroupVersion: "cert-manager.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "certificates", Kind: "Certificate"},
+				{Name: "certificates/status", Kind: "Certificate"},
+				{Name: "certificaterequests", Kind: "CertificateRequest"},
+				{Name: "issuers", Kind: "Issuer"},
+				{Name: "clusterissuers", Kind: "ClusterIssuer"},
+			},
+		},
+		{
+			GroupVersion: "cert-manager.io/v1alpha2",
+			APIResources: []metav1.APIResource{
+				{Name: "certificates", Kind: "Certificate"},
+			},
+		},
+		{
+			GroupVersion: "acme.cert-manager.io/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "orders", Kind: "Order"},
+				{Name: "challenges", Kind: "Challenge"},
+			},
+		},
+	}
+}
+
+func kubeClientServing(resources []*metav1.APIResourceList) *kubefake.Clientset {
+	client := kubefake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = resources
+	return client
+}
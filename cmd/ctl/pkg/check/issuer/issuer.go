@@ -0,0 +1,247 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/policy"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Check that an Issuer or ClusterIssuer is ready, and validate Certificates
+against the policy of the issuer.
+
+For Venafi issuers, Certificates are validated against the zone policy cached
+in the status of the issuer, which is the same validation that is performed by
+the webhook when Certificates are created or updated.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Validate all Certificates in the current namespace that reference the Issuer 'venafi'.
+{{.BuildName}} check issuer venafi
+
+# Validate the Certificates 'my-app' and 'my-api' against the Issuer 'venafi'.
+{{.BuildName}} check issuer venafi --certificate my-app --certificate my-api
+
+# Validate all Certificates in all namespaces that reference the ClusterIssuer 'venafi'.
+{{.BuildName}} check issuer venafi --cluster-issuer --all-namespaces`)))
+)
+
+// Options is a struct to support check issuer command
+type Options struct {
+	// ClusterIssuer is true if the named issuer is a ClusterIssuer
+	ClusterIssuer bool
+
+	// Certificates is the list of Certificates to validate. If empty, all
+	// Certificates referencing the issuer are validated.
+	Certificates []string
+
+	// AllNamespaces validates Certificates in all namespaces
+	AllNamespaces bool
+
+	genericclioptions.IOStreams
+	*factory.Factory
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdCheckIssuer returns a cobra command for checking an issuer and
+// validating Certificates against its policy
+func NewCmdCheckIssuer(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:     "issuer",
+		Short:   "Check an issuer and validate Certificates against its policy",
+		Long:    long,
+		Example: example,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx, args[0]))
+		},
+	}
+	cmd.Flags().BoolVar(&o.ClusterIssuer, "cluster-issuer", o.ClusterIssuer, "If present, the named issuer is a ClusterIssuer.")
+	cmd.Flags().StringSliceVar(&o.Certificates, "certificate", o.Certificates, "Name of a Certificate to validate. May be repeated. If not specified, all Certificates referencing the issuer are validated.")
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, validate Certificates across namespaces. Namespace in current context is ignored even if specified with --namespace.")
+
+	o.Factory = factory.New(ctx, cmd)
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("the name of the issuer to check must be specified as the only argument")
+	}
+
+	if o.AllNamespaces && len(o.Certificates) > 0 {
+		return errors.New("cannot specify Certificate names in conjunction with --all-namespaces")
+	}
+
+	if !o.ClusterIssuer && o.AllNamespaces {
+		return errors.New("--all-namespaces can only be used in conjunction with --cluster-issuer")
+	}
+
+	return nil
+}
+
+// Run executes check issuer command
+func (o *Options) Run(ctx context.Context, name string) error {
+	var (
+		issuer cmapi.GenericIssuer
+		err    error
+	)
+	if o.ClusterIssuer {
+		issuer, err = o.CMClient.CertmanagerV1().ClusterIssuers().Get(ctx, name, metav1.GetOptions{})
+	} else {
+		issuer, err = o.CMClient.CertmanagerV1().Issuers(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return err
+	}
+
+	kind := issuerKind(issuer)
+	if apiutil.IssuerHasCondition(issuer, cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}) {
+		fmt.Fprintf(o.Out, "%s %q is ready\n", kind, name)
+	} else {
+		fmt.Fprintf(o.Out, "%s %q is not ready\n", kind, name)
+	}
+
+	if issuer.GetSpec().Venafi == nil {
+		fmt.Fprintf(o.Out, "%s %q is not a Venafi issuer, there is no policy to validate Certificates against\n", kind, name)
+		return nil
+	}
+
+	zonePolicy := policy.ForIssuer(issuer)
+	if zonePolicy == nil {
+		return fmt.Errorf("the policy of Venafi zone %q has not been cached in the status of %s %q yet", issuer.GetSpec().Venafi.Zone, kind, name)
+	}
+
+	fmt.Fprintf(o.Out, "Policy of Venafi zone %q", zonePolicy.Zone)
+	if zonePolicy.LastSyncTime != nil {
+		fmt.Fprintf(o.Out, " last synced at %s", zonePolicy.LastSyncTime.Time)
+	}
+	fmt.Fprintln(o.Out)
+
+	crts, err := o.certificatesFor(ctx, issuer)
+	if err != nil {
+		return err
+	}
+
+	if len(crts) == 0 {
+		fmt.Fprintf(o.Out, "No Certificates reference %s %q\n", kind, name)
+		return nil
+	}
+
+	violations := 0
+	for _, crt := range crts {
+		el := policy.ValidateCertificateSpec(zonePolicy, &crt.Spec, field.NewPath("spec"))
+		if len(el) == 0 {
+			fmt.Fprintf(o.Out, "Certificate %s/%s satisfies the zone policy\n", crt.Namespace, crt.Name)
+			continue
+		}
+
+		violations++
+		fmt.Fprintf(o.Out, "Certificate %s/%s violates the zone policy:\n", crt.Namespace, crt.Name)
+		for _, err := range el {
+			fmt.Fprintf(o.Out, "  %s\n", err.Error())
+		}
+	}
+
+	if violations > 0 {
+		return fmt.Errorf("%d of %d Certificates violate the policy of Venafi zone %q", violations, len(crts), zonePolicy.Zone)
+	}
+
+	return nil
+}
+
+// certificatesFor returns the Certificates named in the options, or all
+// Certificates referencing the given issuer if none were named.
+func (o *Options) certificatesFor(ctx context.Context, issuer cmapi.GenericIssuer) ([]cmapi.Certificate, error) {
+	if len(o.Certificates) > 0 {
+		var crts []cmapi.Certificate
+		for _, name := range o.Certificates {
+			crt, err := o.CMClient.CertmanagerV1().Certificates(o.Namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, err
+			}
+			crts = append(crts, *crt)
+		}
+		return crts, nil
+	}
+
+	namespace := o.Namespace
+	if o.AllNamespaces {
+		namespace = metav1.NamespaceAll
+	}
+
+	crtList, err := o.CMClient.CertmanagerV1().Certificates(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var crts []cmapi.Certificate
+	for _, crt := range crtList.Items {
+		ref := crt.Spec.IssuerRef
+		if ref.Name != issuer.GetName() {
+			continue
+		}
+		if len(ref.Group) > 0 && ref.Group != certmanager.GroupName {
+			continue
+		}
+		kind := ref.Kind
+		if len(kind) == 0 {
+			kind = cmapi.IssuerKind
+		}
+		if kind != issuerKind(issuer) {
+			continue
+		}
+		crts = append(crts, crt)
+	}
+
+	return crts, nil
+}
+
+func issuerKind(issuer cmapi.GenericIssuer) string {
+	if _, ok := issuer.(*cmapi.ClusterIssuer); ok {
+		return cmapi.ClusterIssuerKind
+	}
+	return cmapi.IssuerKind
+}
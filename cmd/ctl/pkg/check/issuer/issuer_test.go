@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/factory"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRun(t *testing.T) {
+	zonePolicy := &cmapi.VenafiZonePolicy{
+		Zone:     "test-zone",
+		DNSNames: []string{`^.*\.example\.com$`},
+	}
+
+	venafiIssuer := gen.Issuer("venafi",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone"}),
+		gen.SetIssuerVenafiZonePolicy(zonePolicy),
+		gen.AddIssuerCondition(cmapi.IssuerCondition{Type: cmapi.IssuerConditionReady, Status: cmmeta.ConditionTrue}),
+	)
+	uncachedIssuer := gen.Issuer("uncached",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone"}),
+	)
+	caIssuer := gen.Issuer("ca",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}),
+	)
+
+	validCrt := gen.Certificate("valid",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateDNSNames("foo.example.com"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "venafi"}),
+	)
+	invalidCrt := gen.Certificate("invalid",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateDNSNames("foo.example.org"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "venafi", Kind: "Issuer"}),
+	)
+	otherCrt := gen.Certificate("other",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateDNSNames("foo.example.org"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "venafi", Kind: "ClusterIssuer"}),
+	)
+
+	tests := map[string]struct {
+		issuer       string
+		certificates []string
+		objects      []runtime.Object
+
+		expectedOutput string
+		expectedErr    string
+	}{
+		"all Certificates referencing the issuer satisfying the policy": {
+			issuer:  "venafi",
+			objects: []runtime.Object{venafiIssuer, validCrt, otherCrt},
+			expectedOutput: `Issuer "venafi" is ready
+Policy of Venafi zone "test-zone"
+Certificate testns/valid satisfies the zone policy
+`,
+		},
+		"a Certificate referencing the issuer violating the policy": {
+			issuer:  "venafi",
+			objects: []runtime.Object{venafiIssuer, validCrt, invalidCrt, otherCrt},
+			expectedOutput: `Issuer "venafi" is ready
+Policy of Venafi zone "test-zone"
+Certificate testns/invalid violates the zone policy:
+  spec.dnsNames[0]: Invalid value: "foo.example.org": does not match any of the regular expressions allowed by the Venafi zone policy: ^.*\.example\.com$
+Certificate testns/valid satisfies the zone policy
+`,
+			expectedErr: `1 of 2 Certificates violate the policy of Venafi zone "test-zone"`,
+		},
+		"a named Certificate": {
+			issuer:       "venafi",
+			certificates: []string{"valid"},
+			objects:      []runtime.Object{venafiIssuer, validCrt, invalidCrt},
+			expectedOutput: `Issuer "venafi" is ready
+Policy of Venafi zone "test-zone"
+Certificate testns/valid satisfies the zone policy
+`,
+		},
+		"a Venafi issuer without a cached policy": {
+			issuer:  "uncached",
+			objects: []runtime.Object{uncachedIssuer},
+			expectedOutput: `Issuer "uncached" is not ready
+`,
+			expectedErr: `the policy of Venafi zone "test-zone" has not been cached in the status of Issuer "uncached" yet`,
+		},
+		"a non-Venafi issuer": {
+			issuer:  "ca",
+			objects: []runtime.Object{caIssuer},
+			expectedOutput: `Issuer "ca" is not ready
+Issuer "ca" is not a Venafi issuer, there is no policy to validate Certificates against
+`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := NewOptions(streams)
+			o.Certificates = test.certificates
+			o.Factory = &factory.Factory{
+				Namespace: "testns",
+				CMClient:  cmfake.NewSimpleClientset(test.objects...),
+			}
+
+			err := o.Run(context.Background(), test.issuer)
+			if test.expectedErr != "" {
+				assert.EqualError(t, err, test.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedOutput, out.String())
+		})
+	}
+}
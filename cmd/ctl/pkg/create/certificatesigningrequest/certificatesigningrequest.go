@@ -25,7 +25,6 @@ import (
 	"strconv"
 	"time"
 
-	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 	"github.com/spf13/cobra"
 	certificatesv1 "k8s.io/api/certificates/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -370,10 +369,10 @@ func buildCertificateSigningRequest(crt *cmapi.Certificate, pk []byte, crName, s
 	if csr.Annotations == nil {
 		csr.Annotations = make(map[string]string)
 	}
-	csr.Annotations[experimentalapi.CertificateSigningRequestIsCAAnnotationKey] = strconv.FormatBool(crt.Spec.IsCA)
+	csr.Annotations[cmapi.CertificateSigningRequestIsCAAnnotationKey] = strconv.FormatBool(crt.Spec.IsCA)
 	if crt.Spec.Duration != nil {
 		duration := crt.Spec.Duration.Duration
-		csr.Annotations[experimentalapi.CertificateSigningRequestDurationAnnotationKey] = duration.String()
+		csr.Annotations[cmapi.CertificateSigningRequestDurationAnnotationKey] = duration.String()
 		seconds := int32(duration.Seconds())  // technically this could overflow but I do not think it matters
 		csr.Spec.ExpirationSeconds = &seconds // if this is less than 600, the API server will fail the request
 	}
@@ -0,0 +1,219 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encrypt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiserver/pkg/storage/value/encrypt/envelope/kmsv2"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	"github.com/cert-manager/cert-manager/internal/credentials"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Envelope encrypt credentials so that they can be stored encrypted in Secrets
+referenced by issuers and DNS providers.
+
+Values are encrypted with the KMS v2 plugin or the local encryption key file
+which the cert-manager controller is configured with, using the
+--credentials-kms-plugin-endpoint or --credentials-encryption-key-file flags.
+
+If a Secret manifest is given, the values of its data and stringData are
+encrypted and the Secret is printed. Values which are already encrypted are
+left unchanged. Otherwise, a single value is read from stdin and printed in
+encrypted form.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Encrypt all the values of a Secret manifest using a KMS plugin
+{{.BuildName}} encrypt -f secret.yaml --credentials-kms-plugin-endpoint unix:///var/run/kms-plugin/socket.sock > encrypted-secret.yaml
+
+# Encrypt the 'secret' key of an existing Secret using a local key file
+kubectl get secret vault-approle -o yaml | {{.BuildName}} encrypt -f - --key secret --credentials-encryption-key-file key.txt | kubectl apply -f -
+
+# Encrypt a single value, such as an ACME EAB HMAC key
+echo -n "$HMAC_KEY" | {{.BuildName}} encrypt --credentials-encryption-key-file key.txt
+`)))
+)
+
+// Options is a struct to support encrypt command
+type Options struct {
+	// Filename is the path to the Secret manifest to encrypt, or "-" to read
+	// it from stdin. If empty, a single value is read from stdin.
+	Filename string
+	// Keys are the keys of the Secret to encrypt. If empty, all keys are
+	// encrypted.
+	Keys []string
+	// KMSPluginEndpoint is the endpoint of the KMS v2 plugin used to encrypt.
+	KMSPluginEndpoint string
+	// EncryptionKeyFile is the path to the local AES-256 key used to
+	// encrypt, instead of a KMS plugin.
+	EncryptionKeyFile string
+
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdEncrypt returns a cobra command for envelope encrypting credentials
+func NewCmdEncrypt(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "encrypt",
+		Short:                 "Envelope encrypt credentials stored in Secrets",
+		Long:                  long,
+		Example:               example,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run(ctx))
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", o.Filename, "Path to a Secret manifest whose values are encrypted, or '-' to read it from stdin. If unset, a single value is read from stdin.")
+	cmd.Flags().StringSliceVar(&o.Keys, "key", o.Keys, "Key of the Secret to encrypt. May be repeated. If unset, all the keys of the Secret are encrypted.")
+	cmd.Flags().StringVar(&o.KMSPluginEndpoint, "credentials-kms-plugin-endpoint", o.KMSPluginEndpoint, "Endpoint of the KMS v2 plugin used to encrypt, such as 'unix:///var/run/kms-plugin/socket.sock'.")
+	cmd.Flags().StringVar(&o.EncryptionKeyFile, "credentials-encryption-key-file", o.EncryptionKeyFile, "Path to the file containing the base64 encoded 32 byte AES key used to encrypt, instead of a KMS plugin.")
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("encrypt does not take any arguments")
+	}
+	if len(o.KMSPluginEndpoint) == 0 && len(o.EncryptionKeyFile) == 0 {
+		return errors.New("either --credentials-kms-plugin-endpoint or --credentials-encryption-key-file has to be provided")
+	}
+	if len(o.KMSPluginEndpoint) > 0 && len(o.EncryptionKeyFile) > 0 {
+		return errors.New("the --credentials-kms-plugin-endpoint and --credentials-encryption-key-file flags are mutually exclusive")
+	}
+	if len(o.Filename) == 0 && len(o.Keys) > 0 {
+		return errors.New("--key can only be used together with --filename")
+	}
+	return nil
+}
+
+// Run executes encrypt command
+func (o *Options) Run(ctx context.Context) error {
+	svc, err := credentials.NewKeyService(ctx, o.KMSPluginEndpoint, o.EncryptionKeyFile)
+	if err != nil {
+		return err
+	}
+
+	if len(o.Filename) == 0 {
+		value, err := io.ReadAll(o.In)
+		if err != nil {
+			return fmt.Errorf("error reading value from stdin: %w", err)
+		}
+		if len(value) == 0 {
+			return errors.New("no value to encrypt was given on stdin")
+		}
+		encrypted, err := credentials.Encrypt(ctx, svc, value)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, string(encrypted))
+		return nil
+	}
+
+	var manifest []byte
+	if o.Filename == "-" {
+		manifest, err = io.ReadAll(o.In)
+	} else {
+		manifest, err = os.ReadFile(o.Filename)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading manifest: %w", err)
+	}
+
+	var secret corev1.Secret
+	if err := yaml.Unmarshal(manifest, &secret); err != nil {
+		return fmt.Errorf("error decoding Secret: %w", err)
+	}
+	if secret.Kind != "Secret" {
+		return fmt.Errorf("unsupported kind %q, expected a Secret", secret.Kind)
+	}
+
+	if err := encryptSecret(ctx, svc, &secret, o.Keys); err != nil {
+		return err
+	}
+
+	out, err := yaml.Marshal(&secret)
+	if err != nil {
+		return fmt.Errorf("error encoding Secret: %w", err)
+	}
+	_, err = o.Out.Write(out)
+	return err
+}
+
+// encryptSecret encrypts the values of the given Secret with the given keys,
+// or all its values if no keys are given. Values given as stringData are
+// moved to data, since stringData would otherwise overwrite the encrypted
+// values when the Secret is applied.
+func encryptSecret(ctx context.Context, svc kmsv2.Service, secret *corev1.Secret, keys []string) error {
+	if secret.Data == nil {
+		secret.Data = make(map[string][]byte, len(secret.StringData))
+	}
+	for k, v := range secret.StringData {
+		secret.Data[k] = []byte(v)
+	}
+	secret.StringData = nil
+
+	if len(keys) == 0 {
+		for k := range secret.Data {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+	}
+
+	for _, k := range keys {
+		value, ok := secret.Data[k]
+		if !ok {
+			return fmt.Errorf("Secret %s/%s has no key %q", secret.Namespace, secret.Name, k)
+		}
+		if credentials.IsEncrypted(value) {
+			continue
+		}
+		encrypted, err := credentials.Encrypt(ctx, svc, value)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt key %q: %w", k, err)
+		}
+		secret.Data[k] = encrypted
+	}
+	return nil
+}
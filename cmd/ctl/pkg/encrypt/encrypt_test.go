@@ -0,0 +1,190 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package encrypt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cert-manager/cert-manager/internal/credentials"
+)
+
+func writeKeyFile(t *testing.T, dir string) string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+	return path
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := writeKeyFile(t, dir)
+
+	svc, err := credentials.NewKeyService(context.Background(), "", keyFile)
+	require.NoError(t, err)
+	credentials.SetKeyService(svc)
+	defer credentials.SetKeyService(nil)
+
+	alreadyEncrypted, err := credentials.Encrypt(context.Background(), svc, []byte("already-encrypted"))
+	require.NoError(t, err)
+
+	secret := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{Name: "creds", Namespace: "ns"},
+		Data: map[string][]byte{
+			"api-token": []byte("api-token-value"),
+			"plain":     []byte("plain-value"),
+			"encrypted": alreadyEncrypted,
+		},
+		StringData: map[string]string{"hmac": "hmac-value"},
+	}
+	manifest, err := yaml.Marshal(secret)
+	require.NoError(t, err)
+	manifestPath := filepath.Join(dir, "secret.yaml")
+	require.NoError(t, os.WriteFile(manifestPath, manifest, 0600))
+
+	tests := map[string]struct {
+		filename string
+		keys     []string
+		stdin    string
+
+		expectedEncrypted []string
+		expectedPlain     []string
+		expectedErr       string
+	}{
+		"should encrypt all the values of a Secret manifest": {
+			filename:          manifestPath,
+			expectedEncrypted: []string{"api-token", "plain", "encrypted", "hmac"},
+		},
+		"should only encrypt the given keys of a Secret read from stdin": {
+			filename:          "-",
+			stdin:             string(manifest),
+			keys:              []string{"api-token", "hmac"},
+			expectedEncrypted: []string{"api-token", "hmac", "encrypted"},
+			expectedPlain:     []string{"plain"},
+		},
+		"should fail if a given key does not exist": {
+			filename:    manifestPath,
+			keys:        []string{"missing"},
+			expectedErr: `Secret ns/creds has no key "missing"`,
+		},
+		"should encrypt a single value read from stdin": {
+			stdin: "eab-hmac-key",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, in, out, _ := genericclioptions.NewTestIOStreams()
+			in.WriteString(test.stdin)
+
+			o := NewOptions(streams)
+			o.Filename = test.filename
+			o.Keys = test.keys
+			o.EncryptionKeyFile = keyFile
+			require.NoError(t, o.Validate(nil))
+
+			err := o.Run(context.Background())
+			if test.expectedErr != "" {
+				require.EqualError(t, err, test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			if test.filename == "" {
+				value := bytes.TrimSuffix(out.Bytes(), []byte("\n"))
+				require.True(t, credentials.IsEncrypted(value))
+				decrypted, ok, err := credentials.Value(&corev1.Secret{Data: map[string][]byte{"value": value}}, "value")
+				require.NoError(t, err)
+				require.True(t, ok)
+				assert.Equal(t, test.stdin, string(decrypted))
+				return
+			}
+
+			var got corev1.Secret
+			require.NoError(t, yaml.Unmarshal(out.Bytes(), &got))
+			assert.Empty(t, got.StringData)
+
+			expected := map[string]string{
+				"api-token": "api-token-value",
+				"plain":     "plain-value",
+				"encrypted": "already-encrypted",
+				"hmac":      "hmac-value",
+			}
+			for _, k := range test.expectedEncrypted {
+				assert.True(t, credentials.IsEncrypted(got.Data[k]), "expected key %q to be encrypted", k)
+			}
+			for _, k := range test.expectedPlain {
+				assert.False(t, credentials.IsEncrypted(got.Data[k]), "expected key %q not to be encrypted", k)
+			}
+			for k, v := range expected {
+				decrypted, ok, err := credentials.Value(&got, k)
+				require.NoError(t, err)
+				require.True(t, ok)
+				assert.Equal(t, v, string(decrypted))
+			}
+			assert.Equal(t, string(alreadyEncrypted), string(got.Data["encrypted"]), "already encrypted values should be left unchanged")
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		options     Options
+		args        []string
+		expectedErr string
+	}{
+		"should require a key service": {
+			options:     Options{},
+			expectedErr: "either --credentials-kms-plugin-endpoint or --credentials-encryption-key-file has to be provided",
+		},
+		"should not allow both a KMS plugin and a key file": {
+			options:     Options{KMSPluginEndpoint: "unix:///kms.sock", EncryptionKeyFile: "key"},
+			expectedErr: "the --credentials-kms-plugin-endpoint and --credentials-encryption-key-file flags are mutually exclusive",
+		},
+		"should not allow keys without a manifest": {
+			options:     Options{EncryptionKeyFile: "key", Keys: []string{"a"}},
+			expectedErr: "--key can only be used together with --filename",
+		},
+		"should not allow arguments": {
+			options:     Options{EncryptionKeyFile: "key"},
+			args:        []string{"value"},
+			expectedErr: "encrypt does not take any arguments",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.EqualError(t, test.options.Validate(test.args), test.expectedErr)
+		})
+	}
+}
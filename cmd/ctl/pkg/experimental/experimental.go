@@ -40,6 +40,7 @@ func NewCmdExperimental(ctx context.Context, ioStreams genericclioptions.IOStrea
 	create.AddCommand(certificatesigningrequest.NewCmdCreateCSR(ctx, ioStreams))
 	cmds.AddCommand(create)
 	cmds.AddCommand(install.NewCmdInstall(ctx, ioStreams))
+	cmds.AddCommand(install.NewCmdImages(ctx, ioStreams))
 	cmds.AddCommand(uninstall.NewCmd(ctx, ioStreams))
 
 	return cmds
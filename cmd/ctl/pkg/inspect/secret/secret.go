@@ -65,7 +65,8 @@ const issuedForTemplate = `Issued For:
 	Common Name:	{{ .CommonName }}
 	Organization:	{{ .CommonName }}
 	OrganizationalUnit:	{{ .OrganizationalUnit }}
-	Country:	{{ .Country }}`
+	Country:	{{ .Country }}
+	Subject:	{{ .Subject }}`
 
 const certificateTemplate = `Certificate:
 	Signing Algorithm:	{{ .SigningAlgorithm }}
@@ -233,16 +234,29 @@ func describeIssuedFor(cert *x509.Certificate) string {
 		Organization       string
 		OrganizationalUnit string
 		Country            string
+		Subject            string
 	}{
 		CommonName:         printOrNone(cert.Subject.CommonName),
 		Organization:       printSliceOrOne(cert.Subject.Organization),
 		OrganizationalUnit: printSliceOrOne(cert.Subject.Organization),
 		Country:            printSliceOrOne(cert.Subject.Country),
+		Subject:            printOrNone(describeSubject(cert)),
 	})
 
 	return b.String()
 }
 
+// describeSubject returns the full subject of the certificate, in the order
+// it is encoded. This includes every attribute of a literal subject, not just
+// the attributes shown above.
+func describeSubject(cert *x509.Certificate) string {
+	rdnSequence, err := pki.UnmarshalRawDerBytesToRDNSequence(cert.RawSubject)
+	if err != nil {
+		return cert.Subject.String()
+	}
+	return pki.MarshalRDNSequenceToSubjectString(rdnSequence)
+}
+
 func describeCertificate(cert *x509.Certificate) string {
 	var b bytes.Buffer
 	template.Must(template.New("certificateTemplate").Parse(certificateTemplate)).Execute(&b, struct {
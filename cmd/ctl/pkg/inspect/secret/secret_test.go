@@ -127,6 +127,15 @@ func MustParseCertificate(t *testing.T, certData string) *x509.Certificate {
 	return x509Cert
 }
 
+func mustParseSubject(t *testing.T, subject string) []byte {
+	rawSubject, err := pki.ParseSubjectStringToRawDERBytes(subject)
+	if err != nil {
+		t.Fatalf("error when parsing subject: %v", err)
+	}
+
+	return rawSubject
+}
+
 func Test_describeCRL(t *testing.T) {
 	tests := []struct {
 		name string
@@ -149,6 +158,8 @@ func Test_describeCRL(t *testing.T) {
 }
 
 func Test_describeCertificate(t *testing.T) {
+	pssCert := mustSelfSignRSAPSSCertificate(t)
+
 	tests := []struct {
 		name string
 		cert *x509.Certificate
@@ -164,6 +175,18 @@ func Test_describeCertificate(t *testing.T) {
 	Fingerprints: 	` + testCertFingerprint + `
 	Is a CA certificate: false
 	CRL:	<none>
+	OCSP:	<none>`,
+		},
+		{
+			name: "Describe certificate signed using RSASSA-PSS",
+			cert: pssCert,
+			want: `Certificate:
+	Signing Algorithm:	SHA256-RSAPSS
+	Public Key Algorithm: 	RSA
+	Serial Number:	` + pssCert.SerialNumber.String() + `
+	Fingerprints: 	` + fingerprintCert(pssCert) + `
+	Is a CA certificate: false
+	CRL:	<none>
 	OCSP:	<none>`,
 		},
 	}
@@ -248,7 +271,20 @@ func Test_describeIssuedFor(t *testing.T) {
 	Common Name:	<none>
 	Organization:	<none>
 	OrganizationalUnit:	cncf
-	Country:	GB`,
+	Country:	GB
+	Subject:	OU=cert-manager,O=cncf,C=GB`,
+		},
+		{
+			name: "Describe cert with literal subject",
+			cert: &x509.Certificate{
+				RawSubject: mustParseSubject(t, "UID=jsmith,DC=example,DC=com"),
+			},
+			want: `Issued For:
+	Common Name:	<none>
+	Organization:	<none>
+	OrganizationalUnit:	<none>
+	Country:	<none>
+	Subject:	UID=jsmith,DC=example,DC=com`,
 		},
 	}
 	for _, tt := range tests {
@@ -390,3 +426,27 @@ func makeInvisibleVisible(in string) string {
 
 	return in
 }
+
+// mustSelfSignRSAPSSCertificate returns a self-signed certificate for an RSA
+// key which requests the PSS signature scheme.
+func mustSelfSignRSAPSSCertificate(t *testing.T) *x509.Certificate {
+	crt := gen.Certificate("test-pss",
+		gen.SetCertificateDNSNames("pss.example.com"),
+		gen.SetCertificateKeyAlgorithm(v1.RSAKeyAlgorithm),
+	)
+	crt.Spec.PrivateKey.SignatureScheme = v1.PSSSignatureScheme
+
+	pk, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template, err := pki.GenerateTemplate(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, cert, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
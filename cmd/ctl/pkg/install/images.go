@@ -0,0 +1,230 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"helm.sh/helm/v3/pkg/cli"
+	"helm.sh/helm/v3/pkg/cli/values"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/releaseutil"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+)
+
+type ImagesOptions struct {
+	settings  *cli.EnvSettings
+	client    *action.Install
+	valueOpts *values.Options
+
+	ChartName    string
+	ChartArchive string
+	Registry     string
+
+	genericclioptions.IOStreams
+}
+
+// acmeSolverImageFlag is the controller flag which configures the image of
+// the pods solving ACME HTTP01 challenges. These pods are created by the
+// controller at runtime, so their image only appears as a container argument.
+const acmeSolverImageFlag = "--acme-http01-solver-image="
+
+func imagesDesc() string {
+	return build.WithTemplate(`This command lists the container images that '{{.BuildName}} x install' deploys
+for the given chart version and values, including the ACME HTTP01 solver image.
+
+The list can be used to mirror the images into a private registry for air-gapped
+installations. When '--registry' is set, each image is printed next to the image
+it should be mirrored to, which replaces the registry of the image with the given
+prefix.
+
+Some example uses:
+	$ {{.BuildName}} x images
+or
+	$ {{.BuildName}} x images --version v1.4.0 --set prometheus.enabled=false
+or
+	$ {{.BuildName}} x images --chart-archive ./cert-manager-v1.4.0.tgz --registry registry.example.com/mirror
+`)
+}
+
+func NewCmdImages(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	settings := cli.New()
+
+	options := &ImagesOptions{
+		settings:  settings,
+		client:    action.NewInstall(new(action.Configuration)),
+		valueOpts: &values.Options{},
+
+		IOStreams: ioStreams,
+	}
+
+	cmd := &cobra.Command{
+		Use:   "images",
+		Short: "List the images deployed by cert-manager",
+		Long:  imagesDesc(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			images, err := options.runImages()
+			if err != nil {
+				return err
+			}
+
+			for _, image := range images {
+				if options.Registry == "" {
+					fmt.Fprintln(ioStreams.Out, image)
+					continue
+				}
+				fmt.Fprintf(ioStreams.Out, "%s %s\n", image, rewriteImageRegistry(image, options.Registry))
+			}
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	addValueOptionsFlags(cmd.Flags(), options.valueOpts)
+	addChartPathOptionsFlags(cmd.Flags(), &options.client.ChartPathOptions)
+
+	cmd.Flags().StringVar(&options.ChartName, "chart-name", "cert-manager", "Name of the chart to render")
+	cmd.Flags().MarkHidden("chart-name")
+	cmd.Flags().StringVar(&options.Registry, "registry", "", "Registry prefix to rewrite the images to, e.g. registry.example.com/mirror")
+	addChartArchiveFlag(cmd, &options.ChartArchive)
+
+	return cmd
+}
+
+// runImages renders the chart without contacting a cluster and returns the
+// sorted list of unique images found in the rendered manifests and hooks.
+func (o *ImagesOptions) runImages() ([]string, error) {
+	log.SetFlags(0)         // Disable prefixing logs with timestamps.
+	log.SetOutput(o.ErrOut) // Log everything to stderr so the output does not get corrupted.
+
+	chart, err := loadChart(o.ChartArchive, o.ChartName, &o.client.ChartPathOptions, o.settings)
+	if err != nil {
+		return nil, err
+	}
+
+	p := getter.All(o.settings)
+	chartValues, err := o.valueOpts.MergeValues(p)
+	if err != nil {
+		return nil, err
+	}
+	chartValues[installCRDsFlagName] = false // CRDs do not contain any images
+
+	o.client.DryRun = true
+	o.client.ClientOnly = true
+	o.client.ReleaseName = "cert-manager"
+	o.client.Namespace = defaultCertManagerNamespace
+	// See runInstall for why the Kube version is overridden.
+	o.client.KubeVersion = &chartutil.KubeVersion{
+		Version: "v999.999.999",
+		Major:   "999",
+		Minor:   "999",
+	}
+	rel, err := o.client.Run(chart, chartValues)
+	if err != nil {
+		return nil, err
+	}
+
+	// The startupapicheck Job is a hook, so it is not part of the manifest
+	manifests := []string{rel.Manifest}
+	for _, hook := range rel.Hooks {
+		manifests = append(manifests, hook.Manifest)
+	}
+
+	return imagesFromManifests(manifests)
+}
+
+// imagesFromManifests returns the sorted list of unique images used by the
+// containers of the resources in the given multi-document YAML manifests.
+func imagesFromManifests(manifests []string) ([]string, error) {
+	found := map[string]struct{}{}
+	for _, manifest := range manifests {
+		for _, doc := range releaseutil.SplitManifests(manifest) {
+			var obj interface{}
+			if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+				return nil, fmt.Errorf("failed to parse rendered manifest: %w", err)
+			}
+			collectImages(obj, found)
+		}
+	}
+
+	images := make([]string, 0, len(found))
+	for image := range found {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	return images, nil
+}
+
+// collectImages walks the given object and adds the images of all containers
+// found in any pod spec, as well as the ACME HTTP01 solver image passed as an
+// argument to a container.
+func collectImages(obj interface{}, found map[string]struct{}) {
+	switch obj := obj.(type) {
+	case map[string]interface{}:
+		for key, value := range obj {
+			switch key {
+			case "containers", "initContainers", "ephemeralContainers":
+				containers, _ := value.([]interface{})
+				for _, container := range containers {
+					container, _ := container.(map[string]interface{})
+					if image, _ := container["image"].(string); image != "" {
+						found[image] = struct{}{}
+					}
+					args, _ := container["args"].([]interface{})
+					for _, arg := range args {
+						arg, _ := arg.(string)
+						if strings.HasPrefix(arg, acmeSolverImageFlag) {
+							found[strings.TrimPrefix(arg, acmeSolverImageFlag)] = struct{}{}
+						}
+					}
+				}
+			default:
+				collectImages(value, found)
+			}
+		}
+	case []interface{}:
+		for _, value := range obj {
+			collectImages(value, found)
+		}
+	}
+}
+
+// rewriteImageRegistry replaces the registry of the image with the given
+// registry prefix, keeping its repository path, tag and digest. Like Docker,
+// the first path component of the image is only treated as a registry if it
+// contains a '.' or ':' or is "localhost"; otherwise the image is on Docker Hub
+// and keeps its full path.
+func rewriteImageRegistry(image, registry string) string {
+	name := image
+	if i := strings.Index(image, "/"); i >= 0 {
+		if host := image[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			name = image[i+1:]
+		}
+	}
+	return strings.TrimSuffix(registry, "/") + "/" + name
+}
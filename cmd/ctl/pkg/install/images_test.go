@@ -0,0 +1,202 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package install
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chartutil"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const testValues = `init:
+  image: busybox:1.36
+acmesolver:
+  image: quay.io/jetstack/cert-manager-acmesolver:v1.99.0
+`
+
+const testDeploymentTemplate = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: cert-manager
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: {{ .Values.init.image }}
+      containers:
+      - name: cert-manager-controller
+        image: "quay.io/jetstack/cert-manager-controller:{{ .Chart.AppVersion }}"
+        args:
+        - --v=2
+        - --acme-http01-solver-image={{ .Values.acmesolver.image }}
+`
+
+const testHookTemplate = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: cert-manager-startupapicheck
+  annotations:
+    helm.sh/hook: post-install
+spec:
+  template:
+    spec:
+      containers:
+      - name: cert-manager-startupapicheck
+        image: "quay.io/jetstack/cert-manager-ctl:{{ .Chart.AppVersion }}"
+`
+
+// saveTestChart packages a minimal cert-manager chart into a temporary
+// directory and returns the path of the archive.
+func saveTestChart(t *testing.T) string {
+	ch := &chart.Chart{
+		Metadata: &chart.Metadata{
+			APIVersion: chart.APIVersionV1,
+			Name:       "cert-manager",
+			Version:    "v1.99.0",
+			AppVersion: "v1.99.0",
+		},
+		Raw: []*chart.File{
+			{Name: chartutil.ValuesfileName, Data: []byte(testValues)},
+		},
+		Templates: []*chart.File{
+			{Name: "templates/deployment.yaml", Data: []byte(testDeploymentTemplate)},
+			{Name: "templates/startupapicheck-job.yaml", Data: []byte(testHookTemplate)},
+		},
+	}
+
+	path, err := chartutil.Save(ch, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunImages(t *testing.T) {
+	archive := saveTestChart(t)
+
+	tests := map[string]struct {
+		args []string
+
+		expectedOut string
+		expectedErr bool
+	}{
+		"list the images of the chart, its hooks and the acmesolver": {
+			args: []string{"--chart-archive", archive},
+			expectedOut: `busybox:1.36
+quay.io/jetstack/cert-manager-acmesolver:v1.99.0
+quay.io/jetstack/cert-manager-controller:v1.99.0
+quay.io/jetstack/cert-manager-ctl:v1.99.0
+`,
+		},
+		"apply values when rendering the chart": {
+			args: []string{"--chart-archive", archive, "--set", "acmesolver.image=example.com/acmesolver:v1"},
+			expectedOut: `busybox:1.36
+example.com/acmesolver:v1
+quay.io/jetstack/cert-manager-controller:v1.99.0
+quay.io/jetstack/cert-manager-ctl:v1.99.0
+`,
+		},
+		"rewrite images to a registry": {
+			args: []string{"--chart-archive", archive, "--registry", "registry.example.com/mirror/"},
+			expectedOut: `busybox:1.36 registry.example.com/mirror/busybox:1.36
+quay.io/jetstack/cert-manager-acmesolver:v1.99.0 registry.example.com/mirror/jetstack/cert-manager-acmesolver:v1.99.0
+quay.io/jetstack/cert-manager-controller:v1.99.0 registry.example.com/mirror/jetstack/cert-manager-controller:v1.99.0
+quay.io/jetstack/cert-manager-ctl:v1.99.0 registry.example.com/mirror/jetstack/cert-manager-ctl:v1.99.0
+`,
+		},
+		"fail if the chart archive does not exist": {
+			args:        []string{"--chart-archive", archive + ".missing"},
+			expectedErr: true,
+		},
+		"fail if both a chart archive and version are given": {
+			args:        []string{"--chart-archive", archive, "--version", "v1.99.0"},
+			expectedErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			cmd := NewCmdImages(context.Background(), streams)
+			cmd.SetArgs(test.args)
+
+			err := cmd.Execute()
+			if test.expectedErr != (err != nil) {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+			if got := out.String(); got != test.expectedOut {
+				t.Errorf("unexpected output, exp=%q got=%q", test.expectedOut, got)
+			}
+		})
+	}
+}
+
+func TestImagesFromManifests(t *testing.T) {
+	manifests := []string{`apiVersion: v1
+kind: Pod
+spec:
+  containers:
+  - image: b:1
+  - image: a:1
+---
+apiVersion: batch/v1
+kind: CronJob
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+          - image: a:1
+          ephemeralContainers:
+          - image: c:1
+`, `apiVersion: v1
+kind: ConfigMap
+data:
+  image: not-an-image
+`}
+
+	images, err := imagesFromManifests(manifests)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := []string{"a:1", "b:1", "c:1"}; !reflect.DeepEqual(images, exp) {
+		t.Errorf("unexpected images, exp=%v got=%v", exp, images)
+	}
+}
+
+func TestRewriteImageRegistry(t *testing.T) {
+	tests := map[string]string{
+		"quay.io/jetstack/cert-manager-controller:v1.11.0": "mirror.local/jetstack/cert-manager-controller:v1.11.0",
+		"registry.k8s.io/pause@sha256:abc":                 "mirror.local/pause@sha256:abc",
+		"localhost/foo:v1":                                 "mirror.local/foo:v1",
+		"localhost:5000/foo/bar:v1":                        "mirror.local/foo/bar:v1",
+		"jetstack/cert-manager-ctl:v1":                     "mirror.local/jetstack/cert-manager-ctl:v1",
+		"busybox":                                          "mirror.local/busybox",
+	}
+
+	for image, exp := range tests {
+		if got := rewriteImageRegistry(image, "mirror.local"); got != exp {
+			t.Errorf("rewriteImageRegistry(%q): exp=%q got=%q", image, exp, got)
+		}
+	}
+}
@@ -28,7 +28,6 @@ import (
 	"github.com/spf13/cobra"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
-	"helm.sh/helm/v3/pkg/chart/loader"
 	"helm.sh/helm/v3/pkg/chartutil"
 	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/cli/values"
@@ -46,9 +45,10 @@ type InstallOptions struct {
 	cfg       *action.Configuration
 	valueOpts *values.Options
 
-	ChartName string
-	DryRun    bool
-	Wait      bool
+	ChartName    string
+	ChartArchive string
+	DryRun       bool
+	Wait         bool
 
 	genericclioptions.IOStreams
 }
@@ -62,6 +62,9 @@ func installDesc() string {
 	return build.WithTemplate(`This command installs cert-manager. It uses the Helm libraries to do so.
 
 The latest published cert-manager chart in the "https://charts.jetstack.io" repo is used.
+In air-gapped environments, a chart archive downloaded in advance can be installed
+using the '--chart-archive' flag instead. The images it deploys can be listed and
+mirrored using '{{.BuildName}} x images'.
 Most of the features supported by 'helm install' are also supported by this command.
 In addition, this command will always correctly install the required CRD resources.
 
@@ -73,6 +76,8 @@ or
 	$ {{.BuildName}} x install --version v1.4.0
 or
 	$ {{.BuildName}} x install --set prometheus.enabled=false
+or
+	$ {{.BuildName}} x install --chart-archive ./cert-manager-v1.4.0.tgz
 
 To override values in the cert-manager chart, use either the '--values' flag and
 pass in a file or use the '--set' flag and pass configuration from the command line.
@@ -140,6 +145,7 @@ func NewCmdInstall(ctx context.Context, ioStreams genericclioptions.IOStreams) *
 	cmd.Flags().StringVar(&options.ChartName, "chart-name", "cert-manager", "Name of the chart to install")
 	cmd.Flags().MarkHidden("chart-name")
 	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "Simulate install and output manifest")
+	addChartArchiveFlag(cmd, &options.ChartArchive)
 
 	return cmd
 }
@@ -164,12 +170,7 @@ func (o *InstallOptions) runInstall(ctx context.Context) (*release.Release, erro
 	log.SetOutput(o.ErrOut) // Log everything to stderr so dry-run output does not get corrupted.
 
 	// Find chart
-	cp, err := o.client.ChartPathOptions.LocateChart(o.ChartName, o.settings)
-	if err != nil {
-		return nil, err
-	}
-
-	chart, err := loader.Load(cp)
+	chart, err := loadChart(o.ChartArchive, o.ChartName, &o.client.ChartPathOptions, o.settings)
 	if err != nil {
 		return nil, err
 	}
@@ -17,12 +17,17 @@ limitations under the License.
 package install
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/cli"
 	"helm.sh/helm/v3/pkg/cli/values"
 	"k8s.io/client-go/util/homedir"
 )
@@ -68,3 +73,34 @@ func addChartPathOptionsFlags(f *pflag.FlagSet, c *action.ChartPathOptions) {
 	c.RepoURL = "https://charts.jetstack.io"
 	f.StringVar(&c.Version, "version", "", "specify a version constraint for the chart version to use. This constraint can be a specific tag (e.g. 1.1.1) or it may reference a valid range (e.g. ^2.0.0). If this is not specified, the latest version is used")
 }
+
+// addChartArchiveFlag adds the --chart-archive flag, which can not be combined
+// with --version since the archive contains a single chart version.
+func addChartArchiveFlag(cmd *cobra.Command, chartArchive *string) {
+	cmd.Flags().StringVar(chartArchive, "chart-archive", "", "Path to a local cert-manager chart archive (.tgz) to use instead of downloading the chart from the repository")
+	cmd.MarkFlagsMutuallyExclusive("chart-archive", "version")
+}
+
+// loadChart loads the chart from chartArchive if it is set. Otherwise the
+// chart with the given name is located, and if needed downloaded, using the
+// chart path options.
+func loadChart(chartArchive, chartName string, c *action.ChartPathOptions, settings *cli.EnvSettings) (*chart.Chart, error) {
+	cp := chartArchive
+	if cp != "" {
+		info, err := os.Stat(cp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chart archive: %w", err)
+		}
+		if info.IsDir() {
+			return nil, fmt.Errorf("chart archive %q is a directory, expected a packaged chart", cp)
+		}
+	} else {
+		var err error
+		cp, err = c.LocateChart(chartName, settings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return loader.Load(cp)
+}
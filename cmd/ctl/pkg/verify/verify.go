@@ -0,0 +1,220 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cert-manager/cert-manager/cmd/ctl/pkg/build"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/receipt"
+)
+
+var (
+	long = templates.LongDesc(i18n.T(`
+Verify the issuance receipt of a certificate offline.
+
+The issuance receipt is read from the annotations of a Secret or a
+CertificateRequest manifest, which may be in YAML or JSON format. The signature
+of the receipt is checked using the public key of the receipt signing key
+configured on the cert-manager controller, and the serial number and SANs of
+the receipt are checked against the certificate stored in the manifest.`))
+
+	example = templates.Examples(i18n.T(build.WithTemplate(`
+# Export the public key of the receipt signing key
+kubectl get secret receipt-signing-key -n cert-manager -o jsonpath='{.data.tls\.key}' | base64 -d | openssl pkey -pubout > receipt.pub
+
+# Verify the issuance receipt of the Secret 'my-crt-tls'
+kubectl get secret my-crt-tls -o yaml > secret.yaml
+{{.BuildName}} verify -f secret.yaml --public-key receipt.pub
+
+# Verify the issuance receipt of the CertificateRequest 'my-crt-1'
+kubectl get certificaterequest my-crt-1 -o yaml > cr.yaml
+{{.BuildName}} verify -f cr.yaml --public-key receipt.pub
+`)))
+)
+
+// Options is a struct to support verify command
+type Options struct {
+	// Filename is the path to the Secret or CertificateRequest manifest.
+	Filename string
+	// PublicKeyFilename is the path to the PEM encoded public key of the
+	// receipt signing key.
+	PublicKeyFilename string
+
+	genericclioptions.IOStreams
+}
+
+// NewOptions returns initialized Options
+func NewOptions(ioStreams genericclioptions.IOStreams) *Options {
+	return &Options{
+		IOStreams: ioStreams,
+	}
+}
+
+// NewCmdVerify returns a cobra command for verifying issuance receipts
+func NewCmdVerify(ctx context.Context, ioStreams genericclioptions.IOStreams) *cobra.Command {
+	o := NewOptions(ioStreams)
+
+	cmd := &cobra.Command{
+		Use:                   "verify",
+		Short:                 "Verify the issuance receipt of a certificate offline",
+		Long:                  long,
+		Example:               example,
+		DisableFlagsInUseLine: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Validate(args))
+			cmdutil.CheckErr(o.Run())
+		},
+	}
+
+	cmd.Flags().StringVarP(&o.Filename, "filename", "f", o.Filename, "Path to a Secret or CertificateRequest manifest holding an issuance receipt.")
+	cmd.Flags().StringVar(&o.PublicKeyFilename, "public-key", o.PublicKeyFilename, "Path to the PEM encoded public key of the receipt signing key.")
+
+	return cmd
+}
+
+// Validate validates the provided options
+func (o *Options) Validate(args []string) error {
+	if len(args) > 0 {
+		return errors.New("verify does not take any arguments")
+	}
+	if len(o.Filename) == 0 {
+		return errors.New("the path to a Secret or CertificateRequest manifest has to be provided with --filename")
+	}
+	if len(o.PublicKeyFilename) == 0 {
+		return errors.New("the path to the public key of the receipt signing key has to be provided with --public-key")
+	}
+	return nil
+}
+
+// Run executes verify command
+func (o *Options) Run() error {
+	manifest, err := os.ReadFile(o.Filename)
+	if err != nil {
+		return fmt.Errorf("error reading manifest: %w", err)
+	}
+	keyBytes, err := os.ReadFile(o.PublicKeyFilename)
+	if err != nil {
+		return fmt.Errorf("error reading public key: %w", err)
+	}
+	publicKey, err := receipt.DecodePublicKeyBytes(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	token, certBytes, err := receiptAndCertificate(manifest)
+	if err != nil {
+		return err
+	}
+
+	r, err := receipt.Verify(token, publicKey)
+	if err != nil {
+		return err
+	}
+	cert, err := pki.DecodeX509CertificateBytes(certBytes)
+	if err != nil {
+		return fmt.Errorf("error decoding certificate: %w", err)
+	}
+	if err := r.MatchesCertificate(cert); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Issuance receipt is valid:\n%s\n", describeReceipt(r))
+
+	return nil
+}
+
+// receiptAndCertificate returns the issuance receipt and the PEM encoded
+// certificate of the given Secret or CertificateRequest manifest.
+func receiptAndCertificate(manifest []byte) (string, []byte, error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(manifest, &typeMeta); err != nil {
+		return "", nil, fmt.Errorf("error decoding manifest: %w", err)
+	}
+
+	var (
+		meta      metav1.ObjectMeta
+		certBytes []byte
+	)
+	switch typeMeta.Kind {
+	case "Secret":
+		var secret corev1.Secret
+		if err := yaml.Unmarshal(manifest, &secret); err != nil {
+			return "", nil, fmt.Errorf("error decoding Secret: %w", err)
+		}
+		meta, certBytes = secret.ObjectMeta, secret.Data[corev1.TLSCertKey]
+	case cmapi.CertificateRequestKind:
+		var req cmapi.CertificateRequest
+		if err := yaml.Unmarshal(manifest, &req); err != nil {
+			return "", nil, fmt.Errorf("error decoding CertificateRequest: %w", err)
+		}
+		meta, certBytes = req.ObjectMeta, req.Status.Certificate
+	default:
+		return "", nil, fmt.Errorf("unsupported kind %q, expected a Secret or a CertificateRequest", typeMeta.Kind)
+	}
+
+	token, ok := meta.Annotations[cmapi.IssuanceReceiptAnnotationKey]
+	if !ok {
+		return "", nil, fmt.Errorf("%s %s/%s has no %q annotation", typeMeta.Kind, meta.Namespace, meta.Name, cmapi.IssuanceReceiptAnnotationKey)
+	}
+	if len(certBytes) == 0 {
+		return "", nil, fmt.Errorf("%s %s/%s does not contain a certificate", typeMeta.Kind, meta.Namespace, meta.Name)
+	}
+
+	return token, certBytes, nil
+}
+
+func describeReceipt(r *receipt.Receipt) string {
+	issuerRef := r.IssuerRef.Name
+	if len(r.IssuerRef.Kind) > 0 {
+		issuerRef = r.IssuerRef.Kind + "/" + issuerRef
+	}
+	if len(r.IssuerRef.Group) > 0 {
+		issuerRef = issuerRef + " (" + r.IssuerRef.Group + ")"
+	}
+
+	lines := []string{
+		fmt.Sprintf("Certificate:\t%s/%s", r.Certificate.Namespace, r.Certificate.Name),
+		fmt.Sprintf("Revision:\t%d", r.Revision),
+		fmt.Sprintf("CertificateRequest:\t%s", r.CertificateRequest),
+		fmt.Sprintf("Serial Number:\t%s", r.SerialNumber),
+		fmt.Sprintf("DNS Names:\t%s", strings.Join(r.DNSNames, ", ")),
+		fmt.Sprintf("IP Addresses:\t%s", strings.Join(r.IPAddresses, ", ")),
+		fmt.Sprintf("URIs:\t%s", strings.Join(r.URIs, ", ")),
+		fmt.Sprintf("Email Addresses:\t%s", strings.Join(r.EmailAddresses, ", ")),
+		fmt.Sprintf("Issuer:\t%s", issuerRef),
+		fmt.Sprintf("Requested By:\t%s", r.Requester),
+		fmt.Sprintf("Issued At:\t%s", r.IssuedAt),
+	}
+
+	return "\t" + strings.Join(lines, "\n\t")
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verify
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	fakeclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/yaml"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/receipt"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRun(t *testing.T) {
+	signingKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	otherKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	crt := gen.Certificate("test", gen.SetCertificateNamespace("ns"), gen.SetCertificateDNSNames("example.com"))
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fakeclock.NewFakeClock(time.Now()))
+	otherBundle := testcrypto.MustCreateCryptoBundle(t, crt, fakeclock.NewFakeClock(time.Now()))
+
+	token, err := receipt.Sign(receipt.New(crt, bundle.CertificateRequestReady, bundle.Cert, 1, time.Now()), signingKey)
+	require.NoError(t, err)
+
+	secret := func(certPEM []byte) *corev1.Secret {
+		return &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-tls",
+				Namespace:   "ns",
+				Annotations: map[string]string{cmapi.IssuanceReceiptAnnotationKey: token},
+			},
+			Data: map[string][]byte{corev1.TLSCertKey: certPEM},
+		}
+	}
+	req := gen.CertificateRequestFrom(bundle.CertificateRequestReady,
+		gen.AddCertificateRequestAnnotations(map[string]string{cmapi.IssuanceReceiptAnnotationKey: token}))
+	req.TypeMeta = metav1.TypeMeta{APIVersion: cmapi.SchemeGroupVersion.String(), Kind: cmapi.CertificateRequestKind}
+
+	tests := map[string]struct {
+		manifest    interface{}
+		publicKey   interface{}
+		expectedErr string
+	}{
+		"should verify the receipt of a Secret": {
+			manifest:  secret(bundle.CertBytes),
+			publicKey: signingKey.Public(),
+		},
+		"should verify the receipt of a CertificateRequest": {
+			manifest:  req,
+			publicKey: signingKey.Public(),
+		},
+		"should fail if the receipt was signed by another key": {
+			manifest:    secret(bundle.CertBytes),
+			publicKey:   otherKey.Public(),
+			expectedErr: "invalid receipt signature: square/go-jose: error in cryptographic primitive",
+		},
+		"should fail if the receipt was issued for another certificate": {
+			manifest:    secret(otherBundle.CertBytes),
+			publicKey:   signingKey.Public(),
+			expectedErr: "serial number",
+		},
+		"should fail if the manifest has no receipt": {
+			manifest:    &corev1.Secret{TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"}, ObjectMeta: metav1.ObjectMeta{Name: "test-tls", Namespace: "ns"}},
+			publicKey:   signingKey.Public(),
+			expectedErr: `Secret ns/test-tls has no "cert-manager.io/issuance-receipt" annotation`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			manifest, err := yaml.Marshal(test.manifest)
+			require.NoError(t, err)
+			manifestPath := filepath.Join(dir, "manifest.yaml")
+			require.NoError(t, os.WriteFile(manifestPath, manifest, 0600))
+
+			der, err := x509.MarshalPKIXPublicKey(test.publicKey)
+			require.NoError(t, err)
+			publicKeyPath := filepath.Join(dir, "receipt.pub")
+			require.NoError(t, os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600))
+
+			streams, _, out, _ := genericclioptions.NewTestIOStreams()
+			o := NewOptions(streams)
+			o.Filename = manifestPath
+			o.PublicKeyFilename = publicKeyPath
+			require.NoError(t, o.Validate(nil))
+
+			err = o.Run()
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.True(t, strings.HasPrefix(err.Error(), test.expectedErr), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), "Issuance receipt is valid:")
+			assert.Contains(t, out.String(), "Certificate:\tns/test")
+		})
+	}
+}
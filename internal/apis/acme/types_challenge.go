@@ -128,4 +128,8 @@ type ChallengeStatus struct {
 	// State contains the current 'state' of the challenge.
 	// If not set, the state of the challenge is unknown.
 	State State
+
+	// SelectedZone is the DNS zone in which the DNS01 challenge record was
+	// presented, as selected by the DNS01 provider.
+	SelectedZone string
 }
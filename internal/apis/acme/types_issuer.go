@@ -102,6 +102,28 @@ type ACMEIssuer struct {
 	// it it will create an error on the Order.
 	// Defaults to false.
 	EnableDurationFeature bool
+
+	// PrivateKeyFallbacks is an ordered list of private key algorithms and
+	// sizes to fall back to when the ACME server rejects a CSR during order
+	// finalization because of its private key, for example because the key
+	// is too weak or not supported by the server. On such a rejection,
+	// cert-manager generates a new private key using the next entry of this
+	// list and retries the issuance. If the list is empty or exhausted, the
+	// issuance is retried with the same key after the usual backoff.
+	PrivateKeyFallbacks []ACMEPrivateKeyFallback
+}
+
+// ACMEPrivateKeyFallback is a private key algorithm and size which is used
+// instead of those requested by a Certificate when the ACME server rejects
+// the Certificate's private key.
+type ACMEPrivateKeyFallback struct {
+	// Algorithm is the private key algorithm of the fallback key.
+	// One of `RSA`, `ECDSA` or `Ed25519`.
+	Algorithm string
+
+	// Size is the key bit size of the fallback key. It has the same meaning
+	// and defaults as `spec.privateKey.size` on a Certificate.
+	Size int
 }
 
 // ACMEExternalAccountBinding is a reference to a CA external account of the ACME
@@ -184,6 +206,26 @@ type CertificateDNSNameSelector struct {
 	// If neither has more matches, the solver defined earlier in the list
 	// will be selected.
 	DNSZones []string
+
+	// A selector matching the user that requested the certificate, as
+	// recorded on the CertificateRequest or Kubernetes
+	// CertificateSigningRequest. Solvers using this selector can only be
+	// selected for Orders created for a CertificateRequest or
+	// CertificateSigningRequest.
+	// If multiple solvers match, each matching requester criterion counts
+	// the same as a matching label in matchLabels.
+	MatchRequester *CertificateRequesterSelector
+}
+
+// CertificateRequesterSelector selects a challenge solver based on the
+// identity of the user that requested the certificate.
+type CertificateRequesterSelector struct {
+	// List of usernames, one of which must be the username of the requester.
+	Usernames []string
+
+	// List of groups, at least one of which the requester must be a member
+	// of.
+	Groups []string
 }
 
 // ACMEChallengeSolverHTTP01 contains configuration detailing how to solve
@@ -204,6 +246,62 @@ type ACMEChallengeSolverHTTP01 struct {
 	// This solver is experimental, and fields / behaviour may change in the future.
 	// +optional
 	GatewayHTTPRoute *ACMEChallengeSolverHTTP01GatewayHTTPRoute
+
+	// The publish HTTP01 challenge solver does not create any pods, services
+	// or routes. Instead, challenge tokens are published as key-value pairs to
+	// a ConfigMap, a Secret or an HTTP endpoint, from which servers outside of
+	// the cluster serve them. The self check still verifies that the token is
+	// served before the challenge is accepted.
+	// +optional
+	Publish *ACMEChallengeSolverHTTP01Publish
+}
+
+// ACMEChallengeSolverHTTP01Publish configures where the tokens of HTTP01
+// challenges are published. Exactly one of the fields must be set.
+type ACMEChallengeSolverHTTP01Publish struct {
+	// ConfigMap publishes tokens as keys of a ConfigMap, with the key
+	// authorization as value.
+	// +optional
+	ConfigMap *ACMEChallengeSolverHTTP01PublishObject
+
+	// Secret publishes tokens as keys of a Secret, with the key authorization
+	// as value.
+	// +optional
+	Secret *ACMEChallengeSolverHTTP01PublishObject
+
+	// Webhook publishes tokens by sending requests to an HTTP endpoint.
+	// +optional
+	Webhook *ACMEChallengeSolverHTTP01PublishWebhook
+}
+
+// ACMEChallengeSolverHTTP01PublishObject is a ConfigMap or Secret to which
+// challenge tokens are published.
+type ACMEChallengeSolverHTTP01PublishObject struct {
+	// Name of the ConfigMap or Secret. It is created if it does not exist. It
+	// is in the namespace of the Issuer, or in the cluster resource namespace
+	// for a ClusterIssuer.
+	Name string
+}
+
+// ACMEChallengeSolverHTTP01PublishWebhook is an HTTP endpoint to which
+// challenge tokens are pushed.
+type ACMEChallengeSolverHTTP01PublishWebhook struct {
+	// URL of the endpoint. A token is published with a PUT request to
+	// `<url>/<token>` whose body is the key authorization, and removed with a
+	// DELETE request to the same URL.
+	URL string
+
+	// CABundle is a PEM encoded CA bundle used to validate the TLS certificate
+	// of the endpoint. If unset, the system trust store is used.
+	// +optional
+	CABundle []byte
+
+	// AuthorizationSecretRef is a reference to a key in a Secret containing a
+	// bearer token, which is sent in the Authorization header of every
+	// request. The Secret is in the namespace of the Issuer, or in the cluster
+	// resource namespace for a ClusterIssuer.
+	// +optional
+	AuthorizationSecretRef *cmmeta.SecretKeySelector
 }
 
 type ACMEChallengeSolverHTTP01Ingress struct {
@@ -214,9 +312,16 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 
 	// The ingress class to use when creating Ingress resources to solve ACME
 	// challenges that use this challenge solver.
-	// Only one of 'class' or 'name' may be specified.
+	// Only one of 'class', 'ingressClassName' or 'name' may be specified.
 	Class *string
 
+	// The ingress class name to set in the spec.ingressClassName field of
+	// Ingress resources created to solve ACME challenges that use this
+	// challenge solver.
+	// Only one of 'class', 'ingressClassName' or 'name' may be specified.
+	// +optional
+	IngressClassName *string
+
 	// The name of the ingress resource that should have ACME challenge solving
 	// routes inserted into it in order to solve HTTP01 challenges.
 	// This is typically used in conjunction with ingress controllers like
@@ -386,6 +491,8 @@ type ACMEIssuerDNS01ProviderCloudDNS struct {
 	ServiceAccount *cmmeta.SecretKeySelector
 	Project        string
 	HostedZoneName string
+	ZoneVisibility DNSZoneVisibility
+	Network        string
 }
 
 // ACMEIssuerDNS01ProviderCloudflare is a structure containing the DNS
@@ -402,6 +509,11 @@ type ACMEIssuerDNS01ProviderCloudflare struct {
 
 	// API token used to authenticate with Cloudflare.
 	APIToken *cmmeta.SecretKeySelector
+
+	// ZoneID is the ID of the Cloudflare zone in which challenge records are
+	// created. If set, cert-manager does not need to look up the zone, so an
+	// API token scoped to this single zone is sufficient.
+	ZoneID string
 }
 
 // ACMEIssuerDNS01ProviderDigitalOcean is a structure containing the DNS
@@ -443,6 +555,10 @@ type ACMEIssuerDNS01ProviderRoute53 struct {
 
 	// Always set the region when using AccessKeyID and SecretAccessKey
 	Region string
+
+	ZoneVisibility DNSZoneVisibility
+
+	VPCID string
 }
 
 // ACMEIssuerDNS01ProviderAzureDNS is a structure containing the
@@ -466,6 +582,10 @@ type ACMEIssuerDNS01ProviderAzureDNS struct {
 	Environment AzureDNSEnvironment
 
 	ManagedIdentity *AzureManagedIdentity
+
+	ZoneVisibility DNSZoneVisibility
+
+	VirtualNetworkID string
 }
 
 type AzureManagedIdentity struct {
@@ -474,6 +594,13 @@ type AzureManagedIdentity struct {
 	ResourceID string
 }
 
+type DNSZoneVisibility string
+
+const (
+	DNSZoneVisibilityPublic  DNSZoneVisibility = "Public"
+	DNSZoneVisibilityPrivate DNSZoneVisibility = "Private"
+)
+
 type AzureDNSEnvironment string
 
 const (
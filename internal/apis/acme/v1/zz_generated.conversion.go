@@ -164,6 +164,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01Publish)(nil), (*acme.ACMEChallengeSolverHTTP01Publish)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(a.(*v1.ACMEChallengeSolverHTTP01Publish), b.(*acme.ACMEChallengeSolverHTTP01Publish), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01Publish)(nil), (*v1.ACMEChallengeSolverHTTP01Publish)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01Publish_To_v1_ACMEChallengeSolverHTTP01Publish(a.(*acme.ACMEChallengeSolverHTTP01Publish), b.(*v1.ACMEChallengeSolverHTTP01Publish), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01PublishObject)(nil), (*acme.ACMEChallengeSolverHTTP01PublishObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject(a.(*v1.ACMEChallengeSolverHTTP01PublishObject), b.(*acme.ACMEChallengeSolverHTTP01PublishObject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01PublishObject)(nil), (*v1.ACMEChallengeSolverHTTP01PublishObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1_ACMEChallengeSolverHTTP01PublishObject(a.(*acme.ACMEChallengeSolverHTTP01PublishObject), b.(*v1.ACMEChallengeSolverHTTP01PublishObject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEChallengeSolverHTTP01PublishWebhook)(nil), (*acme.ACMEChallengeSolverHTTP01PublishWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(a.(*v1.ACMEChallengeSolverHTTP01PublishWebhook), b.(*acme.ACMEChallengeSolverHTTP01PublishWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01PublishWebhook)(nil), (*v1.ACMEChallengeSolverHTTP01PublishWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1_ACMEChallengeSolverHTTP01PublishWebhook(a.(*acme.ACMEChallengeSolverHTTP01PublishWebhook), b.(*v1.ACMEChallengeSolverHTTP01PublishWebhook), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.ACMEExternalAccountBinding)(nil), (*acme.ACMEExternalAccountBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(a.(*v1.ACMEExternalAccountBinding), b.(*acme.ACMEExternalAccountBinding), scope)
 	}); err != nil {
@@ -274,6 +304,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.ACMEPrivateKeyFallback)(nil), (*acme.ACMEPrivateKeyFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback(a.(*v1.ACMEPrivateKeyFallback), b.(*acme.ACMEPrivateKeyFallback), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEPrivateKeyFallback)(nil), (*v1.ACMEPrivateKeyFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEPrivateKeyFallback_To_v1_ACMEPrivateKeyFallback(a.(*acme.ACMEPrivateKeyFallback), b.(*v1.ACMEPrivateKeyFallback), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.AzureManagedIdentity)(nil), (*acme.AzureManagedIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_AzureManagedIdentity_To_acme_AzureManagedIdentity(a.(*v1.AzureManagedIdentity), b.(*acme.AzureManagedIdentity), scope)
 	}); err != nil {
@@ -294,6 +334,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRequesterSelector)(nil), (*acme.CertificateRequesterSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector(a.(*v1.CertificateRequesterSelector), b.(*acme.CertificateRequesterSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.CertificateRequesterSelector)(nil), (*v1.CertificateRequesterSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_CertificateRequesterSelector_To_v1_CertificateRequesterSelector(a.(*acme.CertificateRequesterSelector), b.(*v1.CertificateRequesterSelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.Challenge)(nil), (*acme.Challenge)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_Challenge_To_acme_Challenge(a.(*v1.Challenge), b.(*acme.Challenge), scope)
 	}); err != nil {
@@ -441,7 +491,15 @@ func Convert_acme_ACMEChallenge_To_v1_ACMEChallenge(in *acme.ACMEChallenge, out
 
 func autoConvert_v1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *v1.ACMEChallengeSolver, out *acme.ACMEChallengeSolver, s conversion.Scope) error {
 	out.Selector = (*acme.CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
-	out.HTTP01 = (*acme.ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
+	if in.HTTP01 != nil {
+		in, out := &in.HTTP01, &out.HTTP01
+		*out = new(acme.ACMEChallengeSolverHTTP01)
+		if err := Convert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP01 = nil
+	}
 	if in.DNS01 != nil {
 		in, out := &in.DNS01, &out.DNS01
 		*out = new(acme.ACMEChallengeSolverDNS01)
@@ -461,7 +519,15 @@ func Convert_v1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *v1.ACMEChall
 
 func autoConvert_acme_ACMEChallengeSolver_To_v1_ACMEChallengeSolver(in *acme.ACMEChallengeSolver, out *v1.ACMEChallengeSolver, s conversion.Scope) error {
 	out.Selector = (*v1.CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
-	out.HTTP01 = (*v1.ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
+	if in.HTTP01 != nil {
+		in, out := &in.HTTP01, &out.HTTP01
+		*out = new(v1.ACMEChallengeSolverHTTP01)
+		if err := Convert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP01 = nil
+	}
 	if in.DNS01 != nil {
 		in, out := &in.DNS01, &out.DNS01
 		*out = new(v1.ACMEChallengeSolverDNS01)
@@ -648,6 +714,15 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1_ACMEChallengeSolverDNS01(in *ac
 func autoConvert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *v1.ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	if in.Publish != nil {
+		in, out := &in.Publish, &out.Publish
+		*out = new(acme.ACMEChallengeSolverHTTP01Publish)
+		if err := Convert_v1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Publish = nil
+	}
 	return nil
 }
 
@@ -659,6 +734,15 @@ func Convert_v1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *v1.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*v1.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*v1.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	if in.Publish != nil {
+		in, out := &in.Publish, &out.Publish
+		*out = new(v1.ACMEChallengeSolverHTTP01Publish)
+		if err := Convert_acme_ACMEChallengeSolverHTTP01Publish_To_v1_ACMEChallengeSolverHTTP01Publish(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Publish = nil
+	}
 	return nil
 }
 
@@ -694,6 +778,7 @@ func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1_ACMEChallengeS
 func autoConvert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *v1.ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -708,6 +793,7 @@ func Convert_v1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP
 func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1_ACMEChallengeSolverHTTP01Ingress(in *acme.ACMEChallengeSolverHTTP01Ingress, out *v1.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = corev1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*v1.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*v1.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -847,6 +933,106 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1_ACMEChallengeSo
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1_ACMEChallengeSolverHTTP01IngressTemplate(in, out, s)
 }
 
+func autoConvert_v1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(in *v1.ACMEChallengeSolverHTTP01Publish, out *acme.ACMEChallengeSolverHTTP01Publish, s conversion.Scope) error {
+	out.ConfigMap = (*acme.ACMEChallengeSolverHTTP01PublishObject)(unsafe.Pointer(in.ConfigMap))
+	out.Secret = (*acme.ACMEChallengeSolverHTTP01PublishObject)(unsafe.Pointer(in.Secret))
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(acme.ACMEChallengeSolverHTTP01PublishWebhook)
+		if err := Convert_v1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Webhook = nil
+	}
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(in *v1.ACMEChallengeSolverHTTP01Publish, out *acme.ACMEChallengeSolverHTTP01Publish, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01Publish_To_v1_ACMEChallengeSolverHTTP01Publish(in *acme.ACMEChallengeSolverHTTP01Publish, out *v1.ACMEChallengeSolverHTTP01Publish, s conversion.Scope) error {
+	out.ConfigMap = (*v1.ACMEChallengeSolverHTTP01PublishObject)(unsafe.Pointer(in.ConfigMap))
+	out.Secret = (*v1.ACMEChallengeSolverHTTP01PublishObject)(unsafe.Pointer(in.Secret))
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(v1.ACMEChallengeSolverHTTP01PublishWebhook)
+		if err := Convert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1_ACMEChallengeSolverHTTP01PublishWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Webhook = nil
+	}
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01Publish_To_v1_ACMEChallengeSolverHTTP01Publish is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01Publish_To_v1_ACMEChallengeSolverHTTP01Publish(in *acme.ACMEChallengeSolverHTTP01Publish, out *v1.ACMEChallengeSolverHTTP01Publish, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01Publish_To_v1_ACMEChallengeSolverHTTP01Publish(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject(in *v1.ACMEChallengeSolverHTTP01PublishObject, out *acme.ACMEChallengeSolverHTTP01PublishObject, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject(in *v1.ACMEChallengeSolverHTTP01PublishObject, out *acme.ACMEChallengeSolverHTTP01PublishObject, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1_ACMEChallengeSolverHTTP01PublishObject(in *acme.ACMEChallengeSolverHTTP01PublishObject, out *v1.ACMEChallengeSolverHTTP01PublishObject, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1_ACMEChallengeSolverHTTP01PublishObject is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1_ACMEChallengeSolverHTTP01PublishObject(in *acme.ACMEChallengeSolverHTTP01PublishObject, out *v1.ACMEChallengeSolverHTTP01PublishObject, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1_ACMEChallengeSolverHTTP01PublishObject(in, out, s)
+}
+
+func autoConvert_v1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(in *v1.ACMEChallengeSolverHTTP01PublishWebhook, out *acme.ACMEChallengeSolverHTTP01PublishWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthorizationSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook is an autogenerated conversion function.
+func Convert_v1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(in *v1.ACMEChallengeSolverHTTP01PublishWebhook, out *acme.ACMEChallengeSolverHTTP01PublishWebhook, s conversion.Scope) error {
+	return autoConvert_v1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1_ACMEChallengeSolverHTTP01PublishWebhook(in *acme.ACMEChallengeSolverHTTP01PublishWebhook, out *v1.ACMEChallengeSolverHTTP01PublishWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthorizationSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1_ACMEChallengeSolverHTTP01PublishWebhook is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1_ACMEChallengeSolverHTTP01PublishWebhook(in *acme.ACMEChallengeSolverHTTP01PublishWebhook, out *v1.ACMEChallengeSolverHTTP01PublishWebhook, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1_ACMEChallengeSolverHTTP01PublishWebhook(in, out, s)
+}
+
 func autoConvert_v1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *v1.ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
@@ -906,6 +1092,7 @@ func autoConvert_v1_ACMEIssuer_To_acme_ACMEIssuer(in *v1.ACMEIssuer, out *acme.A
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.PrivateKeyFallbacks = *(*[]acme.ACMEPrivateKeyFallback)(unsafe.Pointer(&in.PrivateKeyFallbacks))
 	return nil
 }
 
@@ -940,6 +1127,7 @@ func autoConvert_acme_ACMEIssuer_To_v1_ACMEIssuer(in *acme.ACMEIssuer, out *v1.A
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.PrivateKeyFallbacks = *(*[]v1.ACMEPrivateKeyFallback)(unsafe.Pointer(&in.PrivateKeyFallbacks))
 	return nil
 }
 
@@ -1024,6 +1212,8 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS01Provi
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = acme.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*acme.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	out.ZoneVisibility = acme.DNSZoneVisibility(in.ZoneVisibility)
+	out.VirtualNetworkID = in.VirtualNetworkID
 	return nil
 }
 
@@ -1049,6 +1239,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1_ACMEIssuerDNS01Provi
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = v1.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*v1.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	out.ZoneVisibility = v1.DNSZoneVisibility(in.ZoneVisibility)
+	out.VirtualNetworkID = in.VirtualNetworkID
 	return nil
 }
 
@@ -1069,6 +1261,8 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01Provi
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.ZoneVisibility = acme.DNSZoneVisibility(in.ZoneVisibility)
+	out.Network = in.Network
 	return nil
 }
 
@@ -1089,6 +1283,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1_ACMEIssuerDNS01Provi
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.ZoneVisibility = v1.DNSZoneVisibility(in.ZoneVisibility)
+	out.Network = in.Network
 	return nil
 }
 
@@ -1117,6 +1313,7 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS01Pro
 	} else {
 		out.APIToken = nil
 	}
+	out.ZoneID = in.ZoneID
 	return nil
 }
 
@@ -1145,6 +1342,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1_ACMEIssuerDNS01Pro
 	} else {
 		out.APIToken = nil
 	}
+	out.ZoneID = in.ZoneID
 	return nil
 }
 
@@ -1224,6 +1422,8 @@ func autoConvert_v1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01Provid
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
 	out.Region = in.Region
+	out.ZoneVisibility = acme.DNSZoneVisibility(in.ZoneVisibility)
+	out.VPCID = in.VPCID
 	return nil
 }
 
@@ -1249,6 +1449,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1_ACMEIssuerDNS01Provid
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
 	out.Region = in.Region
+	out.ZoneVisibility = v1.DNSZoneVisibility(in.ZoneVisibility)
+	out.VPCID = in.VPCID
 	return nil
 }
 
@@ -1303,6 +1505,28 @@ func Convert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in *acme.ACMEIssuerSta
 	return autoConvert_acme_ACMEIssuerStatus_To_v1_ACMEIssuerStatus(in, out, s)
 }
 
+func autoConvert_v1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback(in *v1.ACMEPrivateKeyFallback, out *acme.ACMEPrivateKeyFallback, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_v1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback is an autogenerated conversion function.
+func Convert_v1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback(in *v1.ACMEPrivateKeyFallback, out *acme.ACMEPrivateKeyFallback, s conversion.Scope) error {
+	return autoConvert_v1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback(in, out, s)
+}
+
+func autoConvert_acme_ACMEPrivateKeyFallback_To_v1_ACMEPrivateKeyFallback(in *acme.ACMEPrivateKeyFallback, out *v1.ACMEPrivateKeyFallback, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_acme_ACMEPrivateKeyFallback_To_v1_ACMEPrivateKeyFallback is an autogenerated conversion function.
+func Convert_acme_ACMEPrivateKeyFallback_To_v1_ACMEPrivateKeyFallback(in *acme.ACMEPrivateKeyFallback, out *v1.ACMEPrivateKeyFallback, s conversion.Scope) error {
+	return autoConvert_acme_ACMEPrivateKeyFallback_To_v1_ACMEPrivateKeyFallback(in, out, s)
+}
+
 func autoConvert_v1_AzureManagedIdentity_To_acme_AzureManagedIdentity(in *v1.AzureManagedIdentity, out *acme.AzureManagedIdentity, s conversion.Scope) error {
 	out.ClientID = in.ClientID
 	out.ResourceID = in.ResourceID
@@ -1329,6 +1553,7 @@ func autoConvert_v1_CertificateDNSNameSelector_To_acme_CertificateDNSNameSelecto
 	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	out.MatchRequester = (*acme.CertificateRequesterSelector)(unsafe.Pointer(in.MatchRequester))
 	return nil
 }
 
@@ -1341,6 +1566,7 @@ func autoConvert_acme_CertificateDNSNameSelector_To_v1_CertificateDNSNameSelecto
 	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	out.MatchRequester = (*v1.CertificateRequesterSelector)(unsafe.Pointer(in.MatchRequester))
 	return nil
 }
 
@@ -1349,6 +1575,28 @@ func Convert_acme_CertificateDNSNameSelector_To_v1_CertificateDNSNameSelector(in
 	return autoConvert_acme_CertificateDNSNameSelector_To_v1_CertificateDNSNameSelector(in, out, s)
 }
 
+func autoConvert_v1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector(in *v1.CertificateRequesterSelector, out *acme.CertificateRequesterSelector, s conversion.Scope) error {
+	out.Usernames = *(*[]string)(unsafe.Pointer(&in.Usernames))
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	return nil
+}
+
+// Convert_v1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector is an autogenerated conversion function.
+func Convert_v1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector(in *v1.CertificateRequesterSelector, out *acme.CertificateRequesterSelector, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector(in, out, s)
+}
+
+func autoConvert_acme_CertificateRequesterSelector_To_v1_CertificateRequesterSelector(in *acme.CertificateRequesterSelector, out *v1.CertificateRequesterSelector, s conversion.Scope) error {
+	out.Usernames = *(*[]string)(unsafe.Pointer(&in.Usernames))
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	return nil
+}
+
+// Convert_acme_CertificateRequesterSelector_To_v1_CertificateRequesterSelector is an autogenerated conversion function.
+func Convert_acme_CertificateRequesterSelector_To_v1_CertificateRequesterSelector(in *acme.CertificateRequesterSelector, out *v1.CertificateRequesterSelector, s conversion.Scope) error {
+	return autoConvert_acme_CertificateRequesterSelector_To_v1_CertificateRequesterSelector(in, out, s)
+}
+
 func autoConvert_v1_Challenge_To_acme_Challenge(in *v1.Challenge, out *acme.Challenge, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_ChallengeSpec_To_acme_ChallengeSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1472,6 +1720,7 @@ func autoConvert_v1_ChallengeStatus_To_acme_ChallengeStatus(in *v1.ChallengeStat
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = acme.State(in.State)
+	out.SelectedZone = in.SelectedZone
 	return nil
 }
 
@@ -1485,6 +1734,7 @@ func autoConvert_acme_ChallengeStatus_To_v1_ChallengeStatus(in *acme.ChallengeSt
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = v1.State(in.State)
+	out.SelectedZone = in.SelectedZone
 	return nil
 }
 
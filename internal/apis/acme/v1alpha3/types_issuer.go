@@ -113,6 +113,31 @@ type ACMEIssuer struct {
 	// Defaults to false.
 	// +optional
 	EnableDurationFeature bool `json:"enableDurationFeature,omitempty"`
+
+	// PrivateKeyFallbacks is an ordered list of private key algorithms and
+	// sizes to fall back to when the ACME server rejects a CSR during order
+	// finalization because of its private key, for example because the key
+	// is too weak or not supported by the server. On such a rejection,
+	// cert-manager generates a new private key using the next entry of this
+	// list and retries the issuance. If the list is empty or exhausted, the
+	// issuance is retried with the same key after the usual backoff.
+	// +optional
+	PrivateKeyFallbacks []ACMEPrivateKeyFallback `json:"privateKeyFallbacks,omitempty"`
+}
+
+// ACMEPrivateKeyFallback is a private key algorithm and size which is used
+// instead of those requested by a Certificate when the ACME server rejects
+// the Certificate's private key.
+type ACMEPrivateKeyFallback struct {
+	// Algorithm is the private key algorithm of the fallback key.
+	// One of `RSA`, `ECDSA` or `Ed25519`.
+	// +kubebuilder:validation:Enum=RSA;ECDSA;Ed25519
+	Algorithm string `json:"algorithm"`
+
+	// Size is the key bit size of the fallback key. It has the same meaning
+	// and defaults as `spec.privateKey.size` on a Certificate.
+	// +optional
+	Size int `json:"size,omitempty"`
 }
 
 // ACMEExternalAccountBinding is a reference to a CA external account of the ACME
@@ -202,6 +227,29 @@ type CertificateDNSNameSelector struct {
 	// will be selected.
 	// +optional
 	DNSZones []string `json:"dnsZones,omitempty"`
+
+	// A selector matching the user that requested the certificate, as
+	// recorded on the CertificateRequest or Kubernetes
+	// CertificateSigningRequest. Solvers using this selector can only be
+	// selected for Orders created for a CertificateRequest or
+	// CertificateSigningRequest.
+	// If multiple solvers match, each matching requester criterion counts
+	// the same as a matching label in matchLabels.
+	// +optional
+	MatchRequester *CertificateRequesterSelector `json:"matchRequester,omitempty"`
+}
+
+// CertificateRequesterSelector selects a challenge solver based on the
+// identity of the user that requested the certificate.
+type CertificateRequesterSelector struct {
+	// List of usernames, one of which must be the username of the requester.
+	// +optional
+	Usernames []string `json:"usernames,omitempty"`
+
+	// List of groups, at least one of which the requester must be a member
+	// of.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
 }
 
 // ACMEChallengeSolverHTTP01 contains configuration detailing how to solve
@@ -224,6 +272,62 @@ type ACMEChallengeSolverHTTP01 struct {
 	// This solver is experimental, and fields / behaviour may change in the future.
 	// +optional
 	GatewayHTTPRoute *ACMEChallengeSolverHTTP01GatewayHTTPRoute `json:"gatewayHTTPRoute,omitempty"`
+
+	// The publish HTTP01 challenge solver does not create any pods, services
+	// or routes. Instead, challenge tokens are published as key-value pairs to
+	// a ConfigMap, a Secret or an HTTP endpoint, from which servers outside of
+	// the cluster serve them. The self check still verifies that the token is
+	// served before the challenge is accepted.
+	// +optional
+	Publish *ACMEChallengeSolverHTTP01Publish `json:"publish,omitempty"`
+}
+
+// ACMEChallengeSolverHTTP01Publish configures where the tokens of HTTP01
+// challenges are published. Exactly one of the fields must be set.
+type ACMEChallengeSolverHTTP01Publish struct {
+	// ConfigMap publishes tokens as keys of a ConfigMap, with the key
+	// authorization as value.
+	// +optional
+	ConfigMap *ACMEChallengeSolverHTTP01PublishObject `json:"configMap,omitempty"`
+
+	// Secret publishes tokens as keys of a Secret, with the key authorization
+	// as value.
+	// +optional
+	Secret *ACMEChallengeSolverHTTP01PublishObject `json:"secret,omitempty"`
+
+	// Webhook publishes tokens by sending requests to an HTTP endpoint.
+	// +optional
+	Webhook *ACMEChallengeSolverHTTP01PublishWebhook `json:"webhook,omitempty"`
+}
+
+// ACMEChallengeSolverHTTP01PublishObject is a ConfigMap or Secret to which
+// challenge tokens are published.
+type ACMEChallengeSolverHTTP01PublishObject struct {
+	// Name of the ConfigMap or Secret. It is created if it does not exist. It
+	// is in the namespace of the Issuer, or in the cluster resource namespace
+	// for a ClusterIssuer.
+	Name string `json:"name"`
+}
+
+// ACMEChallengeSolverHTTP01PublishWebhook is an HTTP endpoint to which
+// challenge tokens are pushed.
+type ACMEChallengeSolverHTTP01PublishWebhook struct {
+	// URL of the endpoint. A token is published with a PUT request to
+	// `<url>/<token>` whose body is the key authorization, and removed with a
+	// DELETE request to the same URL.
+	URL string `json:"url"`
+
+	// CABundle is a PEM encoded CA bundle used to validate the TLS certificate
+	// of the endpoint. If unset, the system trust store is used.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+
+	// AuthorizationSecretRef is a reference to a key in a Secret containing a
+	// bearer token, which is sent in the Authorization header of every
+	// request. The Secret is in the namespace of the Issuer, or in the cluster
+	// resource namespace for a ClusterIssuer.
+	// +optional
+	AuthorizationSecretRef *cmmeta.SecretKeySelector `json:"authorizationSecretRef,omitempty"`
 }
 
 type ACMEChallengeSolverHTTP01Ingress struct {
@@ -234,10 +338,17 @@ type ACMEChallengeSolverHTTP01Ingress struct {
 
 	// The ingress class to use when creating Ingress resources to solve ACME
 	// challenges that use this challenge solver.
-	// Only one of 'class' or 'name' may be specified.
+	// Only one of 'class', 'ingressClassName' or 'name' may be specified.
 	// +optional
 	Class *string `json:"class,omitempty"`
 
+	// The ingress class name to set in the spec.ingressClassName field of
+	// Ingress resources created to solve ACME challenges that use this
+	// challenge solver.
+	// Only one of 'class', 'ingressClassName' or 'name' may be specified.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
 	// The name of the ingress resource that should have ACME challenge solving
 	// routes inserted into it in order to solve HTTP01 challenges.
 	// This is typically used in conjunction with ingress controllers like
@@ -436,6 +547,19 @@ type ACMEIssuerDNS01ProviderCloudDNS struct {
 	// If left empty cert-manager will automatically choose a zone.
 	// +optional
 	HostedZoneName string `json:"hostedZoneName,omitempty"`
+
+	// ZoneVisibility selects whether a public or a private managed zone is
+	// used when cert-manager automatically chooses a zone. If unset, a public
+	// zone is preferred, falling back to a private zone if no public zone
+	// exists. Has no effect if HostedZoneName is set.
+	// +optional
+	ZoneVisibility DNSZoneVisibility `json:"zoneVisibility,omitempty"`
+
+	// Network restricts the automatically chosen private managed zone to
+	// zones visible to this VPC network, given by its name or URL.
+	// Can only be set if ZoneVisibility is Private.
+	// +optional
+	Network string `json:"network,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderCloudflare is a structure containing the DNS
@@ -455,6 +579,12 @@ type ACMEIssuerDNS01ProviderCloudflare struct {
 	// API token used to authenticate with Cloudflare.
 	// +optional
 	APIToken *cmmeta.SecretKeySelector `json:"apiTokenSecretRef,omitempty"`
+
+	// ZoneID is the ID of the Cloudflare zone in which challenge records are
+	// created. If set, cert-manager does not need to look up the zone, so an
+	// API token scoped to this single zone is sufficient.
+	// +optional
+	ZoneID string `json:"zoneID,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderDigitalOcean is a structure containing the DNS
@@ -492,6 +622,17 @@ type ACMEIssuerDNS01ProviderRoute53 struct {
 
 	// Always set the region when using AccessKeyID and SecretAccessKey
 	Region string `json:"region"`
+
+	// ZoneVisibility selects whether a public or a private hosted zone is
+	// used when the hosted zone is looked up by name. Defaults to Public.
+	// Has no effect if HostedZoneID is set.
+	// +optional
+	ZoneVisibility DNSZoneVisibility `json:"zoneVisibility,omitempty"`
+
+	// VPCID restricts the looked up private hosted zone to zones associated
+	// with this VPC. Can only be set if ZoneVisibility is Private.
+	// +optional
+	VPCID string `json:"vpcID,omitempty"`
 }
 
 // ACMEIssuerDNS01ProviderAzureDNS is a structure containing the
@@ -526,6 +667,17 @@ type ACMEIssuerDNS01ProviderAzureDNS struct {
 	// managed identity configuration, can not be used at the same time as clientID, clientSecretSecretRef or tenantID
 	// +optional
 	ManagedIdentity *AzureManagedIdentity `json:"managedIdentity,omitempty"`
+
+	// ZoneVisibility selects whether the challenge record is created in an
+	// Azure DNS zone or in an Azure Private DNS zone. Defaults to Public.
+	// +optional
+	ZoneVisibility DNSZoneVisibility `json:"zoneVisibility,omitempty"`
+
+	// VirtualNetworkID restricts the private DNS zone to zones linked to the
+	// virtual network with this resource ID. Can only be set if
+	// ZoneVisibility is Private.
+	// +optional
+	VirtualNetworkID string `json:"virtualNetworkID,omitempty"`
 }
 
 type AzureManagedIdentity struct {
@@ -538,6 +690,20 @@ type AzureManagedIdentity struct {
 	ResourceID string `json:"resourceID,omitempty"`
 }
 
+// DNSZoneVisibility is the visibility of a zone hosted by a cloud DNS
+// provider. Public zones are resolvable from the internet, whereas private
+// zones are only resolvable from within the networks they are attached to.
+// +kubebuilder:validation:Enum=Public;Private
+type DNSZoneVisibility string
+
+const (
+	// DNSZoneVisibilityPublic selects a zone resolvable from the internet.
+	DNSZoneVisibilityPublic DNSZoneVisibility = "Public"
+	// DNSZoneVisibilityPrivate selects a zone only resolvable from within
+	// the networks it is attached to.
+	DNSZoneVisibilityPrivate DNSZoneVisibility = "Private"
+)
+
 // +kubebuilder:validation:Enum=AzurePublicCloud;AzureChinaCloud;AzureGermanCloud;AzureUSGovernmentCloud
 type AzureDNSEnvironment string
 
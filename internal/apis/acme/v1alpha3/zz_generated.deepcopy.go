@@ -177,6 +177,11 @@ func (in *ACMEChallengeSolverHTTP01) DeepCopyInto(out *ACMEChallengeSolverHTTP01
 		*out = new(ACMEChallengeSolverHTTP01GatewayHTTPRoute)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Publish != nil {
+		in, out := &in.Publish, &out.Publish
+		*out = new(ACMEChallengeSolverHTTP01Publish)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -228,6 +233,11 @@ func (in *ACMEChallengeSolverHTTP01Ingress) DeepCopyInto(out *ACMEChallengeSolve
 		*out = new(string)
 		**out = **in
 	}
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
 	if in.PodTemplate != nil {
 		in, out := &in.PodTemplate, &out.PodTemplate
 		*out = new(ACMEChallengeSolverHTTP01IngressPodTemplate)
@@ -386,6 +396,79 @@ func (in *ACMEChallengeSolverHTTP01IngressTemplate) DeepCopy() *ACMEChallengeSol
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01Publish) DeepCopyInto(out *ACMEChallengeSolverHTTP01Publish) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ACMEChallengeSolverHTTP01PublishObject)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(ACMEChallengeSolverHTTP01PublishObject)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(ACMEChallengeSolverHTTP01PublishWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01Publish.
+func (in *ACMEChallengeSolverHTTP01Publish) DeepCopy() *ACMEChallengeSolverHTTP01Publish {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01Publish)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01PublishObject) DeepCopyInto(out *ACMEChallengeSolverHTTP01PublishObject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01PublishObject.
+func (in *ACMEChallengeSolverHTTP01PublishObject) DeepCopy() *ACMEChallengeSolverHTTP01PublishObject {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01PublishObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEChallengeSolverHTTP01PublishWebhook) DeepCopyInto(out *ACMEChallengeSolverHTTP01PublishWebhook) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(metav1.SecretKeySelector)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEChallengeSolverHTTP01PublishWebhook.
+func (in *ACMEChallengeSolverHTTP01PublishWebhook) DeepCopy() *ACMEChallengeSolverHTTP01PublishWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEChallengeSolverHTTP01PublishWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACMEExternalAccountBinding) DeepCopyInto(out *ACMEExternalAccountBinding) {
 	*out = *in
@@ -424,6 +507,11 @@ func (in *ACMEIssuer) DeepCopyInto(out *ACMEIssuer) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PrivateKeyFallbacks != nil {
+		in, out := &in.PrivateKeyFallbacks, &out.PrivateKeyFallbacks
+		*out = make([]ACMEPrivateKeyFallback, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -639,6 +727,22 @@ func (in *ACMEIssuerStatus) DeepCopy() *ACMEIssuerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ACMEPrivateKeyFallback) DeepCopyInto(out *ACMEPrivateKeyFallback) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACMEPrivateKeyFallback.
+func (in *ACMEPrivateKeyFallback) DeepCopy() *ACMEPrivateKeyFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(ACMEPrivateKeyFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureManagedIdentity) DeepCopyInto(out *AzureManagedIdentity) {
 	*out = *in
@@ -675,6 +779,11 @@ func (in *CertificateDNSNameSelector) DeepCopyInto(out *CertificateDNSNameSelect
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.MatchRequester != nil {
+		in, out := &in.MatchRequester, &out.MatchRequester
+		*out = new(CertificateRequesterSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -688,6 +797,32 @@ func (in *CertificateDNSNameSelector) DeepCopy() *CertificateDNSNameSelector {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRequesterSelector) DeepCopyInto(out *CertificateRequesterSelector) {
+	*out = *in
+	if in.Usernames != nil {
+		in, out := &in.Usernames, &out.Usernames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRequesterSelector.
+func (in *CertificateRequesterSelector) DeepCopy() *CertificateRequesterSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRequesterSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Challenge) DeepCopyInto(out *Challenge) {
 	*out = *in
@@ -163,6 +163,36 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01Publish)(nil), (*acme.ACMEChallengeSolverHTTP01Publish)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(a.(*ACMEChallengeSolverHTTP01Publish), b.(*acme.ACMEChallengeSolverHTTP01Publish), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01Publish)(nil), (*ACMEChallengeSolverHTTP01Publish)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01Publish_To_v1beta1_ACMEChallengeSolverHTTP01Publish(a.(*acme.ACMEChallengeSolverHTTP01Publish), b.(*ACMEChallengeSolverHTTP01Publish), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01PublishObject)(nil), (*acme.ACMEChallengeSolverHTTP01PublishObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject(a.(*ACMEChallengeSolverHTTP01PublishObject), b.(*acme.ACMEChallengeSolverHTTP01PublishObject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01PublishObject)(nil), (*ACMEChallengeSolverHTTP01PublishObject)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1beta1_ACMEChallengeSolverHTTP01PublishObject(a.(*acme.ACMEChallengeSolverHTTP01PublishObject), b.(*ACMEChallengeSolverHTTP01PublishObject), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ACMEChallengeSolverHTTP01PublishWebhook)(nil), (*acme.ACMEChallengeSolverHTTP01PublishWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(a.(*ACMEChallengeSolverHTTP01PublishWebhook), b.(*acme.ACMEChallengeSolverHTTP01PublishWebhook), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEChallengeSolverHTTP01PublishWebhook)(nil), (*ACMEChallengeSolverHTTP01PublishWebhook)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook(a.(*acme.ACMEChallengeSolverHTTP01PublishWebhook), b.(*ACMEChallengeSolverHTTP01PublishWebhook), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*ACMEExternalAccountBinding)(nil), (*acme.ACMEExternalAccountBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(a.(*ACMEExternalAccountBinding), b.(*acme.ACMEExternalAccountBinding), scope)
 	}); err != nil {
@@ -273,6 +303,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*ACMEPrivateKeyFallback)(nil), (*acme.ACMEPrivateKeyFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback(a.(*ACMEPrivateKeyFallback), b.(*acme.ACMEPrivateKeyFallback), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.ACMEPrivateKeyFallback)(nil), (*ACMEPrivateKeyFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_ACMEPrivateKeyFallback_To_v1beta1_ACMEPrivateKeyFallback(a.(*acme.ACMEPrivateKeyFallback), b.(*ACMEPrivateKeyFallback), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*AzureManagedIdentity)(nil), (*acme.AzureManagedIdentity)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_AzureManagedIdentity_To_acme_AzureManagedIdentity(a.(*AzureManagedIdentity), b.(*acme.AzureManagedIdentity), scope)
 	}); err != nil {
@@ -293,6 +333,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificateRequesterSelector)(nil), (*acme.CertificateRequesterSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector(a.(*CertificateRequesterSelector), b.(*acme.CertificateRequesterSelector), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*acme.CertificateRequesterSelector)(nil), (*CertificateRequesterSelector)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_acme_CertificateRequesterSelector_To_v1beta1_CertificateRequesterSelector(a.(*acme.CertificateRequesterSelector), b.(*CertificateRequesterSelector), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*Challenge)(nil), (*acme.Challenge)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_Challenge_To_acme_Challenge(a.(*Challenge), b.(*acme.Challenge), scope)
 	}); err != nil {
@@ -440,7 +490,15 @@ func Convert_acme_ACMEChallenge_To_v1beta1_ACMEChallenge(in *acme.ACMEChallenge,
 
 func autoConvert_v1beta1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *ACMEChallengeSolver, out *acme.ACMEChallengeSolver, s conversion.Scope) error {
 	out.Selector = (*acme.CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
-	out.HTTP01 = (*acme.ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
+	if in.HTTP01 != nil {
+		in, out := &in.HTTP01, &out.HTTP01
+		*out = new(acme.ACMEChallengeSolverHTTP01)
+		if err := Convert_v1beta1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP01 = nil
+	}
 	if in.DNS01 != nil {
 		in, out := &in.DNS01, &out.DNS01
 		*out = new(acme.ACMEChallengeSolverDNS01)
@@ -460,7 +518,15 @@ func Convert_v1beta1_ACMEChallengeSolver_To_acme_ACMEChallengeSolver(in *ACMECha
 
 func autoConvert_acme_ACMEChallengeSolver_To_v1beta1_ACMEChallengeSolver(in *acme.ACMEChallengeSolver, out *ACMEChallengeSolver, s conversion.Scope) error {
 	out.Selector = (*CertificateDNSNameSelector)(unsafe.Pointer(in.Selector))
-	out.HTTP01 = (*ACMEChallengeSolverHTTP01)(unsafe.Pointer(in.HTTP01))
+	if in.HTTP01 != nil {
+		in, out := &in.HTTP01, &out.HTTP01
+		*out = new(ACMEChallengeSolverHTTP01)
+		if err := Convert_acme_ACMEChallengeSolverHTTP01_To_v1beta1_ACMEChallengeSolverHTTP01(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HTTP01 = nil
+	}
 	if in.DNS01 != nil {
 		in, out := &in.DNS01, &out.DNS01
 		*out = new(ACMEChallengeSolverDNS01)
@@ -647,6 +713,15 @@ func Convert_acme_ACMEChallengeSolverDNS01_To_v1beta1_ACMEChallengeSolverDNS01(i
 func autoConvert_v1beta1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01(in *ACMEChallengeSolverHTTP01, out *acme.ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*acme.ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*acme.ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	if in.Publish != nil {
+		in, out := &in.Publish, &out.Publish
+		*out = new(acme.ACMEChallengeSolverHTTP01Publish)
+		if err := Convert_v1beta1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Publish = nil
+	}
 	return nil
 }
 
@@ -658,6 +733,15 @@ func Convert_v1beta1_ACMEChallengeSolverHTTP01_To_acme_ACMEChallengeSolverHTTP01
 func autoConvert_acme_ACMEChallengeSolverHTTP01_To_v1beta1_ACMEChallengeSolverHTTP01(in *acme.ACMEChallengeSolverHTTP01, out *ACMEChallengeSolverHTTP01, s conversion.Scope) error {
 	out.Ingress = (*ACMEChallengeSolverHTTP01Ingress)(unsafe.Pointer(in.Ingress))
 	out.GatewayHTTPRoute = (*ACMEChallengeSolverHTTP01GatewayHTTPRoute)(unsafe.Pointer(in.GatewayHTTPRoute))
+	if in.Publish != nil {
+		in, out := &in.Publish, &out.Publish
+		*out = new(ACMEChallengeSolverHTTP01Publish)
+		if err := Convert_acme_ACMEChallengeSolverHTTP01Publish_To_v1beta1_ACMEChallengeSolverHTTP01Publish(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Publish = nil
+	}
 	return nil
 }
 
@@ -693,6 +777,7 @@ func Convert_acme_ACMEChallengeSolverHTTP01GatewayHTTPRoute_To_v1beta1_ACMEChall
 func autoConvert_v1beta1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolverHTTP01Ingress(in *ACMEChallengeSolverHTTP01Ingress, out *acme.ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = v1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*acme.ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*acme.ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -707,6 +792,7 @@ func Convert_v1beta1_ACMEChallengeSolverHTTP01Ingress_To_acme_ACMEChallengeSolve
 func autoConvert_acme_ACMEChallengeSolverHTTP01Ingress_To_v1beta1_ACMEChallengeSolverHTTP01Ingress(in *acme.ACMEChallengeSolverHTTP01Ingress, out *ACMEChallengeSolverHTTP01Ingress, s conversion.Scope) error {
 	out.ServiceType = v1.ServiceType(in.ServiceType)
 	out.Class = (*string)(unsafe.Pointer(in.Class))
+	out.IngressClassName = (*string)(unsafe.Pointer(in.IngressClassName))
 	out.Name = in.Name
 	out.PodTemplate = (*ACMEChallengeSolverHTTP01IngressPodTemplate)(unsafe.Pointer(in.PodTemplate))
 	out.IngressTemplate = (*ACMEChallengeSolverHTTP01IngressTemplate)(unsafe.Pointer(in.IngressTemplate))
@@ -846,6 +932,106 @@ func Convert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1beta1_ACMEChalle
 	return autoConvert_acme_ACMEChallengeSolverHTTP01IngressTemplate_To_v1beta1_ACMEChallengeSolverHTTP01IngressTemplate(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(in *ACMEChallengeSolverHTTP01Publish, out *acme.ACMEChallengeSolverHTTP01Publish, s conversion.Scope) error {
+	out.ConfigMap = (*acme.ACMEChallengeSolverHTTP01PublishObject)(unsafe.Pointer(in.ConfigMap))
+	out.Secret = (*acme.ACMEChallengeSolverHTTP01PublishObject)(unsafe.Pointer(in.Secret))
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(acme.ACMEChallengeSolverHTTP01PublishWebhook)
+		if err := Convert_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Webhook = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(in *ACMEChallengeSolverHTTP01Publish, out *acme.ACMEChallengeSolverHTTP01Publish, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01Publish_To_acme_ACMEChallengeSolverHTTP01Publish(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01Publish_To_v1beta1_ACMEChallengeSolverHTTP01Publish(in *acme.ACMEChallengeSolverHTTP01Publish, out *ACMEChallengeSolverHTTP01Publish, s conversion.Scope) error {
+	out.ConfigMap = (*ACMEChallengeSolverHTTP01PublishObject)(unsafe.Pointer(in.ConfigMap))
+	out.Secret = (*ACMEChallengeSolverHTTP01PublishObject)(unsafe.Pointer(in.Secret))
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(ACMEChallengeSolverHTTP01PublishWebhook)
+		if err := Convert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Webhook = nil
+	}
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01Publish_To_v1beta1_ACMEChallengeSolverHTTP01Publish is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01Publish_To_v1beta1_ACMEChallengeSolverHTTP01Publish(in *acme.ACMEChallengeSolverHTTP01Publish, out *ACMEChallengeSolverHTTP01Publish, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01Publish_To_v1beta1_ACMEChallengeSolverHTTP01Publish(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject(in *ACMEChallengeSolverHTTP01PublishObject, out *acme.ACMEChallengeSolverHTTP01PublishObject, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject(in *ACMEChallengeSolverHTTP01PublishObject, out *acme.ACMEChallengeSolverHTTP01PublishObject, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01PublishObject_To_acme_ACMEChallengeSolverHTTP01PublishObject(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1beta1_ACMEChallengeSolverHTTP01PublishObject(in *acme.ACMEChallengeSolverHTTP01PublishObject, out *ACMEChallengeSolverHTTP01PublishObject, s conversion.Scope) error {
+	out.Name = in.Name
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1beta1_ACMEChallengeSolverHTTP01PublishObject is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1beta1_ACMEChallengeSolverHTTP01PublishObject(in *acme.ACMEChallengeSolverHTTP01PublishObject, out *ACMEChallengeSolverHTTP01PublishObject, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01PublishObject_To_v1beta1_ACMEChallengeSolverHTTP01PublishObject(in, out, s)
+}
+
+func autoConvert_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(in *ACMEChallengeSolverHTTP01PublishWebhook, out *acme.ACMEChallengeSolverHTTP01PublishWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(meta.SecretKeySelector)
+		if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthorizationSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook is an autogenerated conversion function.
+func Convert_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(in *ACMEChallengeSolverHTTP01PublishWebhook, out *acme.ACMEChallengeSolverHTTP01PublishWebhook, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook_To_acme_ACMEChallengeSolverHTTP01PublishWebhook(in, out, s)
+}
+
+func autoConvert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook(in *acme.ACMEChallengeSolverHTTP01PublishWebhook, out *ACMEChallengeSolverHTTP01PublishWebhook, s conversion.Scope) error {
+	out.URL = in.URL
+	out.CABundle = *(*[]byte)(unsafe.Pointer(&in.CABundle))
+	if in.AuthorizationSecretRef != nil {
+		in, out := &in.AuthorizationSecretRef, &out.AuthorizationSecretRef
+		*out = new(apismetav1.SecretKeySelector)
+		if err := metav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AuthorizationSecretRef = nil
+	}
+	return nil
+}
+
+// Convert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook is an autogenerated conversion function.
+func Convert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook(in *acme.ACMEChallengeSolverHTTP01PublishWebhook, out *ACMEChallengeSolverHTTP01PublishWebhook, s conversion.Scope) error {
+	return autoConvert_acme_ACMEChallengeSolverHTTP01PublishWebhook_To_v1beta1_ACMEChallengeSolverHTTP01PublishWebhook(in, out, s)
+}
+
 func autoConvert_v1beta1_ACMEExternalAccountBinding_To_acme_ACMEExternalAccountBinding(in *ACMEExternalAccountBinding, out *acme.ACMEExternalAccountBinding, s conversion.Scope) error {
 	out.KeyID = in.KeyID
 	if err := metav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.Key, &out.Key, s); err != nil {
@@ -905,6 +1091,7 @@ func autoConvert_v1beta1_ACMEIssuer_To_acme_ACMEIssuer(in *ACMEIssuer, out *acme
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.PrivateKeyFallbacks = *(*[]acme.ACMEPrivateKeyFallback)(unsafe.Pointer(&in.PrivateKeyFallbacks))
 	return nil
 }
 
@@ -939,6 +1126,7 @@ func autoConvert_acme_ACMEIssuer_To_v1beta1_ACMEIssuer(in *acme.ACMEIssuer, out
 	}
 	out.DisableAccountKeyGeneration = in.DisableAccountKeyGeneration
 	out.EnableDurationFeature = in.EnableDurationFeature
+	out.PrivateKeyFallbacks = *(*[]ACMEPrivateKeyFallback)(unsafe.Pointer(&in.PrivateKeyFallbacks))
 	return nil
 }
 
@@ -1023,6 +1211,8 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderAzureDNS_To_acme_ACMEIssuerDNS01
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = acme.AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*acme.AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	out.ZoneVisibility = acme.DNSZoneVisibility(in.ZoneVisibility)
+	out.VirtualNetworkID = in.VirtualNetworkID
 	return nil
 }
 
@@ -1048,6 +1238,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderAzureDNS_To_v1beta1_ACMEIssuerDNS01
 	out.HostedZoneName = in.HostedZoneName
 	out.Environment = AzureDNSEnvironment(in.Environment)
 	out.ManagedIdentity = (*AzureManagedIdentity)(unsafe.Pointer(in.ManagedIdentity))
+	out.ZoneVisibility = DNSZoneVisibility(in.ZoneVisibility)
+	out.VirtualNetworkID = in.VirtualNetworkID
 	return nil
 }
 
@@ -1068,6 +1260,8 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudDNS_To_acme_ACMEIssuerDNS01
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.ZoneVisibility = acme.DNSZoneVisibility(in.ZoneVisibility)
+	out.Network = in.Network
 	return nil
 }
 
@@ -1088,6 +1282,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudDNS_To_v1beta1_ACMEIssuerDNS01
 	}
 	out.Project = in.Project
 	out.HostedZoneName = in.HostedZoneName
+	out.ZoneVisibility = DNSZoneVisibility(in.ZoneVisibility)
+	out.Network = in.Network
 	return nil
 }
 
@@ -1116,6 +1312,7 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderCloudflare_To_acme_ACMEIssuerDNS
 	} else {
 		out.APIToken = nil
 	}
+	out.ZoneID = in.ZoneID
 	return nil
 }
 
@@ -1144,6 +1341,7 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderCloudflare_To_v1beta1_ACMEIssuerDNS
 	} else {
 		out.APIToken = nil
 	}
+	out.ZoneID = in.ZoneID
 	return nil
 }
 
@@ -1223,6 +1421,8 @@ func autoConvert_v1beta1_ACMEIssuerDNS01ProviderRoute53_To_acme_ACMEIssuerDNS01P
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
 	out.Region = in.Region
+	out.ZoneVisibility = acme.DNSZoneVisibility(in.ZoneVisibility)
+	out.VPCID = in.VPCID
 	return nil
 }
 
@@ -1248,6 +1448,8 @@ func autoConvert_acme_ACMEIssuerDNS01ProviderRoute53_To_v1beta1_ACMEIssuerDNS01P
 	out.Role = in.Role
 	out.HostedZoneID = in.HostedZoneID
 	out.Region = in.Region
+	out.ZoneVisibility = DNSZoneVisibility(in.ZoneVisibility)
+	out.VPCID = in.VPCID
 	return nil
 }
 
@@ -1302,6 +1504,28 @@ func Convert_acme_ACMEIssuerStatus_To_v1beta1_ACMEIssuerStatus(in *acme.ACMEIssu
 	return autoConvert_acme_ACMEIssuerStatus_To_v1beta1_ACMEIssuerStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback(in *ACMEPrivateKeyFallback, out *acme.ACMEPrivateKeyFallback, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_v1beta1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback is an autogenerated conversion function.
+func Convert_v1beta1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback(in *ACMEPrivateKeyFallback, out *acme.ACMEPrivateKeyFallback, s conversion.Scope) error {
+	return autoConvert_v1beta1_ACMEPrivateKeyFallback_To_acme_ACMEPrivateKeyFallback(in, out, s)
+}
+
+func autoConvert_acme_ACMEPrivateKeyFallback_To_v1beta1_ACMEPrivateKeyFallback(in *acme.ACMEPrivateKeyFallback, out *ACMEPrivateKeyFallback, s conversion.Scope) error {
+	out.Algorithm = in.Algorithm
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_acme_ACMEPrivateKeyFallback_To_v1beta1_ACMEPrivateKeyFallback is an autogenerated conversion function.
+func Convert_acme_ACMEPrivateKeyFallback_To_v1beta1_ACMEPrivateKeyFallback(in *acme.ACMEPrivateKeyFallback, out *ACMEPrivateKeyFallback, s conversion.Scope) error {
+	return autoConvert_acme_ACMEPrivateKeyFallback_To_v1beta1_ACMEPrivateKeyFallback(in, out, s)
+}
+
 func autoConvert_v1beta1_AzureManagedIdentity_To_acme_AzureManagedIdentity(in *AzureManagedIdentity, out *acme.AzureManagedIdentity, s conversion.Scope) error {
 	out.ClientID = in.ClientID
 	out.ResourceID = in.ResourceID
@@ -1328,6 +1552,7 @@ func autoConvert_v1beta1_CertificateDNSNameSelector_To_acme_CertificateDNSNameSe
 	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	out.MatchRequester = (*acme.CertificateRequesterSelector)(unsafe.Pointer(in.MatchRequester))
 	return nil
 }
 
@@ -1340,6 +1565,7 @@ func autoConvert_acme_CertificateDNSNameSelector_To_v1beta1_CertificateDNSNameSe
 	out.MatchLabels = *(*map[string]string)(unsafe.Pointer(&in.MatchLabels))
 	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
 	out.DNSZones = *(*[]string)(unsafe.Pointer(&in.DNSZones))
+	out.MatchRequester = (*CertificateRequesterSelector)(unsafe.Pointer(in.MatchRequester))
 	return nil
 }
 
@@ -1348,6 +1574,28 @@ func Convert_acme_CertificateDNSNameSelector_To_v1beta1_CertificateDNSNameSelect
 	return autoConvert_acme_CertificateDNSNameSelector_To_v1beta1_CertificateDNSNameSelector(in, out, s)
 }
 
+func autoConvert_v1beta1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector(in *CertificateRequesterSelector, out *acme.CertificateRequesterSelector, s conversion.Scope) error {
+	out.Usernames = *(*[]string)(unsafe.Pointer(&in.Usernames))
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	return nil
+}
+
+// Convert_v1beta1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector is an autogenerated conversion function.
+func Convert_v1beta1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector(in *CertificateRequesterSelector, out *acme.CertificateRequesterSelector, s conversion.Scope) error {
+	return autoConvert_v1beta1_CertificateRequesterSelector_To_acme_CertificateRequesterSelector(in, out, s)
+}
+
+func autoConvert_acme_CertificateRequesterSelector_To_v1beta1_CertificateRequesterSelector(in *acme.CertificateRequesterSelector, out *CertificateRequesterSelector, s conversion.Scope) error {
+	out.Usernames = *(*[]string)(unsafe.Pointer(&in.Usernames))
+	out.Groups = *(*[]string)(unsafe.Pointer(&in.Groups))
+	return nil
+}
+
+// Convert_acme_CertificateRequesterSelector_To_v1beta1_CertificateRequesterSelector is an autogenerated conversion function.
+func Convert_acme_CertificateRequesterSelector_To_v1beta1_CertificateRequesterSelector(in *acme.CertificateRequesterSelector, out *CertificateRequesterSelector, s conversion.Scope) error {
+	return autoConvert_acme_CertificateRequesterSelector_To_v1beta1_CertificateRequesterSelector(in, out, s)
+}
+
 func autoConvert_v1beta1_Challenge_To_acme_Challenge(in *Challenge, out *acme.Challenge, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_ChallengeSpec_To_acme_ChallengeSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -1471,6 +1719,7 @@ func autoConvert_v1beta1_ChallengeStatus_To_acme_ChallengeStatus(in *ChallengeSt
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = acme.State(in.State)
+	out.SelectedZone = in.SelectedZone
 	return nil
 }
 
@@ -1484,6 +1733,7 @@ func autoConvert_acme_ChallengeStatus_To_v1beta1_ChallengeStatus(in *acme.Challe
 	out.Presented = in.Presented
 	out.Reason = in.Reason
 	out.State = State(in.State)
+	out.SelectedZone = in.SelectedZone
 	return nil
 }
 
@@ -57,6 +57,10 @@ const (
 	// Annotation key for subject serial number.
 	SubjectSerialNumberAnnotationKey = "cert-manager.io/subject-serialnumber"
 
+	// Annotation key for the literal subject. Requires the
+	// LiteralCertificateSubject feature gate.
+	LiteralSubjectAnnotationKey = "cert-manager.io/literal-subject"
+
 	// Annotation key the 'name' of the Issuer resource.
 	IssuerNameAnnotationKey = "cert-manager.io/issuer-name"
 
@@ -72,6 +76,12 @@ const (
 	// Annotation key used to denote whether a Secret is named on a Certificate
 	// as a 'next private key' Secret resource.
 	IsNextPrivateKeySecretLabelKey = "cert-manager.io/next-private-key"
+
+	// Annotation key holding the signed issuance receipt of a certificate.
+	// It is set on the Secret of a Certificate and on the CertificateRequest
+	// of the revision that issued it, when the controller is configured with
+	// a receipt signing key. The value is a JWS in compact serialization.
+	IssuanceReceiptAnnotationKey = "cert-manager.io/issuance-receipt"
 )
 
 const (
@@ -85,6 +95,18 @@ const (
 	// acmeIssuerHTTP01IngressClassAnnotation can be used to override the http01 ingressClass
 	// if the challenge type is set to http01
 	IngressACMEIssuerHTTP01IngressClassAnnotationKey = "acme.cert-manager.io/http01-ingress-class"
+	// acmeIssuerHTTP01IngressClassNameAnnotation can be used to override the http01
+	// ingressClassName if the challenge type is set to http01
+	IngressACMEIssuerHTTP01IngressClassNameAnnotationKey = "acme.cert-manager.io/http01-ingress-ingressclassname"
+	// acmeIssuerHTTP01ServiceTypeAnnotation can be used to override the http01
+	// ingress serviceType if the challenge type is set to http01
+	IngressACMEIssuerHTTP01ServiceTypeAnnotationKey = "acme.cert-manager.io/http01-ingress-service-type"
+	// acmeIssuerHTTP01IngressAnnotationsAnnotation holds a JSON object of annotations
+	// which are merged into the http01 ingressTemplate annotations
+	IngressACMEIssuerHTTP01IngressAnnotationsAnnotationKey = "acme.cert-manager.io/http01-ingress-annotations"
+	// acmeIssuerHTTP01IngressLabelsAnnotation holds a JSON object of labels
+	// which are merged into the http01 ingressTemplate labels
+	IngressACMEIssuerHTTP01IngressLabelsAnnotationKey = "acme.cert-manager.io/http01-ingress-labels"
 
 	// IngressClassAnnotationKey picks a specific "class" for the Ingress. The
 	// controller only processes Ingresses with this annotation either unset, or
@@ -120,6 +142,7 @@ const (
 	IssuerKind             = "Issuer"
 	CertificateKind        = "Certificate"
 	CertificateRequestKind = "CertificateRequest"
+	SSHCertificateKind     = "SSHCertificate"
 )
 
 const (
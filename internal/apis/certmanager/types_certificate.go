@@ -77,6 +77,17 @@ const (
 	PKCS8 PrivateKeyEncoding = "PKCS8"
 )
 
+type PrivateKeySignatureScheme string
+
+const (
+	// PKCS1v15SignatureScheme signs using RSASSA-PKCS1-v1_5.
+	PKCS1v15SignatureScheme PrivateKeySignatureScheme = "PKCS1v15"
+
+	// PSSSignatureScheme signs using RSASSA-PSS, with a salt length equal to
+	// the length of the hash.
+	PSSSignatureScheme PrivateKeySignatureScheme = "PSS"
+)
+
 // CertificateSpec defines the desired state of Certificate.
 // A valid Certificate requires at least one of a CommonName, DNSName, or
 // URISAN to be valid.
@@ -112,6 +123,9 @@ type CertificateSpec struct {
 	RenewBefore *metav1.Duration
 
 	// DNSNames is a list of DNS subjectAltNames to be set on the Certificate.
+	// Internationalized domain names may be given in their Unicode form, in
+	// which case they are converted to A-labels (punycode) as defined by
+	// UTS #46 when requesting the certificate.
 	DNSNames []string
 
 	// IPAddresses is a list of IP address subjectAltNames to be set on the Certificate.
@@ -178,6 +192,26 @@ type CertificateSpec struct {
 	// `--feature-gates=AdditionalCertificateOutputFormats=true` option on both
 	// the controller and webhook components.
 	AdditionalOutputFormats []CertificateAdditionalOutputFormat
+
+	// RemoteTarget configures a Secret in another cluster to which the
+	// issued certificate is written, in addition to the `secretName` Secret.
+	// The remote Secret has the same name as `secretName` and is kept
+	// consistent with it. This is an Alpha Feature and is only enabled with
+	// the `--feature-gates=RemoteSecretTargets=true` option on both the
+	// controller and webhook components.
+	RemoteTarget *CertificateRemoteTarget
+}
+
+// CertificateRemoteTarget is a namespace in a remote cluster to which the
+// Secret of a Certificate is written.
+type CertificateRemoteTarget struct {
+	// KubeconfigSecretRef is a reference to a key in a Secret in the namespace
+	// of the Certificate, which contains a kubeconfig for the remote cluster.
+	KubeconfigSecretRef cmmeta.SecretKeySelector
+
+	// Namespace is the namespace in the remote cluster in which the Secret is
+	// written.
+	Namespace string
 }
 
 // CertificatePrivateKey contains configuration options for private keys
@@ -216,6 +250,15 @@ type CertificatePrivateKey struct {
 	// and will default to `256` if not specified.
 	// No other values are allowed.
 	Size int
+
+	// SignatureScheme is the RSA signature scheme used to sign the
+	// certificate signing request. The CA and SelfSigned issuers sign the
+	// certificate using the same scheme if their signing key is an RSA key.
+	// If provided, allowed values are `PKCS1v15` and `PSS` standing for
+	// RSASSA-PKCS1-v1_5 and RSASSA-PSS, respectively.
+	// Defaults to `PKCS1v15` if not specified. May only be set if `algorithm`
+	// is `RSA`.
+	SignatureScheme PrivateKeySignatureScheme
 }
 
 // CertificateOutputFormatType specifies which additional output formats should
@@ -385,6 +428,31 @@ type CertificateStatus struct {
 	// delay till the next issuance will be calculated using formula
 	// time.Hour * 2 ^ (failedIssuanceAttempts - 1).
 	FailedIssuanceAttempts *int `json:"failedIssuanceAttempts,omitempty"`
+
+	// PrivateKeyFallback is set by the keymanager controller when the issuer
+	// rejected the private key of this Certificate and configures a fallback
+	// private key algorithm and size to use instead. While set, private keys
+	// are generated and checked using the fallback in place of the algorithm
+	// and size in `spec.privateKey`. The fallback only applies to the
+	// generation of the Certificate it was chosen for, and is discarded once
+	// the spec of the Certificate changes.
+	// +optional
+	PrivateKeyFallback *CertificatePrivateKeyFallback `json:"privateKeyFallback,omitempty"`
+}
+
+// CertificatePrivateKeyFallback is a private key algorithm and size used in
+// place of those in the spec of a Certificate.
+type CertificatePrivateKeyFallback struct {
+	// Algorithm is the private key algorithm of the fallback key.
+	Algorithm PrivateKeyAlgorithm `json:"algorithm"`
+
+	// Size is the key bit size of the fallback key.
+	// +optional
+	Size int `json:"size,omitempty"`
+
+	// ObservedGeneration is the generation of the Certificate the fallback
+	// was chosen for.
+	ObservedGeneration int64 `json:"observedGeneration"`
 }
 
 // CertificateCondition contains condition information for an Certificate.
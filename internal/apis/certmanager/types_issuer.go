@@ -304,6 +304,86 @@ type IssuerStatus struct {
 	// This field should only be set if the Issuer is configured to use an ACME
 	// server to issue certificates.
 	ACME *cmacme.ACMEIssuerStatus
+
+	// Venafi specific status options.
+	// This field should only be set if the Issuer is configured to use a
+	// Venafi TPP or Venafi Cloud instance to issue certificates.
+	Venafi *VenafiIssuerStatus
+}
+
+// VenafiIssuerStatus contains Venafi specific status information about an
+// Issuer.
+type VenafiIssuerStatus struct {
+	// ZonePolicy is the policy of the configured Venafi zone, as last read
+	// from the Venafi server. It is used to validate Certificates and
+	// CertificateRequests before they are submitted to Venafi.
+	ZonePolicy *VenafiZonePolicy
+}
+
+// VenafiZonePolicy describes the restrictions a Venafi zone places on the
+// certificates it issues. Regular expressions use the RE2 syntax, and an
+// empty list means that the field is not restricted by the policy.
+type VenafiZonePolicy struct {
+	// Zone is the Venafi zone the policy was read from.
+	Zone string
+
+	// LastSyncTime is the time at which the policy was last read from the
+	// Venafi server.
+	LastSyncTime *metav1.Time
+
+	// CommonNames is the list of regular expressions the common name must
+	// match.
+	CommonNames []string
+
+	// DNSNames is the list of regular expressions every DNS name must match.
+	DNSNames []string
+
+	// IPAddresses is the list of regular expressions every IP address must
+	// match.
+	IPAddresses []string
+
+	// EmailAddresses is the list of regular expressions every email address
+	// must match.
+	EmailAddresses []string
+
+	// URIs is the list of regular expressions every URI must match.
+	URIs []string
+
+	// Organizations is the list of regular expressions every organization
+	// must match.
+	Organizations []string
+
+	// OrganizationalUnits is the list of regular expressions every
+	// organizational unit must match.
+	OrganizationalUnits []string
+
+	// Countries is the list of regular expressions every country must match.
+	Countries []string
+
+	// Provinces is the list of regular expressions every province must match.
+	Provinces []string
+
+	// Localities is the list of regular expressions every locality must
+	// match.
+	Localities []string
+
+	// AllowedKeys is the list of private key algorithms and sizes allowed by
+	// the zone.
+	AllowedKeys []VenafiAllowedKey
+
+	// AllowWildcards is true if the zone allows wildcard DNS names.
+	AllowWildcards bool
+}
+
+// VenafiAllowedKey is a private key algorithm, and the sizes of that
+// algorithm, allowed by a Venafi zone.
+type VenafiAllowedKey struct {
+	// Algorithm is the private key algorithm.
+	Algorithm PrivateKeyAlgorithm
+
+	// Sizes is the list of allowed key sizes in bits. For ECDSA keys this is
+	// the size of the curve, for example 256 for P-256.
+	Sizes []int
 }
 
 // IssuerCondition contains condition information for an Issuer.
@@ -114,6 +114,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificatePrivateKeyFallback)(nil), (*certmanager.CertificatePrivateKeyFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback(a.(*v1.CertificatePrivateKeyFallback), b.(*certmanager.CertificatePrivateKeyFallback), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificatePrivateKeyFallback)(nil), (*v1.CertificatePrivateKeyFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificatePrivateKeyFallback_To_v1_CertificatePrivateKeyFallback(a.(*certmanager.CertificatePrivateKeyFallback), b.(*v1.CertificatePrivateKeyFallback), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.CertificateRemoteTarget)(nil), (*certmanager.CertificateRemoteTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(a.(*v1.CertificateRemoteTarget), b.(*certmanager.CertificateRemoteTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRemoteTarget)(nil), (*v1.CertificateRemoteTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRemoteTarget_To_v1_CertificateRemoteTarget(a.(*certmanager.CertificateRemoteTarget), b.(*v1.CertificateRemoteTarget), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_CertificateRequest_To_certmanager_CertificateRequest(a.(*v1.CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -284,6 +304,66 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.SSHCertificate)(nil), (*certmanager.SSHCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SSHCertificate_To_certmanager_SSHCertificate(a.(*v1.SSHCertificate), b.(*certmanager.SSHCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SSHCertificate)(nil), (*v1.SSHCertificate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SSHCertificate_To_v1_SSHCertificate(a.(*certmanager.SSHCertificate), b.(*v1.SSHCertificate), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.SSHCertificateCondition)(nil), (*certmanager.SSHCertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SSHCertificateCondition_To_certmanager_SSHCertificateCondition(a.(*v1.SSHCertificateCondition), b.(*certmanager.SSHCertificateCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SSHCertificateCondition)(nil), (*v1.SSHCertificateCondition)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SSHCertificateCondition_To_v1_SSHCertificateCondition(a.(*certmanager.SSHCertificateCondition), b.(*v1.SSHCertificateCondition), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.SSHCertificateList)(nil), (*certmanager.SSHCertificateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SSHCertificateList_To_certmanager_SSHCertificateList(a.(*v1.SSHCertificateList), b.(*certmanager.SSHCertificateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SSHCertificateList)(nil), (*v1.SSHCertificateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SSHCertificateList_To_v1_SSHCertificateList(a.(*certmanager.SSHCertificateList), b.(*v1.SSHCertificateList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.SSHCertificatePrivateKey)(nil), (*certmanager.SSHCertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SSHCertificatePrivateKey_To_certmanager_SSHCertificatePrivateKey(a.(*v1.SSHCertificatePrivateKey), b.(*certmanager.SSHCertificatePrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SSHCertificatePrivateKey)(nil), (*v1.SSHCertificatePrivateKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SSHCertificatePrivateKey_To_v1_SSHCertificatePrivateKey(a.(*certmanager.SSHCertificatePrivateKey), b.(*v1.SSHCertificatePrivateKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.SSHCertificateSpec)(nil), (*certmanager.SSHCertificateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SSHCertificateSpec_To_certmanager_SSHCertificateSpec(a.(*v1.SSHCertificateSpec), b.(*certmanager.SSHCertificateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SSHCertificateSpec)(nil), (*v1.SSHCertificateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SSHCertificateSpec_To_v1_SSHCertificateSpec(a.(*certmanager.SSHCertificateSpec), b.(*v1.SSHCertificateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*v1.SSHCertificateStatus)(nil), (*certmanager.SSHCertificateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_SSHCertificateStatus_To_certmanager_SSHCertificateStatus(a.(*v1.SSHCertificateStatus), b.(*certmanager.SSHCertificateStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.SSHCertificateStatus)(nil), (*v1.SSHCertificateStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_SSHCertificateStatus_To_v1_SSHCertificateStatus(a.(*certmanager.SSHCertificateStatus), b.(*v1.SSHCertificateStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.SelfSignedIssuer)(nil), (*certmanager.SelfSignedIssuer)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(a.(*v1.SelfSignedIssuer), b.(*certmanager.SelfSignedIssuer), scope)
 	}); err != nil {
@@ -344,6 +424,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiAllowedKey)(nil), (*certmanager.VenafiAllowedKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiAllowedKey_To_certmanager_VenafiAllowedKey(a.(*v1.VenafiAllowedKey), b.(*certmanager.VenafiAllowedKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiAllowedKey)(nil), (*v1.VenafiAllowedKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiAllowedKey_To_v1_VenafiAllowedKey(a.(*certmanager.VenafiAllowedKey), b.(*v1.VenafiAllowedKey), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiCloud_To_certmanager_VenafiCloud(a.(*v1.VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -364,6 +454,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiIssuerStatus)(nil), (*certmanager.VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(a.(*v1.VenafiIssuerStatus), b.(*certmanager.VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiIssuerStatus)(nil), (*v1.VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus(a.(*certmanager.VenafiIssuerStatus), b.(*v1.VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.VenafiTPP)(nil), (*certmanager.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_VenafiTPP_To_certmanager_VenafiTPP(a.(*v1.VenafiTPP), b.(*certmanager.VenafiTPP), scope)
 	}); err != nil {
@@ -374,6 +474,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*v1.VenafiZonePolicy)(nil), (*certmanager.VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(a.(*v1.VenafiZonePolicy), b.(*certmanager.VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiZonePolicy)(nil), (*v1.VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy(a.(*certmanager.VenafiZonePolicy), b.(*v1.VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*v1.X509Subject)(nil), (*certmanager.X509Subject)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1_X509Subject_To_certmanager_X509Subject(a.(*v1.X509Subject), b.(*certmanager.X509Subject), scope)
 	}); err != nil {
@@ -604,6 +714,7 @@ func autoConvert_v1_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(i
 	out.Encoding = certmanager.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
+	out.SignatureScheme = certmanager.PrivateKeySignatureScheme(in.SignatureScheme)
 	return nil
 }
 
@@ -617,6 +728,7 @@ func autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(i
 	out.Encoding = v1.PrivateKeyEncoding(in.Encoding)
 	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
 	out.Size = in.Size
+	out.SignatureScheme = v1.PrivateKeySignatureScheme(in.SignatureScheme)
 	return nil
 }
 
@@ -625,6 +737,56 @@ func Convert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in *c
 	return autoConvert_certmanager_CertificatePrivateKey_To_v1_CertificatePrivateKey(in, out, s)
 }
 
+func autoConvert_v1_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback(in *v1.CertificatePrivateKeyFallback, out *certmanager.CertificatePrivateKeyFallback, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_v1_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback is an autogenerated conversion function.
+func Convert_v1_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback(in *v1.CertificatePrivateKeyFallback, out *certmanager.CertificatePrivateKeyFallback, s conversion.Scope) error {
+	return autoConvert_v1_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback(in, out, s)
+}
+
+func autoConvert_certmanager_CertificatePrivateKeyFallback_To_v1_CertificatePrivateKeyFallback(in *certmanager.CertificatePrivateKeyFallback, out *v1.CertificatePrivateKeyFallback, s conversion.Scope) error {
+	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_certmanager_CertificatePrivateKeyFallback_To_v1_CertificatePrivateKeyFallback is an autogenerated conversion function.
+func Convert_certmanager_CertificatePrivateKeyFallback_To_v1_CertificatePrivateKeyFallback(in *certmanager.CertificatePrivateKeyFallback, out *v1.CertificatePrivateKeyFallback, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificatePrivateKeyFallback_To_v1_CertificatePrivateKeyFallback(in, out, s)
+}
+
+func autoConvert_v1_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(in *v1.CertificateRemoteTarget, out *certmanager.CertificateRemoteTarget, s conversion.Scope) error {
+	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.KubeconfigSecretRef, &out.KubeconfigSecretRef, s); err != nil {
+		return err
+	}
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget is an autogenerated conversion function.
+func Convert_v1_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(in *v1.CertificateRemoteTarget, out *certmanager.CertificateRemoteTarget, s conversion.Scope) error {
+	return autoConvert_v1_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRemoteTarget_To_v1_CertificateRemoteTarget(in *certmanager.CertificateRemoteTarget, out *v1.CertificateRemoteTarget, s conversion.Scope) error {
+	if err := internalapismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.KubeconfigSecretRef, &out.KubeconfigSecretRef, s); err != nil {
+		return err
+	}
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_certmanager_CertificateRemoteTarget_To_v1_CertificateRemoteTarget is an autogenerated conversion function.
+func Convert_certmanager_CertificateRemoteTarget_To_v1_CertificateRemoteTarget(in *certmanager.CertificateRemoteTarget, out *v1.CertificateRemoteTarget, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRemoteTarget_To_v1_CertificateRemoteTarget(in, out, s)
+}
+
 func autoConvert_v1_CertificateRequest_To_certmanager_CertificateRequest(in *v1.CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -845,6 +1007,15 @@ func autoConvert_v1_CertificateSpec_To_certmanager_CertificateSpec(in *v1.Certif
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.RemoteTarget != nil {
+		in, out := &in.RemoteTarget, &out.RemoteTarget
+		*out = new(certmanager.CertificateRemoteTarget)
+		if err := Convert_v1_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemoteTarget = nil
+	}
 	return nil
 }
 
@@ -878,6 +1049,15 @@ func autoConvert_certmanager_CertificateSpec_To_v1_CertificateSpec(in *certmanag
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]v1.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.RemoteTarget != nil {
+		in, out := &in.RemoteTarget, &out.RemoteTarget
+		*out = new(v1.CertificateRemoteTarget)
+		if err := Convert_certmanager_CertificateRemoteTarget_To_v1_CertificateRemoteTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemoteTarget = nil
+	}
 	return nil
 }
 
@@ -890,6 +1070,7 @@ func autoConvert_v1_CertificateStatus_To_certmanager_CertificateStatus(in *v1.Ce
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.PrivateKeyFallback = (*certmanager.CertificatePrivateKeyFallback)(unsafe.Pointer(in.PrivateKeyFallback))
 	return nil
 }
 
@@ -907,6 +1088,7 @@ func autoConvert_certmanager_CertificateStatus_To_v1_CertificateStatus(in *certm
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.PrivateKeyFallback = (*v1.CertificatePrivateKeyFallback)(unsafe.Pointer(in.PrivateKeyFallback))
 	return nil
 }
 
@@ -1196,6 +1378,7 @@ func Convert_certmanager_IssuerSpec_To_v1_IssuerSpec(in *certmanager.IssuerSpec,
 func autoConvert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*certmanager.VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
 	return nil
 }
 
@@ -1207,6 +1390,7 @@ func Convert_v1_IssuerStatus_To_certmanager_IssuerStatus(in *v1.IssuerStatus, ou
 func autoConvert_certmanager_IssuerStatus_To_v1_IssuerStatus(in *certmanager.IssuerStatus, out *v1.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]v1.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*apisacmev1.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*v1.VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
 	return nil
 }
 
@@ -1267,6 +1451,204 @@ func Convert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in *certmanager.PKC
 	return autoConvert_certmanager_PKCS12Keystore_To_v1_PKCS12Keystore(in, out, s)
 }
 
+func autoConvert_v1_SSHCertificate_To_certmanager_SSHCertificate(in *v1.SSHCertificate, out *certmanager.SSHCertificate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1_SSHCertificateSpec_To_certmanager_SSHCertificateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_SSHCertificateStatus_To_certmanager_SSHCertificateStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_SSHCertificate_To_certmanager_SSHCertificate is an autogenerated conversion function.
+func Convert_v1_SSHCertificate_To_certmanager_SSHCertificate(in *v1.SSHCertificate, out *certmanager.SSHCertificate, s conversion.Scope) error {
+	return autoConvert_v1_SSHCertificate_To_certmanager_SSHCertificate(in, out, s)
+}
+
+func autoConvert_certmanager_SSHCertificate_To_v1_SSHCertificate(in *certmanager.SSHCertificate, out *v1.SSHCertificate, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_certmanager_SSHCertificateSpec_To_v1_SSHCertificateSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_certmanager_SSHCertificateStatus_To_v1_SSHCertificateStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_SSHCertificate_To_v1_SSHCertificate is an autogenerated conversion function.
+func Convert_certmanager_SSHCertificate_To_v1_SSHCertificate(in *certmanager.SSHCertificate, out *v1.SSHCertificate, s conversion.Scope) error {
+	return autoConvert_certmanager_SSHCertificate_To_v1_SSHCertificate(in, out, s)
+}
+
+func autoConvert_v1_SSHCertificateCondition_To_certmanager_SSHCertificateCondition(in *v1.SSHCertificateCondition, out *certmanager.SSHCertificateCondition, s conversion.Scope) error {
+	out.Type = certmanager.SSHCertificateConditionType(in.Type)
+	out.Status = meta.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_v1_SSHCertificateCondition_To_certmanager_SSHCertificateCondition is an autogenerated conversion function.
+func Convert_v1_SSHCertificateCondition_To_certmanager_SSHCertificateCondition(in *v1.SSHCertificateCondition, out *certmanager.SSHCertificateCondition, s conversion.Scope) error {
+	return autoConvert_v1_SSHCertificateCondition_To_certmanager_SSHCertificateCondition(in, out, s)
+}
+
+func autoConvert_certmanager_SSHCertificateCondition_To_v1_SSHCertificateCondition(in *certmanager.SSHCertificateCondition, out *v1.SSHCertificateCondition, s conversion.Scope) error {
+	out.Type = v1.SSHCertificateConditionType(in.Type)
+	out.Status = apismetav1.ConditionStatus(in.Status)
+	out.LastTransitionTime = (*metav1.Time)(unsafe.Pointer(in.LastTransitionTime))
+	out.Reason = in.Reason
+	out.Message = in.Message
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_certmanager_SSHCertificateCondition_To_v1_SSHCertificateCondition is an autogenerated conversion function.
+func Convert_certmanager_SSHCertificateCondition_To_v1_SSHCertificateCondition(in *certmanager.SSHCertificateCondition, out *v1.SSHCertificateCondition, s conversion.Scope) error {
+	return autoConvert_certmanager_SSHCertificateCondition_To_v1_SSHCertificateCondition(in, out, s)
+}
+
+func autoConvert_v1_SSHCertificateList_To_certmanager_SSHCertificateList(in *v1.SSHCertificateList, out *certmanager.SSHCertificateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]certmanager.SSHCertificate, len(*in))
+		for i := range *in {
+			if err := Convert_v1_SSHCertificate_To_certmanager_SSHCertificate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_v1_SSHCertificateList_To_certmanager_SSHCertificateList is an autogenerated conversion function.
+func Convert_v1_SSHCertificateList_To_certmanager_SSHCertificateList(in *v1.SSHCertificateList, out *certmanager.SSHCertificateList, s conversion.Scope) error {
+	return autoConvert_v1_SSHCertificateList_To_certmanager_SSHCertificateList(in, out, s)
+}
+
+func autoConvert_certmanager_SSHCertificateList_To_v1_SSHCertificateList(in *certmanager.SSHCertificateList, out *v1.SSHCertificateList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.SSHCertificate, len(*in))
+		for i := range *in {
+			if err := Convert_certmanager_SSHCertificate_To_v1_SSHCertificate(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+// Convert_certmanager_SSHCertificateList_To_v1_SSHCertificateList is an autogenerated conversion function.
+func Convert_certmanager_SSHCertificateList_To_v1_SSHCertificateList(in *certmanager.SSHCertificateList, out *v1.SSHCertificateList, s conversion.Scope) error {
+	return autoConvert_certmanager_SSHCertificateList_To_v1_SSHCertificateList(in, out, s)
+}
+
+func autoConvert_v1_SSHCertificatePrivateKey_To_certmanager_SSHCertificatePrivateKey(in *v1.SSHCertificatePrivateKey, out *certmanager.SSHCertificatePrivateKey, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_v1_SSHCertificatePrivateKey_To_certmanager_SSHCertificatePrivateKey is an autogenerated conversion function.
+func Convert_v1_SSHCertificatePrivateKey_To_certmanager_SSHCertificatePrivateKey(in *v1.SSHCertificatePrivateKey, out *certmanager.SSHCertificatePrivateKey, s conversion.Scope) error {
+	return autoConvert_v1_SSHCertificatePrivateKey_To_certmanager_SSHCertificatePrivateKey(in, out, s)
+}
+
+func autoConvert_certmanager_SSHCertificatePrivateKey_To_v1_SSHCertificatePrivateKey(in *certmanager.SSHCertificatePrivateKey, out *v1.SSHCertificatePrivateKey, s conversion.Scope) error {
+	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	return nil
+}
+
+// Convert_certmanager_SSHCertificatePrivateKey_To_v1_SSHCertificatePrivateKey is an autogenerated conversion function.
+func Convert_certmanager_SSHCertificatePrivateKey_To_v1_SSHCertificatePrivateKey(in *certmanager.SSHCertificatePrivateKey, out *v1.SSHCertificatePrivateKey, s conversion.Scope) error {
+	return autoConvert_certmanager_SSHCertificatePrivateKey_To_v1_SSHCertificatePrivateKey(in, out, s)
+}
+
+func autoConvert_v1_SSHCertificateSpec_To_certmanager_SSHCertificateSpec(in *v1.SSHCertificateSpec, out *certmanager.SSHCertificateSpec, s conversion.Scope) error {
+	out.Type = certmanager.SSHCertificateType(in.Type)
+	out.KeyID = in.KeyID
+	out.PublicKey = in.PublicKey
+	out.PrivateKey = (*certmanager.SSHCertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	out.SecretName = in.SecretName
+	out.Principals = *(*[]string)(unsafe.Pointer(&in.Principals))
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.CriticalOptions = *(*map[string]string)(unsafe.Pointer(&in.CriticalOptions))
+	out.Extensions = *(*map[string]string)(unsafe.Pointer(&in.Extensions))
+	if err := internalapismetav1.Convert_v1_ObjectReference_To_meta_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1_SSHCertificateSpec_To_certmanager_SSHCertificateSpec is an autogenerated conversion function.
+func Convert_v1_SSHCertificateSpec_To_certmanager_SSHCertificateSpec(in *v1.SSHCertificateSpec, out *certmanager.SSHCertificateSpec, s conversion.Scope) error {
+	return autoConvert_v1_SSHCertificateSpec_To_certmanager_SSHCertificateSpec(in, out, s)
+}
+
+func autoConvert_certmanager_SSHCertificateSpec_To_v1_SSHCertificateSpec(in *certmanager.SSHCertificateSpec, out *v1.SSHCertificateSpec, s conversion.Scope) error {
+	out.Type = v1.SSHCertificateType(in.Type)
+	out.KeyID = in.KeyID
+	out.PublicKey = in.PublicKey
+	out.PrivateKey = (*v1.SSHCertificatePrivateKey)(unsafe.Pointer(in.PrivateKey))
+	out.SecretName = in.SecretName
+	out.Principals = *(*[]string)(unsafe.Pointer(&in.Principals))
+	out.Duration = (*metav1.Duration)(unsafe.Pointer(in.Duration))
+	out.RenewBefore = (*metav1.Duration)(unsafe.Pointer(in.RenewBefore))
+	out.CriticalOptions = *(*map[string]string)(unsafe.Pointer(&in.CriticalOptions))
+	out.Extensions = *(*map[string]string)(unsafe.Pointer(&in.Extensions))
+	if err := internalapismetav1.Convert_meta_ObjectReference_To_v1_ObjectReference(&in.IssuerRef, &out.IssuerRef, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_certmanager_SSHCertificateSpec_To_v1_SSHCertificateSpec is an autogenerated conversion function.
+func Convert_certmanager_SSHCertificateSpec_To_v1_SSHCertificateSpec(in *certmanager.SSHCertificateSpec, out *v1.SSHCertificateSpec, s conversion.Scope) error {
+	return autoConvert_certmanager_SSHCertificateSpec_To_v1_SSHCertificateSpec(in, out, s)
+}
+
+func autoConvert_v1_SSHCertificateStatus_To_certmanager_SSHCertificateStatus(in *v1.SSHCertificateStatus, out *certmanager.SSHCertificateStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]certmanager.SSHCertificateCondition)(unsafe.Pointer(&in.Conditions))
+	out.SerialNumber = in.SerialNumber
+	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	return nil
+}
+
+// Convert_v1_SSHCertificateStatus_To_certmanager_SSHCertificateStatus is an autogenerated conversion function.
+func Convert_v1_SSHCertificateStatus_To_certmanager_SSHCertificateStatus(in *v1.SSHCertificateStatus, out *certmanager.SSHCertificateStatus, s conversion.Scope) error {
+	return autoConvert_v1_SSHCertificateStatus_To_certmanager_SSHCertificateStatus(in, out, s)
+}
+
+func autoConvert_certmanager_SSHCertificateStatus_To_v1_SSHCertificateStatus(in *certmanager.SSHCertificateStatus, out *v1.SSHCertificateStatus, s conversion.Scope) error {
+	out.Conditions = *(*[]v1.SSHCertificateCondition)(unsafe.Pointer(&in.Conditions))
+	out.SerialNumber = in.SerialNumber
+	out.NotBefore = (*metav1.Time)(unsafe.Pointer(in.NotBefore))
+	out.NotAfter = (*metav1.Time)(unsafe.Pointer(in.NotAfter))
+	out.RenewalTime = (*metav1.Time)(unsafe.Pointer(in.RenewalTime))
+	return nil
+}
+
+// Convert_certmanager_SSHCertificateStatus_To_v1_SSHCertificateStatus is an autogenerated conversion function.
+func Convert_certmanager_SSHCertificateStatus_To_v1_SSHCertificateStatus(in *certmanager.SSHCertificateStatus, out *v1.SSHCertificateStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_SSHCertificateStatus_To_v1_SSHCertificateStatus(in, out, s)
+}
+
 func autoConvert_v1_SelfSignedIssuer_To_certmanager_SelfSignedIssuer(in *v1.SelfSignedIssuer, out *certmanager.SelfSignedIssuer, s conversion.Scope) error {
 	out.CRLDistributionPoints = *(*[]string)(unsafe.Pointer(&in.CRLDistributionPoints))
 	return nil
@@ -1487,6 +1869,28 @@ func Convert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in *certm
 	return autoConvert_certmanager_VaultKubernetesAuth_To_v1_VaultKubernetesAuth(in, out, s)
 }
 
+func autoConvert_v1_VenafiAllowedKey_To_certmanager_VenafiAllowedKey(in *v1.VenafiAllowedKey, out *certmanager.VenafiAllowedKey, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_v1_VenafiAllowedKey_To_certmanager_VenafiAllowedKey is an autogenerated conversion function.
+func Convert_v1_VenafiAllowedKey_To_certmanager_VenafiAllowedKey(in *v1.VenafiAllowedKey, out *certmanager.VenafiAllowedKey, s conversion.Scope) error {
+	return autoConvert_v1_VenafiAllowedKey_To_certmanager_VenafiAllowedKey(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiAllowedKey_To_v1_VenafiAllowedKey(in *certmanager.VenafiAllowedKey, out *v1.VenafiAllowedKey, s conversion.Scope) error {
+	out.Algorithm = v1.PrivateKeyAlgorithm(in.Algorithm)
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_certmanager_VenafiAllowedKey_To_v1_VenafiAllowedKey is an autogenerated conversion function.
+func Convert_certmanager_VenafiAllowedKey_To_v1_VenafiAllowedKey(in *certmanager.VenafiAllowedKey, out *v1.VenafiAllowedKey, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiAllowedKey_To_v1_VenafiAllowedKey(in, out, s)
+}
+
 func autoConvert_v1_VenafiCloud_To_certmanager_VenafiCloud(in *v1.VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := internalapismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
@@ -1569,6 +1973,26 @@ func Convert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in *certmanager.VenafiI
 	return autoConvert_certmanager_VenafiIssuer_To_v1_VenafiIssuer(in, out, s)
 }
 
+func autoConvert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *v1.VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*certmanager.VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *v1.VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *v1.VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*v1.VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *v1.VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiIssuerStatus_To_v1_VenafiIssuerStatus(in, out, s)
+}
+
 func autoConvert_v1_VenafiTPP_To_certmanager_VenafiTPP(in *v1.VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := internalapismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
@@ -1597,6 +2021,52 @@ func Convert_certmanager_VenafiTPP_To_v1_VenafiTPP(in *certmanager.VenafiTPP, ou
 	return autoConvert_certmanager_VenafiTPP_To_v1_VenafiTPP(in, out, s)
 }
 
+func autoConvert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *v1.VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.LastSyncTime = (*metav1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.CommonNames = *(*[]string)(unsafe.Pointer(&in.CommonNames))
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
+	out.OrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.OrganizationalUnits))
+	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
+	out.Provinces = *(*[]string)(unsafe.Pointer(&in.Provinces))
+	out.Localities = *(*[]string)(unsafe.Pointer(&in.Localities))
+	out.AllowedKeys = *(*[]certmanager.VenafiAllowedKey)(unsafe.Pointer(&in.AllowedKeys))
+	out.AllowWildcards = in.AllowWildcards
+	return nil
+}
+
+// Convert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *v1.VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_v1_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *v1.VenafiZonePolicy, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.LastSyncTime = (*metav1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.CommonNames = *(*[]string)(unsafe.Pointer(&in.CommonNames))
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
+	out.OrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.OrganizationalUnits))
+	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
+	out.Provinces = *(*[]string)(unsafe.Pointer(&in.Provinces))
+	out.Localities = *(*[]string)(unsafe.Pointer(&in.Localities))
+	out.AllowedKeys = *(*[]v1.VenafiAllowedKey)(unsafe.Pointer(&in.AllowedKeys))
+	out.AllowWildcards = in.AllowWildcards
+	return nil
+}
+
+// Convert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *v1.VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiZonePolicy_To_v1_VenafiZonePolicy(in, out, s)
+}
+
 func autoConvert_v1_X509Subject_To_certmanager_X509Subject(in *v1.X509Subject, out *certmanager.X509Subject, s conversion.Scope) error {
 	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
 	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
@@ -107,6 +107,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*CertificatePrivateKeyFallback)(nil), (*certmanager.CertificatePrivateKeyFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback(a.(*CertificatePrivateKeyFallback), b.(*certmanager.CertificatePrivateKeyFallback), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificatePrivateKeyFallback)(nil), (*CertificatePrivateKeyFallback)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificatePrivateKeyFallback_To_v1alpha2_CertificatePrivateKeyFallback(a.(*certmanager.CertificatePrivateKeyFallback), b.(*CertificatePrivateKeyFallback), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*CertificateRemoteTarget)(nil), (*certmanager.CertificateRemoteTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(a.(*CertificateRemoteTarget), b.(*certmanager.CertificateRemoteTarget), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.CertificateRemoteTarget)(nil), (*CertificateRemoteTarget)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_CertificateRemoteTarget_To_v1alpha2_CertificateRemoteTarget(a.(*certmanager.CertificateRemoteTarget), b.(*CertificateRemoteTarget), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*CertificateRequest)(nil), (*certmanager.CertificateRequest)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_CertificateRequest_To_certmanager_CertificateRequest(a.(*CertificateRequest), b.(*certmanager.CertificateRequest), scope)
 	}); err != nil {
@@ -322,6 +342,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VenafiAllowedKey)(nil), (*certmanager.VenafiAllowedKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VenafiAllowedKey_To_certmanager_VenafiAllowedKey(a.(*VenafiAllowedKey), b.(*certmanager.VenafiAllowedKey), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiAllowedKey)(nil), (*VenafiAllowedKey)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiAllowedKey_To_v1alpha2_VenafiAllowedKey(a.(*certmanager.VenafiAllowedKey), b.(*VenafiAllowedKey), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VenafiCloud)(nil), (*certmanager.VenafiCloud)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VenafiCloud_To_certmanager_VenafiCloud(a.(*VenafiCloud), b.(*certmanager.VenafiCloud), scope)
 	}); err != nil {
@@ -342,6 +372,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VenafiIssuerStatus)(nil), (*certmanager.VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(a.(*VenafiIssuerStatus), b.(*certmanager.VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiIssuerStatus)(nil), (*VenafiIssuerStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiIssuerStatus_To_v1alpha2_VenafiIssuerStatus(a.(*certmanager.VenafiIssuerStatus), b.(*VenafiIssuerStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*VenafiTPP)(nil), (*certmanager.VenafiTPP)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VenafiTPP_To_certmanager_VenafiTPP(a.(*VenafiTPP), b.(*certmanager.VenafiTPP), scope)
 	}); err != nil {
@@ -352,6 +392,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*VenafiZonePolicy)(nil), (*certmanager.VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha2_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(a.(*VenafiZonePolicy), b.(*certmanager.VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*certmanager.VenafiZonePolicy)(nil), (*VenafiZonePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_certmanager_VenafiZonePolicy_To_v1alpha2_VenafiZonePolicy(a.(*certmanager.VenafiZonePolicy), b.(*VenafiZonePolicy), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*X509Subject)(nil), (*certmanager.X509Subject)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_X509Subject_To_certmanager_X509Subject(a.(*X509Subject), b.(*certmanager.X509Subject), scope)
 	}); err != nil {
@@ -599,6 +649,7 @@ func Convert_certmanager_CertificateList_To_v1alpha2_CertificateList(in *certman
 
 func autoConvert_v1alpha2_CertificatePrivateKey_To_certmanager_CertificatePrivateKey(in *CertificatePrivateKey, out *certmanager.CertificatePrivateKey, s conversion.Scope) error {
 	out.RotationPolicy = certmanager.PrivateKeyRotationPolicy(in.RotationPolicy)
+	out.SignatureScheme = certmanager.PrivateKeySignatureScheme(in.SignatureScheme)
 	return nil
 }
 
@@ -612,9 +663,60 @@ func autoConvert_certmanager_CertificatePrivateKey_To_v1alpha2_CertificatePrivat
 	// WARNING: in.Encoding requires manual conversion: does not exist in peer-type
 	// WARNING: in.Algorithm requires manual conversion: does not exist in peer-type
 	// WARNING: in.Size requires manual conversion: does not exist in peer-type
+	out.SignatureScheme = PrivateKeySignatureScheme(in.SignatureScheme)
+	return nil
+}
+
+func autoConvert_v1alpha2_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback(in *CertificatePrivateKeyFallback, out *certmanager.CertificatePrivateKeyFallback, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_v1alpha2_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback is an autogenerated conversion function.
+func Convert_v1alpha2_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback(in *CertificatePrivateKeyFallback, out *certmanager.CertificatePrivateKeyFallback, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificatePrivateKeyFallback_To_certmanager_CertificatePrivateKeyFallback(in, out, s)
+}
+
+func autoConvert_certmanager_CertificatePrivateKeyFallback_To_v1alpha2_CertificatePrivateKeyFallback(in *certmanager.CertificatePrivateKeyFallback, out *CertificatePrivateKeyFallback, s conversion.Scope) error {
+	out.Algorithm = KeyAlgorithm(in.Algorithm)
+	out.Size = in.Size
+	out.ObservedGeneration = in.ObservedGeneration
+	return nil
+}
+
+// Convert_certmanager_CertificatePrivateKeyFallback_To_v1alpha2_CertificatePrivateKeyFallback is an autogenerated conversion function.
+func Convert_certmanager_CertificatePrivateKeyFallback_To_v1alpha2_CertificatePrivateKeyFallback(in *certmanager.CertificatePrivateKeyFallback, out *CertificatePrivateKeyFallback, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificatePrivateKeyFallback_To_v1alpha2_CertificatePrivateKeyFallback(in, out, s)
+}
+
+func autoConvert_v1alpha2_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(in *CertificateRemoteTarget, out *certmanager.CertificateRemoteTarget, s conversion.Scope) error {
+	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.KubeconfigSecretRef, &out.KubeconfigSecretRef, s); err != nil {
+		return err
+	}
+	out.Namespace = in.Namespace
+	return nil
+}
+
+// Convert_v1alpha2_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget is an autogenerated conversion function.
+func Convert_v1alpha2_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(in *CertificateRemoteTarget, out *certmanager.CertificateRemoteTarget, s conversion.Scope) error {
+	return autoConvert_v1alpha2_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(in, out, s)
+}
+
+func autoConvert_certmanager_CertificateRemoteTarget_To_v1alpha2_CertificateRemoteTarget(in *certmanager.CertificateRemoteTarget, out *CertificateRemoteTarget, s conversion.Scope) error {
+	if err := apismetav1.Convert_meta_SecretKeySelector_To_v1_SecretKeySelector(&in.KubeconfigSecretRef, &out.KubeconfigSecretRef, s); err != nil {
+		return err
+	}
+	out.Namespace = in.Namespace
 	return nil
 }
 
+// Convert_certmanager_CertificateRemoteTarget_To_v1alpha2_CertificateRemoteTarget is an autogenerated conversion function.
+func Convert_certmanager_CertificateRemoteTarget_To_v1alpha2_CertificateRemoteTarget(in *certmanager.CertificateRemoteTarget, out *CertificateRemoteTarget, s conversion.Scope) error {
+	return autoConvert_certmanager_CertificateRemoteTarget_To_v1alpha2_CertificateRemoteTarget(in, out, s)
+}
+
 func autoConvert_v1alpha2_CertificateRequest_To_certmanager_CertificateRequest(in *CertificateRequest, out *certmanager.CertificateRequest, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha2_CertificateRequestSpec_To_certmanager_CertificateRequestSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -845,6 +947,15 @@ func autoConvert_v1alpha2_CertificateSpec_To_certmanager_CertificateSpec(in *Cer
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]certmanager.CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.RemoteTarget != nil {
+		in, out := &in.RemoteTarget, &out.RemoteTarget
+		*out = new(certmanager.CertificateRemoteTarget)
+		if err := Convert_v1alpha2_CertificateRemoteTarget_To_certmanager_CertificateRemoteTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemoteTarget = nil
+	}
 	return nil
 }
 
@@ -894,6 +1005,15 @@ func autoConvert_certmanager_CertificateSpec_To_v1alpha2_CertificateSpec(in *cer
 	out.EncodeUsagesInRequest = (*bool)(unsafe.Pointer(in.EncodeUsagesInRequest))
 	out.RevisionHistoryLimit = (*int32)(unsafe.Pointer(in.RevisionHistoryLimit))
 	out.AdditionalOutputFormats = *(*[]CertificateAdditionalOutputFormat)(unsafe.Pointer(&in.AdditionalOutputFormats))
+	if in.RemoteTarget != nil {
+		in, out := &in.RemoteTarget, &out.RemoteTarget
+		*out = new(CertificateRemoteTarget)
+		if err := Convert_certmanager_CertificateRemoteTarget_To_v1alpha2_CertificateRemoteTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RemoteTarget = nil
+	}
 	return nil
 }
 
@@ -906,6 +1026,7 @@ func autoConvert_v1alpha2_CertificateStatus_To_certmanager_CertificateStatus(in
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.PrivateKeyFallback = (*certmanager.CertificatePrivateKeyFallback)(unsafe.Pointer(in.PrivateKeyFallback))
 	return nil
 }
 
@@ -923,6 +1044,7 @@ func autoConvert_certmanager_CertificateStatus_To_v1alpha2_CertificateStatus(in
 	out.Revision = (*int)(unsafe.Pointer(in.Revision))
 	out.NextPrivateKeySecretName = (*string)(unsafe.Pointer(in.NextPrivateKeySecretName))
 	out.FailedIssuanceAttempts = (*int)(unsafe.Pointer(in.FailedIssuanceAttempts))
+	out.PrivateKeyFallback = (*CertificatePrivateKeyFallback)(unsafe.Pointer(in.PrivateKeyFallback))
 	return nil
 }
 
@@ -1212,6 +1334,7 @@ func Convert_certmanager_IssuerSpec_To_v1alpha2_IssuerSpec(in *certmanager.Issue
 func autoConvert_v1alpha2_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus, out *certmanager.IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]certmanager.IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acme.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*certmanager.VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
 	return nil
 }
 
@@ -1223,6 +1346,7 @@ func Convert_v1alpha2_IssuerStatus_To_certmanager_IssuerStatus(in *IssuerStatus,
 func autoConvert_certmanager_IssuerStatus_To_v1alpha2_IssuerStatus(in *certmanager.IssuerStatus, out *IssuerStatus, s conversion.Scope) error {
 	out.Conditions = *(*[]IssuerCondition)(unsafe.Pointer(&in.Conditions))
 	out.ACME = (*acmev1alpha2.ACMEIssuerStatus)(unsafe.Pointer(in.ACME))
+	out.Venafi = (*VenafiIssuerStatus)(unsafe.Pointer(in.Venafi))
 	return nil
 }
 
@@ -1498,6 +1622,28 @@ func autoConvert_certmanager_VaultKubernetesAuth_To_v1alpha2_VaultKubernetesAuth
 	return nil
 }
 
+func autoConvert_v1alpha2_VenafiAllowedKey_To_certmanager_VenafiAllowedKey(in *VenafiAllowedKey, out *certmanager.VenafiAllowedKey, s conversion.Scope) error {
+	out.Algorithm = certmanager.PrivateKeyAlgorithm(in.Algorithm)
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_v1alpha2_VenafiAllowedKey_To_certmanager_VenafiAllowedKey is an autogenerated conversion function.
+func Convert_v1alpha2_VenafiAllowedKey_To_certmanager_VenafiAllowedKey(in *VenafiAllowedKey, out *certmanager.VenafiAllowedKey, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VenafiAllowedKey_To_certmanager_VenafiAllowedKey(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiAllowedKey_To_v1alpha2_VenafiAllowedKey(in *certmanager.VenafiAllowedKey, out *VenafiAllowedKey, s conversion.Scope) error {
+	out.Algorithm = KeyAlgorithm(in.Algorithm)
+	out.Sizes = *(*[]int)(unsafe.Pointer(&in.Sizes))
+	return nil
+}
+
+// Convert_certmanager_VenafiAllowedKey_To_v1alpha2_VenafiAllowedKey is an autogenerated conversion function.
+func Convert_certmanager_VenafiAllowedKey_To_v1alpha2_VenafiAllowedKey(in *certmanager.VenafiAllowedKey, out *VenafiAllowedKey, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiAllowedKey_To_v1alpha2_VenafiAllowedKey(in, out, s)
+}
+
 func autoConvert_v1alpha2_VenafiCloud_To_certmanager_VenafiCloud(in *VenafiCloud, out *certmanager.VenafiCloud, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := apismetav1.Convert_v1_SecretKeySelector_To_meta_SecretKeySelector(&in.APITokenSecretRef, &out.APITokenSecretRef, s); err != nil {
@@ -1580,6 +1726,26 @@ func Convert_certmanager_VenafiIssuer_To_v1alpha2_VenafiIssuer(in *certmanager.V
 	return autoConvert_certmanager_VenafiIssuer_To_v1alpha2_VenafiIssuer(in, out, s)
 }
 
+func autoConvert_v1alpha2_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*certmanager.VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_v1alpha2_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_v1alpha2_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in *VenafiIssuerStatus, out *certmanager.VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VenafiIssuerStatus_To_certmanager_VenafiIssuerStatus(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiIssuerStatus_To_v1alpha2_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *VenafiIssuerStatus, s conversion.Scope) error {
+	out.ZonePolicy = (*VenafiZonePolicy)(unsafe.Pointer(in.ZonePolicy))
+	return nil
+}
+
+// Convert_certmanager_VenafiIssuerStatus_To_v1alpha2_VenafiIssuerStatus is an autogenerated conversion function.
+func Convert_certmanager_VenafiIssuerStatus_To_v1alpha2_VenafiIssuerStatus(in *certmanager.VenafiIssuerStatus, out *VenafiIssuerStatus, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiIssuerStatus_To_v1alpha2_VenafiIssuerStatus(in, out, s)
+}
+
 func autoConvert_v1alpha2_VenafiTPP_To_certmanager_VenafiTPP(in *VenafiTPP, out *certmanager.VenafiTPP, s conversion.Scope) error {
 	out.URL = in.URL
 	if err := apismetav1.Convert_v1_LocalObjectReference_To_meta_LocalObjectReference(&in.CredentialsRef, &out.CredentialsRef, s); err != nil {
@@ -1608,6 +1774,52 @@ func Convert_certmanager_VenafiTPP_To_v1alpha2_VenafiTPP(in *certmanager.VenafiT
 	return autoConvert_certmanager_VenafiTPP_To_v1alpha2_VenafiTPP(in, out, s)
 }
 
+func autoConvert_v1alpha2_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.LastSyncTime = (*v1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.CommonNames = *(*[]string)(unsafe.Pointer(&in.CommonNames))
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
+	out.OrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.OrganizationalUnits))
+	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
+	out.Provinces = *(*[]string)(unsafe.Pointer(&in.Provinces))
+	out.Localities = *(*[]string)(unsafe.Pointer(&in.Localities))
+	out.AllowedKeys = *(*[]certmanager.VenafiAllowedKey)(unsafe.Pointer(&in.AllowedKeys))
+	out.AllowWildcards = in.AllowWildcards
+	return nil
+}
+
+// Convert_v1alpha2_VenafiZonePolicy_To_certmanager_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_v1alpha2_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in *VenafiZonePolicy, out *certmanager.VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_v1alpha2_VenafiZonePolicy_To_certmanager_VenafiZonePolicy(in, out, s)
+}
+
+func autoConvert_certmanager_VenafiZonePolicy_To_v1alpha2_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *VenafiZonePolicy, s conversion.Scope) error {
+	out.Zone = in.Zone
+	out.LastSyncTime = (*v1.Time)(unsafe.Pointer(in.LastSyncTime))
+	out.CommonNames = *(*[]string)(unsafe.Pointer(&in.CommonNames))
+	out.DNSNames = *(*[]string)(unsafe.Pointer(&in.DNSNames))
+	out.IPAddresses = *(*[]string)(unsafe.Pointer(&in.IPAddresses))
+	out.EmailAddresses = *(*[]string)(unsafe.Pointer(&in.EmailAddresses))
+	out.URIs = *(*[]string)(unsafe.Pointer(&in.URIs))
+	out.Organizations = *(*[]string)(unsafe.Pointer(&in.Organizations))
+	out.OrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.OrganizationalUnits))
+	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
+	out.Provinces = *(*[]string)(unsafe.Pointer(&in.Provinces))
+	out.Localities = *(*[]string)(unsafe.Pointer(&in.Localities))
+	out.AllowedKeys = *(*[]VenafiAllowedKey)(unsafe.Pointer(&in.AllowedKeys))
+	out.AllowWildcards = in.AllowWildcards
+	return nil
+}
+
+// Convert_certmanager_VenafiZonePolicy_To_v1alpha2_VenafiZonePolicy is an autogenerated conversion function.
+func Convert_certmanager_VenafiZonePolicy_To_v1alpha2_VenafiZonePolicy(in *certmanager.VenafiZonePolicy, out *VenafiZonePolicy, s conversion.Scope) error {
+	return autoConvert_certmanager_VenafiZonePolicy_To_v1alpha2_VenafiZonePolicy(in, out, s)
+}
+
 func autoConvert_v1alpha2_X509Subject_To_certmanager_X509Subject(in *X509Subject, out *certmanager.X509Subject, s conversion.Scope) error {
 	out.Countries = *(*[]string)(unsafe.Pointer(&in.Countries))
 	out.OrganizationalUnits = *(*[]string)(unsafe.Pointer(&in.OrganizationalUnits))
@@ -193,6 +193,39 @@ func (in *CertificatePrivateKey) DeepCopy() *CertificatePrivateKey {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatePrivateKeyFallback) DeepCopyInto(out *CertificatePrivateKeyFallback) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePrivateKeyFallback.
+func (in *CertificatePrivateKeyFallback) DeepCopy() *CertificatePrivateKeyFallback {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatePrivateKeyFallback)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRemoteTarget) DeepCopyInto(out *CertificateRemoteTarget) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRemoteTarget.
+func (in *CertificateRemoteTarget) DeepCopy() *CertificateRemoteTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRemoteTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRequest) DeepCopyInto(out *CertificateRequest) {
 	*out = *in
@@ -472,6 +505,11 @@ func (in *CertificateSpec) DeepCopyInto(out *CertificateSpec) {
 		*out = make([]CertificateAdditionalOutputFormat, len(*in))
 		copy(*out, *in)
 	}
+	if in.RemoteTarget != nil {
+		in, out := &in.RemoteTarget, &out.RemoteTarget
+		*out = new(CertificateRemoteTarget)
+		**out = **in
+	}
 	return
 }
 
@@ -526,6 +564,11 @@ func (in *CertificateStatus) DeepCopyInto(out *CertificateStatus) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.PrivateKeyFallback != nil {
+		in, out := &in.PrivateKeyFallback, &out.PrivateKeyFallback
+		*out = new(CertificatePrivateKeyFallback)
+		**out = **in
+	}
 	return
 }
 
@@ -754,6 +797,11 @@ func (in *IssuerStatus) DeepCopyInto(out *IssuerStatus) {
 		*out = new(acmev1alpha2.ACMEIssuerStatus)
 		**out = **in
 	}
+	if in.Venafi != nil {
+		in, out := &in.Venafi, &out.Venafi
+		*out = new(VenafiIssuerStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -935,6 +983,27 @@ func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiAllowedKey) DeepCopyInto(out *VenafiAllowedKey) {
+	*out = *in
+	if in.Sizes != nil {
+		in, out := &in.Sizes, &out.Sizes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiAllowedKey.
+func (in *VenafiAllowedKey) DeepCopy() *VenafiAllowedKey {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiAllowedKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiCloud) DeepCopyInto(out *VenafiCloud) {
 	*out = *in
@@ -978,6 +1047,27 @@ func (in *VenafiIssuer) DeepCopy() *VenafiIssuer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiIssuerStatus) DeepCopyInto(out *VenafiIssuerStatus) {
+	*out = *in
+	if in.ZonePolicy != nil {
+		in, out := &in.ZonePolicy, &out.ZonePolicy
+		*out = new(VenafiZonePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiIssuerStatus.
+func (in *VenafiIssuerStatus) DeepCopy() *VenafiIssuerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiIssuerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VenafiTPP) DeepCopyInto(out *VenafiTPP) {
 	*out = *in
@@ -1000,6 +1090,83 @@ func (in *VenafiTPP) DeepCopy() *VenafiTPP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VenafiZonePolicy) DeepCopyInto(out *VenafiZonePolicy) {
+	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CommonNames != nil {
+		in, out := &in.CommonNames, &out.CommonNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSNames != nil {
+		in, out := &in.DNSNames, &out.DNSNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IPAddresses != nil {
+		in, out := &in.IPAddresses, &out.IPAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EmailAddresses != nil {
+		in, out := &in.EmailAddresses, &out.EmailAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.URIs != nil {
+		in, out := &in.URIs, &out.URIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrganizationalUnits != nil {
+		in, out := &in.OrganizationalUnits, &out.OrganizationalUnits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Countries != nil {
+		in, out := &in.Countries, &out.Countries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Provinces != nil {
+		in, out := &in.Provinces, &out.Provinces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Localities != nil {
+		in, out := &in.Localities, &out.Localities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedKeys != nil {
+		in, out := &in.AllowedKeys, &out.AllowedKeys
+		*out = make([]VenafiAllowedKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VenafiZonePolicy.
+func (in *VenafiZonePolicy) DeepCopy() *VenafiZonePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VenafiZonePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *X509Subject) DeepCopyInto(out *X509Subject) {
 	*out = *in
@@ -78,6 +78,17 @@ const (
 	PKCS8 KeyEncoding = "pkcs8"
 )
 
+type PrivateKeySignatureScheme string
+
+const (
+	// PKCS1v15SignatureScheme signs using RSASSA-PKCS1-v1_5.
+	PKCS1v15SignatureScheme PrivateKeySignatureScheme = "PKCS1v15"
+
+	// PSSSignatureScheme signs using RSASSA-PSS, with a salt length equal to
+	// the length of the hash.
+	PSSSignatureScheme PrivateKeySignatureScheme = "PSS"
+)
+
 // CertificateSpec defines the desired state of Certificate.
 // A valid Certificate requires at least one of a CommonName, DNSName, or
 // URISAN to be valid.
@@ -221,6 +232,27 @@ type CertificateSpec struct {
 	// the controller and webhook components.
 	// +optional
 	AdditionalOutputFormats []CertificateAdditionalOutputFormat `json:"additionalOutputFormats,omitempty"`
+
+	// RemoteTarget configures a Secret in another cluster to which the
+	// issued certificate is written, in addition to the `secretName` Secret.
+	// The remote Secret has the same name as `secretName` and is kept
+	// consistent with it. This is an Alpha Feature and is only enabled with
+	// the `--feature-gates=RemoteSecretTargets=true` option on both the
+	// controller and webhook components.
+	// +optional
+	RemoteTarget *CertificateRemoteTarget `json:"remoteTarget,omitempty"`
+}
+
+// CertificateRemoteTarget is a namespace in a remote cluster to which the
+// Secret of a Certificate is written.
+type CertificateRemoteTarget struct {
+	// KubeconfigSecretRef is a reference to a key in a Secret in the namespace
+	// of the Certificate, which contains a kubeconfig for the remote cluster.
+	KubeconfigSecretRef cmmeta.SecretKeySelector `json:"kubeconfigSecretRef"`
+
+	// Namespace is the namespace in the remote cluster in which the Secret is
+	// written.
+	Namespace string `json:"namespace"`
 }
 
 // CertificatePrivateKey contains configuration options for private keys
@@ -238,6 +270,16 @@ type CertificatePrivateKey struct {
 	// Default is 'Never' for backward compatibility.
 	// +optional
 	RotationPolicy PrivateKeyRotationPolicy `json:"rotationPolicy,omitempty"`
+
+	// SignatureScheme is the RSA signature scheme used to sign the
+	// certificate signing request. The CA and SelfSigned issuers sign the
+	// certificate using the same scheme if their signing key is an RSA key.
+	// If provided, allowed values are `PKCS1v15` and `PSS` standing for
+	// RSASSA-PKCS1-v1_5 and RSASSA-PSS, respectively.
+	// Defaults to `PKCS1v15` if not specified. May only be set if
+	// `keyAlgorithm` is `rsa`.
+	// +optional
+	SignatureScheme PrivateKeySignatureScheme `json:"signatureScheme,omitempty"`
 }
 
 // Denotes how private keys should be generated or sourced when a Certificate
@@ -398,6 +440,31 @@ type CertificateStatus struct {
 	// time.Hour * 2 ^ (failedIssuanceAttempts - 1).
 	// +optional
 	FailedIssuanceAttempts *int `json:"failedIssuanceAttempts,omitempty"`
+
+	// PrivateKeyFallback is set by the keymanager controller when the issuer
+	// rejected the private key of this Certificate and configures a fallback
+	// private key algorithm and size to use instead. While set, private keys
+	// are generated and checked using the fallback in place of the algorithm
+	// and size in `spec.privateKey`. The fallback only applies to the
+	// generation of the Certificate it was chosen for, and is discarded once
+	// the spec of the Certificate changes.
+	// +optional
+	PrivateKeyFallback *CertificatePrivateKeyFallback `json:"privateKeyFallback,omitempty"`
+}
+
+// CertificatePrivateKeyFallback is a private key algorithm and size used in
+// place of those in the spec of a Certificate.
+type CertificatePrivateKeyFallback struct {
+	// Algorithm is the private key algorithm of the fallback key.
+	Algorithm KeyAlgorithm `json:"algorithm"`
+
+	// Size is the key bit size of the fallback key.
+	// +optional
+	Size int `json:"size,omitempty"`
+
+	// ObservedGeneration is the generation of the Certificate the fallback
+	// was chosen for.
+	ObservedGeneration int64 `json:"observedGeneration"`
 }
 
 // CertificateCondition contains condition information for an Certificate.
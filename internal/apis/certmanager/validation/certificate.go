@@ -49,6 +49,7 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 	el = append(el, validateIssuerRef(crt.IssuerRef, fldPath)...)
 
 	var commonName = crt.CommonName
+	var literalSubjectInvalid bool
 	if crt.LiteralSubject != "" {
 
 		if !utilfeature.DefaultFeatureGate.Enabled(feature.LiteralCertificateSubject) {
@@ -58,6 +59,7 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		sequence, err := pki.UnmarshalSubjectStringToRDNSequence(crt.LiteralSubject)
 		if err != nil {
 			el = append(el, field.Invalid(fldPath.Child("literalSubject"), crt.LiteralSubject, err.Error()))
+			literalSubjectInvalid = true
 		}
 
 		// Must contain a CN
@@ -73,15 +75,6 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 			}
 		}
 
-		// Should not contain unrecognized OIDs
-		for _, rdns := range sequence {
-			for _, atv := range rdns {
-				if atv.Type.Equal(nil) {
-					el = append(el, field.Invalid(fldPath.Child("literalSubject"), crt.LiteralSubject, fmt.Sprintf("Literal subject contains unrecognized key with value [%s]", atv.Value)))
-				}
-			}
-		}
-
 		if len(crt.CommonName) != 0 {
 			el = append(el, field.Invalid(fldPath.Child("commonName"), crt.CommonName, "When providing a `LiteralSubject` no `commonName` may be provided."))
 		}
@@ -92,7 +85,9 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 
 	}
 
-	if len(commonName) == 0 && len(crt.DNSNames) == 0 && len(crt.URISANs) == 0 && len(crt.EmailSANs) == 0 && len(crt.IPAddresses) == 0 {
+	// A literal subject which cannot be parsed may still contain a CN, so
+	// don't report a missing identity on top of the parse error.
+	if !literalSubjectInvalid && len(commonName) == 0 && len(crt.DNSNames) == 0 && len(crt.URISANs) == 0 && len(crt.EmailSANs) == 0 && len(crt.IPAddresses) == 0 {
 		el = append(el, field.Invalid(fldPath, "", "at least one of commonName, dnsNames, uris ipAddresses, or emailAddresses must be set"))
 	}
 
@@ -101,6 +96,10 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 		el = append(el, field.TooLong(fldPath.Child("commonName"), crt.CommonName, 64))
 	}
 
+	if len(crt.DNSNames) > 0 {
+		el = append(el, validateDNSNames(crt, fldPath)...)
+	}
+
 	if len(crt.IPAddresses) > 0 {
 		el = append(el, validateIPAddresses(crt, fldPath)...)
 	}
@@ -110,20 +109,8 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 	}
 
 	if crt.PrivateKey != nil {
-		switch crt.PrivateKey.Algorithm {
-		case "", internalcmapi.RSAKeyAlgorithm:
-			if crt.PrivateKey.Size > 0 && (crt.PrivateKey.Size < 2048 || crt.PrivateKey.Size > 8192) {
-				el = append(el, field.Invalid(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
-			}
-		case internalcmapi.ECDSAKeyAlgorithm:
-			if crt.PrivateKey.Size > 0 && crt.PrivateKey.Size != 256 && crt.PrivateKey.Size != 384 && crt.PrivateKey.Size != 521 {
-				el = append(el, field.NotSupported(fldPath.Child("privateKey", "size"), crt.PrivateKey.Size, []string{"256", "384", "521"}))
-			}
-		case internalcmapi.Ed25519KeyAlgorithm:
-			break
-		default:
-			el = append(el, field.Invalid(fldPath.Child("privateKey", "algorithm"), crt.PrivateKey.Algorithm, "must be either empty or one of rsa or ecdsa"))
-		}
+		el = append(el, validatePrivateKeyAlgorithmAndSize(crt.PrivateKey.Algorithm, crt.PrivateKey.Size, fldPath.Child("privateKey"))...)
+		el = append(el, validatePrivateKeySignatureScheme(crt.PrivateKey, fldPath.Child("privateKey"))...)
 	}
 
 	if crt.Duration != nil || crt.RenewBefore != nil {
@@ -147,6 +134,10 @@ func ValidateCertificateSpec(crt *internalcmapi.CertificateSpec, fldPath *field.
 
 	el = append(el, validateAdditionalOutputFormats(crt, fldPath)...)
 
+	if crt.RemoteTarget != nil {
+		el = append(el, validateRemoteTarget(crt.RemoteTarget, fldPath.Child("remoteTarget"))...)
+	}
+
 	return el
 }
 
@@ -180,6 +171,58 @@ func validateIssuerRef(issuerRef cmmeta.ObjectReference, fldPath *field.Path) fi
 	return el
 }
 
+// validatePrivateKeyAlgorithmAndSize validates the algorithm and size of a
+// private key, as found in spec.privateKey of a Certificate.
+func validatePrivateKeyAlgorithmAndSize(algorithm internalcmapi.PrivateKeyAlgorithm, size int, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	switch algorithm {
+	case "", internalcmapi.RSAKeyAlgorithm:
+		if size > 0 && (size < 2048 || size > 8192) {
+			el = append(el, field.Invalid(fldPath.Child("size"), size, "must be between 2048 & 8192 for rsa keyAlgorithm"))
+		}
+	case internalcmapi.ECDSAKeyAlgorithm:
+		if size > 0 && size != 256 && size != 384 && size != 521 {
+			el = append(el, field.NotSupported(fldPath.Child("size"), size, []string{"256", "384", "521"}))
+		}
+	case internalcmapi.Ed25519KeyAlgorithm:
+		break
+	default:
+		el = append(el, field.Invalid(fldPath.Child("algorithm"), algorithm, "must be either empty or one of rsa or ecdsa"))
+	}
+	return el
+}
+
+// validatePrivateKeySignatureScheme validates spec.privateKey.signatureScheme
+// of a Certificate, which may only be set for RSA private keys.
+func validatePrivateKeySignatureScheme(privateKey *internalcmapi.CertificatePrivateKey, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	switch privateKey.SignatureScheme {
+	case "":
+		return el
+	case internalcmapi.PKCS1v15SignatureScheme, internalcmapi.PSSSignatureScheme:
+	default:
+		return append(el, field.NotSupported(fldPath.Child("signatureScheme"), privateKey.SignatureScheme,
+			[]string{string(internalcmapi.PKCS1v15SignatureScheme), string(internalcmapi.PSSSignatureScheme)}))
+	}
+	if privateKey.Algorithm != "" && privateKey.Algorithm != internalcmapi.RSAKeyAlgorithm {
+		el = append(el, field.Forbidden(fldPath.Child("signatureScheme"), "may only be set for rsa keyAlgorithm"))
+	}
+	return el
+}
+
+// validateDNSNames validates the internationalized names in dnsNames, which
+// must be valid IDNA2008 names without labels mixing scripts. Names which
+// only contain ASCII characters are not validated.
+func validateDNSNames(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	for i, d := range a.DNSNames {
+		if _, err := pki.DNSNameToASCII(d); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("dnsNames").Index(i), d, err.Error()))
+		}
+	}
+	return el
+}
+
 func validateIPAddresses(a *internalcmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
 	if len(a.IPAddresses) <= 0 {
 		return nil
@@ -282,3 +325,25 @@ func validateAdditionalOutputFormats(crt *internalcmapi.CertificateSpec, fldPath
 
 	return el
 }
+
+func validateRemoteTarget(target *internalcmapi.CertificateRemoteTarget, fldPath *field.Path) field.ErrorList {
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.RemoteSecretTargets) {
+		return field.ErrorList{field.Forbidden(fldPath, "feature gate RemoteSecretTargets must be enabled")}
+	}
+
+	var el field.ErrorList
+	if len(target.KubeconfigSecretRef.Name) == 0 {
+		el = append(el, field.Required(fldPath.Child("kubeconfigSecretRef", "name"), "secret name is required"))
+	}
+	if len(target.KubeconfigSecretRef.Key) == 0 {
+		el = append(el, field.Required(fldPath.Child("kubeconfigSecretRef", "key"), "secret key is required"))
+	}
+	if len(target.Namespace) == 0 {
+		el = append(el, field.Required(fldPath.Child("namespace"), "namespace is required"))
+	} else {
+		for _, msg := range apivalidation.ValidateNamespaceName(target.Namespace, false) {
+			el = append(el, field.Invalid(fldPath.Child("namespace"), target.Namespace, msg))
+		}
+	}
+	return el
+}
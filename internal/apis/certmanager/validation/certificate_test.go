@@ -114,6 +114,29 @@ func TestValidateCertificate(t *testing.T) {
 			},
 			a: someAdmissionRequest,
 		},
+		"valid with internationalized dnsNames": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"bücher.example", "*.bücher.example", "xn--bcher-kva.example"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"invalid internationalized dnsNames": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					DNSNames:   []string{"example.com", "pаypal.com"},
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("dnsNames").Index(1), "pаypal.com", `invalid internationalized domain name "pаypal.com": label "pаypal" mixes characters of the Cyrillic, Latin scripts`),
+			},
+		},
 		"invalid issuerRef kind": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -350,6 +373,53 @@ func TestValidateCertificate(t *testing.T) {
 				field.NotSupported(fldPath.Child("privateKey", "size"), 100, []string{"256", "384", "521"}),
 			},
 		},
+		"certificate with rsa keyAlgorithm and PSS signatureScheme": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &internalcmapi.CertificatePrivateKey{
+						Algorithm:       internalcmapi.RSAKeyAlgorithm,
+						SignatureScheme: internalcmapi.PSSSignatureScheme,
+					},
+				},
+			},
+			a: someAdmissionRequest,
+		},
+		"certificate with ecdsa keyAlgorithm and PSS signatureScheme": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &internalcmapi.CertificatePrivateKey{
+						Algorithm:       internalcmapi.ECDSAKeyAlgorithm,
+						SignatureScheme: internalcmapi.PSSSignatureScheme,
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("privateKey", "signatureScheme"), "may only be set for rsa keyAlgorithm"),
+			},
+		},
+		"certificate with invalid signatureScheme": {
+			cfg: &internalcmapi.Certificate{
+				Spec: internalcmapi.CertificateSpec{
+					CommonName: "testcn",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					PrivateKey: &internalcmapi.CertificatePrivateKey{
+						SignatureScheme: "PSS-SHA1",
+					},
+				},
+			},
+			a: someAdmissionRequest,
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("privateKey", "signatureScheme"), internalcmapi.PrivateKeySignatureScheme("PSS-SHA1"), []string{"PKCS1v15", "PSS"}),
+			},
+		},
 		"certificate with invalid keyAlgorithm": {
 			cfg: &internalcmapi.Certificate{
 				Spec: internalcmapi.CertificateSpec{
@@ -1017,7 +1087,7 @@ func Test_validateLiteralSubject(t *testing.T) {
 			errs: []*field.Error{
 				field.Invalid(
 					fldPath.Child("literalSubject"),
-					"C=O,B=TX,CN=foo", "Literal subject contains unrecognized key with value [TX]"),
+					"C=O,B=TX,CN=foo", `unknown attribute type "B" in subject`),
 			},
 		},
 	}
@@ -1031,3 +1101,58 @@ func Test_validateLiteralSubject(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateRemoteTarget(t *testing.T) {
+	fldPath := field.NewPath("spec", "remoteTarget")
+	tests := map[string]struct {
+		featureEnabled bool
+		target         *internalcmapi.CertificateRemoteTarget
+		expErr         field.ErrorList
+	}{
+		"if feature disabled, expect error": {
+			featureEnabled: false,
+			target: &internalcmapi.CertificateRemoteTarget{
+				KubeconfigSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "edge-1"}, Key: "kubeconfig"},
+				Namespace:           "default",
+			},
+			expErr: field.ErrorList{
+				field.Forbidden(fldPath, "feature gate RemoteSecretTargets must be enabled"),
+			},
+		},
+		"if feature enabled and target is valid, expect no error": {
+			featureEnabled: true,
+			target: &internalcmapi.CertificateRemoteTarget{
+				KubeconfigSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "edge-1"}, Key: "kubeconfig"},
+				Namespace:           "default",
+			},
+			expErr: nil,
+		},
+		"if feature enabled and fields are missing, expect errors": {
+			featureEnabled: true,
+			target:         &internalcmapi.CertificateRemoteTarget{},
+			expErr: field.ErrorList{
+				field.Required(fldPath.Child("kubeconfigSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("kubeconfigSecretRef", "key"), "secret key is required"),
+				field.Required(fldPath.Child("namespace"), "namespace is required"),
+			},
+		},
+		"if feature enabled and namespace is invalid, expect error": {
+			featureEnabled: true,
+			target: &internalcmapi.CertificateRemoteTarget{
+				KubeconfigSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "edge-1"}, Key: "kubeconfig"},
+				Namespace:           "Not_Valid",
+			},
+			expErr: field.ErrorList{
+				field.Invalid(fldPath.Child("namespace"), "Not_Valid", `a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.RemoteSecretTargets, test.featureEnabled)()
+			gotErr := validateRemoteTarget(test.target, fldPath)
+			assert.Equal(t, test.expErr, gotErr)
+		})
+	}
+}
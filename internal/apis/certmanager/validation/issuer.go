@@ -19,6 +19,7 @@ package validation
 import (
 	"crypto/x509"
 	"fmt"
+	"net/url"
 	"strings"
 
 	admissionv1 "k8s.io/api/admission/v1"
@@ -144,6 +145,15 @@ func ValidateACMEIssuerConfig(iss *cmacme.ACMEIssuer, fldPath *field.Path) (fiel
 		el = append(el, ValidateACMEIssuerChallengeSolverConfig(&sol, fldPath.Child("solvers").Index(i))...)
 	}
 
+	for i, fallback := range iss.PrivateKeyFallbacks {
+		fldPath := fldPath.Child("privateKeyFallbacks").Index(i)
+		if len(fallback.Algorithm) == 0 {
+			el = append(el, field.Required(fldPath.Child("algorithm"), "private key algorithm is a required field"))
+			continue
+		}
+		el = append(el, validatePrivateKeyAlgorithmAndSize(certmanager.PrivateKeyAlgorithm(fallback.Algorithm), fallback.Size, fldPath)...)
+	}
+
 	return el, warnings
 }
 
@@ -182,6 +192,10 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 		numDefined++
 		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(http01.GatewayHTTPRoute, fldPath.Child("gateway"))...)
 	}
+	if http01.Publish != nil {
+		numDefined++
+		el = append(el, ValidateACMEIssuerChallengeSolverHTTP01PublishConfig(http01.Publish, fldPath.Child("publish"))...)
+	}
 	if numDefined == 0 {
 		el = append(el, field.Required(fldPath, "no HTTP01 solver type configured"))
 	}
@@ -195,8 +209,18 @@ func ValidateACMEIssuerChallengeSolverHTTP01Config(http01 *cmacme.ACMEChallengeS
 func ValidateACMEIssuerChallengeSolverHTTP01IngressConfig(ingress *cmacme.ACMEChallengeSolverHTTP01Ingress, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 
-	if ingress.Class != nil && len(ingress.Name) > 0 {
-		el = append(el, field.Forbidden(fldPath, "only one of 'name' or 'class' should be specified"))
+	numDefined := 0
+	if ingress.Class != nil {
+		numDefined++
+	}
+	if ingress.IngressClassName != nil {
+		numDefined++
+	}
+	if len(ingress.Name) > 0 {
+		numDefined++
+	}
+	if numDefined > 1 {
+		el = append(el, field.Forbidden(fldPath, "only one of 'name', 'class' or 'ingressClassName' should be specified"))
 	}
 	switch ingress.ServiceType {
 	case "", corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
@@ -221,6 +245,56 @@ func ValidateACMEIssuerChallengeSolverHTTP01GatewayConfig(gateway *cmacme.ACMECh
 	return el
 }
 
+func ValidateACMEIssuerChallengeSolverHTTP01PublishConfig(publish *cmacme.ACMEChallengeSolverHTTP01Publish, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	numDefined := 0
+	if publish.ConfigMap != nil {
+		numDefined++
+		if len(publish.ConfigMap.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("configMap", "name"), "configmap name is required"))
+		}
+	}
+	if publish.Secret != nil {
+		numDefined++
+		if len(publish.Secret.Name) == 0 {
+			el = append(el, field.Required(fldPath.Child("secret", "name"), "secret name is required"))
+		}
+	}
+	if publish.Webhook != nil {
+		numDefined++
+		el = append(el, validateACMEIssuerChallengeSolverHTTP01PublishWebhook(publish.Webhook, fldPath.Child("webhook"))...)
+	}
+	if numDefined == 0 {
+		el = append(el, field.Required(fldPath, "one of 'configMap', 'secret' or 'webhook' must be specified"))
+	}
+	if numDefined > 1 {
+		el = append(el, field.Forbidden(fldPath, "only one of 'configMap', 'secret' or 'webhook' may be specified"))
+	}
+
+	return el
+}
+
+func validateACMEIssuerChallengeSolverHTTP01PublishWebhook(webhook *cmacme.ACMEChallengeSolverHTTP01PublishWebhook, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	if len(webhook.URL) == 0 {
+		el = append(el, field.Required(fldPath.Child("url"), ""))
+	} else if u, err := url.Parse(webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		el = append(el, field.Invalid(fldPath.Child("url"), webhook.URL, "must be an absolute http or https URL"))
+	}
+	if len(webhook.CABundle) > 0 {
+		if err := validateCABundleNotEmpty(webhook.CABundle); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("caBundle"), "", err.Error()))
+		}
+	}
+	if webhook.AuthorizationSecretRef != nil {
+		el = append(el, ValidateSecretKeySelector(webhook.AuthorizationSecretRef, fldPath.Child("authorizationSecretRef"))...)
+	}
+
+	return el
+}
+
 func ValidateCAIssuerConfig(iss *certmanager.CAIssuer, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if len(iss.SecretName) == 0 {
@@ -439,6 +513,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 				el = append(el, field.Invalid(fldPath.Child("azureDNS", "environment"), p.AzureDNS.Environment,
 					fmt.Sprintf("must be either empty or one of %s, %s, %s or %s", cmacme.AzurePublicCloud, cmacme.AzureChinaCloud, cmacme.AzureGermanCloud, cmacme.AzureUSGovernmentCloud)))
 			}
+			el = append(el, validateDNSZoneVisibility(p.AzureDNS.ZoneVisibility, p.AzureDNS.VirtualNetworkID, "virtualNetworkID", fldPath.Child("azureDNS"))...)
 		}
 	}
 	if p.CloudDNS != nil {
@@ -454,6 +529,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if len(p.CloudDNS.Project) == 0 {
 				el = append(el, field.Required(fldPath.Child("cloudDNS", "project"), ""))
 			}
+			el = append(el, validateDNSZoneVisibility(p.CloudDNS.ZoneVisibility, p.CloudDNS.Network, "network", fldPath.Child("cloudDNS"))...)
 		}
 	}
 	if p.Cloudflare != nil {
@@ -497,6 +573,7 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 			if p.Route53.SecretAccessKeyID != nil {
 				el = append(el, ValidateSecretKeySelector(p.Route53.SecretAccessKeyID, fldPath.Child("route53", "accessKeyIDSecretRef"))...)
 			}
+			el = append(el, validateDNSZoneVisibility(p.Route53.ZoneVisibility, p.Route53.VPCID, "vpcID", fldPath.Child("route53"))...)
 		}
 	}
 	if p.AcmeDNS != nil {
@@ -568,6 +645,24 @@ func ValidateACMEChallengeSolverDNS01(p *cmacme.ACMEChallengeSolverDNS01, fldPat
 	return el
 }
 
+// validateDNSZoneVisibility validates the zone visibility of a cloud DNS
+// provider, and that the network filter with the given field name is only
+// set for private zones.
+func validateDNSZoneVisibility(visibility cmacme.DNSZoneVisibility, network, networkField string, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	switch visibility {
+	case "", cmacme.DNSZoneVisibilityPublic, cmacme.DNSZoneVisibilityPrivate:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("zoneVisibility"), visibility,
+			[]string{string(cmacme.DNSZoneVisibilityPublic), string(cmacme.DNSZoneVisibilityPrivate)}))
+	}
+	if len(network) > 0 && visibility != cmacme.DNSZoneVisibilityPrivate {
+		el = append(el, field.Forbidden(fldPath.Child(networkField),
+			fmt.Sprintf("may only be set if zoneVisibility is %s", cmacme.DNSZoneVisibilityPrivate)))
+	}
+	return el
+}
+
 func ValidateSecretKeySelector(sks *cmmeta.SecretKeySelector, fldPath *field.Path) field.ErrorList {
 	el := field.ErrorList{}
 	if sks.Name == "" {
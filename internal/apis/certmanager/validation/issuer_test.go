@@ -321,6 +321,33 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				field.Required(fldPath.Child("server"), "acme server URL is a required field"),
 			},
 		},
+		"acme issuer with private key fallbacks": {
+			spec: &cmacme.ACMEIssuer{
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				PrivateKeyFallbacks: []cmacme.ACMEPrivateKeyFallback{
+					{Algorithm: "ECDSA", Size: 384},
+					{Algorithm: "RSA"},
+					{Algorithm: "Ed25519"},
+				},
+			},
+		},
+		"acme issuer with invalid private key fallbacks": {
+			spec: &cmacme.ACMEIssuer{
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				PrivateKeyFallbacks: []cmacme.ACMEPrivateKeyFallback{
+					{Size: 2048},
+					{Algorithm: "RSA", Size: 1024},
+					{Algorithm: "ECDSA", Size: 2048},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("privateKeyFallbacks").Index(0).Child("algorithm"), "private key algorithm is a required field"),
+				field.Invalid(fldPath.Child("privateKeyFallbacks").Index(1).Child("size"), 1024, "must be between 2048 & 8192 for rsa keyAlgorithm"),
+				field.NotSupported(fldPath.Child("privateKeyFallbacks").Index(2).Child("size"), 2048, []string{"256", "384", "521"}),
+			},
+		},
 		"acme issuer with an invalid CA bundle": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -543,6 +570,88 @@ func TestValidateACMEIssuerConfig(t *testing.T) {
 				),
 			},
 		},
+		"acme solver with valid http01 publish configmap config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Publish: &cmacme.ACMEChallengeSolverHTTP01Publish{
+								ConfigMap: &cmacme.ACMEChallengeSolverHTTP01PublishObject{Name: "acme-tokens"},
+							},
+						},
+					},
+				},
+			},
+		},
+		"acme solver with valid http01 publish webhook config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Publish: &cmacme.ACMEChallengeSolverHTTP01Publish{
+								Webhook: &cmacme.ACMEChallengeSolverHTTP01PublishWebhook{
+									URL:                    "https://tokens.example.com/acme",
+									AuthorizationSecretRef: &validSecretKeyRef,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"acme solver with http01 publish config without a target": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Publish: &cmacme.ACMEChallengeSolverHTTP01Publish{},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(
+					fldPath.Child("solvers").Index(0).Child("http01", "publish"),
+					"one of 'configMap', 'secret' or 'webhook' must be specified",
+				),
+			},
+		},
+		"acme solver with invalid http01 publish config": {
+			spec: &cmacme.ACMEIssuer{
+				Email:      "valid-email",
+				Server:     "valid-server",
+				PrivateKey: validSecretKeyRef,
+				Solvers: []cmacme.ACMEChallengeSolver{
+					{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+							Publish: &cmacme.ACMEChallengeSolverHTTP01Publish{
+								Secret: &cmacme.ACMEChallengeSolverHTTP01PublishObject{},
+								Webhook: &cmacme.ACMEChallengeSolverHTTP01PublishWebhook{
+									URL:                    "tokens.example.com",
+									AuthorizationSecretRef: &cmmeta.SecretKeySelector{},
+								},
+							},
+						},
+					},
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("solvers").Index(0).Child("http01", "publish", "secret", "name"), "secret name is required"),
+				field.Invalid(fldPath.Child("solvers").Index(0).Child("http01", "publish", "webhook", "url"), "tokens.example.com", "must be an absolute http or https URL"),
+				field.Required(fldPath.Child("solvers").Index(0).Child("http01", "publish", "webhook", "authorizationSecretRef", "name"), "secret name is required"),
+				field.Required(fldPath.Child("solvers").Index(0).Child("http01", "publish", "webhook", "authorizationSecretRef", "key"), "secret key is required"),
+				field.Forbidden(fldPath.Child("solvers").Index(0).Child("http01", "publish"), "only one of 'configMap', 'secret' or 'webhook' may be specified"),
+			},
+		},
 		"acme issue with valid pod template ObjectMeta attributes": {
 			spec: &cmacme.ACMEIssuer{
 				Email:      "valid-email",
@@ -764,6 +873,11 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("abc")},
 			},
 		},
+		"ingress class name field specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{IngressClassName: strPtr("abc")},
+			},
+		},
 		"neither field specified": {
 			cfg: &cmacme.ACMEChallengeSolverHTTP01{
 				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
@@ -783,7 +897,18 @@ func TestValidateACMEIssuerHTTP01Config(t *testing.T) {
 				},
 			},
 			errs: []*field.Error{
-				field.Forbidden(fldPath.Child("ingress"), "only one of 'name' or 'class' should be specified"),
+				field.Forbidden(fldPath.Child("ingress"), "only one of 'name', 'class' or 'ingressClassName' should be specified"),
+			},
+		},
+		"both class and ingress class name fields specified": {
+			cfg: &cmacme.ACMEChallengeSolverHTTP01{
+				Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+					Class:            strPtr("abc"),
+					IngressClassName: strPtr("abc"),
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("ingress"), "only one of 'name', 'class' or 'ingressClassName' should be specified"),
 			},
 		},
 		"acme issuer with valid http01 service config serviceType ClusterIP": {
@@ -998,6 +1123,50 @@ func TestValidateACMEIssuerDNS01Config(t *testing.T) {
 				field.Required(fldPath.Child("route53", "accessKeyIDSecretRef", "key"), "secret key is required"),
 			},
 		},
+		"route53 private zone in a vpc": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region:         "valid",
+					ZoneVisibility: cmacme.DNSZoneVisibilityPrivate,
+					VPCID:          "vpc-1234",
+				},
+			},
+		},
+		"route53 vpcID without private zone visibility": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+					Region: "valid",
+					VPCID:  "vpc-1234",
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("route53", "vpcID"), "may only be set if zoneVisibility is Private"),
+			},
+		},
+		"clouddns invalid zone visibility": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				CloudDNS: &cmacme.ACMEIssuerDNS01ProviderCloudDNS{
+					Project:        "valid",
+					ZoneVisibility: "Internal",
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("cloudDNS", "zoneVisibility"), cmacme.DNSZoneVisibility("Internal"), []string{"Public", "Private"}),
+			},
+		},
+		"azuredns virtualNetworkID with public zone visibility": {
+			cfg: &cmacme.ACMEChallengeSolverDNS01{
+				AzureDNS: &cmacme.ACMEIssuerDNS01ProviderAzureDNS{
+					SubscriptionID:    "valid",
+					ResourceGroupName: "valid",
+					ZoneVisibility:    cmacme.DNSZoneVisibilityPublic,
+					VirtualNetworkID:  "/subscriptions/valid/resourceGroups/valid/providers/Microsoft.Network/virtualNetworks/vnet",
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("azureDNS", "virtualNetworkID"), "may only be set if zoneVisibility is Private"),
+			},
+		},
 		"missing provider config": {
 			cfg: &cmacme.ACMEChallengeSolverDNS01{},
 			errs: []*field.Error{
@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+// Validation functions for cert-manager SSHCertificate types
+
+func ValidateSSHCertificateSpec(crt *internalcmapi.SSHCertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+	if crt.SecretName == "" {
+		el = append(el, field.Required(fldPath.Child("secretName"), "must be specified"))
+	}
+
+	el = append(el, validateIssuerRef(crt.IssuerRef, fldPath)...)
+
+	switch crt.Type {
+	case "", internalcmapi.SSHUserCertificate, internalcmapi.SSHHostCertificate:
+	default:
+		el = append(el, field.NotSupported(fldPath.Child("type"), crt.Type, []string{string(internalcmapi.SSHUserCertificate), string(internalcmapi.SSHHostCertificate)}))
+	}
+
+	if len(crt.Principals) == 0 {
+		el = append(el, field.Required(fldPath.Child("principals"), "at least one principal must be specified"))
+	}
+	for i, principal := range crt.Principals {
+		if principal == "" {
+			el = append(el, field.Invalid(fldPath.Child("principals").Index(i), principal, "must not be empty"))
+		}
+	}
+
+	if crt.PublicKey != "" {
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(crt.PublicKey)); err != nil {
+			el = append(el, field.Invalid(fldPath.Child("publicKey"), crt.PublicKey, fmt.Sprintf("must be a public key in the OpenSSH authorized_keys format: %s", err)))
+		}
+		if crt.PrivateKey != nil {
+			el = append(el, field.Forbidden(fldPath.Child("privateKey"), "may not be specified together with publicKey"))
+		}
+	}
+
+	if crt.PrivateKey != nil {
+		el = append(el, validatePrivateKeyAlgorithmAndSize(crt.PrivateKey.Algorithm, crt.PrivateKey.Size, fldPath.Child("privateKey"))...)
+	}
+
+	if crt.Type == internalcmapi.SSHHostCertificate {
+		if len(crt.CriticalOptions) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("criticalOptions"), "critical options are only supported for User certificates"))
+		}
+		if len(crt.Extensions) > 0 {
+			el = append(el, field.Forbidden(fldPath.Child("extensions"), "extensions are only supported for User certificates"))
+		}
+	}
+
+	el = append(el, validateSSHCertificateDuration(crt, fldPath)...)
+
+	return el
+}
+
+func ValidateSSHCertificate(a *admissionv1.AdmissionRequest, obj runtime.Object) (field.ErrorList, []string) {
+	crt := obj.(*internalcmapi.SSHCertificate)
+	if !utilfeature.DefaultFeatureGate.Enabled(feature.SSHCertificates) {
+		return field.ErrorList{field.Forbidden(field.NewPath("spec"), "Feature gate SSHCertificates must be enabled on both webhook and controller to use the alpha SSHCertificate resource")}, nil
+	}
+	allErrs := ValidateSSHCertificateSpec(&crt.Spec, field.NewPath("spec"))
+	return allErrs, nil
+}
+
+func ValidateUpdateSSHCertificate(a *admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (field.ErrorList, []string) {
+	crt := obj.(*internalcmapi.SSHCertificate)
+	allErrs := ValidateSSHCertificateSpec(&crt.Spec, field.NewPath("spec"))
+	return allErrs, nil
+}
+
+func validateSSHCertificateDuration(crt *internalcmapi.SSHCertificateSpec, fldPath *field.Path) field.ErrorList {
+	el := field.ErrorList{}
+
+	duration := cmapi.DefaultSSHCertificateDuration
+	if crt.Duration != nil {
+		duration = crt.Duration.Duration
+	}
+	if duration < cmapi.MinimumSSHCertificateDuration {
+		el = append(el, field.Invalid(fldPath.Child("duration"), duration, fmt.Sprintf("certificate duration must be greater than %s", cmapi.MinimumSSHCertificateDuration)))
+	}
+	// If spec.renewBefore is set, check that it is not less than the minimum.
+	if crt.RenewBefore != nil && crt.RenewBefore.Duration < cmapi.MinimumRenewBefore {
+		el = append(el, field.Invalid(fldPath.Child("renewBefore"), crt.RenewBefore.Duration, fmt.Sprintf("certificate renewBefore must be greater than %s", cmapi.MinimumRenewBefore)))
+	}
+	// If spec.renewBefore is set, it must be less than the duration.
+	if crt.RenewBefore != nil && crt.RenewBefore.Duration >= duration {
+		el = append(el, field.Invalid(fldPath.Child("renewBefore"), crt.RenewBefore.Duration, fmt.Sprintf("certificate duration %s must be greater than renewBefore %s", duration, crt.RenewBefore.Duration)))
+	}
+	return el
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	internalcmapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+func TestValidateSSHCertificate(t *testing.T) {
+	const publicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl user@example.com"
+	fldPath := field.NewPath("spec")
+
+	tests := map[string]struct {
+		featureEnabled bool
+		cfg            *internalcmapi.SSHCertificate
+		errs           []*field.Error
+	}{
+		"valid user certificate with a public key": {
+			featureEnabled: true,
+			cfg: &internalcmapi.SSHCertificate{
+				Spec: internalcmapi.SSHCertificateSpec{
+					SecretName:      "abc",
+					IssuerRef:       validIssuerRef,
+					Principals:      []string{"alice"},
+					PublicKey:       publicKey,
+					CriticalOptions: map[string]string{"force-command": "/bin/true"},
+					Extensions:      map[string]string{"permit-pty": ""},
+				},
+			},
+		},
+		"valid host certificate with a generated key": {
+			featureEnabled: true,
+			cfg: &internalcmapi.SSHCertificate{
+				Spec: internalcmapi.SSHCertificateSpec{
+					Type:       internalcmapi.SSHHostCertificate,
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Principals: []string{"host.example.com"},
+					PrivateKey: &internalcmapi.SSHCertificatePrivateKey{Algorithm: internalcmapi.ECDSAKeyAlgorithm, Size: 384},
+				},
+			},
+		},
+		"forbidden if the feature gate is disabled": {
+			featureEnabled: false,
+			cfg: &internalcmapi.SSHCertificate{
+				Spec: internalcmapi.SSHCertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Principals: []string{"alice"},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath, "Feature gate SSHCertificates must be enabled on both webhook and controller to use the alpha SSHCertificate resource"),
+			},
+		},
+		"invalid with missing secretName and principals": {
+			featureEnabled: true,
+			cfg: &internalcmapi.SSHCertificate{
+				Spec: internalcmapi.SSHCertificateSpec{
+					IssuerRef: validIssuerRef,
+				},
+			},
+			errs: []*field.Error{
+				field.Required(fldPath.Child("secretName"), "must be specified"),
+				field.Required(fldPath.Child("principals"), "at least one principal must be specified"),
+			},
+		},
+		"invalid with an unknown type": {
+			featureEnabled: true,
+			cfg: &internalcmapi.SSHCertificate{
+				Spec: internalcmapi.SSHCertificateSpec{
+					Type:       "Robot",
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Principals: []string{"alice"},
+				},
+			},
+			errs: []*field.Error{
+				field.NotSupported(fldPath.Child("type"), internalcmapi.SSHCertificateType("Robot"), []string{"User", "Host"}),
+			},
+		},
+		"invalid with a malformed public key and a private key": {
+			featureEnabled: true,
+			cfg: &internalcmapi.SSHCertificate{
+				Spec: internalcmapi.SSHCertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Principals: []string{"alice"},
+					PublicKey:  "not-a-key",
+					PrivateKey: &internalcmapi.SSHCertificatePrivateKey{},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("publicKey"), "not-a-key", "must be a public key in the OpenSSH authorized_keys format: ssh: no key found"),
+				field.Forbidden(fldPath.Child("privateKey"), "may not be specified together with publicKey"),
+			},
+		},
+		"invalid host certificate with critical options and extensions": {
+			featureEnabled: true,
+			cfg: &internalcmapi.SSHCertificate{
+				Spec: internalcmapi.SSHCertificateSpec{
+					Type:            internalcmapi.SSHHostCertificate,
+					SecretName:      "abc",
+					IssuerRef:       validIssuerRef,
+					Principals:      []string{"host.example.com"},
+					CriticalOptions: map[string]string{"force-command": "/bin/true"},
+					Extensions:      map[string]string{"permit-pty": ""},
+				},
+			},
+			errs: []*field.Error{
+				field.Forbidden(fldPath.Child("criticalOptions"), "critical options are only supported for User certificates"),
+				field.Forbidden(fldPath.Child("extensions"), "extensions are only supported for User certificates"),
+			},
+		},
+		"invalid with a duration shorter than the minimum": {
+			featureEnabled: true,
+			cfg: &internalcmapi.SSHCertificate{
+				Spec: internalcmapi.SSHCertificateSpec{
+					SecretName: "abc",
+					IssuerRef:  validIssuerRef,
+					Principals: []string{"alice"},
+					Duration:   &metav1.Duration{Duration: 5 * time.Minute},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("duration"), 5*time.Minute, "certificate duration must be greater than 10m0s"),
+			},
+		},
+		"invalid with renewBefore greater than the default duration": {
+			featureEnabled: true,
+			cfg: &internalcmapi.SSHCertificate{
+				Spec: internalcmapi.SSHCertificateSpec{
+					SecretName:  "abc",
+					IssuerRef:   validIssuerRef,
+					Principals:  []string{"alice"},
+					RenewBefore: &metav1.Duration{Duration: 48 * time.Hour},
+				},
+			},
+			errs: []*field.Error{
+				field.Invalid(fldPath.Child("renewBefore"), 48*time.Hour, "certificate duration 24h0m0s must be greater than renewBefore 48h0m0s"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.SSHCertificates, test.featureEnabled)()
+			errs, warnings := ValidateSSHCertificate(someAdmissionRequest, test.cfg)
+			assert.ElementsMatch(t, errs, test.errs)
+			assert.ElementsMatch(t, warnings, []string{})
+		})
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package approval provides the policy model used by the cert-manager
+// approver controllers to decide whether a CertificateRequest or a Kubernetes
+// CertificateSigningRequest should be approved or denied.
+package approval
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+// Config is the set of approval policies loaded by the approver controllers.
+type Config struct {
+	// Policies is the ordered list of policies. The first policy whose
+	// SignerNames match a request is the one used to evaluate it.
+	Policies []Policy `json:"policies"`
+}
+
+// Policy is a set of constraints that a request must satisfy in order to be
+// approved. Empty allow lists mean that no values of that type may be
+// requested.
+type Policy struct {
+	// Name is used to identify the policy in conditions and events.
+	Name string `json:"name"`
+
+	// SignerNames is the list of signer names this policy applies to, for
+	// example "issuers.cert-manager.io/sandbox.*" or
+	// "clusterissuers.cert-manager.io/*". The wildcard '*' matches any
+	// sequence of characters.
+	SignerNames []string `json:"signerNames"`
+
+	// AllowedDNSNames is a list of wildcard patterns that requested DNS names
+	// must match.
+	AllowedDNSNames []string `json:"allowedDNSNames,omitempty"`
+
+	// AllowedIPRanges is a list of CIDRs that requested IP addresses must be
+	// contained in.
+	AllowedIPRanges []string `json:"allowedIPRanges,omitempty"`
+
+	// AllowedURIs is a list of wildcard patterns that requested URIs must
+	// match.
+	AllowedURIs []string `json:"allowedURIs,omitempty"`
+
+	// AllowedEmailAddresses is a list of wildcard patterns that requested
+	// email addresses must match.
+	AllowedEmailAddresses []string `json:"allowedEmailAddresses,omitempty"`
+
+	// AllowedUsages is the list of key usages that may be requested. If empty,
+	// any usage may be requested.
+	AllowedUsages []cmapi.KeyUsage `json:"allowedUsages,omitempty"`
+
+	// MaxDuration is the maximum duration that may be requested. If unset, any
+	// duration may be requested.
+	MaxDuration *metav1.Duration `json:"maxDuration,omitempty"`
+
+	// AllowCA controls whether a request may ask for a CA certificate.
+	AllowCA bool `json:"allowCA,omitempty"`
+}
+
+// Request is the set of attributes of a CertificateRequest or
+// CertificateSigningRequest that are evaluated against a Policy.
+type Request struct {
+	SignerName     string
+	DNSNames       []string
+	IPAddresses    []net.IP
+	URIs           []*url.URL
+	EmailAddresses []string
+	Usages         []cmapi.KeyUsage
+	Duration       time.Duration
+	IsCA           bool
+}
+
+// LoadConfig reads and validates the policy configuration file at the given
+// path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approval policy file: %w", err)
+	}
+
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode approval policy file %q: %w", path, err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid approval policy file %q: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+// Validate returns an error if any of the policies in the configuration are
+// malformed.
+func (c *Config) Validate() error {
+	for i, policy := range c.Policies {
+		if len(policy.Name) == 0 {
+			return fmt.Errorf("policies[%d]: name must be set", i)
+		}
+		if len(policy.SignerNames) == 0 {
+			return fmt.Errorf("policies[%d]: at least one signerName must be set", i)
+		}
+		for _, cidr := range policy.AllowedIPRanges {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("policies[%d]: invalid allowedIPRanges entry %q: %w", i, cidr, err)
+			}
+		}
+	}
+	return nil
+}
+
+// PolicyFor returns the first policy that applies to the given signer name,
+// or nil if no policy applies.
+func (c *Config) PolicyFor(signerName string) *Policy {
+	if c == nil {
+		return nil
+	}
+	for i := range c.Policies {
+		for _, pattern := range c.Policies[i].SignerNames {
+			if matchWildcard(pattern, signerName) {
+				return &c.Policies[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Evaluate evaluates the request against the policy and returns a list of
+// human readable violations. An empty list means that the request satisfies
+// the policy.
+func (p *Policy) Evaluate(req Request) []string {
+	var violations []string
+
+	for _, name := range req.DNSNames {
+		if !matchAnyWildcard(p.AllowedDNSNames, name) {
+			violations = append(violations, fmt.Sprintf("DNS name %q is not allowed", name))
+		}
+	}
+
+	for _, ip := range req.IPAddresses {
+		if !containsIP(p.AllowedIPRanges, ip) {
+			violations = append(violations, fmt.Sprintf("IP address %q is not allowed", ip))
+		}
+	}
+
+	for _, uri := range req.URIs {
+		if !matchAnyWildcard(p.AllowedURIs, uri.String()) {
+			violations = append(violations, fmt.Sprintf("URI %q is not allowed", uri))
+		}
+	}
+
+	for _, email := range req.EmailAddresses {
+		if !matchAnyWildcard(p.AllowedEmailAddresses, email) {
+			violations = append(violations, fmt.Sprintf("email address %q is not allowed", email))
+		}
+	}
+
+	if len(p.AllowedUsages) > 0 {
+		for _, usage := range req.Usages {
+			if !containsUsage(p.AllowedUsages, usage) {
+				violations = append(violations, fmt.Sprintf("usage %q is not allowed", usage))
+			}
+		}
+	}
+
+	if p.MaxDuration != nil && req.Duration > p.MaxDuration.Duration {
+		violations = append(violations, fmt.Sprintf("requested duration %s exceeds the maximum allowed duration %s", req.Duration, p.MaxDuration.Duration))
+	}
+
+	if req.IsCA && !p.AllowCA {
+		violations = append(violations, "CA certificates are not allowed")
+	}
+
+	return violations
+}
+
+func matchAnyWildcard(patterns []string, s string) bool {
+	for _, pattern := range patterns {
+		if matchWildcard(pattern, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchWildcard reports whether s matches pattern, where '*' in pattern
+// matches any (possibly empty) sequence of characters.
+func matchWildcard(pattern, s string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(s, part)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(part):]
+	}
+
+	return strings.HasSuffix(s, parts[len(parts)-1])
+}
+
+func containsIP(cidrs []string, ip net.IP) bool {
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsUsage(usages []cmapi.KeyUsage, usage cmapi.KeyUsage) bool {
+	for _, u := range usages {
+		if u == usage {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func Test_matchWildcard(t *testing.T) {
+	tests := map[string]struct {
+		pattern, s string
+		exp        bool
+	}{
+		"exact match":                {"example.com", "example.com", true},
+		"exact mismatch":             {"example.com", "example.org", false},
+		"leading wildcard":           {"*.example.com", "foo.example.com", true},
+		"leading wildcard mismatch":  {"*.example.com", "example.com", false},
+		"trailing wildcard":          {"issuers.cert-manager.io/*", "issuers.cert-manager.io/ns.name", true},
+		"middle wildcard":            {"spiffe://*/workload", "spiffe://cluster.local/workload", true},
+		"multiple wildcards":         {"*.*.example.com", "a.b.example.com", true},
+		"wildcard does not overlap":  {"*a*a", "a", false},
+		"match everything":           {"*", "anything", true},
+		"prefix and suffix mismatch": {"foo*bar", "foobaz", false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, matchWildcard(test.pattern, test.s))
+		})
+	}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	policy := Policy{
+		Name:                  "test",
+		SignerNames:           []string{"*"},
+		AllowedDNSNames:       []string{"*.example.com"},
+		AllowedIPRanges:       []string{"10.0.0.0/8"},
+		AllowedURIs:           []string{"spiffe://cluster.local/*"},
+		AllowedEmailAddresses: []string{"*@example.com"},
+		AllowedUsages:         []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth},
+		MaxDuration:           &metav1.Duration{Duration: time.Hour},
+	}
+
+	mustParseURL := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
+
+	tests := map[string]struct {
+		req Request
+		exp []string
+	}{
+		"a request satisfying the policy should have no violations": {
+			req: Request{
+				DNSNames:       []string{"foo.example.com"},
+				IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+				URIs:           []*url.URL{mustParseURL("spiffe://cluster.local/ns/default")},
+				EmailAddresses: []string{"foo@example.com"},
+				Usages:         []cmapi.KeyUsage{cmapi.UsageServerAuth},
+				Duration:       time.Hour,
+			},
+		},
+		"every disallowed attribute should be reported": {
+			req: Request{
+				DNSNames:       []string{"foo.example.org"},
+				IPAddresses:    []net.IP{net.ParseIP("192.168.0.1")},
+				URIs:           []*url.URL{mustParseURL("spiffe://other/ns/default")},
+				EmailAddresses: []string{"foo@example.org"},
+				Usages:         []cmapi.KeyUsage{cmapi.UsageClientAuth},
+				Duration:       2 * time.Hour,
+				IsCA:           true,
+			},
+			exp: []string{
+				`DNS name "foo.example.org" is not allowed`,
+				`IP address "192.168.0.1" is not allowed`,
+				`URI "spiffe://other/ns/default" is not allowed`,
+				`email address "foo@example.org" is not allowed`,
+				`usage "client auth" is not allowed`,
+				"requested duration 2h0m0s exceeds the maximum allowed duration 1h0m0s",
+				"CA certificates are not allowed",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.exp, policy.Evaluate(test.req))
+		})
+	}
+}
+
+func TestConfigPolicyFor(t *testing.T) {
+	config := &Config{Policies: []Policy{
+		{Name: "sandbox", SignerNames: []string{"issuers.cert-manager.io/sandbox.*"}},
+		{Name: "cluster", SignerNames: []string{"clusterissuers.cert-manager.io/*"}},
+	}}
+
+	assert.Equal(t, "sandbox", config.PolicyFor("issuers.cert-manager.io/sandbox.ca").Name)
+	assert.Equal(t, "cluster", config.PolicyFor("clusterissuers.cert-manager.io/ca").Name)
+	assert.Nil(t, config.PolicyFor("issuers.cert-manager.io/default.ca"))
+
+	var nilConfig *Config
+	assert.Nil(t, nilConfig.PolicyFor("clusterissuers.cert-manager.io/ca"))
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	if err := os.WriteFile(valid, []byte(`policies:
+- name: default
+  signerNames: ["clusterissuers.cert-manager.io/*"]
+  allowedDNSNames: ["*.example.com"]
+  allowedIPRanges: ["10.0.0.0/8"]
+  maxDuration: 24h
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, &Config{Policies: []Policy{{
+		Name:            "default",
+		SignerNames:     []string{"clusterissuers.cert-manager.io/*"},
+		AllowedDNSNames: []string{"*.example.com"},
+		AllowedIPRanges: []string{"10.0.0.0/8"},
+		MaxDuration:     &metav1.Duration{Duration: 24 * time.Hour},
+	}}}, config)
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalid, []byte(`policies:
+- name: default
+  signerNames: ["*"]
+  allowedIPRanges: ["not-a-cidr"]
+`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = LoadConfig(invalid)
+	assert.Error(t, err)
+}
+
+func TestSignerNameForIssuerRef(t *testing.T) {
+	tests := map[string]struct {
+		ref cmmeta.ObjectReference
+		exp string
+	}{
+		"an Issuer reference with no kind or group should default to cert-manager.io Issuer": {
+			ref: cmmeta.ObjectReference{Name: "ca"},
+			exp: "issuers.cert-manager.io/test-ns.ca",
+		},
+		"a ClusterIssuer reference should not include the namespace": {
+			ref: cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+			exp: "clusterissuers.cert-manager.io/ca",
+		},
+		"an external issuer reference should use the lower case kind": {
+			ref: cmmeta.ObjectReference{Name: "pca", Kind: "AWSPCAIssuer", Group: "awspca.cert-manager.io"},
+			exp: "awspcaissuers.awspca.cert-manager.io/test-ns.pca",
+		},
+		"an external issuer reference with a kind ending in ClusterIssuer should not include the namespace": {
+			ref: cmmeta.ObjectReference{Name: "pca", Kind: "AWSPCAClusterIssuer", Group: "awspca.cert-manager.io"},
+			exp: "awspcaclusterissuers.awspca.cert-manager.io/pca",
+		},
+		"an external cluster scoped issuer whose kind doesn't end in ClusterIssuer is treated as namespaced": {
+			ref: cmmeta.ObjectReference{Name: "origin", Kind: "GlobalOriginIssuer", Group: "cert-manager.k8s.cloudflare.com"},
+			exp: "globaloriginissuers.cert-manager.k8s.cloudflare.com/test-ns.origin",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cr := &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns"},
+				Spec:       cmapi.CertificateRequestSpec{IssuerRef: test.ref},
+			}
+			assert.Equal(t, test.exp, SignerNameForIssuerRef(cr))
+		})
+	}
+}
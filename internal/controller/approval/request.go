@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approval
+
+import (
+	"fmt"
+	"strings"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// SignerNameForIssuerRef returns the Kubernetes signer name that corresponds
+// to the issuer referenced by a CertificateRequest, so that the same policies
+// can be written for both CertificateRequests and CertificateSigningRequests.
+// For example "issuers.cert-manager.io/<namespace>.<name>" or
+// "clusterissuers.cert-manager.io/<name>".
+//
+// The scope of the issuer is taken from its kind, since the referenced
+// resource isn't looked up: kinds ending in "ClusterIssuer", such as
+// "AWSPCAClusterIssuer", are treated as cluster scoped and all other kinds as
+// namespaced. Policies for external cluster scoped issuers whose kind doesn't
+// follow this convention must use the namespaced signer name.
+func SignerNameForIssuerRef(cr *cmapi.CertificateRequest) string {
+	group := cr.Spec.IssuerRef.Group
+	if len(group) == 0 {
+		group = certmanager.GroupName
+	}
+
+	kind := cr.Spec.IssuerRef.Kind
+	if len(kind) == 0 {
+		kind = cmapi.IssuerKind
+	}
+
+	resource := strings.ToLower(kind) + "s"
+	if strings.HasSuffix(kind, cmapi.ClusterIssuerKind) {
+		return fmt.Sprintf("%s.%s/%s", resource, group, cr.Spec.IssuerRef.Name)
+	}
+
+	return fmt.Sprintf("%s.%s/%s.%s", resource, group, cr.Namespace, cr.Spec.IssuerRef.Name)
+}
+
+// RequestFromCertificateRequest builds a Request from the given
+// CertificateRequest.
+func RequestFromCertificateRequest(cr *cmapi.CertificateRequest) (Request, error) {
+	csr, err := pki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+	if err != nil {
+		return Request{}, err
+	}
+
+	usages := cr.Spec.Usages
+	if len(usages) == 0 {
+		usages = cmapi.DefaultKeyUsages()
+	}
+
+	duration := cmapi.DefaultCertificateDuration
+	if cr.Spec.Duration != nil {
+		duration = cr.Spec.Duration.Duration
+	}
+
+	return Request{
+		SignerName:     SignerNameForIssuerRef(cr),
+		DNSNames:       csr.DNSNames,
+		IPAddresses:    csr.IPAddresses,
+		URIs:           csr.URIs,
+		EmailAddresses: csr.EmailAddresses,
+		Usages:         usages,
+		Duration:       duration,
+		IsCA:           cr.Spec.IsCA,
+	}, nil
+}
+
+// RequestFromCertificateSigningRequest builds a Request from the given
+// Kubernetes CertificateSigningRequest, taking into account the cert-manager
+// request annotations.
+func RequestFromCertificateSigningRequest(csr *certificatesv1.CertificateSigningRequest) (Request, error) {
+	x509CSR, err := pki.DecodeX509CertificateRequestBytes(csr.Spec.Request)
+	if err != nil {
+		return Request{}, err
+	}
+
+	duration, err := pki.DurationFromCertificateSigningRequest(csr)
+	if err != nil {
+		return Request{}, err
+	}
+
+	usages := make([]cmapi.KeyUsage, len(csr.Spec.Usages))
+	for i, usage := range csr.Spec.Usages {
+		usages[i] = cmapi.KeyUsage(usage)
+	}
+
+	return Request{
+		SignerName:     csr.Spec.SignerName,
+		DNSNames:       x509CSR.DNSNames,
+		IPAddresses:    x509CSR.IPAddresses,
+		URIs:           x509CSR.URIs,
+		EmailAddresses: x509CSR.EmailAddresses,
+		Usages:         usages,
+		Duration:       duration,
+		IsCA:           pki.IsCAFromCertificateSigningRequest(csr),
+	}, nil
+}
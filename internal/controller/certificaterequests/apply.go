@@ -45,6 +45,24 @@ func Apply(ctx context.Context, cl cmclient.Interface, fieldManager string, req
 		metav1.PatchOptions{Force: pointer.Bool(true), FieldManager: fieldManager})
 }
 
+// ApplyMetadata will make an Apply API call with the given client to the
+// CertificateRequest's resource endpoint. Only the name, namespace, labels,
+// annotations and finalizers of the given CertificateRequest are applied, so
+// that the given fieldManager does not take ownership of any spec fields.
+// Always sets Force Apply to true.
+func ApplyMetadata(ctx context.Context, cl cmclient.Interface, fieldManager string, req *cmapi.CertificateRequest) error {
+	reqData, err := serializeApplyMetadata(req)
+	if err != nil {
+		return err
+	}
+
+	_, err = cl.CertmanagerV1().CertificateRequests(req.Namespace).Patch(
+		ctx, req.Name, apitypes.ApplyPatchType, reqData,
+		metav1.PatchOptions{Force: pointer.Bool(true), FieldManager: fieldManager})
+
+	return err
+}
+
 // ApplyStatus will make an Apply API call with the given client to the
 // CertificateRequests's status sub-resource endpoint. All data in the given
 // CertificateRequest object is dropped; expect for the name, namespace, and
@@ -87,6 +105,34 @@ func serializeApply(req *cmapi.CertificateRequest) ([]byte, error) {
 	return reqData, nil
 }
 
+// serializeApplyMetadata converts the given CertificateRequest object to
+// JSON. Only the name, namespace, labels, annotations and finalizers will be
+// copied and encoded into the serialized slice. The spec and status fields are
+// omitted entirely, since their required fields would otherwise be serialized
+// with their zero value.
+// TypeMeta will be populated with the Kind "CertificateRequest" and API
+// Version "cert-manager.io/v1" respectively.
+func serializeApplyMetadata(req *cmapi.CertificateRequest) ([]byte, error) {
+	obj := struct {
+		metav1.TypeMeta `json:",inline"`
+		ObjectMeta      metav1.ObjectMeta `json:"metadata"`
+	}{
+		TypeMeta: metav1.TypeMeta{Kind: cmapi.CertificateRequestKind, APIVersion: cmapi.SchemeGroupVersion.Identifier()},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   req.Namespace,
+			Name:        req.Name,
+			Labels:      req.Labels,
+			Annotations: req.Annotations,
+			Finalizers:  req.Finalizers,
+		},
+	}
+	reqData, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal certificaterequest object: %w", err)
+	}
+	return reqData, nil
+}
+
 // serializeApplyStatus converts the given CertificateRequest object to JSON.
 // Only the name, namespace, and status field values will be copied and encoded
 // into the serialized slice. All other fields will be left at their zero
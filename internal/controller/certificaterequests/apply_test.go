@@ -24,6 +24,7 @@ import (
 
 	fuzz "github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
@@ -137,3 +138,22 @@ func Test_serializeApplyStatus(t *testing.T) {
 	close(jobs)
 	wg.Wait()
 }
+
+// This test ensures that when a CertificateRequest object is serialized in
+// preparation for a metadata Apply call, the spec and status are omitted
+// entirely so that no spec fields are claimed by the field manager.
+func Test_serializeApplyMetadata(t *testing.T) {
+	const expJSON = `{"kind":"CertificateRequest","apiVersion":"cert-manager.io/v1","metadata":{"name":"foo","namespace":"bar","creationTimestamp":null,"annotations":{"example.com/annotation":"true"}}}`
+
+	req := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo", Namespace: "bar", ResourceVersion: "123",
+			Annotations: map[string]string{"example.com/annotation": "true"},
+		},
+		Spec: cmapi.CertificateRequestSpec{Request: []byte("csr")},
+	}
+
+	reqData, err := serializeApplyMetadata(req)
+	assert.NoError(t, err)
+	assert.Equal(t, expJSON, string(reqData))
+}
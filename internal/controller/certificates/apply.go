@@ -34,13 +34,33 @@ import (
 // dropped.
 // The given fieldManager is will be used as the FieldManager in the Apply
 // call.
-// Always sets Force Apply to true.
-func Apply(ctx context.Context, cl cmclient.Interface, fieldManager string, crt *cmapi.Certificate) error {
+// force sets whether ownership of fields which conflict with other field
+// managers is taken.
+func Apply(ctx context.Context, cl cmclient.Interface, fieldManager string, force bool, crt *cmapi.Certificate) error {
 	crtData, err := serializeApply(crt)
 	if err != nil {
 		return err
 	}
 
+	_, err = cl.CertmanagerV1().Certificates(crt.Namespace).Patch(
+		ctx, crt.Name, apitypes.ApplyPatchType, crtData,
+		metav1.PatchOptions{Force: pointer.Bool(force), FieldManager: fieldManager},
+	)
+
+	return err
+}
+
+// ApplyMetadata will make a Apply API call with the given client to the
+// certificates resource endpoint. Only the name, namespace, labels,
+// annotations and finalizers of the given Certificate are applied, so that
+// the given fieldManager does not take ownership of any spec fields.
+// Always sets Force Apply to true.
+func ApplyMetadata(ctx context.Context, cl cmclient.Interface, fieldManager string, crt *cmapi.Certificate) error {
+	crtData, err := serializeApplyMetadata(crt)
+	if err != nil {
+		return err
+	}
+
 	_, err = cl.CertmanagerV1().Certificates(crt.Namespace).Patch(
 		ctx, crt.Name, apitypes.ApplyPatchType, crtData,
 		metav1.PatchOptions{Force: pointer.Bool(true), FieldManager: fieldManager},
@@ -86,6 +106,34 @@ func serializeApply(crt *cmapi.Certificate) ([]byte, error) {
 	return crtData, nil
 }
 
+// serializeApplyMetadata converts the given Certificate object in JSON. Only
+// the name, namespace, labels, annotations and finalizers will be copied and
+// encoded into the serialized slice. The spec and status fields are omitted
+// entirely, since their required fields would otherwise be serialized with
+// their zero value.
+// TypeMeta will be populated with the Kind "Certificate" and API Version
+// "cert-manager.io/v1" respectively.
+func serializeApplyMetadata(crt *cmapi.Certificate) ([]byte, error) {
+	obj := struct {
+		metav1.TypeMeta `json:",inline"`
+		ObjectMeta      metav1.ObjectMeta `json:"metadata"`
+	}{
+		TypeMeta: metav1.TypeMeta{Kind: cmapi.CertificateKind, APIVersion: cmapi.SchemeGroupVersion.Identifier()},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   crt.Namespace,
+			Name:        crt.Name,
+			Labels:      crt.Labels,
+			Annotations: crt.Annotations,
+			Finalizers:  crt.Finalizers,
+		},
+	}
+	crtData, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal certificate object: %w", err)
+	}
+	return crtData, nil
+}
+
 // serializeApplyStatus converts the given Certificate object in JSON. Only the
 // name, namespace, and status field values will be copied and encoded into the
 // serialized slice. All other fields will be left at their zero value.
@@ -24,6 +24,7 @@ import (
 
 	fuzz "github.com/google/gofuzz"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 )
@@ -124,3 +125,22 @@ func Test_serializeApplyStatus(t *testing.T) {
 	close(jobs)
 	wg.Wait()
 }
+
+// This test ensures that when a Certificate object is serialized in
+// preparation for a Certificate metadata Apply call, the spec and status are
+// omitted entirely so that no spec fields are claimed by the field manager.
+func Test_serializeApplyMetadata(t *testing.T) {
+	const expJSON = `{"kind":"Certificate","apiVersion":"cert-manager.io/v1","metadata":{"name":"foo","namespace":"bar","creationTimestamp":null,"finalizers":["example.com/finalizer"]}}`
+
+	crt := &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo", Namespace: "bar", ResourceVersion: "123",
+			Finalizers: []string{"example.com/finalizer"},
+		},
+		Spec: cmapi.CertificateSpec{SecretName: "foo", CommonName: "example.com"},
+	}
+
+	crtData, err := serializeApplyMetadata(crt)
+	assert.NoError(t, err)
+	assert.Equal(t, expJSON, string(crtData))
+}
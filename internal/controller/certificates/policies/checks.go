@@ -34,7 +34,10 @@ import (
 	"sigs.k8s.io/structured-merge-diff/v4/value"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -83,7 +86,7 @@ func SecretPrivateKeyMatchesSpec(input Input) (string, string, bool) {
 		return SecretMismatch, fmt.Sprintf("Existing issued Secret contains invalid private key data: %v", err), true
 	}
 
-	violations, err := pki.PrivateKeyMatchesSpec(pk, input.Certificate.Spec)
+	violations, err := pki.PrivateKeyMatchesSpec(pk, pki.CertificateSpecWithPrivateKeyFallback(input.Certificate))
 	if err != nil {
 		return SecretMismatch, fmt.Sprintf("Failed to check private key is up to date: %v", err), true
 	}
@@ -389,6 +392,9 @@ func SecretTemplateMismatchesSecretManagedFields(fieldManager string) Func {
 		for k := range baseAnnotations {
 			managedAnnotations = managedAnnotations.Delete(k)
 		}
+		// The issuance receipt is not part of the base annotations as it
+		// depends on the CertificateRequest of the revision.
+		managedAnnotations.Delete(cmapi.IssuanceReceiptAnnotationKey)
 
 		// Remove the base label from the managed Labels so we can
 		// compare 1 to 1 against the SecretTemplate
@@ -631,3 +637,47 @@ func SecretOwnerReferenceValueMismatch(ownerRefEnabled bool) Func {
 		return "", "", false
 	}
 }
+
+// remoteSecretDataKeys are the Secret data keys which are written by
+// cert-manager, and so must be identical in the remote Secret.
+var remoteSecretDataKeys = []string{
+	corev1.TLSCertKey,
+	corev1.TLSPrivateKeyKey,
+	cmmeta.TLSCAKey,
+	cmapi.PKCS12SecretKey,
+	cmapi.PKCS12TruststoreKey,
+	cmapi.JKSSecretKey,
+	cmapi.JKSTruststoreKey,
+	cmapi.CertificateOutputFormatDERKey,
+	cmapi.CertificateOutputFormatCombinedPEMKey,
+}
+
+// SecretRemoteTargetMismatch validates that the Secret in the remote cluster
+// of the Certificate's remote target matches the Certificate's Secret.
+// Returns true (violation) if the Certificate has a remote target and any of
+// the following:
+//   - the remote Secret does not exist
+//   - the remote Secret is missing the base label
+//   - a data key written by cert-manager differs from the Certificate's Secret
+func SecretRemoteTargetMismatch(input Input) (string, string, bool) {
+	if input.Certificate.Spec.RemoteTarget == nil ||
+		!utilfeature.DefaultFeatureGate.Enabled(feature.RemoteSecretTargets) {
+		return "", "", false
+	}
+
+	if input.RemoteSecret == nil {
+		return RemoteSecretMismatch, "Remote Secret does not exist", true
+	}
+
+	if input.RemoteSecret.Labels[cmapi.PartOfCertManagerControllerLabelKey] != "true" {
+		return RemoteSecretMismatch, fmt.Sprintf("Remote Secret is missing base label %s", cmapi.PartOfCertManagerControllerLabelKey), true
+	}
+
+	for _, key := range remoteSecretDataKeys {
+		if !bytes.Equal(input.Secret.Data[key], input.RemoteSecret.Data[key]) {
+			return RemoteSecretMismatch, fmt.Sprintf("Remote Secret data for key %q does not match the Certificate's Secret", key), true
+		}
+	}
+
+	return "", "", false
+}
@@ -24,11 +24,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 	"k8s.io/utils/pointer"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/stretchr/testify/assert"
@@ -709,6 +712,21 @@ func Test_SecretTemplateMismatchesSecretManagedFields(t *testing.T) {
 			expMessage:          "",
 			expViolation:        false,
 		},
+		"if template is nil and the only managed annotation is the issuance receipt, should return false": {
+			tmpl: nil,
+			secretManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager: fieldManager, FieldsV1: &metav1.FieldsV1{
+					Raw: []byte(`{"f:metadata": {
+							"f:annotations": {
+								"f:cert-manager.io/issuance-receipt": {}
+							}
+						}}`),
+				}},
+			},
+			expReason:    "",
+			expMessage:   "",
+			expViolation: false,
+		},
 		"if template is not-nil but managed fields is nil, should return true": {
 			tmpl: &cmapi.CertificateSecretTemplate{
 				Annotations: map[string]string{"foo": "bar"},
@@ -2164,3 +2182,88 @@ func Test_SecretOwnerReferenceValueMismatch(t *testing.T) {
 		})
 	}
 }
+
+func Test_SecretRemoteTargetMismatch(t *testing.T) {
+	crt := &cmapi.Certificate{Spec: cmapi.CertificateSpec{
+		SecretName: "test-secret",
+		RemoteTarget: &cmapi.CertificateRemoteTarget{
+			KubeconfigSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "edge-1"}, Key: "kubeconfig"},
+			Namespace:           "edge",
+		},
+	}}
+	baseLabels := map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Labels: baseLabels},
+		Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key"), "ca.crt": []byte("ca")},
+	}
+
+	tests := map[string]struct {
+		featureEnabled bool
+		input          Input
+		expReason      string
+		expMessage     string
+		expViolation   bool
+	}{
+		"if the Certificate has no remote target, should return false": {
+			featureEnabled: true,
+			input:          Input{Certificate: &cmapi.Certificate{}, Secret: secret},
+		},
+		"if the feature is disabled, should return false": {
+			featureEnabled: false,
+			input:          Input{Certificate: crt, Secret: secret},
+		},
+		"if the remote Secret does not exist, should return true": {
+			featureEnabled: true,
+			input:          Input{Certificate: crt, Secret: secret},
+			expReason:      RemoteSecretMismatch,
+			expMessage:     "Remote Secret does not exist",
+			expViolation:   true,
+		},
+		"if the remote Secret is missing the base label, should return true": {
+			featureEnabled: true,
+			input: Input{Certificate: crt, Secret: secret, RemoteSecret: &corev1.Secret{
+				Data: secret.Data,
+			}},
+			expReason:    RemoteSecretMismatch,
+			expMessage:   "Remote Secret is missing base label controller.cert-manager.io/fao",
+			expViolation: true,
+		},
+		"if the remote Secret has different data, should return true": {
+			featureEnabled: true,
+			input: Input{Certificate: crt, Secret: secret, RemoteSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Labels: baseLabels},
+				Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("other"), "ca.crt": []byte("ca")},
+			}},
+			expReason:    RemoteSecretMismatch,
+			expMessage:   `Remote Secret data for key "tls.key" does not match the Certificate's Secret`,
+			expViolation: true,
+		},
+		"if the remote Secret has a key which the Certificate's Secret does not, should return true": {
+			featureEnabled: true,
+			input: Input{Certificate: crt, Secret: secret, RemoteSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Labels: baseLabels},
+				Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key"), "ca.crt": []byte("ca"), "key.der": []byte("der")},
+			}},
+			expReason:    RemoteSecretMismatch,
+			expMessage:   `Remote Secret data for key "key.der" does not match the Certificate's Secret`,
+			expViolation: true,
+		},
+		"if the remote Secret matches and has extra unmanaged keys, should return false": {
+			featureEnabled: true,
+			input: Input{Certificate: crt, Secret: secret, RemoteSecret: &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Labels: baseLabels},
+				Data:       map[string][]byte{"tls.crt": []byte("cert"), "tls.key": []byte("key"), "ca.crt": []byte("ca"), "extra": []byte("extra")},
+			}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.RemoteSecretTargets, test.featureEnabled)()
+			gotReason, gotMessage, gotViolation := SecretRemoteTargetMismatch(test.input)
+			assert.Equal(t, test.expReason, gotReason)
+			assert.Equal(t, test.expMessage, gotMessage)
+			assert.Equal(t, test.expViolation, gotViolation)
+		})
+	}
+}
@@ -64,4 +64,8 @@ const (
 	// a missing owner reference to the Certificate, or has an owner reference it
 	// shouldn't have.
 	SecretOwnerRefMismatch string = "SecretOwnerRefMismatch"
+	// RemoteSecretMismatch is a policy violation whereby the Secret in the
+	// remote cluster of the Certificate's remote target is missing, or its
+	// data doesn't match the Certificate's Secret.
+	RemoteSecretMismatch string = "RemoteSecretMismatch"
 )
@@ -40,6 +40,11 @@ type Input struct {
 	// Take a look at the gatherer package's documentation to see more about why
 	// we care about the "next" certificate request.
 	NextRevisionRequest *cmapi.CertificateRequest
+
+	// RemoteSecret is the Secret in the remote cluster of the Certificate's
+	// remote target. It is only set for the post issuance policy checks, and
+	// is nil if the remote Secret does not exist.
+	RemoteSecret *corev1.Secret
 }
 
 // A Func evaluates the given input data and decides whether a check has passed
@@ -102,6 +107,7 @@ func NewSecretPostIssuancePolicyChain(ownerRefEnabled bool, fieldManager string)
 		SecretOwnerReferenceValueMismatch(ownerRefEnabled),
 		SecretKeystoreFormatMatchesSpec,
 		SecretBaseLabelsAreMissing,
+		SecretRemoteTargetMismatch,
 	}
 }
 
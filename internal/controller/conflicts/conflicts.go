@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conflicts contains helpers to surface write conflicts between
+// cert-manager controllers and other field managers, such as GitOps tools, as
+// Kubernetes Events.
+package conflicts
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// ReasonFieldManagerConflict is the reason of the Event recorded when a
+	// write of a controller conflicts with another field manager.
+	ReasonFieldManagerConflict = "FieldManagerConflict"
+
+	// conflictMessagePrefix is the prefix of the message of every
+	// FieldManagerConflict status cause returned by the API server.
+	conflictMessagePrefix = "conflict with "
+)
+
+// CompetingManagers returns the sorted, de-duplicated names of the field
+// managers which own fields that caused the given Server-Side Apply conflict
+// error. Returns nil if the error is not an Apply conflict.
+func CompetingManagers(err error) []string {
+	if !apierrors.IsConflict(err) {
+		return nil
+	}
+
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var managers []string
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict || !strings.HasPrefix(cause.Message, conflictMessagePrefix) {
+			continue
+		}
+
+		// The manager name is always quoted, and may be followed by the
+		// subresource and operation of the managed fields entry.
+		quoted, err := strconv.QuotedPrefix(strings.TrimPrefix(cause.Message, conflictMessagePrefix))
+		if err != nil {
+			continue
+		}
+		manager, err := strconv.Unquote(quoted)
+		if err != nil || seen[manager] {
+			continue
+		}
+
+		seen[manager] = true
+		managers = append(managers, manager)
+	}
+
+	sort.Strings(managers)
+	return managers
+}
+
+// LatestCompetingManager returns the name of the field manager, other than
+// the given fieldManager, which most recently wrote to the given object.
+// Returns an empty string if no other manager owns fields of the object.
+func LatestCompetingManager(obj metav1.Object, fieldManager string) string {
+	var (
+		latest     string
+		latestTime *metav1.Time
+	)
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Manager == fieldManager {
+			continue
+		}
+		if len(latest) == 0 || (entry.Time != nil && (latestTime == nil || latestTime.Before(entry.Time))) {
+			latest, latestTime = entry.Manager, entry.Time
+		}
+	}
+	return latest
+}
+
+// RecordEvent records a Warning Event on the given object if err is a
+// conflict, naming the field managers which compete with the given
+// fieldManager for the object. For Apply conflicts these are the managers
+// returned by the API server. For Update conflicts, which are caused by a
+// stale resource version, this is the latest other manager recorded in the
+// managed fields of the given object.
+// Returns true if an Event was recorded.
+func RecordEvent(rec record.EventRecorder, obj runtime.Object, fieldManager string, err error) bool {
+	if !apierrors.IsConflict(err) {
+		return false
+	}
+
+	managers := CompetingManagers(err)
+	if len(managers) == 0 {
+		if accessor, aerr := meta.Accessor(obj); aerr == nil {
+			if manager := LatestCompetingManager(accessor, fieldManager); len(manager) > 0 {
+				managers = []string{manager}
+			}
+		}
+	}
+
+	if len(managers) == 0 {
+		rec.Eventf(obj, corev1.EventTypeWarning, ReasonFieldManagerConflict,
+			"Write by field manager %q conflicted with a concurrent change: %v", fieldManager, err)
+		return true
+	}
+
+	quoted := make([]string, len(managers))
+	for i, manager := range managers {
+		quoted[i] = strconv.Quote(manager)
+	}
+	rec.Eventf(obj, corev1.EventTypeWarning, ReasonFieldManagerConflict,
+		"Write by field manager %q conflicted with field manager(s) %s: %v", fieldManager, strings.Join(quoted, ", "), err)
+
+	return true
+}
+
+// Apply calls the given apply function without forcing ownership of
+// conflicting fields. If the API server reports an Apply conflict, an Event
+// naming the competing field managers is recorded on obj and the apply
+// function is called again with force, so that the controller still takes
+// ownership of the fields it manages.
+func Apply(rec record.EventRecorder, obj runtime.Object, fieldManager string, apply func(force bool) error) error {
+	err := apply(false)
+	if err == nil || len(CompetingManagers(err)) == 0 {
+		return err
+	}
+
+	RecordEvent(rec, obj, fieldManager, err)
+
+	return apply(true)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conflicts
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+)
+
+func applyConflict(messages ...string) error {
+	var causes []metav1.StatusCause
+	for _, message := range messages {
+		causes = append(causes, metav1.StatusCause{Type: metav1.CauseTypeFieldManagerConflict, Message: message, Field: ".spec.dnsNames"})
+	}
+	return apierrors.NewApplyConflict(causes, "Apply failed")
+}
+
+func TestCompetingManagers(t *testing.T) {
+	tests := map[string]struct {
+		err         error
+		expManagers []string
+	}{
+		"nil error should return no managers": {
+			err:         nil,
+			expManagers: nil,
+		},
+		"non conflict error should return no managers": {
+			err:         errors.New("some error"),
+			expManagers: nil,
+		},
+		"update conflict should return no managers": {
+			err:         apierrors.NewConflict(schema.GroupResource{Resource: "certificates"}, "test", errors.New("object has been modified")),
+			expManagers: nil,
+		},
+		"apply conflict should return the sorted and de-duplicated managers": {
+			err: applyConflict(
+				`conflict with "kubectl-client-side-apply" using cert-manager.io/v1 at 2023-01-01T00:00:00Z`,
+				`conflict with "argocd-controller"`,
+				`conflict with "argocd-controller" with subresource "status"`,
+			),
+			expManagers: []string{"argocd-controller", "kubectl-client-side-apply"},
+		},
+		"apply conflict with unexpected messages should ignore them": {
+			err:         applyConflict(`something unexpected`, `conflict with unquoted`),
+			expManagers: nil,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expManagers, CompetingManagers(test.err))
+		})
+	}
+}
+
+func TestLatestCompetingManager(t *testing.T) {
+	now := time.Now()
+	entry := func(manager string, ago time.Duration) metav1.ManagedFieldsEntry {
+		return metav1.ManagedFieldsEntry{Manager: manager, Time: &metav1.Time{Time: now.Add(-ago)}}
+	}
+
+	obj := &metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{
+		entry("flux", time.Hour),
+		entry("cert-manager-test", time.Minute),
+		entry("argocd-controller", time.Minute*10),
+	}}
+	assert.Equal(t, "argocd-controller", LatestCompetingManager(obj, "cert-manager-test"))
+
+	obj = &metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{entry("cert-manager-test", time.Minute)}}
+	assert.Equal(t, "", LatestCompetingManager(obj, "cert-manager-test"))
+}
+
+func TestApply(t *testing.T) {
+	crt := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"}}
+
+	t.Run("if apply succeeds, should not force or record an event", func(t *testing.T) {
+		recorder := new(testpkg.FakeRecorder)
+		var forces []bool
+		err := Apply(recorder, crt, "cert-manager-test", func(force bool) error {
+			forces = append(forces, force)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{false}, forces)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("if apply conflicts, should record an event naming the managers and force", func(t *testing.T) {
+		recorder := new(testpkg.FakeRecorder)
+		var forces []bool
+		err := Apply(recorder, crt, "cert-manager-test", func(force bool) error {
+			forces = append(forces, force)
+			if !force {
+				return applyConflict(`conflict with "argocd-controller"`)
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []bool{false, true}, forces)
+		assert.Equal(t, []string{
+			`Warning FieldManagerConflict Write by field manager "cert-manager-test" conflicted with field manager(s) "argocd-controller": Apply failed`,
+		}, recorder.Events)
+	})
+
+	t.Run("if apply fails with another error, should return it without forcing", func(t *testing.T) {
+		recorder := new(testpkg.FakeRecorder)
+		var forces []bool
+		err := Apply(recorder, crt, "cert-manager-test", func(force bool) error {
+			forces = append(forces, force)
+			return errors.New("some error")
+		})
+		assert.Error(t, err)
+		assert.Equal(t, []bool{false}, forces)
+		assert.Empty(t, recorder.Events)
+	})
+}
+
+func TestRecordEvent(t *testing.T) {
+	crt := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{
+		Name: "test", Namespace: "test",
+		ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "argocd-controller"}},
+	}}
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "certificates"}, "test", errors.New("object has been modified"))
+
+	recorder := new(testpkg.FakeRecorder)
+	assert.False(t, RecordEvent(recorder, crt, "cert-manager-test", nil))
+	assert.False(t, RecordEvent(recorder, crt, "cert-manager-test", errors.New("some error")))
+	assert.True(t, RecordEvent(recorder, crt, "cert-manager-test", conflict))
+	assert.Equal(t, []string{
+		`Warning FieldManagerConflict Write by field manager "cert-manager-test" conflicted with field manager(s) "argocd-controller": ` + conflict.Error(),
+	}, recorder.Events)
+}
@@ -70,6 +70,18 @@ const (
 	// This feature will add BasicConstraints section with CA field defaulting to false; CA field will be set true if the Certificate resource spec has isCA as true
 	// Github Issue: https://github.com/cert-manager/cert-manager/issues/5539
 	UseCertificateRequestBasicConstraints featuregate.Feature = "UseCertificateRequestBasicConstraints"
+
+	// Alpha: v1.12
+	// SSHCertificates enables the sshcertificates controller, which signs OpenSSH certificates
+	// for SSHCertificate resources using the CA key of CA issuers.
+	// This feature gate must be used together with SSHCertificates webhook feature gate.
+	SSHCertificates featuregate.Feature = "SSHCertificates"
+
+	// Alpha: v1.12
+	// RemoteSecretTargets enables writing the Secret of a Certificate which sets
+	// spec.remoteTarget into a remote cluster, using the referenced kubeconfig Secret.
+	// This feature gate must be used together with RemoteSecretTargets webhook feature gate.
+	RemoteSecretTargets featuregate.Feature = "RemoteSecretTargets"
 )
 
 func init() {
@@ -88,4 +100,6 @@ var defaultCertManagerFeatureGates = map[featuregate.Feature]featuregate.Feature
 	LiteralCertificateSubject:                        {Default: false, PreRelease: featuregate.Alpha},
 	StableCertificateRequestName:                     {Default: false, PreRelease: featuregate.Alpha},
 	UseCertificateRequestBasicConstraints:            {Default: false, PreRelease: featuregate.Alpha},
+	SSHCertificates:                                  {Default: false, PreRelease: featuregate.Alpha},
+	RemoteSecretTargets:                              {Default: false, PreRelease: featuregate.Alpha},
 }
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentials loads the credentials of issuers and DNS providers from
+// Secret resources. Secret values may be envelope encrypted at the
+// application level, using a key held by a KMS v2 plugin, in which case they
+// are transparently decrypted when loaded.
+package credentials
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apiserver/pkg/storage/value/encrypt/envelope/kmsv2"
+	"k8s.io/utils/lru"
+)
+
+const (
+	// kmsCallTimeout is the timeout of the calls made to KMS plugins.
+	kmsCallTimeout = 10 * time.Second
+
+	// dekCacheSize is the number of decrypted data encryption keys kept in
+	// memory.
+	dekCacheSize = 1000
+)
+
+var (
+	lock       sync.RWMutex
+	keyService kmsv2.Service
+	deks       = &dekCache{cache: lru.New(dekCacheSize)}
+)
+
+// SetKeyService sets the KMS service used to decrypt envelope encrypted
+// Secret values. It is called once when the controller starts. If no service
+// is set, loading an encrypted value fails.
+func SetKeyService(svc kmsv2.Service) {
+	lock.Lock()
+	defer lock.Unlock()
+	keyService = svc
+	deks.cache.Clear()
+}
+
+// NewKeyService returns the KMS service for the given options: either a KMS
+// v2 plugin listening on the given unix socket endpoint, such as
+// "unix:///var/run/kms-plugin/socket.sock", or a local AES key file. It
+// returns nil if neither is given.
+func NewKeyService(ctx context.Context, kmsPluginEndpoint, keyFile string) (kmsv2.Service, error) {
+	switch {
+	case len(kmsPluginEndpoint) > 0 && len(keyFile) > 0:
+		return nil, errors.New("only one of a KMS plugin endpoint and an encryption key file may be specified")
+	case len(kmsPluginEndpoint) > 0:
+		svc, err := kmsv2.NewGRPCService(ctx, kmsPluginEndpoint, kmsCallTimeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to KMS plugin: %w", err)
+		}
+		return svc, nil
+	case len(keyFile) > 0:
+		return NewLocalKeyService(keyFile)
+	}
+	return nil, nil
+}
+
+// Value returns the value stored in the given Secret at the given key, and
+// whether the key exists. Envelope encrypted values are decrypted using the
+// KMS service set with SetKeyService.
+//
+// All the credentials of issuers and DNS providers are loaded with Value, so
+// that any of them may be stored encrypted.
+func Value(secret *corev1.Secret, key string) ([]byte, bool, error) {
+	value, ok := secret.Data[key]
+	if !ok || !IsEncrypted(value) {
+		return value, ok, nil
+	}
+
+	lock.RLock()
+	svc := keyService
+	lock.RUnlock()
+	if svc == nil {
+		return nil, true, fmt.Errorf("value of key %q in secret '%s/%s' is envelope encrypted, but no KMS plugin or encryption key is configured", key, secret.Namespace, secret.Name)
+	}
+
+	plaintext, err := decrypt(context.Background(), svc, deks, value)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to decrypt value of key %q in secret '%s/%s': %w", key, secret.Namespace, secret.Name, err)
+	}
+
+	return plaintext, true, nil
+}
+
+// EncryptValue envelope encrypts the given value using the KMS service set
+// with SetKeyService, so that credentials generated by cert-manager are
+// stored encrypted as well. The value is returned as is if no service is set.
+func EncryptValue(ctx context.Context, value []byte) ([]byte, error) {
+	lock.RLock()
+	svc := keyService
+	lock.RUnlock()
+	if svc == nil {
+		return value, nil
+	}
+
+	return Encrypt(ctx, svc, value)
+}
+
+// dekCache caches decrypted data encryption keys, indexed by the hash of
+// their encrypted form.
+type dekCache struct {
+	cache *lru.Cache
+}
+
+func (c *dekCache) get(encryptedDEK []byte) ([]byte, bool) {
+	dek, ok := c.cache.Get(sha256.Sum256(encryptedDEK))
+	if !ok {
+		return nil, false
+	}
+	return dek.([]byte), true
+}
+
+func (c *dekCache) add(encryptedDEK, dek []byte) {
+	c.cache.Add(sha256.Sum256(encryptedDEK), dek)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/storage/value/encrypt/envelope/kmsv2"
+)
+
+func newTestKeyService(t *testing.T) kmsv2.Service {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0600))
+
+	svc, err := NewKeyService(context.Background(), "", path)
+	require.NoError(t, err)
+	require.NotNil(t, svc)
+	return svc
+}
+
+func TestValue(t *testing.T) {
+	svc := newTestKeyService(t)
+	otherSvc := newTestKeyService(t)
+
+	encrypted, err := Encrypt(context.Background(), svc, []byte("api-token"))
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.NotContains(t, string(encrypted), "api-token")
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "creds"},
+		Data: map[string][]byte{
+			"plain":     []byte("password"),
+			"encrypted": encrypted,
+		},
+	}
+
+	tests := map[string]struct {
+		keyService  kmsv2.Service
+		key         string
+		expValue    []byte
+		expOK       bool
+		expectedErr string
+	}{
+		"plain values are returned as is": {
+			key:      "plain",
+			expValue: []byte("password"),
+			expOK:    true,
+		},
+		"missing keys are reported": {
+			keyService: svc,
+			key:        "missing",
+			expOK:      false,
+		},
+		"encrypted values are decrypted": {
+			keyService: svc,
+			key:        "encrypted",
+			expValue:   []byte("api-token"),
+			expOK:      true,
+		},
+		"encrypted values fail to load without a key service": {
+			key:         "encrypted",
+			expOK:       true,
+			expectedErr: `value of key "encrypted" in secret 'ns/creds' is envelope encrypted, but no KMS plugin or encryption key is configured`,
+		},
+		"encrypted values fail to load with another key": {
+			keyService:  otherSvc,
+			key:         "encrypted",
+			expOK:       true,
+			expectedErr: `failed to decrypt value of key "encrypted" in secret 'ns/creds'`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			SetKeyService(test.keyService)
+			defer SetKeyService(nil)
+
+			value, ok, err := Value(secret, test.key)
+			assert.Equal(t, test.expOK, ok)
+			if test.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expValue, value)
+		})
+	}
+}
+
+func TestEncryptValue(t *testing.T) {
+	defer SetKeyService(nil)
+
+	SetKeyService(nil)
+	value, err := EncryptValue(context.Background(), []byte("account-key"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("account-key"), value)
+
+	SetKeyService(newTestKeyService(t))
+	value, err = EncryptValue(context.Background(), []byte("account-key"))
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(value))
+
+	decrypted, ok, err := Value(&corev1.Secret{Data: map[string][]byte{"tls.key": value}}, "tls.key")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("account-key"), decrypted)
+}
+
+func TestNewKeyService(t *testing.T) {
+	svc, err := NewKeyService(context.Background(), "", "")
+	require.NoError(t, err)
+	assert.Nil(t, svc)
+
+	_, err = NewKeyService(context.Background(), "unix:///tmp/kms.sock", "/tmp/key")
+	assert.EqualError(t, err, "only one of a KMS plugin endpoint and an encryption key file may be specified")
+
+	path := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0600))
+	_, err = NewKeyService(context.Background(), "", path)
+	assert.EqualError(t, err, `encryption key in file "`+path+`" must be 32 bytes long, got 5`)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apiserver/pkg/storage/value/encrypt/envelope/kmsv2"
+)
+
+// EnvelopePrefix is the prefix of envelope encrypted Secret values. It is
+// followed by the base64 encoding of the JSON encoded envelope.
+const EnvelopePrefix = "cert-manager.io/envelope/v1:"
+
+// envelope is an encrypted Secret value. The value is encrypted with a
+// random data encryption key (DEK) using AES-256-GCM, and the DEK is itself
+// encrypted by a KMS v2 service using its key encryption key.
+type envelope struct {
+	// KeyID is the ID of the key encryption key which encrypted the DEK.
+	KeyID string `json:"keyID"`
+	// EncryptedDEK is the DEK, as encrypted by the KMS service.
+	EncryptedDEK []byte `json:"encryptedDEK"`
+	// Annotations are the annotations returned by the KMS service when
+	// encrypting the DEK, which must be passed back to it to decrypt it.
+	Annotations map[string][]byte `json:"annotations,omitempty"`
+	// Ciphertext is the AES-GCM nonce followed by the encrypted value.
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// IsEncrypted returns true if the given Secret value is envelope encrypted.
+func IsEncrypted(value []byte) bool {
+	return bytes.HasPrefix(value, []byte(EnvelopePrefix))
+}
+
+// Encrypt envelope encrypts the given value using the given KMS service. The
+// result can be stored in a Secret instead of the plain value.
+func Encrypt(ctx context.Context, svc kmsv2.Service, plaintext []byte) ([]byte, error) {
+	dek := make([]byte, 32)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, fmt.Errorf("failed to generate data encryption key: %w", err)
+	}
+
+	ciphertext, err := aesGCMSeal(dek, plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := svc.Encrypt(ctx, string(uuid.NewUUID()), dek)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt data encryption key: %w", err)
+	}
+
+	env, err := json.Marshal(envelope{
+		KeyID:        resp.KeyID,
+		EncryptedDEK: resp.Ciphertext,
+		Annotations:  resp.Annotations,
+		Ciphertext:   ciphertext,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(EnvelopePrefix + base64.StdEncoding.EncodeToString(env)), nil
+}
+
+// decrypt decrypts the given envelope encrypted value. Decrypted DEKs are
+// cached so that the KMS service is not called each time a Secret is read.
+func decrypt(ctx context.Context, svc kmsv2.Service, dekCache *dekCache, value []byte) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(string(bytes.TrimPrefix(value, []byte(EnvelopePrefix))))
+	if err != nil {
+		return nil, fmt.Errorf("invalid envelope encoding: %w", err)
+	}
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, fmt.Errorf("invalid envelope encoding: %w", err)
+	}
+
+	dek, ok := dekCache.get(env.EncryptedDEK)
+	if !ok {
+		dek, err = svc.Decrypt(ctx, string(uuid.NewUUID()), &kmsv2.DecryptRequest{
+			Ciphertext:  env.EncryptedDEK,
+			KeyID:       env.KeyID,
+			Annotations: env.Annotations,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt data encryption key with key %q: %w", env.KeyID, err)
+		}
+		dekCache.add(env.EncryptedDEK, dek)
+	}
+
+	return aesGCMOpen(dek, env.Ciphertext)
+}
+
+// aesGCMSeal encrypts the plaintext with the given key, and returns the
+// random nonce followed by the ciphertext.
+func aesGCMSeal(key, plaintext []byte) ([]byte, error) {
+	aead, err := newAESGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// aesGCMOpen decrypts data produced by aesGCMSeal.
+func aesGCMOpen(key, data []byte) ([]byte, error) {
+	aead, err := newAESGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newAESGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ct contains a minimal client for the read API of Certificate
+// Transparency logs, as defined in RFC 6962 section 4.
+package ct
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/cryptobyte"
+)
+
+const (
+	// GetSTHPath is the path of the get-sth endpoint, relative to the log URL.
+	GetSTHPath = "ct/v1/get-sth"
+	// GetEntriesPath is the path of the get-entries endpoint, relative to the
+	// log URL.
+	GetEntriesPath = "ct/v1/get-entries"
+
+	// maxResponseSize is the maximum size of a response read from a log.
+	maxResponseSize = 16 << 20
+)
+
+// EntryType is the type of a log entry, see RFC 6962 section 3.4.
+type EntryType uint16
+
+const (
+	// X509Entry is a log entry of an issued certificate.
+	X509Entry EntryType = 0
+	// PrecertEntry is a log entry of a precertificate.
+	PrecertEntry EntryType = 1
+)
+
+// SignedTreeHead is the response of the get-sth endpoint.
+type SignedTreeHead struct {
+	TreeSize          uint64 `json:"tree_size"`
+	Timestamp         uint64 `json:"timestamp"`
+	SHA256RootHash    []byte `json:"sha256_root_hash"`
+	TreeHeadSignature []byte `json:"tree_head_signature"`
+}
+
+// LeafEntry is a single raw entry of the response of the get-entries
+// endpoint.
+type LeafEntry struct {
+	LeafInput []byte `json:"leaf_input"`
+	ExtraData []byte `json:"extra_data"`
+}
+
+type getEntriesResponse struct {
+	Entries []LeafEntry `json:"entries"`
+}
+
+// Entry is a parsed log entry.
+type Entry struct {
+	// Index is the index of the entry in the log.
+	Index uint64
+
+	// Type is the type of the entry.
+	Type EntryType
+
+	// Timestamp is the time the log accepted the entry, in milliseconds since
+	// the epoch.
+	Timestamp uint64
+
+	// Certificate is the logged certificate. For precertificates, this is
+	// parsed from the TBSCertificate, so it has no valid signature.
+	Certificate *x509.Certificate
+}
+
+// Client reads entries from a single Certificate Transparency log.
+type Client struct {
+	logURL     *url.URL
+	httpClient *http.Client
+}
+
+// NewClient returns a Client for the log with the given URL, for example
+// https://ct.googleapis.com/logs/argon2023/.
+func NewClient(logURL string, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(logURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid log URL %q: %w", logURL, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return nil, fmt.Errorf("invalid log URL %q: scheme must be https or http", logURL)
+	}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{logURL: u, httpClient: httpClient}, nil
+}
+
+// GetSTH returns the latest signed tree head of the log.
+// The signature of the tree head is not verified.
+func (c *Client) GetSTH(ctx context.Context) (*SignedTreeHead, error) {
+	var sth SignedTreeHead
+	if err := c.get(ctx, GetSTHPath, nil, &sth); err != nil {
+		return nil, err
+	}
+	return &sth, nil
+}
+
+// GetEntries returns the entries of the log with indexes from start to end,
+// inclusive. Logs may return fewer entries than requested, so the number of
+// returned entries must be checked by the caller.
+func (c *Client) GetEntries(ctx context.Context, start, end uint64) ([]Entry, error) {
+	if end < start {
+		return nil, fmt.Errorf("invalid range of entries [%d, %d]", start, end)
+	}
+
+	query := url.Values{
+		"start": []string{strconv.FormatUint(start, 10)},
+		"end":   []string{strconv.FormatUint(end, 10)},
+	}
+	var resp getEntriesResponse
+	if err := c.get(ctx, GetEntriesPath, query, &resp); err != nil {
+		return nil, err
+	}
+	if uint64(len(resp.Entries)) > end-start+1 {
+		return nil, fmt.Errorf("log returned %d entries, but only %d were requested", len(resp.Entries), end-start+1)
+	}
+
+	entries := make([]Entry, 0, len(resp.Entries))
+	for i, leaf := range resp.Entries {
+		entry, err := ParseLeafInput(leaf.LeafInput)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse entry %d: %w", start+uint64(i), err)
+		}
+		entry.Index = start + uint64(i)
+		entries = append(entries, *entry)
+	}
+
+	return entries, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	u := c.logURL.ResolveReference(&url.URL{Path: path, RawQuery: query.Encode()})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response of %s: %w", u, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d from %s: %s", resp.StatusCode, u, string(body))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", u, err)
+	}
+
+	return nil
+}
+
+// ParseLeafInput parses the TLS encoded MerkleTreeLeaf of a log entry, see
+// RFC 6962 section 3.4.
+func ParseLeafInput(leafInput []byte) (*Entry, error) {
+	var (
+		s                 = cryptobyte.String(leafInput)
+		version, leafType uint8
+		entry             Entry
+		entryType         uint16
+	)
+
+	if !s.ReadUint8(&version) || !s.ReadUint8(&leafType) {
+		return nil, errors.New("truncated leaf")
+	}
+	if version != 0 {
+		return nil, fmt.Errorf("unsupported leaf version %d", version)
+	}
+	if leafType != 0 {
+		return nil, fmt.Errorf("unsupported leaf type %d", leafType)
+	}
+
+	if !s.ReadUint64(&entry.Timestamp) || !s.ReadUint16(&entryType) {
+		return nil, errors.New("truncated leaf")
+	}
+	entry.Type = EntryType(entryType)
+
+	var err error
+	switch entry.Type {
+	case X509Entry:
+		var der cryptobyte.String
+		if !s.ReadUint24LengthPrefixed(&der) {
+			return nil, errors.New("truncated certificate")
+		}
+		entry.Certificate, err = x509.ParseCertificate(der)
+	case PrecertEntry:
+		var issuerKeyHash, tbs cryptobyte.String
+		if !s.ReadBytes((*[]byte)(&issuerKeyHash), 32) || !s.ReadUint24LengthPrefixed(&tbs) {
+			return nil, errors.New("truncated precertificate")
+		}
+		entry.Certificate, err = parseTBSCertificate(tbs)
+	default:
+		return nil, fmt.Errorf("unsupported entry type %d", entry.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var extensions cryptobyte.String
+	if !s.ReadUint16LengthPrefixed(&extensions) || !s.Empty() {
+		return nil, errors.New("invalid leaf extensions")
+	}
+
+	return &entry, nil
+}
+
+// tbsCertificate is the ASN.1 structure of a TBSCertificate, see RFC 5280
+// section 4.1.
+type tbsCertificate struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           asn1.RawValue
+	Subject            asn1.RawValue
+	PublicKey          asn1.RawValue
+	UniqueID           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueID    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"omitempty,optional,explicit,tag:3"`
+}
+
+type certificate struct {
+	TBSCertificate     asn1.RawValue
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+// parseTBSCertificate parses the TBSCertificate of a precertificate by
+// wrapping it in a certificate with an empty signature, since the x509
+// package can only parse complete certificates.
+func parseTBSCertificate(der []byte) (*x509.Certificate, error) {
+	var tbs tbsCertificate
+	rest, err := asn1.Unmarshal(der, &tbs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse precertificate: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("failed to parse precertificate: trailing data")
+	}
+
+	wrapped, err := asn1.Marshal(certificate{
+		TBSCertificate:     asn1.RawValue{FullBytes: der},
+		SignatureAlgorithm: tbs.SignatureAlgorithm,
+		SignatureValue:     asn1.BitString{Bytes: []byte{0}, BitLength: 8},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse precertificate: %w", err)
+	}
+	return cert, nil
+}
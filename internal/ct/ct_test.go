@@ -0,0 +1,144 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ct_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/cert-manager/internal/ct"
+	"github.com/cert-manager/cert-manager/internal/ct/fake"
+)
+
+func mustCreateCertificate(t *testing.T, serial int64, dnsNames ...string) (*x509.Certificate, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, key.Public(), key)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, ca
+}
+
+func TestClient(t *testing.T) {
+	cert, ca := mustCreateCertificate(t, 1234, "example.com", "www.example.com")
+	precert, _ := mustCreateCertificate(t, 5678, "shadow.example.com")
+
+	log := &fake.Log{}
+	log.AddCertificate(cert)
+	log.AddPrecertificate(precert, ca)
+	log.AddCertificate(cert)
+
+	server := httptest.NewServer(log)
+	defer server.Close()
+
+	client, err := ct.NewClient(server.URL+"/logs/test", server.Client())
+	require.NoError(t, err)
+
+	sth, err := client.GetSTH(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), sth.TreeSize)
+
+	entries, err := client.GetEntries(context.Background(), 0, 1)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, uint64(0), entries[0].Index)
+	assert.Equal(t, ct.X509Entry, entries[0].Type)
+	assert.Equal(t, cert.Raw, entries[0].Certificate.Raw)
+
+	assert.Equal(t, uint64(1), entries[1].Index)
+	assert.Equal(t, ct.PrecertEntry, entries[1].Type)
+	assert.Equal(t, precert.RawTBSCertificate, entries[1].Certificate.RawTBSCertificate)
+	assert.Equal(t, []string{"shadow.example.com"}, entries[1].Certificate.DNSNames)
+	assert.Equal(t, big.NewInt(5678), entries[1].Certificate.SerialNumber)
+	assert.Equal(t, precert.RawSubjectPublicKeyInfo, entries[1].Certificate.RawSubjectPublicKeyInfo)
+
+	log.MaxEntries = 1
+	entries, err = client.GetEntries(context.Background(), 1, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, uint64(1), entries[0].Index)
+
+	_, err = client.GetEntries(context.Background(), 5, 6)
+	assert.Error(t, err)
+
+	_, err = client.GetEntries(context.Background(), 2, 1)
+	assert.Error(t, err)
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := ct.NewClient("ftp://ct.example.com/", nil)
+	assert.Error(t, err)
+
+	_, err = ct.NewClient("https://ct.example.com/logs/2023", nil)
+	assert.NoError(t, err)
+}
+
+func TestParseLeafInput(t *testing.T) {
+	tests := map[string][]byte{
+		"empty leaf":             {},
+		"unsupported version":    {1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		"unsupported leaf type":  {0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		"unsupported entry type": {0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2},
+		"truncated certificate":  {0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 5, 1},
+		"invalid certificate":    {0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 0, 0},
+		"truncated precert":      {0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 2, 3},
+	}
+
+	for name, leaf := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ct.ParseLeafInput(leaf)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake contains an in-memory stand-in for a Certificate Transparency
+// log, which serves the get-sth and get-entries endpoints of RFC 6962.
+package fake
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+
+	"github.com/cert-manager/cert-manager/internal/ct"
+)
+
+// Log is an in-memory CT log. It implements http.Handler, so it can be served
+// with httptest.NewServer. The tree head is not signed and no Merkle tree is
+// computed, since clients of this package do not verify either.
+type Log struct {
+	// MaxEntries is the maximum number of entries returned by a single call to
+	// get-entries. Zero means no limit.
+	MaxEntries int
+
+	lock    sync.Mutex
+	entries []ct.LeafEntry
+}
+
+var _ http.Handler = &Log{}
+
+// AddCertificate appends an x509_entry for the given certificate to the log.
+func (l *Log) AddCertificate(cert *x509.Certificate) {
+	b := leafBuilder(ct.X509Entry)
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(cert.Raw)
+	})
+	l.add(b)
+}
+
+// AddPrecertificate appends a precert_entry for the given certificate to the
+// log, using its TBSCertificate as the logged precertificate.
+func (l *Log) AddPrecertificate(cert *x509.Certificate, issuer *x509.Certificate) {
+	b := leafBuilder(ct.PrecertEntry)
+	issuerKeyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+	b.AddBytes(issuerKeyHash[:])
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		b.AddBytes(cert.RawTBSCertificate)
+	})
+	l.add(b)
+}
+
+// Size returns the number of entries in the log.
+func (l *Log) Size() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return len(l.entries)
+}
+
+func leafBuilder(entryType ct.EntryType) *cryptobyte.Builder {
+	b := cryptobyte.NewBuilder(nil)
+	b.AddUint8(0) // version v1
+	b.AddUint8(0) // timestamped_entry
+	b.AddUint64(uint64(time.Now().UnixMilli()))
+	b.AddUint16(uint16(entryType))
+	return b
+}
+
+func (l *Log) add(b *cryptobyte.Builder) {
+	// no extensions
+	b.AddUint16(0)
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.entries = append(l.entries, ct.LeafEntry{LeafInput: b.BytesOrPanic()})
+}
+
+// ServeHTTP implements http.Handler.
+func (l *Log) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/"+ct.GetSTHPath):
+		writeJSON(w, ct.SignedTreeHead{
+			TreeSize:  uint64(len(l.entries)),
+			Timestamp: uint64(time.Now().UnixMilli()),
+		})
+	case strings.HasSuffix(r.URL.Path, "/"+ct.GetEntriesPath):
+		start, err := strconv.ParseUint(r.URL.Query().Get("start"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid start", http.StatusBadRequest)
+			return
+		}
+		end, err := strconv.ParseUint(r.URL.Query().Get("end"), 10, 64)
+		if err != nil || end < start || start >= uint64(len(l.entries)) {
+			http.Error(w, "invalid end", http.StatusBadRequest)
+			return
+		}
+		if end >= uint64(len(l.entries)) {
+			end = uint64(len(l.entries)) - 1
+		}
+		if l.MaxEntries > 0 && end-start+1 > uint64(l.MaxEntries) {
+			end = start + uint64(l.MaxEntries) - 1
+		}
+		writeJSON(w, struct {
+			Entries []ct.LeafEntry `json:"entries"`
+		}{Entries: l.entries[start : end+1]})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafipolicy
+
+import (
+	"context"
+	"fmt"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmapiv1internal "github.com/cert-manager/cert-manager/internal/apis/certmanager/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/policy"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
+)
+
+const PluginName = "CertificateVenafiZonePolicy"
+
+// CertificateVenafiZonePolicy is a plugin that validates Certificates which
+// reference a Venafi issuer against the zone policy cached in the status of
+// that issuer. Violations are returned as warnings rather than errors, as the
+// cached policy may be out of date; the final decision is left to Venafi when
+// the request is submitted.
+type certificateVenafiZonePolicy struct {
+	*admission.Handler
+
+	issuerLister        cmlisters.IssuerLister
+	clusterIssuerLister cmlisters.ClusterIssuerLister
+	hasSynced           []cache.InformerSynced
+}
+
+var _ admission.ValidationInterface = &certificateVenafiZonePolicy{}
+var _ initializer.WantsCertManagerInformerFactory = &certificateVenafiZonePolicy{}
+
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+func NewPlugin() admission.Interface {
+	return &certificateVenafiZonePolicy{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+func (p *certificateVenafiZonePolicy) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	if request.RequestResource.Group != "cert-manager.io" ||
+		request.RequestResource.Resource != "certificates" ||
+		request.RequestSubResource != "" {
+		return nil, nil
+	}
+
+	crt, ok := obj.(*certmanager.Certificate)
+	if !ok {
+		return nil, nil
+	}
+
+	// Only validate the spec if it has changed, to avoid repeating the same
+	// warnings for every metadata update.
+	if oldCrt, ok := oldObj.(*certmanager.Certificate); ok && apiequality.Semantic.DeepEqual(oldCrt.Spec, crt.Spec) {
+		return nil, nil
+	}
+
+	// Until the issuer caches have synced, the zone policy of the issuer
+	// cannot be known, and as the policy is advisory only the Certificate is
+	// allowed without warnings.
+	for _, hasSynced := range p.hasSynced {
+		if !hasSynced() {
+			return nil, nil
+		}
+	}
+
+	issuer, err := p.issuerFor(crt.Spec.IssuerRef.Group, crt.Spec.IssuerRef.Kind, crt.Spec.IssuerRef.Name, request.Namespace)
+	// The issuer may not exist yet, or may not be readable. The zone policy
+	// is advisory only, so these cases are not treated as errors.
+	if err != nil || issuer == nil {
+		return nil, nil
+	}
+
+	zonePolicy := policy.ForIssuer(issuer)
+	if zonePolicy == nil {
+		return nil, nil
+	}
+
+	var spec cmapi.CertificateSpec
+	if err := cmapiv1internal.Convert_certmanager_CertificateSpec_To_v1_CertificateSpec(&crt.Spec, &spec, nil); err != nil {
+		return nil, nil
+	}
+
+	var warnings []string
+	for _, err := range policy.ValidateCertificateSpec(zonePolicy, &spec, field.NewPath("spec")) {
+		warnings = append(warnings, fmt.Sprintf("Certificate will be rejected by the policy of Venafi zone %q: %s", zonePolicy.Zone, err.Error()))
+	}
+
+	return warnings, nil
+}
+
+// issuerFor returns the cert-manager issuer with the given group, kind and
+// name, or nil if the reference is not to a cert-manager issuer.
+func (p *certificateVenafiZonePolicy) issuerFor(group, kind, name, namespace string) (cmapi.GenericIssuer, error) {
+	if len(group) > 0 && group != "cert-manager.io" {
+		return nil, nil
+	}
+
+	switch kind {
+	case "", cmapi.IssuerKind:
+		return p.issuerLister.Issuers(namespace).Get(name)
+	case cmapi.ClusterIssuerKind:
+		return p.clusterIssuerLister.Get(name)
+	}
+
+	return nil, nil
+}
+
+func (p *certificateVenafiZonePolicy) SetCertManagerInformerFactory(f cminformers.SharedInformerFactory) {
+	issuers := f.Certmanager().V1().Issuers()
+	clusterIssuers := f.Certmanager().V1().ClusterIssuers()
+	p.issuerLister = issuers.Lister()
+	p.clusterIssuerLister = clusterIssuers.Lister()
+	p.hasSynced = []cache.InformerSynced{
+		issuers.Informer().HasSynced,
+		clusterIssuers.Informer().HasSynced,
+	}
+}
+
+func (p *certificateVenafiZonePolicy) ValidateInitialization() error {
+	if p.issuerLister == nil || p.clusterIssuerLister == nil {
+		return fmt.Errorf("cert-manager informer factory is not set")
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafipolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/apis/certmanager"
+	cmmeta "github.com/cert-manager/cert-manager/internal/apis/meta"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var certificateResource = metav1.GroupVersionResource{
+	Group:    "cert-manager.io",
+	Version:  "v1",
+	Resource: "certificates",
+}
+
+func TestValidate(t *testing.T) {
+	zonePolicy := &cmapi.VenafiZonePolicy{
+		Zone:     "test-zone",
+		DNSNames: []string{`^.*\.example\.com$`},
+	}
+
+	venafiIssuer := gen.Issuer("venafi",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone"}),
+		gen.SetIssuerVenafiZonePolicy(zonePolicy),
+	)
+	venafiClusterIssuer := gen.ClusterIssuer("venafi",
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone"}),
+		gen.SetIssuerVenafiZonePolicy(zonePolicy),
+	)
+	staleIssuer := gen.Issuer("stale",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "other-zone"}),
+		gen.SetIssuerVenafiZonePolicy(zonePolicy),
+	)
+
+	certificate := func(issuerRef cmmeta.ObjectReference, dnsNames ...string) *certmanager.Certificate {
+		return &certmanager.Certificate{
+			Spec: certmanager.CertificateSpec{
+				DNSNames:  dnsNames,
+				IssuerRef: issuerRef,
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		op       admissionv1.Operation
+		oldCrt   *certmanager.Certificate
+		crt      *certmanager.Certificate
+		warnings []string
+	}{
+		"should not warn about a Certificate satisfying the zone policy": {
+			op:  admissionv1.Create,
+			crt: certificate(cmmeta.ObjectReference{Name: "venafi"}, "foo.example.com"),
+		},
+		"should warn about a Certificate violating the zone policy of an Issuer": {
+			op:  admissionv1.Create,
+			crt: certificate(cmmeta.ObjectReference{Name: "venafi"}, "foo.example.org"),
+			warnings: []string{
+				`Certificate will be rejected by the policy of Venafi zone "test-zone": spec.dnsNames[0]: Invalid value: "foo.example.org": does not match any of the regular expressions allowed by the Venafi zone policy: ^.*\.example\.com$`,
+			},
+		},
+		"should warn about a Certificate violating the zone policy of a ClusterIssuer": {
+			op:  admissionv1.Create,
+			crt: certificate(cmmeta.ObjectReference{Name: "venafi", Kind: "ClusterIssuer", Group: "cert-manager.io"}, "foo.example.org"),
+			warnings: []string{
+				`Certificate will be rejected by the policy of Venafi zone "test-zone": spec.dnsNames[0]: Invalid value: "foo.example.org": does not match any of the regular expressions allowed by the Venafi zone policy: ^.*\.example\.com$`,
+			},
+		},
+		"should warn about an updated Certificate violating the zone policy": {
+			op:     admissionv1.Update,
+			oldCrt: certificate(cmmeta.ObjectReference{Name: "venafi"}, "foo.example.com"),
+			crt:    certificate(cmmeta.ObjectReference{Name: "venafi"}, "foo.example.org"),
+			warnings: []string{
+				`Certificate will be rejected by the policy of Venafi zone "test-zone": spec.dnsNames[0]: Invalid value: "foo.example.org": does not match any of the regular expressions allowed by the Venafi zone policy: ^.*\.example\.com$`,
+			},
+		},
+		"should not warn about an update which does not change the spec": {
+			op:     admissionv1.Update,
+			oldCrt: certificate(cmmeta.ObjectReference{Name: "venafi"}, "foo.example.org"),
+			crt:    certificate(cmmeta.ObjectReference{Name: "venafi"}, "foo.example.org"),
+		},
+		"should not warn if the cached zone policy is for a different zone": {
+			op:  admissionv1.Create,
+			crt: certificate(cmmeta.ObjectReference{Name: "stale"}, "foo.example.org"),
+		},
+		"should not warn if the issuer does not exist": {
+			op:  admissionv1.Create,
+			crt: certificate(cmmeta.ObjectReference{Name: "missing"}, "foo.example.org"),
+		},
+		"should not warn about Certificates using external issuers": {
+			op:  admissionv1.Create,
+			crt: certificate(cmmeta.ObjectReference{Name: "venafi", Kind: "Issuer", Group: "example.com"}, "foo.example.org"),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			factory := cminformers.NewSharedInformerFactory(cmfake.NewSimpleClientset(venafiIssuer, venafiClusterIssuer, staleIssuer), 0)
+			p := NewPlugin().(*certificateVenafiZonePolicy)
+			p.SetCertManagerInformerFactory(factory)
+			factory.Start(ctx.Done())
+			factory.WaitForCacheSync(ctx.Done())
+
+			request := admissionv1.AdmissionRequest{
+				Operation:       test.op,
+				Namespace:       "testns",
+				RequestResource: &certificateResource,
+			}
+
+			var oldObj runtime.Object
+			if test.oldCrt != nil {
+				oldObj = test.oldCrt
+			}
+
+			warnings, err := p.Validate(ctx, request, oldObj, test.crt)
+			assert.NoError(t, err)
+			assert.Equal(t, test.warnings, warnings)
+		})
+	}
+}
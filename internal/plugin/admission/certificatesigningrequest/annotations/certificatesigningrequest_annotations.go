@@ -0,0 +1,186 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	venafiapi "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
+)
+
+const PluginName = "CertificateSigningRequestAnnotations"
+
+// strictAnnotationPrefixes are the annotation namespaces which are owned
+// entirely by cert-manager on CertificateSigningRequests. Unknown annotations
+// in these namespaces are most likely typos, and are rejected.
+var strictAnnotationPrefixes = []string{
+	"experimental.cert-manager.io/",
+	"venafi.experimental.cert-manager.io/",
+	"venafi.cert-manager.io/",
+}
+
+type certificateSigningRequestAnnotations struct {
+	*admission.Handler
+}
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func() (admission.Interface, error) {
+		return NewPlugin(), nil
+	})
+}
+
+var _ admission.ValidationInterface = &certificateSigningRequestAnnotations{}
+
+func NewPlugin() admission.Interface {
+	return &certificateSigningRequestAnnotations{
+		Handler: admission.NewHandler(admissionv1.Create, admissionv1.Update),
+	}
+}
+
+func (p *certificateSigningRequestAnnotations) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) ([]string, error) {
+	// Only run this admission plugin for Kubernetes CertificateSigningRequest
+	// resources
+	if request.RequestResource.Group != certificatesv1.GroupName ||
+		request.RequestResource.Resource != "certificatesigningrequests" {
+		return nil, nil
+	}
+
+	csr, ok := obj.(*certificatesv1.CertificateSigningRequest)
+	if !ok {
+		return nil, fmt.Errorf("internal error: object in admission request is not of type *certificatesv1.CertificateSigningRequest")
+	}
+
+	// Only validate requests which are destined for a cert-manager signer.
+	ref, ok := util.SignerIssuerRefFromSignerName(csr.Spec.SignerName)
+	if !ok || ref.Group != certmanager.GroupName {
+		return nil, nil
+	}
+
+	el, warnings := ValidateAnnotations(csr)
+	return warnings, el.ToAggregate()
+}
+
+// ValidateAnnotations validates the cert-manager annotations present on the
+// given CertificateSigningRequest. Warnings are returned for any deprecated
+// experimental.cert-manager.io annotations that are in use.
+func ValidateAnnotations(csr *certificatesv1.CertificateSigningRequest) (field.ErrorList, []string) {
+	fldPath := field.NewPath("metadata", "annotations")
+
+	var (
+		el       field.ErrorList
+		warnings []string
+	)
+
+	knownKeys := sets.NewString()
+	for key, experimentalKey := range apiutil.ExperimentalCertificateSigningRequestAnnotationKeys {
+		knownKeys.Insert(key, experimentalKey)
+	}
+
+	for _, key := range sets.StringKeySet(csr.Annotations).List() {
+		if knownKeys.Has(key) {
+			continue
+		}
+		for _, prefix := range strictAnnotationPrefixes {
+			if strings.HasPrefix(key, prefix) {
+				el = append(el, field.NotSupported(fldPath.Key(key), key, knownKeys.List()))
+				break
+			}
+		}
+	}
+
+	for _, key := range sets.StringKeySet(apiutil.ExperimentalCertificateSigningRequestAnnotationKeys).List() {
+		experimentalKey := apiutil.ExperimentalCertificateSigningRequestAnnotationKeys[key]
+		value, hasKey := csr.Annotations[key]
+		experimentalValue, hasExperimentalKey := csr.Annotations[experimentalKey]
+
+		switch {
+		case hasKey && hasExperimentalKey && value != experimentalValue:
+			el = append(el, field.Invalid(fldPath.Key(experimentalKey), experimentalValue,
+				fmt.Sprintf("must match the value of the %q annotation", key)))
+		case hasExperimentalKey:
+			warnings = append(warnings, fmt.Sprintf("annotation %q is deprecated; use %q", experimentalKey, key))
+		}
+
+		validateFn, ok := annotationValidators[key]
+		if !ok {
+			continue
+		}
+		value, foundKey, ok := apiutil.CertificateSigningRequestAnnotation(csr, key)
+		if !ok {
+			continue
+		}
+		if err := validateFn(value); err != nil {
+			el = append(el, field.Invalid(fldPath.Key(foundKey), value, err.Error()))
+		}
+	}
+
+	return el, warnings
+}
+
+var annotationValidators = map[string]func(string) error{
+	cmapi.CertificateSigningRequestDurationAnnotationKey: func(value string) error {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		if duration < cmapi.CertificateSigningRequestMinimumDuration {
+			return fmt.Errorf("duration must be at least %s", cmapi.CertificateSigningRequestMinimumDuration)
+		}
+		return nil
+	},
+	cmapi.CertificateSigningRequestIsCAAnnotationKey: func(value string) error {
+		if value != "true" && value != "false" {
+			return fmt.Errorf(`must be "true" or "false"`)
+		}
+		return nil
+	},
+	cmapi.CertificateSigningRequestPrivateKeyAnnotationKey: func(value string) error {
+		if errs := validation.IsDNS1123Subdomain(value); len(errs) > 0 {
+			return fmt.Errorf("must be a valid Secret name: %s", strings.Join(errs, ", "))
+		}
+		return nil
+	},
+	cmapi.VenafiCustomFieldsAnnotationKey: func(value string) error {
+		var customFields []venafiapi.CustomField
+		if err := json.Unmarshal([]byte(value), &customFields); err != nil {
+			return fmt.Errorf("must be a JSON encoded list of custom fields: %s", err)
+		}
+		for i, customField := range customFields {
+			if len(customField.Name) == 0 {
+				return fmt.Errorf("custom field %d must have a name", i)
+			}
+		}
+		return nil
+	},
+}
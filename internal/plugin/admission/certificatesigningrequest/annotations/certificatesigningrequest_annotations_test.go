@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package annotations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var csrResource = &metav1.GroupVersionResource{
+	Group:    "certificates.k8s.io",
+	Version:  "v1",
+	Resource: "certificatesigningrequests",
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		signerName  string
+		annotations map[string]string
+		warnings    []string
+		err         string
+	}{
+		"should ignore CertificateSigningRequests for non cert-manager signers": {
+			signerName: "kubernetes.io/kube-apiserver-client",
+			annotations: map[string]string{
+				"experimental.cert-manager.io/request-durration": "1h",
+			},
+		},
+		"should allow valid annotations": {
+			signerName: "issuers.cert-manager.io/default.ca",
+			annotations: map[string]string{
+				"cert-manager.io/request-duration":        "1h",
+				"cert-manager.io/request-is-ca":           "true",
+				"cert-manager.io/private-key-secret-name": "my-key",
+				"venafi.cert-manager.io/custom-fields":    `[{"name": "field", "value": "value"}]`,
+				"venafi.cert-manager.io/pickup-id":        "abc",
+				"example.com/unrelated":                   "value",
+			},
+		},
+		"should warn about deprecated experimental annotations": {
+			signerName: "clusterissuers.cert-manager.io/ca",
+			annotations: map[string]string{
+				"experimental.cert-manager.io/request-duration": "1h",
+				"experimental.cert-manager.io/request-is-ca":    "false",
+			},
+			warnings: []string{
+				`annotation "experimental.cert-manager.io/request-duration" is deprecated; use "cert-manager.io/request-duration"`,
+				`annotation "experimental.cert-manager.io/request-is-ca" is deprecated; use "cert-manager.io/request-is-ca"`,
+			},
+		},
+		"should reject unknown annotations in the experimental namespace": {
+			signerName: "clusterissuers.cert-manager.io/ca",
+			annotations: map[string]string{
+				"experimental.cert-manager.io/request-durration": "1h",
+			},
+			err: `metadata.annotations[experimental.cert-manager.io/request-durration]: Unsupported value: "experimental.cert-manager.io/request-durration": supported values: ` +
+				`"cert-manager.io/private-key-secret-name", "cert-manager.io/request-duration", "cert-manager.io/request-is-ca", ` +
+				`"experimental.cert-manager.io/private-key-secret-name", "experimental.cert-manager.io/request-duration", "experimental.cert-manager.io/request-is-ca", ` +
+				`"venafi.cert-manager.io/custom-fields", "venafi.cert-manager.io/pickup-id", ` +
+				`"venafi.experimental.cert-manager.io/custom-fields", "venafi.experimental.cert-manager.io/pickup-id"`,
+		},
+		"should reject experimental annotations that conflict with their replacement": {
+			signerName: "clusterissuers.cert-manager.io/ca",
+			annotations: map[string]string{
+				"cert-manager.io/request-is-ca":              "true",
+				"experimental.cert-manager.io/request-is-ca": "false",
+			},
+			err: `metadata.annotations[experimental.cert-manager.io/request-is-ca]: Invalid value: "false": must match the value of the "cert-manager.io/request-is-ca" annotation`,
+		},
+		"should reject invalid annotation values": {
+			signerName: "clusterissuers.cert-manager.io/ca",
+			annotations: map[string]string{
+				"cert-manager.io/request-duration":                     "5m",
+				"experimental.cert-manager.io/request-is-ca":           "yes",
+				"venafi.cert-manager.io/custom-fields":                 "not-json",
+				"experimental.cert-manager.io/private-key-secret-name": "Not_Valid",
+			},
+			warnings: []string{
+				`annotation "experimental.cert-manager.io/private-key-secret-name" is deprecated; use "cert-manager.io/private-key-secret-name"`,
+				`annotation "experimental.cert-manager.io/request-is-ca" is deprecated; use "cert-manager.io/request-is-ca"`,
+			},
+			err: `[metadata.annotations[experimental.cert-manager.io/private-key-secret-name]: Invalid value: "Not_Valid": must be a valid Secret name: a lowercase RFC 1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*'), ` +
+				`metadata.annotations[cert-manager.io/request-duration]: Invalid value: "5m": duration must be at least 10m0s, ` +
+				`metadata.annotations[experimental.cert-manager.io/request-is-ca]: Invalid value: "yes": must be "true" or "false", ` +
+				`metadata.annotations[venafi.cert-manager.io/custom-fields]: Invalid value: "not-json": must be a JSON encoded list of custom fields: invalid character 'o' in literal null (expecting 'u')]`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := NewPlugin().(*certificateSigningRequestAnnotations)
+			csr := &certificatesv1.CertificateSigningRequest{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Annotations: test.annotations},
+				Spec:       certificatesv1.CertificateSigningRequestSpec{SignerName: test.signerName},
+			}
+
+			warnings, err := p.Validate(context.Background(), admissionv1.AdmissionRequest{
+				Operation:       admissionv1.Create,
+				RequestResource: csrResource,
+			}, nil, csr)
+
+			assert.Equal(t, test.warnings, warnings)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.err)
+			}
+		})
+	}
+}
@@ -52,6 +52,7 @@ var certificateGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificates
 var certificateRequestGVR = certmanagerv1.SchemeGroupVersion.WithResource("certificaterequests")
 var issuerGVR = certmanagerv1.SchemeGroupVersion.WithResource("issuers")
 var clusterIssuerGVR = certmanagerv1.SchemeGroupVersion.WithResource("clusterissuers")
+var sshCertificateGVR = certmanagerv1.SchemeGroupVersion.WithResource("sshcertificates")
 var orderGVR = acmev1.SchemeGroupVersion.WithResource("orders")
 var challengeGVR = acmev1.SchemeGroupVersion.WithResource("challenges")
 
@@ -72,6 +73,7 @@ var validationMapping = map[schema.GroupVersionResource]validationPair{
 	certificateRequestGVR: newValidationPair(cmvalidation.ValidateCertificateRequest, cmvalidation.ValidateUpdateCertificateRequest),
 	issuerGVR:             newValidationPair(cmvalidation.ValidateIssuer, cmvalidation.ValidateUpdateIssuer),
 	clusterIssuerGVR:      newValidationPair(cmvalidation.ValidateClusterIssuer, cmvalidation.ValidateUpdateClusterIssuer),
+	sshCertificateGVR:     newValidationPair(cmvalidation.ValidateSSHCertificate, cmvalidation.ValidateUpdateSSHCertificate),
 	orderGVR:              newValidationPair(acmevalidation.ValidateOrder, acmevalidation.ValidateOrderUpdate),
 	challengeGVR:          newValidationPair(acmevalidation.ValidateChallenge, acmevalidation.ValidateChallengeUpdate),
 }
@@ -18,8 +18,10 @@ package plugin
 
 import (
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/apideprecation"
+	certificatevenafipolicy "github.com/cert-manager/cert-manager/internal/plugin/admission/certificate/venafipolicy"
 	certificaterequestapproval "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/approval"
 	certificaterequestidentity "github.com/cert-manager/cert-manager/internal/plugin/admission/certificaterequest/identity"
+	certificatesigningrequestannotations "github.com/cert-manager/cert-manager/internal/plugin/admission/certificatesigningrequest/annotations"
 	"github.com/cert-manager/cert-manager/internal/plugin/admission/resourcevalidation"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -30,12 +32,16 @@ var AllOrderedPlugins = []string{
 	resourcevalidation.PluginName,
 	certificaterequestidentity.PluginName,
 	certificaterequestapproval.PluginName,
+	certificatesigningrequestannotations.PluginName,
+	certificatevenafipolicy.PluginName,
 }
 
 func RegisterAllPlugins(plugins *admission.Plugins) {
 	apideprecation.Register(plugins)
 	certificaterequestidentity.Register(plugins)
 	certificaterequestapproval.Register(plugins)
+	certificatesigningrequestannotations.Register(plugins)
+	certificatevenafipolicy.Register(plugins)
 	resourcevalidation.Register(plugins)
 }
 
@@ -45,6 +51,8 @@ func DefaultOnAdmissionPlugins() sets.String {
 		resourcevalidation.PluginName,
 		certificaterequestidentity.PluginName,
 		certificaterequestapproval.PluginName,
+		certificatesigningrequestannotations.PluginName,
+		certificatevenafipolicy.PluginName,
 	)
 }
 
@@ -34,8 +34,10 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/utils/pointer"
 
+	"github.com/cert-manager/cert-manager/internal/credentials"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
@@ -43,7 +45,7 @@ var _ Interface = &Vault{}
 
 // ClientBuilder is a function type that returns a new Interface.
 // Can be used in tests to create a mock signer of Vault certificate requests.
-type ClientBuilder func(namespace string, _ func(ns string) CreateToken, _ corelisters.SecretLister, _ v1.GenericIssuer) (Interface, error)
+type ClientBuilder func(namespace string, _ func(ns string) CreateToken, _ corelisters.SecretLister, _ v1.GenericIssuer, _ *metrics.Metrics) (Interface, error)
 
 // Interface implements various high level functionality related to connecting
 // with a Vault server, verifying its status and signing certificate request for
@@ -90,10 +92,11 @@ type Vault struct {
 }
 
 // New returns a new Vault instance with the given namespace, issuer and
-// secrets lister.
+// secrets lister. All requests made to Vault are instrumented with the given
+// metrics.
 // Returned errors may be network failures and should be considered for
 // retrying.
-func New(namespace string, createTokenFn func(ns string) CreateToken, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer) (Interface, error) {
+func New(namespace string, createTokenFn func(ns string) CreateToken, secretsLister corelisters.SecretLister, issuer v1.GenericIssuer, m *metrics.Metrics) (Interface, error) {
 	v := &Vault{
 		createToken:   createTokenFn(namespace),
 		secretsLister: secretsLister,
@@ -111,6 +114,11 @@ func New(namespace string, createTokenFn func(ns string) CreateToken, secretsLis
 		return nil, fmt.Errorf("error initializing Vault client: %s", err.Error())
 	}
 
+	// The transport is wrapped only once the client has been created, since
+	// the Vault client expects to configure a *http.Transport while parsing
+	// the server address. The client shares the HTTP client of the config.
+	cfg.HttpClient.Transport = m.NewInstrumentedTransport(metrics.IssuerClientVault, cfg.HttpClient.Transport, nil)
+
 	// Set the Vault namespace.
 	// An empty namespace string will cause the client to not send the namespace related HTTP headers to Vault.
 	clientNS := client.WithNamespace(issuer.GetSpec().Vault.Namespace)
@@ -294,7 +302,10 @@ func (v *Vault) tokenRef(name, namespace, key string) (string, error) {
 		key = v1.DefaultVaultTokenAuthSecretKey
 	}
 
-	keyBytes, ok := secret.Data[key]
+	keyBytes, ok, err := credentials.Value(secret, key)
+	if err != nil {
+		return "", err
+	}
 	if !ok {
 		return "", fmt.Errorf("no data for %q in secret '%s/%s'", key, name, namespace)
 	}
@@ -315,7 +326,10 @@ func (v *Vault) appRoleRef(appRole *v1.VaultAppRole) (roleId, secretId string, e
 
 	key := appRole.SecretRef.Key
 
-	keyBytes, ok := secret.Data[key]
+	keyBytes, ok, err := credentials.Value(secret, key)
+	if err != nil {
+		return "", "", err
+	}
 	if !ok {
 		return "", "", fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, appRole.SecretRef.Name)
 	}
@@ -389,7 +403,10 @@ func (v *Vault) requestTokenWithKubernetesAuth(client Client, kubernetesAuth *v1
 			key = v1.DefaultVaultTokenAuthSecretKey
 		}
 
-		keyBytes, ok := secret.Data[key]
+		keyBytes, ok, err := credentials.Value(secret, key)
+		if err != nil {
+			return "", err
+		}
 		if !ok {
 			return "", fmt.Errorf("no data for %q in secret '%s/%s'", key, v.namespace, kubernetesAuth.SecretRef.Name)
 		}
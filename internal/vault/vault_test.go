@@ -40,11 +40,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clientcorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/utils/clock"
 
 	vaultfake "github.com/cert-manager/cert-manager/internal/vault/fake"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 	"github.com/cert-manager/cert-manager/test/unit/listers"
@@ -1332,7 +1335,9 @@ func TestNewWithVaultNamespaces(t *testing.T) {
 							},
 						},
 					},
-				})
+				},
+				metrics.New(logf.Log, clock.RealClock{}),
+			)
 			require.NoError(t, err)
 			assert.Equal(t, tc.vaultNS, c.(*Vault).client.(*vault.Client).Namespace(),
 				"The vault client should have the namespace provided in the Issuer recource")
@@ -1388,7 +1393,9 @@ func TestIsVaultInitiatedAndUnsealedIntegration(t *testing.T) {
 					},
 				},
 			},
-		})
+		},
+		metrics.New(logf.Log, clock.RealClock{}),
+	)
 	require.NoError(t, err)
 
 	err = v.IsVaultInitializedAndUnsealed()
@@ -1454,7 +1461,9 @@ func TestSignIntegration(t *testing.T) {
 					},
 				},
 			},
-		})
+		},
+		metrics.New(logf.Log, clock.RealClock{}),
+	)
 	require.NoError(t, err)
 
 	certPEM, caPEM, err := v.Sign(csrPEM, time.Hour)
@@ -43,6 +43,16 @@ const (
 	// This feature gate must be used together with LiteralCertificateSubject webhook feature gate.
 	// See https://github.com/cert-manager/cert-manager/issues/3203 and https://github.com/cert-manager/cert-manager/issues/4424 for context.
 	LiteralCertificateSubject featuregate.Feature = "LiteralCertificateSubject"
+
+	// Alpha: v1.12
+	// SSHCertificates will allow SSHCertificate resources to be created.
+	// This feature gate must be used together with SSHCertificates controller feature gate.
+	SSHCertificates featuregate.Feature = "SSHCertificates"
+
+	// Alpha: v1.12
+	// RemoteSecretTargets will allow Certificates to set spec.remoteTarget.
+	// This feature gate must be used together with RemoteSecretTargets controller feature gate.
+	RemoteSecretTargets featuregate.Feature = "RemoteSecretTargets"
 )
 
 func init() {
@@ -59,4 +69,6 @@ func init() {
 var webhookFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
 	AdditionalCertificateOutputFormats: {Default: false, PreRelease: featuregate.Alpha},
 	LiteralCertificateSubject:          {Default: false, PreRelease: featuregate.Alpha},
+	SSHCertificates:                    {Default: false, PreRelease: featuregate.Alpha},
+	RemoteSecretTargets:                {Default: false, PreRelease: featuregate.Alpha},
 }
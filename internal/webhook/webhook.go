@@ -21,6 +21,10 @@ import (
 	"time"
 
 	"github.com/go-logr/logr"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
 	"k8s.io/client-go/kubernetes"
@@ -32,6 +36,8 @@ import (
 	config "github.com/cert-manager/cert-manager/internal/apis/config/webhook"
 	metainstall "github.com/cert-manager/cert-manager/internal/apis/meta/install"
 	"github.com/cert-manager/cert-manager/internal/plugin"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
@@ -41,6 +47,10 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/webhook/server/tls"
 )
 
+// informerResyncPeriod is the resync period of the informers used by
+// admission plugins.
+const informerResyncPeriod = 10 * time.Hour
+
 var conversionHook handlers.ConversionHook = handlers.NewSchemeBackedConverter(logf.Log, Scheme)
 
 // WithConversionHandler allows you to override the handler for the `/convert`
@@ -64,8 +74,16 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 		return nil, fmt.Errorf("error creating kubernetes client: %s", err)
 	}
 
+	cmcl, err := cmclient.NewForConfig(restcfg)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cert-manager client: %s", err)
+	}
+
+	// Informers used by admission plugins are started when the server is run
+	cmInformers := cminformers.NewSharedInformerFactory(cmcl, informerResyncPeriod)
+
 	// Set up the admission chain
-	admissionHandler, err := buildAdmissionChain(cl)
+	admissionHandler, err := buildAdmissionChain(cl, cmcl, cmInformers)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +99,7 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 		ValidationWebhook: admissionHandler,
 		MutationWebhook:   admissionHandler,
 		ConversionWebhook: conversionHook,
+		InformerFactories: []server.InformerFactory{cmInformers},
 	}
 	for _, fn := range optionFunctions {
 		fn(s)
@@ -88,7 +107,7 @@ func NewCertManagerWebhookServer(log logr.Logger, opts config.WebhookConfigurati
 	return s, nil
 }
 
-func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler, error) {
+func buildAdmissionChain(client kubernetes.Interface, cmClient cmclient.Interface, cmInformers cminformers.SharedInformerFactory) (*admission.RequestHandler, error) {
 	// Set up the admission chain
 	pluginHandler := admission.NewPlugins(Scheme)
 	plugin.RegisterAllPlugins(pluginHandler)
@@ -108,7 +127,7 @@ func buildAdmissionChain(client kubernetes.Interface) (*admission.RequestHandler
 	if err != nil {
 		return nil, fmt.Errorf("error creating authorization handler: %v", err)
 	}
-	pluginInitializer := initializer.New(client, nil, authorizer, nil)
+	pluginInitializer := initializer.New(client, cmClient, nil, cmInformers, authorizer, nil)
 	pluginChain, err := pluginHandler.NewFromPlugins(plugin.DefaultOnAdmissionPlugins().List(), pluginInitializer)
 	if err != nil {
 		return nil, fmt.Errorf("error building admission chain: %v", err)
@@ -144,4 +163,13 @@ func init() {
 	cminstall.Install(Scheme)
 	acmeinstall.Install(Scheme)
 	metainstall.Install(Scheme)
+
+	// The Kubernetes certificates.k8s.io API group has no internal version.
+	// Register the v1 types as the internal version as well, so that admission
+	// plugins can validate CertificateSigningRequests without any conversion.
+	utilruntime.Must(certificatesv1.AddToScheme(Scheme))
+	Scheme.AddKnownTypes(schema.GroupVersion{Group: certificatesv1.GroupName, Version: runtime.APIVersionInternal},
+		&certificatesv1.CertificateSigningRequest{},
+		&certificatesv1.CertificateSigningRequestList{},
+	)
 }
@@ -17,86 +17,65 @@ limitations under the License.
 package client
 
 import (
-	"fmt"
+	"bytes"
+	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
-// This file implements a custom instrumented HTTP client round tripper that
-// exposes prometheus metrics for each endpoint called.
+// maxProblemSize is the maximum size of an ACME problem document that is read
+// to classify a failed request.
+const maxProblemSize = 64 * 1024
+
+// NewInstrumentedClient takes a *http.Client and returns a *http.Client that
+// has its RoundTripper wrapped with instrumentation.
 //
 // We implement this as part of the HTTP client to ensure we don't miss any
 // calls made to the ACME server caused by retries in the underlying ACME
 // library.
-
-// Transport is a http.RoundTripper that collects Prometheus metrics of every
-// request it processes. It allows to be configured with callbacks that process
-// request path and query into a suitable label value.
-type Transport struct {
-	metrics *metrics.Metrics
-
-	wrappedRT http.RoundTripper
-}
-
-// NewInstrumentedClient takes a *http.Client and returns a *http.Client that
-// has its RoundTripper wrapped with instrumentation.
-func NewInstrumentedClient(metrics *metrics.Metrics, client *http.Client) *http.Client {
+func NewInstrumentedClient(m *metrics.Metrics, client *http.Client) *http.Client {
 	// If next client is not defined we'll use http.DefaultClient.
 	if client == nil {
 		client = http.DefaultClient
 	}
 
-	if client.Transport == nil {
-		client.Transport = http.DefaultTransport
-	}
-
-	client.Transport = &Transport{
-		wrappedRT: client.Transport,
-		metrics:   metrics,
-	}
+	client.Transport = m.NewInstrumentedTransport(metrics.IssuerClientACME, client.Transport, classifyACMEError)
 
 	return client
 }
 
-// RoundTrip implements http.RoundTripper. It forwards the request to the
-// wrapped RoundTripper and measures the time it took in Prometheus summary.
-func (it *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
-	statusCode := 999
+// classifyACMEError classifies failed requests by the type of the returned
+// ACME problem document, since ACME servers use 400 Bad Request for transient
+// errors like badNonce as well as for rejected orders. Other failures are
+// classified by their status code.
+func classifyACMEError(resp *http.Response, err error) string {
+	if err != nil || resp == nil || resp.StatusCode != http.StatusBadRequest ||
+		!strings.HasPrefix(resp.Header.Get("Content-Type"), "application/problem+json") {
+		return metrics.ClassifyHTTPError(resp, err)
+	}
 
-	// Remember the current time.
-	start := time.Now()
+	// Peek at the problem document and restore the body for the ACME client
+	body, readErr := io.ReadAll(io.LimitReader(resp.Body, maxProblemSize))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), resp.Body), resp.Body}
 
-	// Make the request using the wrapped RoundTripper.
-	resp, err := it.wrappedRT.RoundTrip(req)
-	if resp != nil {
-		statusCode = resp.StatusCode
+	var problem struct {
+		Type string `json:"type"`
 	}
-
-	labels := []string{
-		req.URL.Scheme,
-		req.URL.Host,
-		pathProcessor(req.URL.Path),
-		req.Method,
-		fmt.Sprintf("%d", statusCode),
+	if readErr != nil || json.Unmarshal(body, &problem) != nil {
+		return metrics.ClassifyHTTPError(resp, nil)
 	}
-	// Observe the time it took to make the request.
-	it.metrics.ObserveACMERequestDuration(time.Since(start), labels...)
-	it.metrics.IncrementACMERequestCount(labels...)
-
-	// return the response and error reported from the next RoundTripper.
-	return resp, err
-}
 
-// pathProcessor will trim the provided path to only include the first 2
-// segments in order to reduce the number of prometheus labels generated
-func pathProcessor(path string) string {
-	p := strings.Split(path, "/")
-	// only record the first two path segments as a prometheus label value
-	if len(p) > 3 {
-		p = p[:3]
+	switch strings.TrimPrefix(problem.Type, "urn:ietf:params:acme:error:") {
+	case "badNonce", "rateLimited", "serverInternal":
+		return metrics.ErrorReasonTransient
+	case "unauthorized", "accountDoesNotExist", "externalAccountRequired", "userActionRequired":
+		return metrics.ErrorReasonAuth
 	}
-	return strings.Join(p, "/")
+	return metrics.ErrorReasonPolicy
 }
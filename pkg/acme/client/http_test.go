@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+)
+
+func TestClassifyACMEError(t *testing.T) {
+	problem := func(typ string) string {
+		return `{"type": "urn:ietf:params:acme:error:` + typ + `", "detail": "detail"}`
+	}
+
+	tests := map[string]struct {
+		status      int
+		contentType string
+		body        string
+		exp         string
+	}{
+		"successful request": {
+			status: http.StatusOK, contentType: "application/json", body: "{}", exp: "",
+		},
+		"bad nonce is transient": {
+			status: http.StatusBadRequest, contentType: "application/problem+json", body: problem("badNonce"), exp: metrics.ErrorReasonTransient,
+		},
+		"unauthorized is an auth error": {
+			status: http.StatusBadRequest, contentType: "application/problem+json", body: problem("externalAccountRequired"), exp: metrics.ErrorReasonAuth,
+		},
+		"rejected identifier is a policy error": {
+			status: http.StatusBadRequest, contentType: "application/problem+json", body: problem("rejectedIdentifier"), exp: metrics.ErrorReasonPolicy,
+		},
+		"rate limits are transient": {
+			status: http.StatusTooManyRequests, contentType: "application/problem+json", body: problem("rateLimited"), exp: metrics.ErrorReasonTransient,
+		},
+		"bad request without a problem document": {
+			status: http.StatusBadRequest, contentType: "text/plain", body: "bad request", exp: metrics.ErrorReasonPolicy,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: test.status,
+				Header:     http.Header{"Content-Type": []string{test.contentType}},
+				Body:       io.NopCloser(strings.NewReader(test.body)),
+			}
+
+			if got := classifyACMEError(resp, nil); got != test.exp {
+				t.Errorf("unexpected classification, exp=%q got=%q", test.exp, got)
+			}
+
+			// the ACME client must still be able to read the response body
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != test.body {
+				t.Errorf("unexpected body after classification, exp=%q got=%q", test.body, body)
+			}
+		})
+	}
+}
@@ -372,3 +372,56 @@ func CertificateRequestIsDenied(cr *cmapi.CertificateRequest) bool {
 
 	return false
 }
+
+// GetSSHCertificateCondition returns the condition of the given type on the
+// SSHCertificate, or nil if it does not exist.
+func GetSSHCertificateCondition(crt *cmapi.SSHCertificate, conditionType cmapi.SSHCertificateConditionType) *cmapi.SSHCertificateCondition {
+	for _, cond := range crt.Status.Conditions {
+		if cond.Type == conditionType {
+			return &cond
+		}
+	}
+	return nil
+}
+
+// SetSSHCertificateCondition will set a 'condition' on the given SSHCertificate.
+//   - If no condition of the same type already exists, the condition will be
+//     inserted with the LastTransitionTime set to the current time.
+//   - If a condition of the same type and state already exists, the condition
+//     will be updated but the LastTransitionTime will not be modified.
+//   - If a condition of the same type and different state already exists, the
+//     condition will be updated and the LastTransitionTime set to the current
+//     time.
+func SetSSHCertificateCondition(crt *cmapi.SSHCertificate, observedGeneration int64, conditionType cmapi.SSHCertificateConditionType,
+	status cmmeta.ConditionStatus, reason, message string) {
+	nowTime := metav1.NewTime(Clock.Now())
+	newCondition := cmapi.SSHCertificateCondition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: &nowTime,
+		ObservedGeneration: observedGeneration,
+	}
+
+	for idx, cond := range crt.Status.Conditions {
+		// Skip unrelated conditions
+		if cond.Type != conditionType {
+			continue
+		}
+
+		// If this update doesn't contain a state transition, we don't update the
+		// conditions LastTransitionTime to Now()
+		if cond.Status == status {
+			newCondition.LastTransitionTime = cond.LastTransitionTime
+		} else {
+			logf.V(logf.InfoLevel).Infof("Found status change for SSHCertificate %q condition %q: %q -> %q; setting lastTransitionTime to %v", crt.Name, conditionType, cond.Status, status, nowTime.Time)
+		}
+
+		crt.Status.Conditions[idx] = newCondition
+		return
+	}
+
+	crt.Status.Conditions = append(crt.Status.Conditions, newCondition)
+	logf.V(logf.InfoLevel).Infof("Setting lastTransitionTime for SSHCertificate %q condition %q to %v", crt.Name, conditionType, nowTime.Time)
+}
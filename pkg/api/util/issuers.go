@@ -54,6 +54,15 @@ func NameForIssuer(i cmapi.GenericIssuer) (string, error) {
 	return "", fmt.Errorf("no issuer specified for Issuer '%s/%s'", i.GetObjectMeta().Namespace, i.GetObjectMeta().Name)
 }
 
+// IssuerSupportsLiteralSubject returns true if the given issuer copies the
+// subject of a CSR into the certificates it signs without modification.
+// Venafi issuers submit the CSR as it is. ACME and Vault issuers build the
+// subject of signed certificates themselves, so cannot be used with the
+// literalSubject field of a Certificate.
+func IssuerSupportsLiteralSubject(i cmapi.GenericIssuer) bool {
+	return i.GetSpec().CA != nil || i.GetSpec().SelfSigned != nil || i.GetSpec().Venafi != nil
+}
+
 // IssuerKind returns the kind of issuer for a certificate.
 func IssuerKind(ref cmmeta.ObjectReference) string {
 	if ref.Kind == "" {
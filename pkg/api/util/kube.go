@@ -21,8 +21,41 @@ import (
 	"math/bits"
 
 	certificatesv1 "k8s.io/api/certificates/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 )
 
+// ExperimentalCertificateSigningRequestAnnotationKeys maps the
+// CertificateSigningRequest annotation keys to the deprecated
+// experimental.cert-manager.io annotation keys that they replace.
+var ExperimentalCertificateSigningRequestAnnotationKeys = map[string]string{
+	cmapi.CertificateSigningRequestDurationAnnotationKey:   experimentalapi.CertificateSigningRequestDurationAnnotationKey,
+	cmapi.CertificateSigningRequestIsCAAnnotationKey:       experimentalapi.CertificateSigningRequestIsCAAnnotationKey,
+	cmapi.CertificateSigningRequestPrivateKeyAnnotationKey: experimentalapi.CertificateSigningRequestPrivateKeyAnnotationKey,
+	cmapi.VenafiCustomFieldsAnnotationKey:                  experimentalapi.CertificateSigningRequestVenafiCustomFieldsAnnotationKey,
+	cmapi.VenafiPickupIDAnnotationKey:                      experimentalapi.CertificateSigningRequestVenafiPickupIDAnnotationKey,
+}
+
+// CertificateSigningRequestAnnotation returns the value of the given
+// annotation key on the CertificateSigningRequest. If the annotation is not
+// set, the value of the deprecated experimental.cert-manager.io annotation it
+// replaces is returned instead. The returned key is the annotation key that
+// the value was read from.
+func CertificateSigningRequestAnnotation(csr *certificatesv1.CertificateSigningRequest, key string) (value, foundKey string, ok bool) {
+	if value, ok := csr.Annotations[key]; ok {
+		return value, key, true
+	}
+
+	if experimentalKey, ok := ExperimentalCertificateSigningRequestAnnotationKeys[key]; ok {
+		if value, ok := csr.Annotations[experimentalKey]; ok {
+			return value, experimentalKey, true
+		}
+	}
+
+	return "", "", false
+}
+
 var keyUsagesKube = map[certificatesv1.KeyUsage]x509.KeyUsage{
 	certificatesv1.UsageSigning:           x509.KeyUsageDigitalSignature,
 	certificatesv1.UsageDigitalSignature:  x509.KeyUsageDigitalSignature,
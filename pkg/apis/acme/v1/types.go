@@ -34,6 +34,50 @@ const (
 	// solver for each ingress class.
 	ACMECertificateHTTP01IngressClassOverride = "acme.cert-manager.io/http01-override-ingress-class"
 
+	// ACMECertificateHTTP01IngressClassNameOverride is annotation to override
+	// the ingress class name.
+	// If this annotation is specified on a Certificate or Order resource when
+	// using the HTTP01 solver type, the ingress.ingressClassName field of the
+	// HTTP01 solver's configuration will be set to the value given here.
+	ACMECertificateHTTP01IngressClassNameOverride = "acme.cert-manager.io/http01-override-ingress-ingressclassname"
+
+	// ACMECertificateHTTP01IngressServiceTypeOverride is annotation to override
+	// the service type of the solver service.
+	// If this annotation is specified on a Certificate or Order resource when
+	// using the HTTP01 solver type, the ingress.serviceType field of the
+	// HTTP01 solver's configuration will be set to the value given here.
+	// Supported values are NodePort or ClusterIP.
+	ACMECertificateHTTP01IngressServiceTypeOverride = "acme.cert-manager.io/http01-override-ingress-service-type"
+
+	// ACMECertificateHTTP01IngressAnnotationsOverride is annotation to
+	// override the annotations of the solver ingress.
+	// Its value is a JSON object of annotations which is merged into the
+	// ingress.ingressTemplate.metadata.annotations field of the HTTP01
+	// solver's configuration, taking precedence over the values given there.
+	// Only the annotations allowed by the issuer using the
+	// ACMEIssuerHTTP01AllowedIngressAnnotationOverridesKey annotation may be
+	// overridden.
+	ACMECertificateHTTP01IngressAnnotationsOverride = "acme.cert-manager.io/http01-override-ingress-annotations"
+
+	// ACMEIssuerHTTP01AllowedIngressAnnotationOverridesKey is an annotation
+	// on an Issuer or ClusterIssuer listing the solver ingress annotations
+	// which may be overridden using the
+	// ACMECertificateHTTP01IngressAnnotationsOverride annotation.
+	// Its value is a comma separated list of annotation keys. A key ending in
+	// '*' allows all annotations with the given prefix, such as
+	// 'nginx.ingress.kubernetes.io/*'. If unset, no annotations may be
+	// overridden.
+	ACMEIssuerHTTP01AllowedIngressAnnotationOverridesKey = "acme.cert-manager.io/http01-allowed-ingress-annotation-overrides"
+
+	// ACMECertificateHTTP01IngressLabelsOverride is annotation to override
+	// the labels of the solver ingress.
+	// Its value is a JSON object of labels which is merged into the
+	// ingress.ingressTemplate.metadata.labels field of the HTTP01 solver's
+	// configuration, taking precedence over the values given there.
+	// Labels with the 'acme.cert-manager.io/' prefix, which is used by the
+	// labels cert-manager adds to the solver ingress, may not be overridden.
+	ACMECertificateHTTP01IngressLabelsOverride = "acme.cert-manager.io/http01-override-ingress-labels"
+
 	// IngressEditInPlaceAnnotationKey is used to toggle the use of ingressClass instead
 	// of ingress on the created Certificate resource
 	IngressEditInPlaceAnnotationKey = "acme.cert-manager.io/http01-edit-in-place"
@@ -143,4 +143,10 @@ type ChallengeStatus struct {
 	// If not set, the state of the challenge is unknown.
 	// +optional
 	State State `json:"state,omitempty"`
+
+	// The DNS zone in which the DNS01 challenge record was presented, as
+	// selected by the DNS01 provider. Only set for providers which look up the
+	// zone of the record, such as Route53, CloudDNS and AzureDNS.
+	// +optional
+	SelectedZone string `json:"selectedZone,omitempty"`
 }
@@ -30,6 +30,12 @@ const (
 
 	// Deprecated: the default is now 2/3 of Certificate's duration
 	DefaultRenewBefore = time.Hour * 24 * 30
+
+	// minimum permitted SSH certificate duration by cert-manager
+	MinimumSSHCertificateDuration = time.Minute * 10
+
+	// default SSH certificate duration if SSHCertificate.spec.duration is not set
+	DefaultSSHCertificateDuration = time.Hour * 24
 )
 
 const (
@@ -16,6 +16,8 @@ limitations under the License.
 
 package v1
 
+import "time"
+
 const (
 
 	// Common label keys added to resources
@@ -70,6 +72,10 @@ const (
 	// Annotation key for subject serial number.
 	SubjectSerialNumberAnnotationKey = "cert-manager.io/subject-serialnumber"
 
+	// Annotation key for the literal subject. Requires the
+	// LiteralCertificateSubject feature gate.
+	LiteralSubjectAnnotationKey = "cert-manager.io/literal-subject"
+
 	// Annotation key for certificate key usages.
 	UsagesAnnotationKey = "cert-manager.io/usages"
 
@@ -89,6 +95,12 @@ const (
 	// as a 'next private key' Secret resource.
 	IsNextPrivateKeySecretLabelKey = "cert-manager.io/next-private-key"
 
+	// Annotation key holding the signed issuance receipt of a certificate.
+	// It is set on the Secret of a Certificate and on the CertificateRequest
+	// of the revision that issued it, when the controller is configured with
+	// a receipt signing key. The value is a JWS in compact serialization.
+	IssuanceReceiptAnnotationKey = "cert-manager.io/issuance-receipt"
+
 	// Annotation key used to limit the number of CertificateRequests to be kept for a Certificate.
 	// Minimum value is 1.
 	// If unset all CertificateRequests will be kept.
@@ -120,6 +132,37 @@ const (
 	// Annotation key used to set the PrivateKeyRotationPolicy for a Certificate.
 	// If unset a policy `Never` will be used.
 	PrivateKeyRotationPolicyAnnotationKey = "cert-manager.io/private-key-rotation-policy"
+
+	// Annotation keys used to configure the CertificateRequests that the
+	// issuers-canary controller periodically creates for an Issuer or
+	// ClusterIssuer. Only issuers with a canary common name or canary DNS
+	// names are exercised. DNS names are given as a comma separated list.
+	CanaryCommonNameAnnotationKey          = "cert-manager.io/canary-common-name"
+	CanaryDNSNamesAnnotationKey            = "cert-manager.io/canary-dns-names"
+	CanaryPrivateKeyAlgorithmAnnotationKey = "cert-manager.io/canary-private-key-algorithm"
+
+	// Annotation key set on an Issuer or ClusterIssuer by the issuers-canary
+	// controller, recording the time its last canary was created in RFC3339
+	// format, so that the canary interval is kept across restarts.
+	CanaryLastCreatedAnnotationKey = "cert-manager.io/canary-last-created"
+
+	// Label key set on CertificateRequests created by the issuers-canary
+	// controller.
+	CanaryLabelKey = "cert-manager.io/canary"
+
+	// Annotation key which must be set to "true" on a CA Issuer or
+	// ClusterIssuer to allow SSHCertificates to be signed with its CA key.
+	// SSH certificates are signed without a CertificateRequest, so they are
+	// not subject to approval. Anyone able to create an SSHCertificate
+	// referencing an allowed issuer can obtain certificates for any
+	// principal, unless the principals are restricted with
+	// SSHAllowedPrincipalsAnnotationKey.
+	AllowSSHCertificatesAnnotationKey = "cert-manager.io/allow-ssh-certificates"
+
+	// Annotation key used to restrict the principals of SSHCertificates
+	// signed by a CA Issuer or ClusterIssuer, given as a comma separated
+	// list. If unset, any principal is allowed.
+	SSHAllowedPrincipalsAnnotationKey = "cert-manager.io/ssh-allowed-principals"
 )
 
 const (
@@ -133,6 +176,20 @@ const (
 	// IngressACMEIssuerHTTP01IngressClassAnnotationKey holds the acmeIssuerHTTP01IngressClassAnnotation value
 	// which can be used to override the http01 ingressClass if the challenge type is set to http01
 	IngressACMEIssuerHTTP01IngressClassAnnotationKey = "acme.cert-manager.io/http01-ingress-class"
+	// IngressACMEIssuerHTTP01IngressClassNameAnnotationKey holds the acmeIssuerHTTP01IngressClassNameAnnotation value
+	// which can be used to override the http01 ingressClassName if the challenge type is set to http01
+	IngressACMEIssuerHTTP01IngressClassNameAnnotationKey = "acme.cert-manager.io/http01-ingress-ingressclassname"
+	// IngressACMEIssuerHTTP01ServiceTypeAnnotationKey holds the acmeIssuerHTTP01ServiceTypeAnnotation value
+	// which can be used to override the http01 ingress serviceType if the challenge type is set to http01
+	IngressACMEIssuerHTTP01ServiceTypeAnnotationKey = "acme.cert-manager.io/http01-ingress-service-type"
+	// IngressACMEIssuerHTTP01IngressAnnotationsAnnotationKey holds a JSON object of annotations which
+	// are merged into the http01 ingressTemplate annotations if the challenge type is set to http01.
+	// Only the annotations allowed by the issuer's acme.cert-manager.io/http01-allowed-ingress-annotation-overrides
+	// annotation may be overridden.
+	IngressACMEIssuerHTTP01IngressAnnotationsAnnotationKey = "acme.cert-manager.io/http01-ingress-annotations"
+	// IngressACMEIssuerHTTP01IngressLabelsAnnotationKey holds a JSON object of labels which
+	// are merged into the http01 ingressTemplate labels if the challenge type is set to http01
+	IngressACMEIssuerHTTP01IngressLabelsAnnotationKey = "acme.cert-manager.io/http01-ingress-labels"
 
 	// IngressClassAnnotationKey picks a specific "class" for the Ingress. The
 	// controller only processes Ingresses with this annotation either unset, or
@@ -140,6 +197,20 @@ const (
 	IngressClassAnnotationKey = "kubernetes.io/ingress.class"
 )
 
+const (
+	// TLSRouteSecretNameAnnotationKey can be set on a TLSRoute to choose the
+	// name of the Certificates, and of their Secrets, created in the namespaces
+	// of the TLSRoute's backends. Defaults to "<TLSRoute name>-tls".
+	TLSRouteSecretNameAnnotationKey = "cert-manager.io/tlsroute-secret-name"
+
+	// TLSRouteNameLabelKey and TLSRouteNamespaceLabelKey are added to the
+	// Certificates created for a TLSRoute, and hold the name and namespace of
+	// that TLSRoute. They are used instead of owner references, since the
+	// Certificates may live in a different namespace than the TLSRoute.
+	TLSRouteNameLabelKey      = "cert-manager.io/tlsroute-name"
+	TLSRouteNamespaceLabelKey = "cert-manager.io/tlsroute-namespace"
+)
+
 // Annotation names for CertificateRequests
 const (
 	// Annotation added to CertificateRequest resources to denote the name of
@@ -153,6 +224,31 @@ const (
 	CertificateRequestRevisionAnnotationKey = "cert-manager.io/certificate-revision"
 )
 
+// Annotation names for Kubernetes CertificateSigningRequests. These replace
+// the experimental.cert-manager.io annotations, which are still honoured when
+// the equivalent annotation below is not set.
+const (
+	// CertificateSigningRequestDurationAnnotationKey is the annotation key
+	// used to request a particular duration represented as a Go Duration.
+	CertificateSigningRequestDurationAnnotationKey = "cert-manager.io/request-duration"
+
+	// CertificateSigningRequestIsCAAnnotationKey is the annotation key used to
+	// request whether the certificate should be marked as CA.
+	CertificateSigningRequestIsCAAnnotationKey = "cert-manager.io/request-is-ca"
+
+	// CertificateSigningRequestPrivateKeyAnnotationKey is the annotation key
+	// used to reference a Secret resource containing the private key used to
+	// sign the request. It is used by the 'self signing' issuer type to
+	// self-sign certificates.
+	CertificateSigningRequestPrivateKeyAnnotationKey = CertificateRequestPrivateKeyAnnotationKey
+
+	// CertificateSigningRequestMinimumDuration is the minimum allowed duration
+	// that can be requested for a CertificateSigningRequest. This has to be
+	// the same as the minimum allowed value for spec.expirationSeconds of a
+	// CertificateSigningRequest.
+	CertificateSigningRequestMinimumDuration = time.Second * 600
+)
+
 const (
 	// IssueTemporaryCertificateAnnotation is an annotation that can be added to
 	// Certificate resources.
@@ -168,6 +264,7 @@ const (
 	IssuerKind             = "Issuer"
 	CertificateKind        = "Certificate"
 	CertificateRequestKind = "CertificateRequest"
+	SSHCertificateKind     = "SSHCertificate"
 )
 
 const (
@@ -207,6 +304,15 @@ const (
 	// Venafi Pickup ID of a certificate signing request that has been submitted
 	// to the Venafi API for collection later.
 	VenafiPickupIDAnnotationKey = "venafi.cert-manager.io/pickup-id"
+
+	// VenafiRetiredAnnotationKey is added to CertificateRequests whose
+	// certificate has been retired in the Venafi inventory.
+	VenafiRetiredAnnotationKey = "venafi.cert-manager.io/retired"
+
+	// VenafiRetirementFinalizer is added to Certificates using a Venafi issuer
+	// when certificate retirement is enabled, so that the certificates can be
+	// retired in the Venafi inventory before the Certificate is deleted.
+	VenafiRetirementFinalizer = "venafi.cert-manager.io/retirement"
 )
 
 // KeyUsage specifies valid usage contexts for keys.
@@ -282,6 +388,27 @@ const (
 	JKSTruststoreKey = "truststore.jks"
 )
 
+// SSHCertificate specific secret keys
+const (
+	// SSHPrivateKeySecretKey is the name of the data entry in the Secret
+	// resource used to store the private key generated for an
+	// SSHCertificate, in the OpenSSH private key format. It is the same key
+	// as used by Secrets of type kubernetes.io/ssh-auth.
+	SSHPrivateKeySecretKey = "ssh-privatekey"
+	// SSHPublicKeySecretKey is the name of the data entry in the Secret
+	// resource used to store the certified public key, in the OpenSSH
+	// authorized_keys format.
+	SSHPublicKeySecretKey = "ssh-publickey"
+	// SSHCertificateSecretKey is the name of the data entry in the Secret
+	// resource used to store the OpenSSH certificate, in the same format as
+	// the `-cert.pub` files written by ssh-keygen.
+	SSHCertificateSecretKey = "ssh-certificate"
+	// SSHCAPublicKeySecretKey is the name of the data entry in the Secret
+	// resource used to store the public key of the certificate authority,
+	// in the OpenSSH authorized_keys format.
+	SSHCAPublicKeySecretKey = "ssh-ca-publickey"
+)
+
 // DefaultKeyUsages contains the default list of key usages
 func DefaultKeyUsages() []KeyUsage {
 	// The serverAuth EKU is required as of Mac OS Catalina: https://support.apple.com/en-us/HT210176
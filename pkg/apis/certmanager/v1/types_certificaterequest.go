@@ -209,4 +209,11 @@ const (
 	// `False`, and cannot be modified once set. Cannot be set alongside
 	// `Approved`.
 	CertificateRequestConditionDenied CertificateRequestConditionType = "Denied"
+
+	// CertificateRequestConditionPrivateKeyRejected indicates that the issuer
+	// rejected the request because of its private key, for example because
+	// the key algorithm is not supported or the key is too weak. Requesting
+	// Certificates may retry with a private key using a different algorithm
+	// or size.
+	CertificateRequestConditionPrivateKeyRejected CertificateRequestConditionType = "PrivateKeyRejected"
 )
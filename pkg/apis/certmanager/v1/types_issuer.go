@@ -330,6 +330,102 @@ type IssuerStatus struct {
 	// server to issue certificates.
 	// +optional
 	ACME *cmacme.ACMEIssuerStatus `json:"acme,omitempty"`
+
+	// Venafi specific status options.
+	// This field should only be set if the Issuer is configured to use a
+	// Venafi TPP or Venafi Cloud instance to issue certificates.
+	// +optional
+	Venafi *VenafiIssuerStatus `json:"venafi,omitempty"`
+}
+
+// VenafiIssuerStatus contains Venafi specific status information about an
+// Issuer.
+type VenafiIssuerStatus struct {
+	// ZonePolicy is the policy of the configured Venafi zone, as last read
+	// from the Venafi server. It is used to validate Certificates and
+	// CertificateRequests before they are submitted to Venafi.
+	// +optional
+	ZonePolicy *VenafiZonePolicy `json:"zonePolicy,omitempty"`
+}
+
+// VenafiZonePolicy describes the restrictions a Venafi zone places on the
+// certificates it issues. Regular expressions use the RE2 syntax, and an
+// empty list means that the field is not restricted by the policy.
+type VenafiZonePolicy struct {
+	// Zone is the Venafi zone the policy was read from.
+	Zone string `json:"zone"`
+
+	// LastSyncTime is the time at which the policy was last read from the
+	// Venafi server.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// CommonNames is the list of regular expressions the common name must
+	// match.
+	// +optional
+	CommonNames []string `json:"commonNames,omitempty"`
+
+	// DNSNames is the list of regular expressions every DNS name must match.
+	// +optional
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// IPAddresses is the list of regular expressions every IP address must
+	// match.
+	// +optional
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+
+	// EmailAddresses is the list of regular expressions every email address
+	// must match.
+	// +optional
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// URIs is the list of regular expressions every URI must match.
+	// +optional
+	URIs []string `json:"uris,omitempty"`
+
+	// Organizations is the list of regular expressions every organization
+	// must match.
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+
+	// OrganizationalUnits is the list of regular expressions every
+	// organizational unit must match.
+	// +optional
+	OrganizationalUnits []string `json:"organizationalUnits,omitempty"`
+
+	// Countries is the list of regular expressions every country must match.
+	// +optional
+	Countries []string `json:"countries,omitempty"`
+
+	// Provinces is the list of regular expressions every province must match.
+	// +optional
+	Provinces []string `json:"provinces,omitempty"`
+
+	// Localities is the list of regular expressions every locality must
+	// match.
+	// +optional
+	Localities []string `json:"localities,omitempty"`
+
+	// AllowedKeys is the list of private key algorithms and sizes allowed by
+	// the zone.
+	// +optional
+	AllowedKeys []VenafiAllowedKey `json:"allowedKeys,omitempty"`
+
+	// AllowWildcards is true if the zone allows wildcard DNS names.
+	// +optional
+	AllowWildcards bool `json:"allowWildcards,omitempty"`
+}
+
+// VenafiAllowedKey is a private key algorithm, and the sizes of that
+// algorithm, allowed by a Venafi zone.
+type VenafiAllowedKey struct {
+	// Algorithm is the private key algorithm.
+	Algorithm PrivateKeyAlgorithm `json:"algorithm"`
+
+	// Sizes is the list of allowed key sizes in bits. For ECDSA keys this is
+	// the size of the curve, for example 256 for P-256.
+	// +optional
+	Sizes []int `json:"sizes,omitempty"`
 }
 
 // IssuerCondition contains condition information for an Issuer.
@@ -0,0 +1,228 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
+
+// An SSHCertificate resource should be created to ensure an up to date OpenSSH
+// certificate, signed by the SSH capable CA key of a CA issuer, is stored in
+// the Kubernetes Secret resource named in `spec.secretName`.
+//
+// The stored certificate will be renewed before it expires (as configured by
+// `spec.renewBefore`).
+// +k8s:openapi-gen=true
+type SSHCertificate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Desired state of the SSHCertificate resource.
+	Spec SSHCertificateSpec `json:"spec"`
+
+	// Status of the SSHCertificate. This is set and managed automatically.
+	// +optional
+	Status SSHCertificateStatus `json:"status"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SSHCertificateList is a list of SSHCertificates
+type SSHCertificateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SSHCertificate `json:"items"`
+}
+
+// +kubebuilder:validation:Enum=User;Host
+type SSHCertificateType string
+
+const (
+	// SSHUserCertificate denotes a certificate authenticating a user to a
+	// host.
+	SSHUserCertificate SSHCertificateType = "User"
+
+	// SSHHostCertificate denotes a certificate authenticating a host to a
+	// user.
+	SSHHostCertificate SSHCertificateType = "Host"
+)
+
+// SSHCertificateSpec defines the desired state of SSHCertificate.
+type SSHCertificateSpec struct {
+	// Type of the certificate, either `User` or `Host`.
+	// If unset, this defaults to `User`.
+	// +optional
+	Type SSHCertificateType `json:"type,omitempty"`
+
+	// KeyID is the key identifier of the certificate, which is logged by the
+	// SSH server when the certificate is used.
+	// If unset, this defaults to `<namespace>/<name>` of the SSHCertificate.
+	// +optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// PublicKey is the public key to be certified, in the OpenSSH
+	// authorized_keys format, for example `ssh-ed25519 AAAA...`.
+	// If unset, a private key is generated as configured by
+	// `spec.privateKey` and stored in the Secret along with the certificate.
+	// +optional
+	PublicKey string `json:"publicKey,omitempty"`
+
+	// Options to control the private key generated when `spec.publicKey` is
+	// not set.
+	// +optional
+	PrivateKey *SSHCertificatePrivateKey `json:"privateKey,omitempty"`
+
+	// SecretName is the name of the Secret resource that will be
+	// automatically created and managed by this SSHCertificate resource. It
+	// will be populated with the OpenSSH certificate, the certified public
+	// key, the public key of the CA and any generated private key.
+	SecretName string `json:"secretName"`
+
+	// Principals are the user names (for user certificates) or host names
+	// (for host certificates) the certificate is valid for.
+	Principals []string `json:"principals"`
+
+	// The requested validity duration of the certificate. The certificate is
+	// valid from one minute before it is signed, to allow for clock skew.
+	// If unset, this defaults to 24 hours.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// How long before the certificate expiry cert-manager should renew the
+	// certificate. If unset, this defaults to 1/3 of the certificate duration.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+
+	// CriticalOptions are the critical options of the certificate, such as
+	// `force-command` or `source-address`. Critical options are only
+	// supported for user certificates.
+	// +optional
+	CriticalOptions map[string]string `json:"criticalOptions,omitempty"`
+
+	// Extensions are the extensions of the certificate, such as
+	// `permit-pty`. Extensions are only supported for user certificates.
+	// If unset, user certificates are given the default extensions of
+	// `ssh-keygen`: `permit-X11-forwarding`, `permit-agent-forwarding`,
+	// `permit-port-forwarding`, `permit-pty` and `permit-user-rc`.
+	// +optional
+	Extensions map[string]string `json:"extensions,omitempty"`
+
+	// IssuerRef is a reference to the issuer for this certificate.
+	// Only CA issuers are supported. The private key stored in the CA Secret
+	// of the issuer is used as the SSH certificate authority, and must be an
+	// RSA, ECDSA (P-256, P-384 or P-521) or Ed25519 key.
+	// SSH certificates are signed without a CertificateRequest and are not
+	// subject to approval, so the issuer must opt in by setting the
+	// `cert-manager.io/allow-ssh-certificates` annotation to "true". The
+	// principals that can be certified may be restricted with the
+	// `cert-manager.io/ssh-allowed-principals` annotation of the issuer.
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+}
+
+// SSHCertificatePrivateKey contains configuration options for the private
+// keys generated for SSHCertificates.
+type SSHCertificatePrivateKey struct {
+	// Algorithm is the private key algorithm of the generated key.
+	// If unset, this defaults to `Ed25519`.
+	// +optional
+	Algorithm PrivateKeyAlgorithm `json:"algorithm,omitempty"`
+
+	// Size is the key bit size of the generated key.
+	// If `algorithm` is `RSA`, valid values are `2048`, `4096` or `8192`,
+	// and defaults to `2048` if unset.
+	// If `algorithm` is `ECDSA`, valid values are `256`, `384` or `521`,
+	// and defaults to `256` if unset.
+	// This field is ignored if `algorithm` is `Ed25519`.
+	// +optional
+	Size int `json:"size,omitempty"`
+}
+
+// SSHCertificateStatus defines the observed state of SSHCertificate
+type SSHCertificateStatus struct {
+	// List of status conditions to indicate the status of the
+	// SSHCertificate. Known condition types are `Ready`.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []SSHCertificateCondition `json:"conditions,omitempty"`
+
+	// The serial number of the certificate stored in the Secret.
+	// +optional
+	SerialNumber string `json:"serialNumber,omitempty"`
+
+	// The time before which the certificate stored in the Secret is not
+	// valid.
+	// +optional
+	NotBefore *metav1.Time `json:"notBefore,omitempty"`
+
+	// The expiration time of the certificate stored in the Secret.
+	// +optional
+	NotAfter *metav1.Time `json:"notAfter,omitempty"`
+
+	// RenewalTime is the time at which the certificate will be next renewed.
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+}
+
+// SSHCertificateCondition contains condition information for an
+// SSHCertificate.
+type SSHCertificateCondition struct {
+	// Type of the condition, known values are (`Ready`).
+	Type SSHCertificateConditionType `json:"type"`
+
+	// Status of the condition, one of (`True`, `False`, `Unknown`).
+	Status cmmeta.ConditionStatus `json:"status"`
+
+	// LastTransitionTime is the timestamp corresponding to the last status
+	// change of this condition.
+	// +optional
+	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
+
+	// Reason is a brief machine readable explanation for the condition's last
+	// transition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a human readable description of the details of the last
+	// transition, complementing reason.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// If set, this represents the .metadata.generation that the condition was
+	// set based upon.
+	// For instance, if .metadata.generation is currently 12, but the
+	// .status.condition[x].observedGeneration is 9, the condition is out of date
+	// with respect to the current state of the SSHCertificate.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// SSHCertificateConditionType represents an SSHCertificate condition value.
+type SSHCertificateConditionType string
+
+const (
+	// SSHCertificateConditionReady indicates that a valid certificate,
+	// matching the spec of the SSHCertificate, is stored in the Secret.
+	SSHCertificateConditionReady SSHCertificateConditionType = "Ready"
+)
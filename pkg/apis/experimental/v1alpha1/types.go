@@ -23,10 +23,12 @@ const (
 	// CertificateSigningRequestDurationAnnotationKey is the
 	// annotation key used to request a particular duration
 	// represented as a Go Duration.
+	// Deprecated: use cert-manager.io/request-duration instead.
 	CertificateSigningRequestDurationAnnotationKey = "experimental.cert-manager.io/request-duration"
 
 	// CertificateSigningRequestIsCAAnnotationKey is the annotation key used to
 	// request whether the certificate should be marked as CA.
+	// Deprecated: use cert-manager.io/request-is-ca instead.
 	CertificateSigningRequestIsCAAnnotationKey = "experimental.cert-manager.io/request-is-ca"
 
 	// CertificateSigningRequestMinimumDuration is the minimum allowed
@@ -44,6 +46,7 @@ const (
 	// sign the request.
 	// This annotation *may* not be present, and is used by the 'self signing'
 	// issuer type to self-sign certificates.
+	// Deprecated: use cert-manager.io/private-key-secret-name instead.
 	CertificateSigningRequestPrivateKeyAnnotationKey = "experimental.cert-manager.io/private-key-secret-name"
 )
 
@@ -54,10 +57,12 @@ const (
 	// This will only work with Venafi TPP v19.3 and higher.
 	// The value is an array with objects containing the name and value keys for
 	// example: `[{"name": "custom-field", "value": "custom-value"}]`
+	// Deprecated: use venafi.cert-manager.io/custom-fields instead.
 	CertificateSigningRequestVenafiCustomFieldsAnnotationKey = "venafi.experimental.cert-manager.io/custom-fields"
 
 	// CertificateSigningRequestVenafiPickupIDAnnotationKey is the annotation key
 	// used to record the Venafi Pickup ID of a certificate signing request that
 	// has been submitted to the Venafi API for collection later.
+	// Deprecated: use venafi.cert-manager.io/pickup-id instead.
 	CertificateSigningRequestVenafiPickupIDAnnotationKey = "venafi.experimental.cert-manager.io/pickup-id"
 )
@@ -32,6 +32,7 @@ type CertmanagerV1Interface interface {
 	CertificateRequestsGetter
 	ClusterIssuersGetter
 	IssuersGetter
+	SSHCertificatesGetter
 }
 
 // CertmanagerV1Client is used to interact with features provided by the cert-manager.io group.
@@ -55,6 +56,10 @@ func (c *CertmanagerV1Client) Issuers(namespace string) IssuerInterface {
 	return newIssuers(c, namespace)
 }
 
+func (c *CertmanagerV1Client) SSHCertificates(namespace string) SSHCertificateInterface {
+	return newSSHCertificates(c, namespace)
+}
+
 // NewForConfig creates a new CertmanagerV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
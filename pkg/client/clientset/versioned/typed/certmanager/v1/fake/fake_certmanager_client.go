@@ -44,6 +44,10 @@ func (c *FakeCertmanagerV1) Issuers(namespace string) v1.IssuerInterface {
 	return &FakeIssuers{c, namespace}
 }
 
+func (c *FakeCertmanagerV1) SSHCertificates(namespace string) v1.SSHCertificateInterface {
+	return &FakeSSHCertificates{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeCertmanagerV1) RESTClient() rest.Interface {
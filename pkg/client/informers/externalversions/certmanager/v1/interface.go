@@ -32,6 +32,8 @@ type Interface interface {
 	ClusterIssuers() ClusterIssuerInformer
 	// Issuers returns a IssuerInformer.
 	Issuers() IssuerInformer
+	// SSHCertificates returns a SSHCertificateInformer.
+	SSHCertificates() SSHCertificateInformer
 }
 
 type version struct {
@@ -64,3 +66,8 @@ func (v *version) ClusterIssuers() ClusterIssuerInformer {
 func (v *version) Issuers() IssuerInformer {
 	return &issuerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// SSHCertificates returns a SSHCertificateInformer.
+func (v *version) SSHCertificates() SSHCertificateInformer {
+	return &sSHCertificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -68,6 +68,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().ClusterIssuers().Informer()}, nil
 	case certmanagerv1.SchemeGroupVersion.WithResource("issuers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().Issuers().Informer()}, nil
+	case certmanagerv1.SchemeGroupVersion.WithResource("sshcertificates"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Certmanager().V1().SSHCertificates().Informer()}, nil
 
 	}
 
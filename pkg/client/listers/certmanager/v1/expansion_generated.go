@@ -45,3 +45,11 @@ type IssuerListerExpansion interface{}
 // IssuerNamespaceListerExpansion allows custom methods to be added to
 // IssuerNamespaceLister.
 type IssuerNamespaceListerExpansion interface{}
+
+// SSHCertificateListerExpansion allows custom methods to be added to
+// SSHCertificateLister.
+type SSHCertificateListerExpansion interface{}
+
+// SSHCertificateNamespaceListerExpansion allows custom methods to be added to
+// SSHCertificateNamespaceLister.
+type SSHCertificateNamespaceListerExpansion interface{}
@@ -23,6 +23,7 @@ import (
 	"github.com/go-logr/logr"
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
@@ -56,12 +57,19 @@ type controller struct {
 	clusterIssuerLister cmlisters.ClusterIssuerLister
 	secretLister        corelisters.SecretLister
 
+	// used to look up the requester of Orders created for
+	// CertificateRequests
+	certificateRequestLister cmlisters.CertificateRequestLister
+
 	// used for testing
 	clock clock.Clock
 	// used to record Events about resources to the API
 	recorder record.EventRecorder
 	// clientset used to update cert-manager API resources
 	cmClient cmclient.Interface
+	// clientset used to look up the requester of Orders created for
+	// CertificateSigningRequests
+	kubeClient kubernetes.Interface
 
 	// fieldManager is the manager name used for the Apply operations on Secrets.
 	fieldManager string
@@ -80,6 +88,7 @@ type controller struct {
 // NewController constructs an orders controller using the provided options.
 func NewController(
 	log logr.Logger,
+	kubeClient kubernetes.Interface,
 	cmClient cmclient.Interface,
 	kubeInformerFactory informers.SharedInformerFactory,
 	cmInformerFactory cminformers.SharedInformerFactory,
@@ -104,6 +113,7 @@ func NewController(
 	issuerInformer := cmInformerFactory.Certmanager().V1().Issuers()
 	challengeInformer := cmInformerFactory.Acme().V1().Challenges()
 	secretInformer := kubeInformerFactory.Core().V1().Secrets()
+	certificateRequestInformer := cmInformerFactory.Certmanager().V1().CertificateRequests()
 
 	// Build a list of InformerSynced functions. The controller will only begin
 	// processing items once all of these informers have synced.
@@ -112,6 +122,7 @@ func NewController(
 		issuerInformer.Informer().HasSynced,
 		challengeInformer.Informer().HasSynced,
 		secretInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
 	}
 
 	// Build all the listers.
@@ -119,6 +130,7 @@ func NewController(
 	issuerLister := issuerInformer.Lister()
 	challengeLister := challengeInformer.Lister()
 	secretLister := secretInformer.Lister()
+	certificateRequestLister := certificateRequestInformer.Lister()
 
 	// If we are running in non-namespaced mode, we also
 	// register event handlers and obtain a lister for ClusterIssuers.
@@ -145,19 +157,21 @@ func NewController(
 	})
 
 	return &controller{
-		clock:               clock,
-		queue:               queue,
-		scheduledWorkQueue:  scheduledWorkQueue,
-		orderLister:         orderLister,
-		issuerLister:        issuerLister,
-		challengeLister:     challengeLister,
-		secretLister:        secretLister,
-		clusterIssuerLister: clusterIssuerLister,
-		helper:              issuer.NewHelper(issuerLister, clusterIssuerLister),
-		recorder:            recorder,
-		cmClient:            cmClient,
-		accountRegistry:     accountRegistry,
-		fieldManager:        fieldManager,
+		clock:                    clock,
+		queue:                    queue,
+		scheduledWorkQueue:       scheduledWorkQueue,
+		orderLister:              orderLister,
+		issuerLister:             issuerLister,
+		challengeLister:          challengeLister,
+		secretLister:             secretLister,
+		clusterIssuerLister:      clusterIssuerLister,
+		certificateRequestLister: certificateRequestLister,
+		helper:                   issuer.NewHelper(issuerLister, clusterIssuerLister),
+		recorder:                 recorder,
+		cmClient:                 cmClient,
+		kubeClient:               kubeClient,
+		accountRegistry:          accountRegistry,
+		fieldManager:             fieldManager,
 	}, queue, mustSync
 
 }
@@ -215,6 +229,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 
 	ctrl, queue, mustSync := NewController(
 		log,
+		ctx.Client,
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
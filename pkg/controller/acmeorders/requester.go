@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// requesterForOrder returns the user that requested the certificate of the
+// given Order, taken from the spec of the CertificateRequest or
+// CertificateSigningRequest which owns the Order. The spec is set by the API
+// server and cannot be changed, unlike the annotations of the Order.
+// The requester is only looked up if a solver of the issuer selects by
+// requester. nil is returned if the Order has no such owner, or if the owner
+// isn't the request the Order was created for.
+func (c *controller) requesterForOrder(ctx context.Context, issuer cmapi.GenericIssuer, o *cmacme.Order) (user.Info, error) {
+	if !selectsByRequester(issuer) {
+		return nil, nil
+	}
+
+	log := logf.FromContext(ctx)
+
+	ref := metav1.GetControllerOf(o)
+	if ref == nil {
+		log.V(logf.DebugLevel).Info("Order has no owner, so no requester")
+		return nil, nil
+	}
+
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		log.V(logf.DebugLevel).Info("Order has an owner with an invalid apiVersion, so no requester", "owner_api_version", ref.APIVersion)
+		return nil, nil
+	}
+
+	var (
+		ownerUID  types.UID
+		request   []byte
+		requester user.Info
+	)
+	switch {
+	case gv.Group == cmapi.SchemeGroupVersion.Group && ref.Kind == cmapi.CertificateRequestKind:
+		cr, err := c.certificateRequestLister.CertificateRequests(o.Namespace).Get(ref.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CertificateRequest %s/%s which owns the Order: %w", o.Namespace, ref.Name, err)
+		}
+		ownerUID = cr.UID
+		request = cr.Spec.Request
+		requester = &user.DefaultInfo{Name: cr.Spec.Username, Groups: cr.Spec.Groups}
+
+	case gv.Group == certificatesv1.GroupName && ref.Kind == "CertificateSigningRequest":
+		// Orders are only created for CertificateSigningRequests if the
+		// experimental CertificateSigningRequest controllers are enabled, so
+		// they are read from the API server rather than through an informer.
+		csr, err := c.kubeClient.CertificatesV1().CertificateSigningRequests().Get(ctx, ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get CertificateSigningRequest %s which owns the Order: %w", ref.Name, err)
+		}
+		ownerUID = csr.UID
+		request = csr.Spec.Request
+		requester = &user.DefaultInfo{Name: csr.Spec.Username, Groups: csr.Spec.Groups}
+
+	default:
+		log.V(logf.DebugLevel).Info("Order is not owned by a CertificateRequest or CertificateSigningRequest, so no requester", "owner_kind", ref.Kind)
+		return nil, nil
+	}
+
+	// Guard against Orders which reference a request of another user as
+	// their owner, since owner references can be set by anyone who can
+	// create Orders.
+	if ownerUID != ref.UID || !bytes.Equal(request, o.Spec.Request) {
+		log.V(logf.WarnLevel).Info("Order was not created for the request which owns it, so no requester", "owner_kind", ref.Kind, "owner_name", ref.Name)
+		return nil, nil
+	}
+
+	return requester, nil
+}
+
+// selectsByRequester returns true if any solver of the given ACME issuer
+// selects by the requester of the certificate.
+func selectsByRequester(issuer cmapi.GenericIssuer) bool {
+	for _, solver := range issuer.GetSpec().ACME.Solvers {
+		if solver.Selector != nil && solver.Selector.MatchRequester != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acmeorders
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/user"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestRequesterForOrder(t *testing.T) {
+	issuer := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Solvers: []cmacme.ACMEChallengeSolver{
+			{
+				Selector: &cmacme.CertificateDNSNameSelector{
+					MatchRequester: &cmacme.CertificateRequesterSelector{Groups: []string{"system:nodes"}},
+				},
+				DNS01: &cmacme.ACMEChallengeSolverDNS01{},
+			},
+		},
+	}))
+	issuerWithoutRequesterSelector := gen.Issuer("testissuer", gen.SetIssuerACME(cmacme.ACMEIssuer{
+		Solvers: []cmacme.ACMEChallengeSolver{{DNS01: &cmacme.ACMEChallengeSolverDNS01{}}},
+	}))
+
+	cr := gen.CertificateRequest("test-cr",
+		gen.SetCertificateRequestNamespace("default"),
+		gen.SetCertificateRequestCSR([]byte("csr")),
+		gen.SetCertificateRequestUsername("system:node:node-1"),
+		gen.SetCertificateRequestGroups([]string{"system:nodes"}),
+	)
+	cr.UID = "cr-uid"
+	csr := gen.CertificateSigningRequest("test-csr",
+		gen.SetCertificateSigningRequestRequest([]byte("csr")),
+		gen.SetCertificateSigningRequestUsername("system:node:node-2"),
+		gen.SetCertificateSigningRequestGroups([]string{"system:nodes"}),
+	)
+	csr.UID = "csr-uid"
+
+	crOwner := *metav1.NewControllerRef(cr, cmapi.SchemeGroupVersion.WithKind(cmapi.CertificateRequestKind))
+	csrOwner := *metav1.NewControllerRef(csr, certificatesv1.SchemeGroupVersion.WithKind("CertificateSigningRequest"))
+	otherUIDOwner := *crOwner.DeepCopy()
+	otherUIDOwner.UID = "other-uid"
+	missingOwner := *crOwner.DeepCopy()
+	missingOwner.Name = "missing"
+
+	tests := map[string]struct {
+		issuer cmapi.GenericIssuer
+		order  *cmacme.Order
+
+		expRequester user.Info
+		expErr       bool
+	}{
+		"the requester is not looked up if no solver selects by requester": {
+			issuer: issuerWithoutRequesterSelector,
+			order:  gen.Order("test", gen.SetOrderNamespace("default"), gen.SetOrderCsr([]byte("csr")), gen.SetOrderOwnerReference(missingOwner)),
+		},
+		"the requester is taken from the owning CertificateRequest": {
+			issuer:       issuer,
+			order:        gen.Order("test", gen.SetOrderNamespace("default"), gen.SetOrderCsr([]byte("csr")), gen.SetOrderOwnerReference(crOwner)),
+			expRequester: &user.DefaultInfo{Name: "system:node:node-1", Groups: []string{"system:nodes"}},
+		},
+		"the requester is taken from the owning CertificateSigningRequest": {
+			issuer:       issuer,
+			order:        gen.Order("test", gen.SetOrderNamespace("default"), gen.SetOrderCsr([]byte("csr")), gen.SetOrderOwnerReference(csrOwner)),
+			expRequester: &user.DefaultInfo{Name: "system:node:node-2", Groups: []string{"system:nodes"}},
+		},
+		"an Order without an owner has no requester": {
+			issuer: issuer,
+			order:  gen.Order("test", gen.SetOrderNamespace("default"), gen.SetOrderCsr([]byte("csr"))),
+		},
+		"an Order owned by a request with a different UID has no requester": {
+			issuer: issuer,
+			order:  gen.Order("test", gen.SetOrderNamespace("default"), gen.SetOrderCsr([]byte("csr")), gen.SetOrderOwnerReference(otherUIDOwner)),
+		},
+		"an Order for a different CSR than its owner has no requester": {
+			issuer: issuer,
+			order:  gen.Order("test", gen.SetOrderNamespace("default"), gen.SetOrderCsr([]byte("other-csr")), gen.SetOrderOwnerReference(crOwner)),
+		},
+		"an error is returned if the owning CertificateRequest does not exist": {
+			issuer: issuer,
+			order:  gen.Order("test", gen.SetOrderNamespace("default"), gen.SetOrderCsr([]byte("csr")), gen.SetOrderOwnerReference(missingOwner)),
+			expErr: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			assert.NoError(t, indexer.Add(cr))
+
+			c := &controller{
+				certificateRequestLister: cmlisters.NewCertificateRequestLister(indexer),
+				kubeClient:               kubefake.NewSimpleClientset([]runtime.Object{csr}...),
+			}
+
+			requester, err := c.requesterForOrder(context.Background(), test.issuer, test.order)
+			if test.expErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expRequester, requester)
+		})
+	}
+}
@@ -20,11 +20,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 func DNSNames(sel cmacme.CertificateDNSNameSelector) Selector {
 	return &dnsNamesSelector{
-		allowedDNSNames: sel.DNSNames,
+		allowedDNSNames: pki.DNSNamesToASCIIOrUnchanged(sel.DNSNames),
 	}
 }
 
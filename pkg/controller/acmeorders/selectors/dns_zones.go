@@ -21,11 +21,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 func DNSZones(sel cmacme.CertificateDNSNameSelector) Selector {
 	return &dnsZonesSelector{
-		allowedDNSZones: sel.DNSZones,
+		allowedDNSZones: pki.DNSNamesToASCIIOrUnchanged(sel.DNSZones),
 	}
 }
 
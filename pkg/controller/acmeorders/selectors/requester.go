@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectors
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+// Requester returns a selector which matches the given requester of an
+// Order against the matchRequester selector. The requester must be taken
+// from the spec of the CertificateRequest or CertificateSigningRequest the
+// Order was created for, which is set by the API server and cannot be
+// changed, rather than from the Order itself. A nil requester only matches
+// selectors without a matchRequester criterion.
+func Requester(sel cmacme.CertificateDNSNameSelector, requester user.Info) Selector {
+	s := &requesterSelector{requester: requester}
+	if sel.MatchRequester != nil {
+		s.allowedUsernames = sel.MatchRequester.Usernames
+		s.allowedGroups = sel.MatchRequester.Groups
+	}
+	return s
+}
+
+type requesterSelector struct {
+	requester        user.Info
+	allowedUsernames []string
+	allowedGroups    []string
+}
+
+func (s *requesterSelector) Matches(meta metav1.ObjectMeta, dnsName string) (bool, int) {
+	if len(s.allowedUsernames) == 0 && len(s.allowedGroups) == 0 {
+		return true, 0
+	}
+
+	if s.requester == nil {
+		return false, 0
+	}
+
+	matches := 0
+	if len(s.allowedUsernames) > 0 {
+		username := s.requester.GetName()
+		if len(username) == 0 || !contains(s.allowedUsernames, username) {
+			return false, 0
+		}
+		matches++
+	}
+
+	if len(s.allowedGroups) > 0 {
+		found := false
+		for _, group := range s.requester.GetGroups() {
+			if contains(s.allowedGroups, group) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, 0
+		}
+		matches++
+	}
+
+	return true, matches
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
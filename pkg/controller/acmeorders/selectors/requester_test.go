@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selectors
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestRequester(t *testing.T) {
+	node := &user.DefaultInfo{
+		Name:   "system:node:node-1",
+		Groups: []string{"system:nodes", "system:authenticated"},
+	}
+
+	tests := []struct {
+		name      string
+		selector  cmacme.CertificateDNSNameSelector
+		requester user.Info
+		matches   bool
+		score     int
+	}{
+		{
+			name:     "matching with an empty selector",
+			selector: cmacme.CertificateDNSNameSelector{},
+			matches:  true,
+			score:    0,
+		},
+		{
+			name: "matching a requester username",
+			selector: cmacme.CertificateDNSNameSelector{
+				MatchRequester: &cmacme.CertificateRequesterSelector{
+					Usernames: []string{"system:node:node-1"},
+				},
+			},
+			requester: node,
+			matches:   true,
+			score:     1,
+		},
+		{
+			name: "matching both a requester username and group",
+			selector: cmacme.CertificateDNSNameSelector{
+				MatchRequester: &cmacme.CertificateRequesterSelector{
+					Usernames: []string{"system:node:node-1"},
+					Groups:    []string{"system:masters", "system:nodes"},
+				},
+			},
+			requester: node,
+			matches:   true,
+			score:     2,
+		},
+		{
+			name: "not matching a requester in none of the groups",
+			selector: cmacme.CertificateDNSNameSelector{
+				MatchRequester: &cmacme.CertificateRequesterSelector{
+					Groups: []string{"system:masters"},
+				},
+			},
+			requester: node,
+			matches:   false,
+			score:     0,
+		},
+		{
+			name: "not matching a group which is a substring of a requester group",
+			selector: cmacme.CertificateDNSNameSelector{
+				MatchRequester: &cmacme.CertificateRequesterSelector{
+					Groups: []string{"system:masters"},
+				},
+			},
+			requester: &user.DefaultInfo{Name: "mallory", Groups: []string{"evil,system:masters"}},
+			matches:   false,
+			score:     0,
+		},
+		{
+			name: "not matching an order without a requester",
+			selector: cmacme.CertificateDNSNameSelector{
+				MatchRequester: &cmacme.CertificateRequesterSelector{
+					Usernames: []string{"system:node:node-1"},
+				},
+			},
+			matches: false,
+			score:   0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testSelector(t, Requester(test.selector, test.requester), metav1.ObjectMeta{}, "node-1.example.com", test.matches, test.score)
+		})
+	}
+}
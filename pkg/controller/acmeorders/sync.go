@@ -120,8 +120,13 @@ func (c *controller) Sync(ctx context.Context, o *cmacme.Order) (err error) {
 		return c.deleteAllChallenges(ctx, o)
 	}
 
+	requester, err := c.requesterForOrder(ctx, genericIssuer, o)
+	if err != nil {
+		return err
+	}
+
 	dbg.Info("Computing list of Challenge resources that need to exist to complete this Order")
-	requiredChallenges, err := buildRequiredChallenges(ctx, cl, genericIssuer, o)
+	requiredChallenges, err := buildRequiredChallenges(ctx, cl, genericIssuer, o, requester)
 	if err != nil {
 		log.Error(err, "Failed to determine the list of Challenge resources needed for the Order")
 		c.recorder.Eventf(o, corev1.EventTypeWarning, reasonSolver, "Failed to determine a valid solver configuration for the set of domains on the Order: %v", err)
@@ -401,7 +406,7 @@ func (c *controller) anyRequiredChallengesDoNotExist(requiredChallenges []cmacme
 
 func (c *controller) createRequiredChallenges(ctx context.Context, o *cmacme.Order, requiredChallenges []cmacme.Challenge) error {
 	for _, ch := range requiredChallenges {
-		_, err := c.cmClient.AcmeV1().Challenges(ch.Namespace).Create(ctx, &ch, metav1.CreateOptions{})
+		_, err := c.cmClient.AcmeV1().Challenges(ch.Namespace).Create(ctx, &ch, metav1.CreateOptions{FieldManager: c.fieldManager})
 		if apierrors.IsAlreadyExists(err) {
 			continue
 		}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
 
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
@@ -28,6 +29,7 @@ import (
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/acmeorders/selectors"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/http"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -35,7 +37,7 @@ var (
 	orderGvk = cmacme.SchemeGroupVersion.WithKind("Order")
 )
 
-func buildRequiredChallenges(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, o *cmacme.Order) ([]cmacme.Challenge, error) {
+func buildRequiredChallenges(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, o *cmacme.Order, requester user.Info) ([]cmacme.Challenge, error) {
 	chs := make([]cmacme.Challenge, 0)
 	for _, a := range o.Status.Authorizations {
 		if a.InitialState == cmacme.Valid {
@@ -46,7 +48,7 @@ func buildRequiredChallenges(ctx context.Context, cl acmecl.Interface, issuer cm
 			logf.FromContext(ctx).V(logf.DebugLevel).Info("Authorization already valid, not creating Challenge resource", "identifier", a.Identifier, "is_wildcard", wc)
 			continue
 		}
-		ch, err := buildChallenge(ctx, cl, issuer, o, a)
+		ch, err := buildChallenge(ctx, cl, issuer, o, requester, a)
 		if err != nil {
 			return nil, err
 		}
@@ -55,8 +57,8 @@ func buildRequiredChallenges(ctx context.Context, cl acmecl.Interface, issuer cm
 	return chs, nil
 }
 
-func buildChallenge(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, o *cmacme.Order, authz cmacme.ACMEAuthorization) (*cmacme.Challenge, error) {
-	chSpec, err := challengeSpecForAuthorization(ctx, cl, issuer, o, authz)
+func buildChallenge(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, o *cmacme.Order, requester user.Info, authz cmacme.ACMEAuthorization) (*cmacme.Challenge, error) {
+	chSpec, err := challengeSpecForAuthorization(ctx, cl, issuer, o, requester, authz)
 	if err != nil {
 		// TODO: in this case, we should probably not return the error as it's
 		//  unlikely we can make it succeed by retrying.
@@ -78,7 +80,7 @@ func buildChallenge(ctx context.Context, cl acmecl.Interface, issuer cmapi.Gener
 	}, nil
 }
 
-func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, o *cmacme.Order, authz cmacme.ACMEAuthorization) (*cmacme.ChallengeSpec, error) {
+func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, issuer cmapi.GenericIssuer, o *cmacme.Order, requester user.Info, authz cmacme.ACMEAuthorization) (*cmacme.ChallengeSpec, error) {
 	log := logf.FromContext(ctx, "challengeSpecForAuthorization")
 	dbg := log.V(logf.DebugLevel)
 
@@ -134,12 +136,16 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 		labelsMatch, numLabelsMatch := selectors.Labels(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
 		dnsNamesMatch, numDNSNamesMatch := selectors.DNSNames(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
 		dnsZonesMatch, numDNSZonesMatch := selectors.DNSZones(*cfg.Selector).Matches(o.ObjectMeta, domainToFind)
+		requesterMatch, numRequesterMatch := selectors.Requester(*cfg.Selector, requester).Matches(o.ObjectMeta, domainToFind)
 
-		if !labelsMatch || !dnsNamesMatch || !dnsZonesMatch {
-			dbg.Info("not selecting solver", "labels_match", labelsMatch, "dnsnames_match", dnsNamesMatch, "dnszones_match", dnsZonesMatch)
+		if !labelsMatch || !dnsNamesMatch || !dnsZonesMatch || !requesterMatch {
+			dbg.Info("not selecting solver", "labels_match", labelsMatch, "dnsnames_match", dnsNamesMatch, "dnszones_match", dnsZonesMatch, "requester_match", requesterMatch)
 			continue
 		}
 
+		// requester matches carry the same weight as label matches
+		numLabelsMatch += numRequesterMatch
+
 		dbg.Info("selector matches")
 
 		selectSolver := func() {
@@ -263,9 +269,9 @@ func challengeSpecForAuthorization(ctx context.Context, cl acmecl.Interface, iss
 		return nil, err
 	}
 
-	// 4. handle overriding the HTTP01 ingress class and name fields using the
-	//    ACMECertificateHTTP01IngressNameOverride & Class annotations
-	if err := applyIngressParameterAnnotationOverrides(o, selectedSolver); err != nil {
+	// 4. handle overriding the HTTP01 ingress fields using the
+	//    ACMECertificateHTTP01Ingress*Override annotations
+	if err := applyIngressParameterAnnotationOverrides(issuer, o, selectedSolver); err != nil {
 		return nil, err
 	}
 
@@ -295,30 +301,11 @@ func challengeType(t string) (cmacme.ACMEChallengeType, error) {
 	}
 }
 
-func applyIngressParameterAnnotationOverrides(o *cmacme.Order, s *cmacme.ACMEChallengeSolver) error {
+func applyIngressParameterAnnotationOverrides(issuer cmapi.GenericIssuer, o *cmacme.Order, s *cmacme.ACMEChallengeSolver) error {
 	if s.HTTP01 == nil || s.HTTP01.Ingress == nil || o.Annotations == nil {
 		return nil
 	}
-
-	manualIngressName, hasManualIngressName := o.Annotations[cmacme.ACMECertificateHTTP01IngressNameOverride]
-	manualIngressClass, hasManualIngressClass := o.Annotations[cmacme.ACMECertificateHTTP01IngressClassOverride]
-	// don't allow both override annotations to be specified at once
-	if hasManualIngressName && hasManualIngressClass {
-		return fmt.Errorf("both ingress name and ingress class overrides specified - only one may be specified at a time")
-	}
-	// if an override annotation is specified, clear out the existing solver
-	// config
-	if hasManualIngressClass || hasManualIngressName {
-		s.HTTP01.Ingress.Class = nil
-		s.HTTP01.Ingress.Name = ""
-	}
-	if hasManualIngressName {
-		s.HTTP01.Ingress.Name = manualIngressName
-	}
-	if hasManualIngressClass {
-		s.HTTP01.Ingress.Class = &manualIngressClass
-	}
-	return nil
+	return http.ApplyIngressOverrides(s.HTTP01.Ingress, o.Annotations, http.AllowedIngressAnnotationOverrides(issuer.GetAnnotations()))
 }
 
 func keyForChallenge(cl acmecl.Interface, token string, chType cmacme.ACMEChallengeType) (string, error) {
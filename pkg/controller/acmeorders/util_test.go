@@ -22,7 +22,9 @@ import (
 	"testing"
 
 	"github.com/kr/pretty"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
 	"k8s.io/utils/pointer"
 
 	acmecl "github.com/cert-manager/cert-manager/pkg/acme/client"
@@ -93,6 +95,7 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 		acmeClient acmecl.Interface
 		issuer     v1.GenericIssuer
 		order      *cmacme.Order
+		requester  user.Info
 		authz      *cmacme.ACMEAuthorization
 
 		expectedChallengeSpec *cmacme.ChallengeSpec
@@ -176,6 +179,129 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				},
 			},
 		},
+		"should merge the ingress override annotations into the issuer's solver": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverHTTP01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmacme.ACMECertificateHTTP01IngressClassNameOverride:   "test-class-name-to-override",
+						cmacme.ACMECertificateHTTP01IngressServiceTypeOverride: "ClusterIP",
+						cmacme.ACMECertificateHTTP01IngressLabelsOverride:      `{"team":"a"}`,
+					},
+				},
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Key:     "http01",
+				Solver: cmacme.ACMEChallengeSolver{
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							IngressClassName: pointer.StringPtr("test-class-name-to-override"),
+							ServiceType:      corev1.ServiceTypeClusterIP,
+							IngressTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressTemplate{
+								ACMEChallengeSolverHTTP01IngressObjectMeta: cmacme.ACMEChallengeSolverHTTP01IngressObjectMeta{
+									Labels: map[string]string{"team": "a"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"should merge the ingress annotations override if allowed by the issuer": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmacme.ACMEIssuerHTTP01AllowedIngressAnnotationOverridesKey: "example.com/*",
+					},
+				},
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverHTTP01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmacme.ACMECertificateHTTP01IngressAnnotationsOverride: `{"example.com/team":"a"}`,
+					},
+				},
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeHTTP01,
+				DNSName: "example.com",
+				Token:   acmeChallengeHTTP01.Token,
+				Key:     "http01",
+				Solver: cmacme.ACMEChallengeSolver{
+					HTTP01: &cmacme.ACMEChallengeSolverHTTP01{
+						Ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+							Name: "empty-selector-solver",
+							IngressTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressTemplate{
+								ACMEChallengeSolverHTTP01IngressObjectMeta: cmacme.ACMEChallengeSolverHTTP01IngressObjectMeta{
+									Annotations: map[string]string{"example.com/team": "a"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"should return an error if the ingress annotations override is not allowed by the issuer": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{emptySelectorSolverHTTP01},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						cmacme.ACMECertificateHTTP01IngressAnnotationsOverride: `{"example.com/team":"a"}`,
+					},
+				},
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"example.com"},
+				},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeHTTP01},
+			},
+			expectedError: true,
+		},
 		"should return an error if both ingress class and name override annotations are set": {
 			acmeClient: basicACMEClient,
 			issuer: &v1.Issuer{
@@ -865,6 +991,63 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 				Solver:  exampleComDNSNameSelectorSolver,
 			},
 		},
+		"should select the solver matching the requester of the order": {
+			acmeClient: basicACMEClient,
+			issuer: &v1.Issuer{
+				Spec: v1.IssuerSpec{
+					IssuerConfig: v1.IssuerConfig{
+						ACME: &cmacme.ACMEIssuer{
+							Solvers: []cmacme.ACMEChallengeSolver{
+								emptySelectorSolverDNS01,
+								{
+									Selector: &cmacme.CertificateDNSNameSelector{
+										MatchRequester: &cmacme.CertificateRequesterSelector{
+											Groups: []string{"system:nodes"},
+										},
+									},
+									DNS01: &cmacme.ACMEChallengeSolverDNS01{
+										Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+											Email: "nodes-requester-selector-solver",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			order: &cmacme.Order{
+				Spec: cmacme.OrderSpec{
+					DNSNames: []string{"node-1.example.com"},
+				},
+			},
+			requester: &user.DefaultInfo{
+				Name:   "system:node:node-1",
+				Groups: []string{"system:nodes", "system:authenticated"},
+			},
+			authz: &cmacme.ACMEAuthorization{
+				Identifier: "node-1.example.com",
+				Challenges: []cmacme.ACMEChallenge{*acmeChallengeDNS01},
+			},
+			expectedChallengeSpec: &cmacme.ChallengeSpec{
+				Type:    cmacme.ACMEChallengeTypeDNS01,
+				DNSName: "node-1.example.com",
+				Token:   acmeChallengeDNS01.Token,
+				Key:     "dns01",
+				Solver: cmacme.ACMEChallengeSolver{
+					Selector: &cmacme.CertificateDNSNameSelector{
+						MatchRequester: &cmacme.CertificateRequesterSelector{
+							Groups: []string{"system:nodes"},
+						},
+					},
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+							Email: "nodes-requester-selector-solver",
+						},
+					},
+				},
+			},
+		},
 		"should allow matching with dnsZones": {
 			acmeClient: basicACMEClient,
 			issuer: &v1.Issuer{
@@ -1260,7 +1443,7 @@ func TestChallengeSpecForAuthorization(t *testing.T) {
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
 			ctx := context.Background()
-			cs, err := challengeSpecForAuthorization(ctx, test.acmeClient, test.issuer, test.order, *test.authz)
+			cs, err := challengeSpecForAuthorization(ctx, test.acmeClient, test.issuer, test.order, test.requester, *test.authz)
 			if err != nil && !test.expectedError {
 				t.Errorf("expected to not get an error, but got: %v", err)
 				t.Fail()
@@ -27,9 +27,12 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/util"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 var (
@@ -146,6 +149,27 @@ func translateAnnotations(crt *cmapi.Certificate, ingLikeAnnotations map[string]
 		crt.Spec.Subject = subject
 	}
 
+	if literalSubject, found := ingLikeAnnotations[cmapi.LiteralSubjectAnnotationKey]; found {
+		if !utilfeature.DefaultFeatureGate.Enabled(feature.LiteralCertificateSubject) {
+			return fmt.Errorf("%w %q: the %s feature gate must be enabled", errInvalidIngressAnnotation, cmapi.LiteralSubjectAnnotationKey, feature.LiteralCertificateSubject)
+		}
+
+		// The literal subject replaces the whole subject, so it cannot be
+		// combined with the other subject annotations. The serial number is
+		// the only exception, matching the validation of the Certificate.
+		subjectWithoutSerialNumber := *subject
+		subjectWithoutSerialNumber.SerialNumber = ""
+		if len(crt.Spec.CommonName) > 0 || !reflect.DeepEqual(emptySubject, &subjectWithoutSerialNumber) {
+			return fmt.Errorf("%w %q: cannot be used together with the common name or subject annotations", errInvalidIngressAnnotation, cmapi.LiteralSubjectAnnotationKey)
+		}
+
+		if _, err := pki.UnmarshalSubjectStringToRDNSequence(literalSubject); err != nil {
+			return fmt.Errorf("%w %q: %v", errInvalidIngressAnnotation, cmapi.LiteralSubjectAnnotationKey, err)
+		}
+
+		crt.Spec.LiteralSubject = literalSubject
+	}
+
 	if duration, found := ingLikeAnnotations[cmapi.DurationAnnotationKey]; found {
 		duration, err := time.ParseDuration(duration)
 		if err != nil {
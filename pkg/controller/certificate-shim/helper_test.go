@@ -23,10 +23,13 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmutil "github.com/cert-manager/cert-manager/pkg/util"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
@@ -37,6 +40,8 @@ func Test_translateAnnotations(t *testing.T) {
 		mutate        func(*testCase)
 		check         func(*assert.Assertions, *cmapi.Certificate)
 		expectedError error
+
+		literalCertificateSubjectFeatureEnabled bool
 	}
 
 	validAnnotations := func() map[string]string {
@@ -273,6 +278,43 @@ func Test_translateAnnotations(t *testing.T) {
 			},
 			expectedError: errInvalidIngressAnnotation,
 		},
+		"success literal subject": {
+			crt: gen.Certificate("example-cert"),
+			annotations: map[string]string{
+				cmapi.LiteralSubjectAnnotationKey:      "CN=www.example.com,O=Test Organization,DC=example,DC=com",
+				cmapi.SubjectSerialNumberAnnotationKey: "123456",
+			},
+			literalCertificateSubjectFeatureEnabled: true,
+			check: func(a *assert.Assertions, crt *cmapi.Certificate) {
+				a.Equal("CN=www.example.com,O=Test Organization,DC=example,DC=com", crt.Spec.LiteralSubject)
+				a.Equal("", crt.Spec.CommonName)
+				a.Equal(&cmapi.X509Subject{SerialNumber: "123456"}, crt.Spec.Subject)
+			},
+		},
+		"literal subject with feature gate disabled": {
+			crt: gen.Certificate("example-cert"),
+			annotations: map[string]string{
+				cmapi.LiteralSubjectAnnotationKey: "CN=www.example.com",
+			},
+			expectedError: errInvalidIngressAnnotation,
+		},
+		"literal subject with other subject annotations": {
+			crt:                                     gen.Certificate("example-cert"),
+			annotations:                             validAnnotations(),
+			literalCertificateSubjectFeatureEnabled: true,
+			mutate: func(tc *testCase) {
+				tc.annotations[cmapi.LiteralSubjectAnnotationKey] = "CN=www.example.com"
+			},
+			expectedError: errInvalidIngressAnnotation,
+		},
+		"bad literal subject": {
+			crt: gen.Certificate("example-cert"),
+			annotations: map[string]string{
+				cmapi.LiteralSubjectAnnotationKey: "UNKNOWN=www.example.com",
+			},
+			literalCertificateSubjectFeatureEnabled: true,
+			expectedError:                           errInvalidIngressAnnotation,
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -281,6 +323,8 @@ func Test_translateAnnotations(t *testing.T) {
 			}
 			crt := tc.crt.DeepCopy()
 
+			defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.LiteralCertificateSubject, tc.literalCertificateSubjectFeatureEnabled)()
+
 			err := translateAnnotations(crt, tc.annotations)
 
 			if tc.expectedError != nil {
@@ -37,6 +37,7 @@ import (
 	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/conflicts"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
@@ -46,6 +47,7 @@ import (
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 const (
@@ -130,26 +132,7 @@ func SyncFnFor(
 		}
 
 		for _, crt := range updateCrts {
-
-			if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
-				err = internalcertificates.Apply(ctx, cmClient, fieldManager, &cmapi.Certificate{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:            crt.Name,
-						Namespace:       crt.Namespace,
-						Labels:          crt.Labels,
-						OwnerReferences: crt.OwnerReferences,
-					},
-					Spec: cmapi.CertificateSpec{
-						DNSNames:   crt.Spec.DNSNames,
-						SecretName: crt.Spec.SecretName,
-						IssuerRef:  crt.Spec.IssuerRef,
-						Usages:     crt.Spec.Usages,
-					},
-				})
-			} else {
-				_, err = cmClient.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{})
-			}
-			if err != nil {
+			if err := updateCertificate(ctx, rec, cmClient, fieldManager, crt); err != nil {
 				return err
 			}
 
@@ -174,6 +157,38 @@ func SyncFnFor(
 	}
 }
 
+// updateCertificate updates the given Certificate created by a shim, using
+// server-side apply if the ServerSideApply feature gate is enabled.
+func updateCertificate(ctx context.Context, rec record.EventRecorder, cmClient clientset.Interface, fieldManager string, crt *cmapi.Certificate) error {
+	var err error
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		applyCrt := &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            crt.Name,
+				Namespace:       crt.Namespace,
+				Labels:          crt.Labels,
+				OwnerReferences: crt.OwnerReferences,
+			},
+			Spec: cmapi.CertificateSpec{
+				DNSNames:   crt.Spec.DNSNames,
+				SecretName: crt.Spec.SecretName,
+				IssuerRef:  crt.Spec.IssuerRef,
+				Usages:     crt.Spec.Usages,
+			},
+		}
+		// Fields of the Certificate may also be managed by other tools,
+		// such as GitOps controllers. Conflicts are surfaced as Events on
+		// the Certificate before ownership of the fields is taken.
+		err = conflicts.Apply(rec, crt, fieldManager, func(force bool) error {
+			return internalcertificates.Apply(ctx, cmClient, fieldManager, force, applyCrt)
+		})
+	} else {
+		_, err = cmClient.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{FieldManager: fieldManager})
+		conflicts.RecordEvent(rec, crt, fieldManager, err)
+	}
+	return err
+}
+
 func validateIngressLike(ingLike metav1.Object) field.ErrorList {
 	switch o := ingLike.(type) {
 	case *networkingv1.Ingress:
@@ -232,6 +247,11 @@ func validateIngressTLSBlock(path *field.Path, tlsBlock networkingv1.IngressTLS)
 	if len(tlsBlock.Hosts) == 0 {
 		errs = append(errs, field.Required(path.Child("hosts"), ""))
 	}
+	for i, host := range tlsBlock.Hosts {
+		if _, err := pki.DNSNameToASCII(host); err != nil {
+			errs = append(errs, field.Invalid(path.Child("hosts").Index(i), host, err.Error()))
+		}
+	}
 	if tlsBlock.SecretName == "" {
 		errs = append(errs, field.Required(path.Child("secretName"), ""))
 	}
@@ -315,6 +335,13 @@ func buildCertificates(
 		}
 	case *gwapi.Gateway:
 		for i, l := range ingLike.Spec.Listeners {
+			// The certificate of a TLS passthrough listener is served by the
+			// backends rather than by the Gateway. These Certificates are
+			// created by the tlsroute-shim for the attached TLSRoutes.
+			if l.TLS != nil && l.TLS.Mode != nil && *l.TLS.Mode == gwapi.TLSModePassthrough {
+				log.V(logf.DebugLevel).Info("skipping TLS passthrough listener", "listener", l.Name)
+				continue
+			}
 			err := validateGatewayListenerBlock(field.NewPath("spec", "listeners").Index(i), l, ingLike).ToAggregate()
 			if err != nil {
 				rec.Eventf(ingLike, corev1.EventTypeWarning, reasonBadConfig, "Skipped a listener block: "+err.Error())
@@ -476,6 +503,10 @@ func certNeedsUpdate(a, b *cmapi.Certificate) bool {
 		return true
 	}
 
+	if a.Spec.LiteralSubject != b.Spec.LiteralSubject {
+		return true
+	}
+
 	if len(a.Spec.DNSNames) != len(b.Spec.DNSNames) {
 		return true
 	}
@@ -560,6 +591,17 @@ func certNeedsUpdate(a, b *cmapi.Certificate) bool {
 	return false
 }
 
+// http01IngressOverrideAnnotations maps the ingress-shim annotations that
+// override the Issuer's HTTP01 ingress solver configuration to the
+// corresponding Certificate annotations.
+var http01IngressOverrideAnnotations = map[string]string{
+	cmapi.IngressACMEIssuerHTTP01IngressClassAnnotationKey:       cmacme.ACMECertificateHTTP01IngressClassOverride,
+	cmapi.IngressACMEIssuerHTTP01IngressClassNameAnnotationKey:   cmacme.ACMECertificateHTTP01IngressClassNameOverride,
+	cmapi.IngressACMEIssuerHTTP01ServiceTypeAnnotationKey:        cmacme.ACMECertificateHTTP01IngressServiceTypeOverride,
+	cmapi.IngressACMEIssuerHTTP01IngressAnnotationsAnnotationKey: cmacme.ACMECertificateHTTP01IngressAnnotationsOverride,
+	cmapi.IngressACMEIssuerHTTP01IngressLabelsAnnotationKey:      cmacme.ACMECertificateHTTP01IngressLabelsOverride,
+}
+
 // setIssuerSpecificConfig configures given Certificate's annotation by reading
 // two Ingress-specific annotations.
 //
@@ -586,6 +628,10 @@ func certNeedsUpdate(a, b *cmapi.Certificate) bool {
 // configures the Certificate using the override-ingress-class annotation:
 //
 //	acme.cert-manager.io/http01-override-ingress-class: traefik
+//
+// The ingress-ingressclassname, ingress-service-type, ingress-annotations and
+// ingress-labels Ingress annotations are copied to the Certificate in the same
+// way, see http01IngressOverrideAnnotations.
 func setIssuerSpecificConfig(crt *cmapi.Certificate, ingLike metav1.Object) {
 	ingAnnotations := ingLike.GetAnnotations()
 	if ingAnnotations == nil {
@@ -605,12 +651,15 @@ func setIssuerSpecificConfig(crt *cmapi.Certificate, ingLike metav1.Object) {
 		crt.Annotations[cmapi.IssueTemporaryCertificateAnnotation] = "true"
 	}
 
-	ingressClassVal, hasIngressClassVal := ingAnnotations[cmapi.IngressACMEIssuerHTTP01IngressClassAnnotationKey]
-	if hasIngressClassVal {
+	for ingKey, crtKey := range http01IngressOverrideAnnotations {
+		val, hasVal := ingAnnotations[ingKey]
+		if !hasVal {
+			continue
+		}
 		if crt.Annotations == nil {
 			crt.Annotations = make(map[string]string)
 		}
-		crt.Annotations[cmacme.ACMECertificateHTTP01IngressClassOverride] = ingressClassVal
+		crt.Annotations[crtKey] = val
 	}
 
 	ingLike.SetAnnotations(ingAnnotations)
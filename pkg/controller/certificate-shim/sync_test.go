@@ -383,6 +383,58 @@ func TestSync(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:   "return a single HTTP01 Certificate for an ingress with a single valid TLS entry and HTTP01 ingress override annotations",
+			Issuer: acmeClusterIssuer,
+			IngressLike: &networkingv1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ingress-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey:                  "issuer-name",
+						cmapi.IngressACMEIssuerHTTP01IngressClassNameAnnotationKey:   "cert-ing",
+						cmapi.IngressACMEIssuerHTTP01ServiceTypeAnnotationKey:        "ClusterIP",
+						cmapi.IngressACMEIssuerHTTP01IngressAnnotationsAnnotationKey: `{"foo":"bar"}`,
+						cmapi.IngressACMEIssuerHTTP01IngressLabelsAnnotationKey:      `{"team":"a"}`,
+					},
+					UID: types.UID("ingress-name"),
+				},
+				Spec: networkingv1.IngressSpec{
+					TLS: []networkingv1.IngressTLS{
+						{
+							Hosts:      []string{"example.com", "www.example.com"},
+							SecretName: "example-com-tls",
+						},
+					},
+				},
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+			ExpectedEvents:      []string{`Normal CreateCertificate Successfully created Certificate "example-com-tls"`},
+			ExpectedCreate: []*cmapi.Certificate{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "example-com-tls",
+						Namespace:       gen.DefaultTestNamespace,
+						OwnerReferences: buildIngressOwnerReferences("ingress-name", gen.DefaultTestNamespace),
+						Annotations: map[string]string{
+							cmacme.ACMECertificateHTTP01IngressClassNameOverride:   "cert-ing",
+							cmacme.ACMECertificateHTTP01IngressServiceTypeOverride: "ClusterIP",
+							cmacme.ACMECertificateHTTP01IngressAnnotationsOverride: `{"foo":"bar"}`,
+							cmacme.ACMECertificateHTTP01IngressLabelsOverride:      `{"team":"a"}`,
+						},
+					},
+					Spec: cmapi.CertificateSpec{
+						DNSNames:   []string{"example.com", "www.example.com"},
+						SecretName: "example-com-tls",
+						IssuerRef: cmmeta.ObjectReference{
+							Name: "issuer-name",
+							Kind: "ClusterIssuer",
+						},
+						Usages: cmapi.DefaultKeyUsages(),
+					},
+				},
+			},
+		},
 		{
 			Name:   "edit-in-place set to false should not trigger editing the ingress in-place",
 			Issuer: acmeClusterIssuer,
@@ -1517,6 +1569,32 @@ func TestSync(t *testing.T) {
 	}
 
 	testGatewayShim := []testT{
+		{
+			Name:   "should skip TLS passthrough listeners since their certificate is served by the backends",
+			Issuer: acmeClusterIssuer,
+			IngressLike: &gwapi.Gateway{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "gateway-name",
+					Namespace: gen.DefaultTestNamespace,
+					Annotations: map[string]string{
+						cmapi.IngressClusterIssuerNameAnnotationKey: "issuer-name",
+					},
+					UID: types.UID("gateway-name"),
+				},
+				Spec: gwapi.GatewaySpec{
+					GatewayClassName: "test-gateway",
+					Listeners: []gwapi.Listener{{
+						Hostname: ptrHostname("example.com"),
+						Port:     443,
+						Protocol: gwapi.TLSProtocolType,
+						TLS: &gwapi.GatewayTLSConfig{
+							Mode: ptrMode(gwapi.TLSModePassthrough),
+						},
+					}},
+				},
+			},
+			ClusterIssuerLister: []runtime.Object{acmeClusterIssuer},
+		},
 		{
 			Name:   "return a single Certificate for a Gateway with a single valid TLS entry and common-name annotation",
 			Issuer: acmeClusterIssuer,
@@ -0,0 +1,572 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shimhelper
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gwlisters "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	reasonCertificateReady    = "CertificateReady"
+	reasonCertificateNotReady = "CertificateNotReady"
+)
+
+var tlsRouteGVK = gwapiv1alpha2.SchemeGroupVersion.WithKind("TLSRoute")
+
+// TLSRouteSyncFn is the reconciliation function passed to the tlsroute-shim
+// controller. The given TLSRoute is nil when the TLSRoute with the given
+// namespace and name no longer exists.
+type TLSRouteSyncFn func(ctx context.Context, namespace, name string, route *gwapiv1alpha2.TLSRoute) error
+
+// TLSRouteSyncFnFor contains the logic to reconcile TLSRoutes attached to TLS
+// passthrough listeners of a Gateway.
+//
+// With TLS passthrough, the Gateway forwards the TLS connection to the
+// backends without terminating it, so the backends need the certificate
+// rather than the Gateway. For each annotated TLSRoute that is attached to a
+// passthrough listener, a Certificate with the TLSRoute's hostnames is created
+// in the namespace of each backend Service. Backends in another namespace than
+// the TLSRoute must be allowed by a ReferenceGrant in that namespace.
+//
+// Since owner references can't cross namespaces, the Certificates are labelled
+// with the name and namespace of the TLSRoute instead, and are deleted by this
+// function once no longer required. The readiness of the Certificates is
+// reported on the TLSRoute using events whenever it changes.
+func TLSRouteSyncFnFor(
+	rec record.EventRecorder,
+	log logr.Logger,
+	cmClient clientset.Interface,
+	cmLister cmlisters.CertificateLister,
+	gatewayLister gwlisters.GatewayLister,
+	referenceGrantLister gwlisters.ReferenceGrantLister,
+	namespaceLister corelisters.NamespaceLister,
+	defaults controller.IngressShimOptions,
+	fieldManager string,
+) TLSRouteSyncFn {
+	readiness := newTLSRouteReadinessReporter(rec)
+
+	return func(ctx context.Context, namespace, name string, route *gwapiv1alpha2.TLSRoute) error {
+		if route == nil {
+			log := log.WithValues("resource_namespace", namespace, "resource_name", name)
+			deleted, err := deleteTLSRouteCertificates(ctx, cmClient, cmLister, namespace, name, func(*cmapi.Certificate) bool { return false })
+			readiness.forget(deleted)
+			for _, crt := range deleted {
+				log.V(logf.InfoLevel).Info("deleted Certificate of deleted TLSRoute", "certificate", crt.Namespace+"/"+crt.Name)
+			}
+			return err
+		}
+
+		log := logf.WithResource(log, route)
+		ctx = logf.NewContext(ctx, log)
+
+		// deleteAll deletes all Certificates of the TLSRoute. It is used when
+		// the TLSRoute no longer requires any Certificate, since Certificates
+		// in other namespaces are not garbage collected.
+		deleteAll := func() error {
+			deleted, err := deleteTLSRouteCertificates(ctx, cmClient, cmLister, route.Namespace, route.Name, func(*cmapi.Certificate) bool { return false })
+			readiness.forget(deleted)
+			for _, crt := range deleted {
+				rec.Eventf(route, corev1.EventTypeNormal, reasonDeleteCertificate, "Successfully deleted unrequired Certificate %s/%s", crt.Namespace, crt.Name)
+			}
+			return err
+		}
+
+		if !hasShimAnnotation(route, nil) {
+			logf.V(logf.DebugLevel).Infof("not syncing TLSRoute resource as it does not contain a %q or %q annotation",
+				cmapi.IngressIssuerNameAnnotationKey, cmapi.IngressClusterIssuerNameAnnotationKey)
+			return deleteAll()
+		}
+
+		issuerName, issuerKind, issuerGroup, err := issuerForIngressLike(defaults, route)
+		if err != nil {
+			log.Error(err, "failed to determine issuer to be used for TLSRoute resource")
+			rec.Eventf(route, corev1.EventTypeWarning, reasonBadConfig, "Could not determine issuer for TLSRoute due to bad annotations: %s",
+				err)
+			return deleteAll()
+		}
+
+		if len(route.Spec.Hostnames) == 0 {
+			rec.Eventf(route, corev1.EventTypeWarning, reasonBadConfig, "Skipped TLSRoute: spec.hostnames: Required value: the hostnames cannot be empty")
+			return deleteAll()
+		}
+
+		attached, err := tlsRouteAttachedToPassthroughListener(gatewayLister, namespaceLister, route)
+		if err != nil {
+			return err
+		}
+		if !attached {
+			rec.Eventf(route, corev1.EventTypeWarning, reasonBadConfig, "Skipped TLSRoute: it is not attached to a Gateway listener using TLS passthrough")
+			return deleteAll()
+		}
+
+		namespaces, err := tlsRouteBackendNamespaces(rec, referenceGrantLister, route)
+		if err != nil {
+			return err
+		}
+
+		secretName := route.Name + "-tls"
+		if name := route.Annotations[cmapi.TLSRouteSecretNameAnnotationKey]; name != "" {
+			secretName = name
+		}
+
+		for _, ns := range namespaces {
+			crt := buildTLSRouteCertificate(route, ns, secretName, issuerName, issuerKind, issuerGroup)
+			if err := translateAnnotations(crt, route.GetAnnotations()); err != nil {
+				return err
+			}
+
+			existingCrt, err := cmLister.Certificates(ns).Get(secretName)
+			if apierrors.IsNotFound(err) {
+				if _, err := cmClient.CertmanagerV1().Certificates(ns).Create(ctx, crt, metav1.CreateOptions{FieldManager: fieldManager}); err != nil {
+					return err
+				}
+				rec.Eventf(route, corev1.EventTypeNormal, reasonCreateCertificate, "Successfully created Certificate %s/%s", ns, secretName)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if !isTLSRouteCertificate(existingCrt, route.Namespace, route.Name) {
+				rec.Eventf(route, corev1.EventTypeWarning, reasonBadConfig, "Refusing to update Certificate %s/%s which was not created for this TLSRoute", ns, secretName)
+				continue
+			}
+
+			if certNeedsUpdate(existingCrt, crt) {
+				updateCrt := existingCrt.DeepCopy()
+				updateCrt.Spec = crt.Spec
+				updateCrt.Labels = crt.Labels
+				updateCrt.OwnerReferences = crt.OwnerReferences
+				if err := updateCertificate(ctx, rec, cmClient, fieldManager, updateCrt); err != nil {
+					return err
+				}
+				rec.Eventf(route, corev1.EventTypeNormal, reasonUpdateCertificate, "Successfully updated Certificate %s/%s", ns, secretName)
+				continue
+			}
+
+			readiness.report(route, existingCrt)
+		}
+
+		required := sets.NewString(namespaces...)
+		deleted, err := deleteTLSRouteCertificates(ctx, cmClient, cmLister, route.Namespace, route.Name, func(crt *cmapi.Certificate) bool {
+			return required.Has(crt.Namespace) && crt.Name == secretName
+		})
+		readiness.forget(deleted)
+		for _, crt := range deleted {
+			rec.Eventf(route, corev1.EventTypeNormal, reasonDeleteCertificate, "Successfully deleted unrequired Certificate %s/%s", crt.Namespace, crt.Name)
+		}
+		return err
+	}
+}
+
+// buildTLSRouteCertificate builds the Certificate for the backends of the
+// given TLSRoute in the given namespace.
+func buildTLSRouteCertificate(route *gwapiv1alpha2.TLSRoute, namespace, secretName, issuerName, issuerKind, issuerGroup string) *cmapi.Certificate {
+	crtLabels := make(map[string]string, len(route.Labels)+2)
+	for k, v := range route.Labels {
+		crtLabels[k] = v
+	}
+	crtLabels[cmapi.TLSRouteNameLabelKey] = route.Name
+	crtLabels[cmapi.TLSRouteNamespaceLabelKey] = route.Namespace
+
+	var ownerReferences []metav1.OwnerReference
+	if namespace == route.Namespace {
+		ownerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(route, tlsRouteGVK)}
+	}
+
+	dnsNames := make([]string, 0, len(route.Spec.Hostnames))
+	for _, hostname := range route.Spec.Hostnames {
+		dnsNames = append(dnsNames, string(hostname))
+	}
+
+	return &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            secretName,
+			Namespace:       namespace,
+			Labels:          crtLabels,
+			OwnerReferences: ownerReferences,
+		},
+		Spec: cmapi.CertificateSpec{
+			DNSNames:   dnsNames,
+			SecretName: secretName,
+			IssuerRef: cmmeta.ObjectReference{
+				Name:  issuerName,
+				Kind:  issuerKind,
+				Group: issuerGroup,
+			},
+			Usages: cmapi.DefaultKeyUsages(),
+		},
+	}
+}
+
+// isTLSRouteCertificate returns true if the given Certificate was created for
+// the TLSRoute with the given namespace and name.
+func isTLSRouteCertificate(crt *cmapi.Certificate, namespace, name string) bool {
+	return crt.Labels[cmapi.TLSRouteNamespaceLabelKey] == namespace && crt.Labels[cmapi.TLSRouteNameLabelKey] == name
+}
+
+// tlsRouteAttachedToPassthroughListener returns true if the given TLSRoute
+// is attached to a listener using TLS passthrough of one of its parent
+// Gateways. Following the Gateway API, a route is attached to a listener if
+// the parent reference selects the listener by section name and port, the
+// listener's allowedRoutes allow TLSRoutes from the namespace of the route,
+// the hostnames of the route and listener intersect, and the route has been
+// accepted by the Gateway for that parent reference.
+func tlsRouteAttachedToPassthroughListener(gatewayLister gwlisters.GatewayLister, namespaceLister corelisters.NamespaceLister, route *gwapiv1alpha2.TLSRoute) (bool, error) {
+	for _, ref := range route.Spec.ParentRefs {
+		if ref.Group != nil && *ref.Group != gwapi.GroupName {
+			continue
+		}
+		if ref.Kind != nil && *ref.Kind != "Gateway" {
+			continue
+		}
+		if !tlsRouteAccepted(route, ref) {
+			continue
+		}
+		namespace := route.Namespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+
+		gateway, err := gatewayLister.Gateways(namespace).Get(string(ref.Name))
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+
+		for _, l := range gateway.Spec.Listeners {
+			if ref.SectionName != nil && *ref.SectionName != l.Name {
+				continue
+			}
+			if ref.Port != nil && *ref.Port != l.Port {
+				continue
+			}
+			if l.Protocol != gwapi.TLSProtocolType || l.TLS == nil || l.TLS.Mode == nil || *l.TLS.Mode != gwapi.TLSModePassthrough {
+				continue
+			}
+			if !listenerAllowsTLSRouteKind(l) || !listenerHostnameMatches(l, route.Spec.Hostnames) {
+				continue
+			}
+			allowed, err := listenerAllowsRouteNamespace(namespaceLister, gateway, l, route.Namespace)
+			if err != nil {
+				return false, err
+			}
+			if allowed {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// tlsRouteAccepted returns true if the status of the given TLSRoute has an
+// Accepted condition set to True for the given parent reference.
+func tlsRouteAccepted(route *gwapiv1alpha2.TLSRoute, ref gwapi.ParentReference) bool {
+	for _, parent := range route.Status.Parents {
+		if parentRefsEqual(parent.ParentRef, ref, route.Namespace) {
+			if apimeta.IsStatusConditionTrue(parent.Conditions, string(gwapi.RouteConditionAccepted)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// parentRefsEqual returns true if both parent references refer to the same
+// Gateway listener, taking the defaults of unset fields into account.
+func parentRefsEqual(a, b gwapi.ParentReference, routeNamespace string) bool {
+	group := func(ref gwapi.ParentReference) gwapi.Group {
+		if ref.Group == nil {
+			return gwapi.GroupName
+		}
+		return *ref.Group
+	}
+	kind := func(ref gwapi.ParentReference) gwapi.Kind {
+		if ref.Kind == nil {
+			return "Gateway"
+		}
+		return *ref.Kind
+	}
+	namespace := func(ref gwapi.ParentReference) gwapi.Namespace {
+		if ref.Namespace == nil {
+			return gwapi.Namespace(routeNamespace)
+		}
+		return *ref.Namespace
+	}
+	sectionName := func(ref gwapi.ParentReference) gwapi.SectionName {
+		if ref.SectionName == nil {
+			return ""
+		}
+		return *ref.SectionName
+	}
+	port := func(ref gwapi.ParentReference) gwapi.PortNumber {
+		if ref.Port == nil {
+			return 0
+		}
+		return *ref.Port
+	}
+	return group(a) == group(b) && kind(a) == kind(b) && namespace(a) == namespace(b) &&
+		a.Name == b.Name && sectionName(a) == sectionName(b) && port(a) == port(b)
+}
+
+// listenerAllowsTLSRouteKind returns true if the allowedRoutes of the given
+// listener allow TLSRoutes. If no kinds are given, the kinds allowed by the
+// protocol of the listener are allowed, which is TLSRoute for TLS listeners.
+func listenerAllowsTLSRouteKind(l gwapi.Listener) bool {
+	if l.AllowedRoutes == nil || len(l.AllowedRoutes.Kinds) == 0 {
+		return true
+	}
+	for _, k := range l.AllowedRoutes.Kinds {
+		if (k.Group == nil || *k.Group == gwapi.GroupName) && k.Kind == "TLSRoute" {
+			return true
+		}
+	}
+	return false
+}
+
+// listenerAllowsRouteNamespace returns true if the allowedRoutes of the given
+// listener allow routes from the given namespace. By default, only routes in
+// the namespace of the Gateway are allowed.
+func listenerAllowsRouteNamespace(namespaceLister corelisters.NamespaceLister, gateway *gwapi.Gateway, l gwapi.Listener, namespace string) (bool, error) {
+	from := gwapi.NamespacesFromSame
+	var selector *metav1.LabelSelector
+	if l.AllowedRoutes != nil && l.AllowedRoutes.Namespaces != nil {
+		if l.AllowedRoutes.Namespaces.From != nil {
+			from = *l.AllowedRoutes.Namespaces.From
+		}
+		selector = l.AllowedRoutes.Namespaces.Selector
+	}
+
+	switch from {
+	case gwapi.NamespacesFromAll:
+		return true, nil
+	case gwapi.NamespacesFromSame:
+		return namespace == gateway.Namespace, nil
+	case gwapi.NamespacesFromSelector:
+		if selector == nil {
+			return false, nil
+		}
+		sel, err := metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return false, nil
+		}
+		ns, err := namespaceLister.Get(namespace)
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		return sel.Matches(labels.Set(ns.Labels)), nil
+	default:
+		return false, nil
+	}
+}
+
+// listenerHostnameMatches returns true if the hostname of the given listener
+// intersects with one of the given route hostnames. A listener without a
+// hostname matches all hostnames.
+func listenerHostnameMatches(l gwapi.Listener, hostnames []gwapiv1alpha2.Hostname) bool {
+	if l.Hostname == nil || *l.Hostname == "" {
+		return true
+	}
+	for _, h := range hostnames {
+		if hostnamesIntersect(string(*l.Hostname), string(h)) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostnamesIntersect returns true if the two hostnames, either of which may
+// be a wildcard such as '*.example.com', match a common hostname.
+func hostnamesIntersect(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if strings.HasPrefix(a, "*.") && strings.HasSuffix(b, a[1:]) {
+		return true
+	}
+	return strings.HasPrefix(b, "*.") && strings.HasSuffix(a, b[1:])
+}
+
+// tlsRouteBackendNamespaces returns the sorted namespaces of the backend
+// Services of the given TLSRoute. Backends in another namespace are skipped
+// unless a ReferenceGrant in that namespace allows references from TLSRoutes
+// in the namespace of the TLSRoute.
+func tlsRouteBackendNamespaces(rec record.EventRecorder, referenceGrantLister gwlisters.ReferenceGrantLister, route *gwapiv1alpha2.TLSRoute) ([]string, error) {
+	namespaces := sets.NewString()
+	for _, rule := range route.Spec.Rules {
+		for _, ref := range rule.BackendRefs {
+			if ref.Group != nil && *ref.Group != "" {
+				continue
+			}
+			if ref.Kind != nil && *ref.Kind != "Service" {
+				continue
+			}
+			namespace := route.Namespace
+			if ref.Namespace != nil {
+				namespace = string(*ref.Namespace)
+			}
+
+			if namespace != route.Namespace {
+				granted, err := serviceReferenceGranted(referenceGrantLister, route.Namespace, namespace, string(ref.Name))
+				if err != nil {
+					return nil, err
+				}
+				if !granted {
+					rec.Eventf(route, corev1.EventTypeWarning, reasonBadConfig, "Skipped backend %s/%s: no ReferenceGrant allows references from TLSRoutes in namespace %q",
+						namespace, ref.Name, route.Namespace)
+					continue
+				}
+			}
+
+			namespaces.Insert(namespace)
+		}
+	}
+	return namespaces.List(), nil
+}
+
+// serviceReferenceGranted returns true if a ReferenceGrant in the namespace
+// of the Service allows TLSRoutes in fromNamespace to reference it.
+func serviceReferenceGranted(referenceGrantLister gwlisters.ReferenceGrantLister, fromNamespace, namespace, name string) (bool, error) {
+	grants, err := referenceGrantLister.ReferenceGrants(namespace).List(labels.Everything())
+	if err != nil {
+		return false, err
+	}
+
+	for _, grant := range grants {
+		fromAllowed := false
+		for _, from := range grant.Spec.From {
+			if from.Group == gwapi.GroupName && from.Kind == "TLSRoute" && string(from.Namespace) == fromNamespace {
+				fromAllowed = true
+				break
+			}
+		}
+		if !fromAllowed {
+			continue
+		}
+		for _, to := range grant.Spec.To {
+			if to.Group == "" && to.Kind == "Service" && (to.Name == nil || string(*to.Name) == name) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// deleteTLSRouteCertificates deletes the Certificates created for the
+// TLSRoute with the given namespace and name, except those for which keep
+// returns true. It returns the Certificates that were deleted.
+func deleteTLSRouteCertificates(ctx context.Context, cmClient clientset.Interface, cmLister cmlisters.CertificateLister, namespace, name string, keep func(*cmapi.Certificate) bool) ([]*cmapi.Certificate, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		cmapi.TLSRouteNamespaceLabelKey: namespace,
+		cmapi.TLSRouteNameLabelKey:      name,
+	})
+	crts, err := cmLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []*cmapi.Certificate
+	for _, crt := range crts {
+		if keep(crt) {
+			continue
+		}
+		err := cmClient.CertmanagerV1().Certificates(crt.Namespace).Delete(ctx, crt.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return deleted, err
+		}
+		deleted = append(deleted, crt)
+	}
+	return deleted, nil
+}
+
+// tlsRouteReadinessReporter reports the readiness of the Certificates of
+// TLSRoutes on the TLSRoute using events. To avoid firing an event on every
+// sync, an event is only fired when the readiness of a Certificate changes.
+type tlsRouteReadinessReporter struct {
+	rec record.EventRecorder
+
+	lock     sync.Mutex
+	reported map[types.NamespacedName]string
+}
+
+func newTLSRouteReadinessReporter(rec record.EventRecorder) *tlsRouteReadinessReporter {
+	return &tlsRouteReadinessReporter{rec: rec, reported: make(map[types.NamespacedName]string)}
+}
+
+// report fires an event for the Ready condition of the given Certificate on
+// the TLSRoute it was created for, unless the same readiness has already
+// been reported.
+func (r *tlsRouteReadinessReporter) report(route *gwapiv1alpha2.TLSRoute, crt *cmapi.Certificate) {
+	cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionReady)
+	if cond == nil || cond.Status == cmmeta.ConditionUnknown {
+		return
+	}
+
+	key := types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name}
+	state := string(route.UID) + "/" + string(cond.Status) + "/" + cond.Message
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.reported[key] == state {
+		return
+	}
+	r.reported[key] = state
+
+	switch cond.Status {
+	case cmmeta.ConditionTrue:
+		r.rec.Eventf(route, corev1.EventTypeNormal, reasonCertificateReady, "Certificate %s/%s is ready", crt.Namespace, crt.Name)
+	case cmmeta.ConditionFalse:
+		r.rec.Eventf(route, corev1.EventTypeWarning, reasonCertificateNotReady, "Certificate %s/%s is not ready: %s", crt.Namespace, crt.Name, cond.Message)
+	}
+}
+
+// forget removes the reported readiness of the given deleted Certificates.
+func (r *tlsRouteReadinessReporter) forget(crts []*cmapi.Certificate) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, crt := range crts {
+		delete(r.reported, types.NamespacedName{Namespace: crt.Namespace, Name: crt.Name})
+	}
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shimhelper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gwlisters "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1beta1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestTLSRouteSync(t *testing.T) {
+	const backendNamespace = "backend-namespace"
+
+	passthroughGateway := &gwapi.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "gateway", Namespace: gen.DefaultTestNamespace},
+		Spec: gwapi.GatewaySpec{
+			Listeners: []gwapi.Listener{
+				{
+					Name:     "https",
+					Port:     443,
+					Protocol: gwapi.HTTPSProtocolType,
+					TLS:      &gwapi.GatewayTLSConfig{Mode: ptrMode(gwapi.TLSModeTerminate)},
+				},
+				{
+					Name:     "passthrough",
+					Port:     8443,
+					Protocol: gwapi.TLSProtocolType,
+					TLS:      &gwapi.GatewayTLSConfig{Mode: ptrMode(gwapi.TLSModePassthrough)},
+				},
+			},
+		},
+	}
+	backendGrant := &gwapi.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{Name: "allow-tlsroutes", Namespace: backendNamespace},
+		Spec: gwapi.ReferenceGrantSpec{
+			From: []gwapi.ReferenceGrantFrom{{Group: gwapi.GroupName, Kind: "TLSRoute", Namespace: gen.DefaultTestNamespace}},
+			To:   []gwapi.ReferenceGrantTo{{Group: "", Kind: "Service"}},
+		},
+	}
+
+	buildRoute := func(sectionName string, backendNamespaces ...string) *gwapiv1alpha2.TLSRoute {
+		section := gwapi.SectionName(sectionName)
+		var backendRefs []gwapiv1alpha2.BackendRef
+		for _, ns := range backendNamespaces {
+			backendRef := gwapiv1alpha2.BackendRef{BackendObjectReference: gwapi.BackendObjectReference{Name: "backend"}}
+			if ns != gen.DefaultTestNamespace {
+				namespace := gwapi.Namespace(ns)
+				backendRef.Namespace = &namespace
+			}
+			backendRefs = append(backendRefs, backendRef)
+		}
+		parentRef := gwapi.ParentReference{Name: "gateway", SectionName: &section}
+		return &gwapiv1alpha2.TLSRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "route",
+				Namespace:   gen.DefaultTestNamespace,
+				UID:         types.UID("route"),
+				Annotations: map[string]string{cmapi.IngressIssuerNameAnnotationKey: "issuer-name"},
+			},
+			Spec: gwapiv1alpha2.TLSRouteSpec{
+				CommonRouteSpec: gwapi.CommonRouteSpec{
+					ParentRefs: []gwapi.ParentReference{parentRef},
+				},
+				Hostnames: []gwapiv1alpha2.Hostname{"example.com"},
+				Rules:     []gwapiv1alpha2.TLSRouteRule{{BackendRefs: backendRefs}},
+			},
+			Status: gwapiv1alpha2.TLSRouteStatus{RouteStatus: gwapi.RouteStatus{
+				Parents: []gwapi.RouteParentStatus{{
+					ParentRef:  parentRef,
+					Conditions: []metav1.Condition{{Type: string(gwapi.RouteConditionAccepted), Status: metav1.ConditionTrue}},
+				}},
+			}},
+		}
+	}
+	// withPassthroughListener returns a copy of the passthrough Gateway with
+	// the passthrough listener modified by the given function.
+	withPassthroughListener := func(mod func(*gwapi.Listener)) *gwapi.Gateway {
+		gateway := passthroughGateway.DeepCopy()
+		mod(&gateway.Spec.Listeners[1])
+		return gateway
+	}
+	notAttachedEvent := `Warning BadConfig Skipped TLSRoute: it is not attached to a Gateway listener using TLS passthrough`
+
+	buildCrt := func(namespace string, conditions ...cmapi.CertificateCondition) *cmapi.Certificate {
+		crt := &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "route-tls",
+				Namespace: namespace,
+				Labels: map[string]string{
+					cmapi.TLSRouteNameLabelKey:      "route",
+					cmapi.TLSRouteNamespaceLabelKey: gen.DefaultTestNamespace,
+				},
+			},
+			Spec: cmapi.CertificateSpec{
+				DNSNames:   []string{"example.com"},
+				SecretName: "route-tls",
+				IssuerRef:  cmmeta.ObjectReference{Name: "issuer-name", Kind: "Issuer"},
+				Usages:     cmapi.DefaultKeyUsages(),
+			},
+			Status: cmapi.CertificateStatus{Conditions: conditions},
+		}
+		if namespace == gen.DefaultTestNamespace {
+			crt.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(buildRoute("passthrough"), tlsRouteGVK)}
+		}
+		return crt
+	}
+
+	tests := map[string]struct {
+		routeName       string
+		route           *gwapiv1alpha2.TLSRoute
+		gateway         *gwapi.Gateway
+		namespaces      []*corev1.Namespace
+		existingCrts    []runtime.Object
+		referenceGrants []*gwapi.ReferenceGrant
+		// syncs is the number of times the TLSRoute is synced, defaulting
+		// to once.
+		syncs int
+
+		expectedCreate []*cmapi.Certificate
+		expectedDelete []*cmapi.Certificate
+		expectedEvents []string
+	}{
+		"should create Certificates in the namespaces of the backends allowed by a ReferenceGrant": {
+			route:           buildRoute("passthrough", gen.DefaultTestNamespace, backendNamespace, "other-namespace"),
+			referenceGrants: []*gwapi.ReferenceGrant{backendGrant},
+			expectedCreate:  []*cmapi.Certificate{buildCrt(backendNamespace), buildCrt(gen.DefaultTestNamespace)},
+			expectedEvents: []string{
+				`Warning BadConfig Skipped backend other-namespace/backend: no ReferenceGrant allows references from TLSRoutes in namespace "default-unit-test-ns"`,
+				`Normal CreateCertificate Successfully created Certificate backend-namespace/route-tls`,
+				`Normal CreateCertificate Successfully created Certificate default-unit-test-ns/route-tls`,
+			},
+		},
+		"should not create Certificates if the TLSRoute isn't attached to a passthrough listener": {
+			route:          buildRoute("https", gen.DefaultTestNamespace),
+			expectedEvents: []string{notAttachedEvent},
+		},
+		"should not create Certificates if the TLSRoute hasn't been accepted by the Gateway": {
+			route: func() *gwapiv1alpha2.TLSRoute {
+				route := buildRoute("passthrough", gen.DefaultTestNamespace)
+				route.Status.Parents[0].Conditions[0].Status = metav1.ConditionFalse
+				return route
+			}(),
+			expectedEvents: []string{notAttachedEvent},
+		},
+		"should not create Certificates if the TLSRoute was accepted for another parent": {
+			route: func() *gwapiv1alpha2.TLSRoute {
+				route := buildRoute("passthrough", gen.DefaultTestNamespace)
+				other := gwapi.SectionName("https")
+				route.Status.Parents[0].ParentRef.SectionName = &other
+				return route
+			}(),
+			expectedEvents: []string{notAttachedEvent},
+		},
+		"should not create Certificates if the listener doesn't allow TLSRoutes": {
+			route: buildRoute("passthrough", gen.DefaultTestNamespace),
+			gateway: withPassthroughListener(func(l *gwapi.Listener) {
+				l.AllowedRoutes = &gwapi.AllowedRoutes{Kinds: []gwapi.RouteGroupKind{{Kind: "TCPRoute"}}}
+			}),
+			expectedEvents: []string{notAttachedEvent},
+		},
+		"should not create Certificates if the listener hostname doesn't match the TLSRoute hostnames": {
+			route: buildRoute("passthrough", gen.DefaultTestNamespace),
+			gateway: withPassthroughListener(func(l *gwapi.Listener) {
+				hostname := gwapi.Hostname("*.example.org")
+				l.Hostname = &hostname
+			}),
+			expectedEvents: []string{notAttachedEvent},
+		},
+		"should create Certificates if the listener wildcard hostname matches the TLSRoute hostnames": {
+			route: func() *gwapiv1alpha2.TLSRoute {
+				route := buildRoute("passthrough", gen.DefaultTestNamespace)
+				route.Spec.Hostnames = []gwapiv1alpha2.Hostname{"foo.example.com"}
+				return route
+			}(),
+			gateway: withPassthroughListener(func(l *gwapi.Listener) {
+				hostname := gwapi.Hostname("*.example.com")
+				l.Hostname = &hostname
+			}),
+			expectedCreate: []*cmapi.Certificate{func() *cmapi.Certificate {
+				crt := buildCrt(gen.DefaultTestNamespace)
+				crt.Spec.DNSNames = []string{"foo.example.com"}
+				return crt
+			}()},
+			expectedEvents: []string{`Normal CreateCertificate Successfully created Certificate default-unit-test-ns/route-tls`},
+		},
+		"should not create Certificates if the listener only allows routes from namespaces with other labels": {
+			route: buildRoute("passthrough", gen.DefaultTestNamespace),
+			gateway: withPassthroughListener(func(l *gwapi.Listener) {
+				from := gwapi.NamespacesFromSelector
+				l.AllowedRoutes = &gwapi.AllowedRoutes{Namespaces: &gwapi.RouteNamespaces{
+					From:     &from,
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"passthrough": "allowed"}},
+				}}
+			}),
+			namespaces:     []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: gen.DefaultTestNamespace}}},
+			expectedEvents: []string{notAttachedEvent},
+		},
+		"should create Certificates if the listener allows routes from namespaces with matching labels": {
+			route: buildRoute("passthrough", gen.DefaultTestNamespace),
+			gateway: withPassthroughListener(func(l *gwapi.Listener) {
+				from := gwapi.NamespacesFromSelector
+				l.AllowedRoutes = &gwapi.AllowedRoutes{Namespaces: &gwapi.RouteNamespaces{
+					From:     &from,
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"passthrough": "allowed"}},
+				}}
+			}),
+			namespaces: []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{
+				Name:   gen.DefaultTestNamespace,
+				Labels: map[string]string{"passthrough": "allowed"},
+			}}},
+			expectedCreate: []*cmapi.Certificate{buildCrt(gen.DefaultTestNamespace)},
+			expectedEvents: []string{`Normal CreateCertificate Successfully created Certificate default-unit-test-ns/route-tls`},
+		},
+		"should not create Certificates if the TLSRoute has no hostnames": {
+			route: func() *gwapiv1alpha2.TLSRoute {
+				route := buildRoute("passthrough", gen.DefaultTestNamespace)
+				route.Spec.Hostnames = nil
+				return route
+			}(),
+			expectedEvents: []string{`Warning BadConfig Skipped TLSRoute: spec.hostnames: Required value: the hostnames cannot be empty`},
+		},
+		"should report the readiness of existing Certificates on the TLSRoute only once": {
+			route: buildRoute("passthrough", gen.DefaultTestNamespace, backendNamespace),
+			syncs: 2,
+			existingCrts: []runtime.Object{
+				buildCrt(gen.DefaultTestNamespace, cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionTrue}),
+				buildCrt(backendNamespace, cmapi.CertificateCondition{Type: cmapi.CertificateConditionReady, Status: cmmeta.ConditionFalse, Message: "Issuing certificate as Secret does not exist"}),
+			},
+			referenceGrants: []*gwapi.ReferenceGrant{backendGrant},
+			expectedEvents: []string{
+				`Warning CertificateNotReady Certificate backend-namespace/route-tls is not ready: Issuing certificate as Secret does not exist`,
+				`Normal CertificateReady Certificate default-unit-test-ns/route-tls is ready`,
+			},
+		},
+		"should not update a Certificate that wasn't created for the TLSRoute": {
+			route:          buildRoute("passthrough", gen.DefaultTestNamespace),
+			existingCrts:   []runtime.Object{buildCertificate("route-tls", gen.DefaultTestNamespace, nil)},
+			expectedEvents: []string{`Warning BadConfig Refusing to update Certificate default-unit-test-ns/route-tls which was not created for this TLSRoute`},
+		},
+		"should delete the Certificates of backend namespaces that are no longer referenced": {
+			route: buildRoute("passthrough", gen.DefaultTestNamespace),
+			existingCrts: []runtime.Object{
+				buildCrt(gen.DefaultTestNamespace),
+				buildCrt(backendNamespace),
+			},
+			expectedDelete: []*cmapi.Certificate{buildCrt(backendNamespace)},
+			expectedEvents: []string{`Normal DeleteCertificate Successfully deleted unrequired Certificate backend-namespace/route-tls`},
+		},
+		"should delete all the Certificates of a TLSRoute that is no longer annotated": {
+			route: func() *gwapiv1alpha2.TLSRoute {
+				route := buildRoute("passthrough", backendNamespace)
+				route.Annotations = nil
+				return route
+			}(),
+			existingCrts:    []runtime.Object{buildCrt(backendNamespace)},
+			referenceGrants: []*gwapi.ReferenceGrant{backendGrant},
+			expectedDelete:  []*cmapi.Certificate{buildCrt(backendNamespace)},
+			expectedEvents:  []string{`Normal DeleteCertificate Successfully deleted unrequired Certificate backend-namespace/route-tls`},
+		},
+		"should delete all the Certificates of a TLSRoute that is no longer attached to a passthrough listener": {
+			route:           buildRoute("https", backendNamespace),
+			existingCrts:    []runtime.Object{buildCrt(backendNamespace)},
+			referenceGrants: []*gwapi.ReferenceGrant{backendGrant},
+			expectedDelete:  []*cmapi.Certificate{buildCrt(backendNamespace)},
+			expectedEvents: []string{
+				notAttachedEvent,
+				`Normal DeleteCertificate Successfully deleted unrequired Certificate backend-namespace/route-tls`,
+			},
+		},
+		"should delete all the Certificates of a deleted TLSRoute": {
+			routeName:      "route",
+			existingCrts:   []runtime.Object{buildCrt(backendNamespace)},
+			expectedDelete: []*cmapi.Certificate{buildCrt(backendNamespace)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var expectedActions []testpkg.Action
+			for _, crt := range test.expectedCreate {
+				expectedActions = append(expectedActions, testpkg.NewAction(coretesting.NewCreateAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"), crt.Namespace, crt)))
+			}
+			for _, crt := range test.expectedDelete {
+				expectedActions = append(expectedActions, testpkg.NewAction(coretesting.NewDeleteAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"), crt.Namespace, crt.Name)))
+			}
+			b := &testpkg.Builder{
+				T:                  t,
+				CertManagerObjects: test.existingCrts,
+				ExpectedActions:    expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			b.Init()
+			defer b.Stop()
+
+			gateway := passthroughGateway
+			if test.gateway != nil {
+				gateway = test.gateway
+			}
+			gatewayIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if err := gatewayIndexer.Add(gateway); err != nil {
+				t.Fatal(err)
+			}
+			namespaceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, ns := range test.namespaces {
+				if err := namespaceIndexer.Add(ns); err != nil {
+					t.Fatal(err)
+				}
+			}
+			grantIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, grant := range test.referenceGrants {
+				if err := grantIndexer.Add(grant); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			sync := TLSRouteSyncFnFor(b.Recorder, logr.Discard(), b.CMClient, b.SharedInformerFactory.Certmanager().V1().Certificates().Lister(),
+				gwlisters.NewGatewayLister(gatewayIndexer), gwlisters.NewReferenceGrantLister(grantIndexer), corelisters.NewNamespaceLister(namespaceIndexer),
+				controller.IngressShimOptions{}, "cert-manager-test")
+			b.Start()
+
+			name := test.routeName
+			if test.route != nil {
+				name = test.route.Name
+			}
+			for i := 0; i < test.syncs || i == 0; i++ {
+				if err := sync(context.Background(), gen.DefaultTestNamespace, name, test.route); err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}
+
+			if err := b.AllEventsCalled(); err != nil {
+				t.Error(err)
+			}
+			if err := b.AllActionsExecuted(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
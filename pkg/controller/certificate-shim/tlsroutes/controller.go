@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gwlistersv1alpha2 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1alpha2"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	shimhelper "github.com/cert-manager/cert-manager/pkg/controller/certificate-shim"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ControllerName is the name of the tlsroute-shim controller. It creates
+	// Certificates for the backends of TLSRoutes attached to Gateway listeners
+	// using TLS passthrough. It requires the TLSRoute CRD of the experimental
+	// Gateway API channel, and is thus not enabled by default.
+	ControllerName = "tlsroute-shim"
+)
+
+type controller struct {
+	tlsRouteLister gwlistersv1alpha2.TLSRouteLister
+	sync           shimhelper.TLSRouteSyncFn
+
+	// For testing purposes.
+	queue workqueue.RateLimitingInterface
+}
+
+func (c *controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	tlsRouteInformer := ctx.GWShared.Gateway().V1alpha2().TLSRoutes()
+	gatewayInformer := ctx.GWShared.Gateway().V1beta1().Gateways()
+	referenceGrantInformer := ctx.GWShared.Gateway().V1beta1().ReferenceGrants()
+	namespaceInformer := ctx.KubeSharedInformerFactory.Core().V1().Namespaces()
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+
+	c.tlsRouteLister = tlsRouteInformer.Lister()
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+	c.sync = shimhelper.TLSRouteSyncFnFor(ctx.Recorder, log, ctx.CMClient, certificateInformer.Lister(), gatewayInformer.Lister(),
+		referenceGrantInformer.Lister(), namespaceInformer.Lister(), ctx.IngressShimOptions, ctx.FieldManager)
+
+	// TLSRoutes are requeued on "Deleted" events so that the Certificates
+	// created in the namespaces of their backends are deleted, since these
+	// can't be garbage collected using owner references.
+	tlsRouteInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{
+		Queue: c.queue,
+	})
+
+	// The Certificates are requeued to recreate them when deleted, and to
+	// report their readiness on the TLSRoute.
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: certificateHandler(c.queue),
+	})
+
+	// Whether a TLSRoute is attached to a passthrough listener depends on
+	// its parent Gateways and the labels of its namespace, and whether its
+	// cross namespace backends are allowed depends on ReferenceGrants.
+	gatewayInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: gatewayHandler(c.queue, c.tlsRouteLister),
+	})
+	referenceGrantInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: referenceGrantHandler(c.queue, c.tlsRouteLister),
+	})
+	namespaceInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: namespaceHandler(c.queue, c.tlsRouteLister),
+	})
+
+	mustSync := []cache.InformerSynced{
+		tlsRouteInformer.Informer().HasSynced,
+		gatewayInformer.Informer().HasSynced,
+		referenceGrantInformer.Informer().HasSynced,
+		namespaceInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+	}
+
+	return c.queue, mustSync, nil
+}
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		runtime.HandleError(fmt.Errorf("invalid resource key: %s", key))
+		return nil
+	}
+
+	route, err := c.tlsRouteLister.TLSRoutes(namespace).Get(name)
+	if err != nil {
+		if k8sErrors.IsNotFound(err) {
+			return c.sync(ctx, namespace, name, nil)
+		}
+
+		return err
+	}
+
+	return c.sync(ctx, namespace, name, route)
+}
+
+// certificateHandler requeues the TLSRoute a Certificate was created for.
+// Since the Certificate may live in another namespace than the TLSRoute, the
+// TLSRoute is found using the labels of the Certificate rather than its owner
+// references.
+func certificateHandler(queue workqueue.RateLimitingInterface) func(obj interface{}) {
+	return func(obj interface{}) {
+		crt, ok := obj.(*cmapi.Certificate)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("not a Certificate object: %#v", obj))
+			return
+		}
+
+		namespace, hasNamespace := crt.Labels[cmapi.TLSRouteNamespaceLabelKey]
+		name, hasName := crt.Labels[cmapi.TLSRouteNameLabelKey]
+		if !hasNamespace || !hasName {
+			return
+		}
+
+		queue.Add(namespace + "/" + name)
+	}
+}
+
+// gatewayHandler requeues the TLSRoutes that reference a Gateway as parent.
+func gatewayHandler(queue workqueue.RateLimitingInterface, tlsRouteLister gwlistersv1alpha2.TLSRouteLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		gateway, ok := obj.(*gwapi.Gateway)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("not a Gateway object: %#v", obj))
+			return
+		}
+
+		routes, err := tlsRouteLister.List(labels.Everything())
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("error listing TLSRoutes: %w", err))
+			return
+		}
+
+		for _, route := range routes {
+			for _, ref := range route.Spec.ParentRefs {
+				namespace := route.Namespace
+				if ref.Namespace != nil {
+					namespace = string(*ref.Namespace)
+				}
+				if namespace == gateway.Namespace && string(ref.Name) == gateway.Name {
+					queue.Add(route.Namespace + "/" + route.Name)
+					break
+				}
+			}
+		}
+	}
+}
+
+// referenceGrantHandler requeues the TLSRoutes in the namespaces a
+// ReferenceGrant allows references from.
+func referenceGrantHandler(queue workqueue.RateLimitingInterface, tlsRouteLister gwlistersv1alpha2.TLSRouteLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		grant, ok := obj.(*gwapi.ReferenceGrant)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("not a ReferenceGrant object: %#v", obj))
+			return
+		}
+
+		for _, from := range grant.Spec.From {
+			if from.Kind != "TLSRoute" {
+				continue
+			}
+			routes, err := tlsRouteLister.TLSRoutes(string(from.Namespace)).List(labels.Everything())
+			if err != nil {
+				runtime.HandleError(fmt.Errorf("error listing TLSRoutes: %w", err))
+				return
+			}
+			for _, route := range routes {
+				queue.Add(route.Namespace + "/" + route.Name)
+			}
+		}
+	}
+}
+
+// namespaceHandler requeues the TLSRoutes in a namespace, since Gateway
+// listeners may select the namespaces routes are allowed from by label.
+func namespaceHandler(queue workqueue.RateLimitingInterface, tlsRouteLister gwlistersv1alpha2.TLSRouteLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		ns, ok := obj.(*corev1.Namespace)
+		if !ok {
+			runtime.HandleError(fmt.Errorf("not a Namespace object: %#v", obj))
+			return
+		}
+
+		routes, err := tlsRouteLister.TLSRoutes(ns.Name).List(labels.Everything())
+		if err != nil {
+			runtime.HandleError(fmt.Errorf("error listing TLSRoutes: %w", err))
+			return
+		}
+		for _, route := range routes {
+			queue.Add(route.Namespace + "/" + route.Name)
+		}
+	}
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controller{queue: workqueue.NewNamedRateLimitingQueue(controllerpkg.DefaultItemBasedRateLimiter(), ControllerName)}).
+			Complete()
+	})
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	gwapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapi "sigs.k8s.io/gateway-api/apis/v1beta1"
+	gwlistersv1alpha2 "sigs.k8s.io/gateway-api/pkg/client/listers/apis/v1alpha2"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func Test_handlers(t *testing.T) {
+	gatewayNamespace := gwapi.Namespace("gateway-namespace")
+	routes := []*gwapiv1alpha2.TLSRoute{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-1", Name: "route-1"},
+			Spec: gwapiv1alpha2.TLSRouteSpec{CommonRouteSpec: gwapi.CommonRouteSpec{
+				ParentRefs: []gwapi.ParentReference{{Name: "gateway-1", Namespace: &gatewayNamespace}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-1", Name: "route-2"},
+			Spec: gwapiv1alpha2.TLSRouteSpec{CommonRouteSpec: gwapi.CommonRouteSpec{
+				ParentRefs: []gwapi.ParentReference{{Name: "gateway-1"}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-2", Name: "route-3"},
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, route := range routes {
+		require.NoError(t, indexer.Add(route))
+	}
+	lister := gwlistersv1alpha2.NewTLSRouteLister(indexer)
+
+	tests := map[string]struct {
+		handler      func(workqueue.RateLimitingInterface) func(interface{})
+		obj          interface{}
+		expectedKeys []string
+	}{
+		"Certificate created for a TLSRoute requeues the TLSRoute": {
+			handler: certificateHandler,
+			obj: &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "backend-namespace", Name: "route-1-tls", Labels: map[string]string{
+				cmapi.TLSRouteNamespaceLabelKey: "namespace-1",
+				cmapi.TLSRouteNameLabelKey:      "route-1",
+			}}},
+			expectedKeys: []string{"namespace-1/route-1"},
+		},
+		"Certificate not created for a TLSRoute is ignored": {
+			handler: certificateHandler,
+			obj:     &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "namespace-1", Name: "cert-1"}},
+		},
+		"Gateway requeues the TLSRoutes referencing it as parent": {
+			handler: func(queue workqueue.RateLimitingInterface) func(interface{}) {
+				return gatewayHandler(queue, lister)
+			},
+			obj:          &gwapi.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "gateway-namespace", Name: "gateway-1"}},
+			expectedKeys: []string{"namespace-1/route-1"},
+		},
+		"ReferenceGrant requeues the TLSRoutes it allows references from": {
+			handler: func(queue workqueue.RateLimitingInterface) func(interface{}) {
+				return referenceGrantHandler(queue, lister)
+			},
+			obj: &gwapi.ReferenceGrant{Spec: gwapi.ReferenceGrantSpec{
+				From: []gwapi.ReferenceGrantFrom{
+					{Group: gwapi.GroupName, Kind: "TLSRoute", Namespace: "namespace-2"},
+					{Group: gwapi.GroupName, Kind: "HTTPRoute", Namespace: "namespace-1"},
+				},
+			}},
+			expectedKeys: []string{"namespace-2/route-3"},
+		},
+		"Namespace requeues the TLSRoutes in the namespace": {
+			handler: func(queue workqueue.RateLimitingInterface) func(interface{}) {
+				return namespaceHandler(queue, lister)
+			},
+			obj:          &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "namespace-2"}},
+			expectedKeys: []string{"namespace-2/route-3"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+			defer queue.ShutDown()
+
+			test.handler(queue)(test.obj)
+
+			var keys []string
+			for queue.Len() > 0 {
+				key, _ := queue.Get()
+				keys = append(keys, key.(string))
+				queue.Done(key)
+			}
+			assert.Equal(t, test.expectedKeys, keys)
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/x509"
 	"fmt"
+	"strings"
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +32,7 @@ import (
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmacmeclientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/typed/acme/v1"
 	cmacmelisters "github.com/cert-manager/cert-manager/pkg/client/listers/acme/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -48,8 +50,30 @@ const (
 	// this controller when enabling or disabling it from
 	// command line flags.
 	CRControllerName = "certificaterequests-issuer-acme"
+
+	// badCSRProblemType is the ACME problem type returned by ACME servers
+	// when they reject the CSR used to finalize an order.
+	badCSRProblemType = "urn:ietf:params:acme:error:badCSR"
 )
 
+// privateKeyRejectedDetails are the phrases in the detail of a badCSR ACME
+// problem which known ACME servers use when they reject the public key of a
+// CSR, rather than its identifiers or extensions. A badCSR problem with any
+// other detail does not mark the private key as rejected, so that the private
+// key is only replaced when a different key may be accepted.
+var privateKeyRejectedDetails = []string{
+	// Boulder, e.g. Let's Encrypt, rejects keys which do not satisfy its key
+	// policy with "invalid public key in CSR: key too small: 1024" and
+	// similar details.
+	"invalid public key in csr",
+	// Boulder rejects CSRs which use the ACME account key.
+	"certificate public key must be different than account key",
+	// Pebble fails to parse CSRs with keys unsupported by Go's x509 package,
+	// with "Error parsing Base64url-encoded CSR: x509: unsupported elliptic
+	// curve".
+	"x509: unsupported elliptic curve",
+}
+
 // ACME is a controller that implements `certificaterequests.Issuer`.
 type ACME struct {
 	// used to record Events about resources to the API
@@ -193,6 +217,13 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 
 	// If the acme order has failed then so too does the CertificateRequest meet the same fate.
 	if acme.IsFailureState(order.Status.State) {
+		// Signal to the requester that a request with a different private
+		// key may succeed.
+		if orderRejectedPrivateKey(order) {
+			apiutil.SetCertificateRequestCondition(cr, cmapi.CertificateRequestConditionPrivateKeyRejected,
+				cmmeta.ConditionTrue, "BadCSR", order.Status.Reason)
+		}
+
 		message := fmt.Sprintf("Failed to wait for order resource %q to become ready", expectedOrder.Name)
 		err := fmt.Errorf("order is in %q state: %s", order.Status.State, order.Status.Reason)
 		a.reporter.Failed(cr, err, "OrderFailed", message)
@@ -238,6 +269,26 @@ func (a *ACME) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuer cm
 	}, nil
 }
 
+// orderRejectedPrivateKey returns true if the ACME server rejected the CSR of
+// the given failed Order because of its public key. The order controller
+// records the ACME problem returned when finalizing the Order in its reason,
+// as "<status> <problem type>: <detail>".
+func orderRejectedPrivateKey(order *cmacme.Order) bool {
+	marker := " " + badCSRProblemType + ": "
+	i := strings.Index(order.Status.Reason, marker)
+	if i < 0 {
+		return false
+	}
+
+	detail := strings.ToLower(order.Status.Reason[i+len(marker):])
+	for _, phrase := range privateKeyRejectedDetails {
+		if strings.Contains(detail, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
 // Build order. If we error here it is a terminating failure.
 func buildOrder(cr *cmapi.CertificateRequest, csr *x509.CertificateRequest, enableDurationFeature bool) (*cmacme.Order, error) {
 	var ipAddresses []string
@@ -484,6 +484,45 @@ func TestSign(t *testing.T) {
 			},
 		},
 
+		"if the order failed because the ACME server rejected the private key then we should report failure and the rejection": {
+			certificateRequest: baseCR.DeepCopy(),
+			builder: &testpkg.Builder{
+				ExpectedEvents: []string{
+					`Warning OrderFailed Failed to wait for order resource "test-cr-1733622556" to become ready: order is in "errored" state: Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error finalizing order :: invalid public key in CSR: key too small: 1024`,
+				},
+				CertManagerObjects: []runtime.Object{baseCR.DeepCopy(), baseIssuer.DeepCopy(),
+					gen.OrderFrom(baseOrder,
+						gen.SetOrderState(cmacme.Errored),
+						gen.SetOrderReason("Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error finalizing order :: invalid public key in CSR: key too small: 1024"),
+					),
+				},
+				ExpectedActions: []testpkg.Action{
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(baseCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionPrivateKeyRejected,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             "BadCSR",
+								Message:            "Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error finalizing order :: invalid public key in CSR: key too small: 1024",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            `Failed to wait for order resource "test-cr-1733622556" to become ready: order is in "errored" state: Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error finalizing order :: invalid public key in CSR: key too small: 1024`,
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+		},
+
 		"if the order is in an unknown state, then report pending": {
 			certificateRequest: baseCR.DeepCopy(),
 			builder: &testpkg.Builder{
@@ -779,3 +818,60 @@ func Test_buildOrder(t *testing.T) {
 		}
 	})
 }
+
+func Test_orderRejectedPrivateKey(t *testing.T) {
+	tests := map[string]struct {
+		reason string
+		exp    bool
+	}{
+		"Boulder rejected a key which is too small": {
+			reason: "Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error finalizing order :: invalid public key in CSR: key too small: 1024",
+			exp:    true,
+		},
+		"Boulder rejected an unsupported curve": {
+			reason: "Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error finalizing order :: invalid public key in CSR: ECDSA curve P-521 not allowed",
+			exp:    true,
+		},
+		"Boulder rejected the account key": {
+			reason: "Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error finalizing order :: certificate public key must be different than account key",
+			exp:    true,
+		},
+		"Boulder rejected an identifier": {
+			reason: "Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error finalizing order :: CSR contains a name not present in the order",
+			exp:    false,
+		},
+		"Boulder rejected the signature algorithm": {
+			reason: "Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error finalizing order :: signature algorithm not supported",
+			exp:    false,
+		},
+		"Pebble rejected an unsupported curve": {
+			reason: "Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Error parsing Base64url-encoded CSR: x509: unsupported elliptic curve",
+			exp:    true,
+		},
+		"Pebble rejected an identifier": {
+			reason: "Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: Order includes different number of DNSnames identifiers than CSR specifies",
+			exp:    false,
+		},
+		"badCSR details which only mention keys": {
+			reason: "Failed to finalize Order: 400 urn:ietf:params:acme:error:badCSR: RSA key usage extension not allowed",
+			exp:    false,
+		},
+		"other ACME problems with a matching detail": {
+			reason: "Failed to finalize Order: 403 urn:ietf:params:acme:error:unauthorized: invalid public key in CSR",
+			exp:    false,
+		},
+		"order failed while solving challenges": {
+			reason: "simulated failure",
+			exp:    false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			order := gen.Order("test", gen.SetOrderState(cmacme.Errored), gen.SetOrderReason(test.reason))
+			if got := orderRejectedPrivateKey(order); got != test.exp {
+				t.Errorf("unexpected result, exp=%t got=%t", test.exp, got)
+			}
+		})
+	}
+}
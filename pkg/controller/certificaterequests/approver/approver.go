@@ -26,6 +26,7 @@ import (
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
+	"github.com/cert-manager/cert-manager/internal/controller/approval"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
@@ -37,10 +38,11 @@ const (
 )
 
 // Controller is a CertificateRequest controller which manages the "Approved"
-// condition. In the absence of any automated policy engine, this controller
-// will _always_ set the "Approved" condition to True. All CertificateRequest
-// signing controllers should wait until the "Approved" condition is set to
-// True before processing.
+// condition. In the absence of an approval policy for the referenced issuer,
+// this controller will _always_ set the "Approved" condition to True. If an
+// approval policy applies, the request is approved or denied based on that
+// policy. All CertificateRequest signing controllers should wait until the
+// "Approved" condition is set to True before processing.
 type Controller struct {
 	// logger to be used by this controller
 	log logr.Logger
@@ -49,6 +51,10 @@ type Controller struct {
 	cmClient                 cmclient.Interface
 	fieldManager             string
 
+	// approvalPolicy is the optional set of policies to evaluate requests
+	// against.
+	approvalPolicy *approval.Config
+
 	recorder record.EventRecorder
 
 	queue workqueue.RateLimitingInterface
@@ -77,6 +83,7 @@ func (c *Controller) Register(ctx *controllerpkg.Context) (workqueue.RateLimitin
 	c.cmClient = ctx.CMClient
 	c.fieldManager = ctx.FieldManager
 	c.recorder = ctx.Recorder
+	c.approvalPolicy = ctx.ApprovalPolicy
 
 	c.log.V(logf.DebugLevel).Info("certificate request approver controller registered")
 
@@ -18,6 +18,7 @@ package approver
 
 import (
 	"context"
+	"crypto/x509"
 	"testing"
 	"time"
 
@@ -25,16 +26,30 @@ import (
 	coretesting "k8s.io/client-go/testing"
 	fakeclock "k8s.io/utils/clock/testing"
 
+	"github.com/cert-manager/cert-manager/internal/controller/approval"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func TestProcessItem(t *testing.T) {
 	// now time is the current time at the start of the test (the clock is fixed)
 	now := time.Now()
 	metaNow := metav1.NewTime(now)
+
+	csrPEM, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames("foo.example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &approval.Config{Policies: []approval.Policy{{
+		Name:            "example",
+		SignerNames:     []string{"issuers.cert-manager.io/testns.*"},
+		AllowedDNSNames: []string{"*.example.com"},
+	}}}
+
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem.
 		// if not set, the 'namespace/name' of the 'CertificateRequest' field will be used.
@@ -45,6 +60,9 @@ func TestProcessItem(t *testing.T) {
 		// if not set, the 'key' will be passed to ProcessItem instead.
 		request *cmapi.CertificateRequest
 
+		// policy is the approval policy configured on the controller, if any.
+		policy *approval.Config
+
 		// expectedEvent, if set, is an 'event string' that is expected to be fired.
 		expectedEvent string
 
@@ -165,6 +183,70 @@ func TestProcessItem(t *testing.T) {
 			},
 			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
 		},
+		"approve CertificateRequest that satisfies the approval policy": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					Request:   csrPEM,
+					IssuerRef: cmmeta.ObjectReference{Name: "ca"},
+				},
+			},
+			policy: policy,
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+		},
+		"approve CertificateRequest if no approval policy applies to the issuer": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "otherns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					Request:   csrPEM,
+					IssuerRef: cmmeta.ObjectReference{Name: "ca"},
+				},
+			},
+			policy: &approval.Config{Policies: []approval.Policy{{
+				Name:        "nothing-allowed",
+				SignerNames: []string{"issuers.cert-manager.io/testns.*"},
+			}}},
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionApproved,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             "cert-manager.io",
+					Message:            ApprovedMessage,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: "Normal cert-manager.io Certificate request has been approved by cert-manager.io",
+		},
+		"deny CertificateRequest that violates the approval policy": {
+			request: &cmapi.CertificateRequest{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test"},
+				Spec: cmapi.CertificateRequestSpec{
+					Request:   csrPEM,
+					IssuerRef: cmmeta.ObjectReference{Name: "ca"},
+					IsCA:      true,
+				},
+			},
+			policy: policy,
+			expectedConditions: []cmapi.CertificateRequestCondition{
+				{
+					Type:               cmapi.CertificateRequestConditionDenied,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             DeniedReason,
+					Message:            `Certificate request has been denied by policy "example": CA certificates are not allowed`,
+					LastTransitionTime: &metaNow,
+				},
+			},
+			expectedEvent: `Warning PolicyViolation Certificate request has been denied by policy "example": CA certificates are not allowed`,
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -177,6 +259,7 @@ func TestProcessItem(t *testing.T) {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, test.request)
 			}
 			builder.Init()
+			builder.ApprovalPolicy = test.policy
 
 			c := new(Controller)
 			_, _, err := c.Register(builder.Context)
@@ -18,10 +18,13 @@ package approver
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/cert-manager/cert-manager/internal/controller/approval"
 	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
@@ -33,11 +36,17 @@ import (
 
 const (
 	ApprovedMessage = "Certificate request has been approved by cert-manager.io"
+
+	// DeniedReason is the reason used when a CertificateRequest violates the
+	// approval policy that applies to it.
+	DeniedReason = "PolicyViolation"
 )
 
 // Sync will set the "Approved" condition to True on synced
-// CertificateRequests. If the "Denied", "Approved" or "Ready" condition
-// already exists, exit early.
+// CertificateRequests, unless an approval policy applies to the referenced
+// issuer and the request violates it, in which case the "Denied" condition is
+// set to True. If the "Denied", "Approved" or "Ready" condition already
+// exists, exit early.
 func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (err error) {
 	log := logf.FromContext(ctx, "approver")
 
@@ -55,8 +64,36 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 		return nil
 	}
 
-	// Update the CertificateRequest approved condition to true.
 	cr = cr.DeepCopy()
+
+	if policy := c.approvalPolicy.PolicyFor(approval.SignerNameForIssuerRef(cr)); policy != nil {
+		violations, err := evaluatePolicy(policy, cr)
+		if err != nil {
+			// A malformed request will never become valid, so deny it rather
+			// than retrying.
+			violations = []string{err.Error()}
+		}
+
+		if len(violations) > 0 {
+			message := fmt.Sprintf("Certificate request has been denied by policy %q: %s", policy.Name, strings.Join(violations, ", "))
+			apiutil.SetCertificateRequestCondition(cr,
+				cmapi.CertificateRequestConditionDenied,
+				cmmeta.ConditionTrue,
+				DeniedReason,
+				message,
+			)
+			if err := c.updateStatusOrApply(ctx, cr); err != nil {
+				return err
+			}
+			c.recorder.Event(cr, corev1.EventTypeWarning, DeniedReason, message)
+
+			log.V(logf.DebugLevel).Info("denied certificate request", "policy", policy.Name, "violations", violations)
+
+			return nil
+		}
+	}
+
+	// Update the CertificateRequest approved condition to true.
 	apiutil.SetCertificateRequestCondition(cr,
 		cmapi.CertificateRequestConditionApproved,
 		cmmeta.ConditionTrue,
@@ -75,6 +112,14 @@ func (c *Controller) Sync(ctx context.Context, cr *cmapi.CertificateRequest) (er
 	return nil
 }
 
+func evaluatePolicy(policy *approval.Policy, cr *cmapi.CertificateRequest) ([]string, error) {
+	req, err := approval.RequestFromCertificateRequest(cr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode request: %w", err)
+	}
+	return policy.Evaluate(req), nil
+}
+
 func (c *Controller) updateStatusOrApply(ctx context.Context, cr *cmapi.CertificateRequest) error {
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
 		return internalcertificaterequests.ApplyStatus(ctx, c.cmClient, c.fieldManager, cr)
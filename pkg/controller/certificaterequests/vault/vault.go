@@ -30,6 +30,7 @@ import (
 	crutil "github.com/cert-manager/cert-manager/pkg/controller/certificaterequests/util"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 )
 
 const (
@@ -46,6 +47,8 @@ type Vault struct {
 	reporter      *crutil.Reporter
 
 	vaultClientBuilder vaultinternal.ClientBuilder
+
+	metrics *metrics.Metrics
 }
 
 func init() {
@@ -67,6 +70,7 @@ func NewVault(ctx *controllerpkg.Context) certificaterequests.Issuer {
 		secretsLister:      ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		reporter:           crutil.NewReporter(ctx.Clock, ctx.Recorder),
 		vaultClientBuilder: vaultinternal.New,
+		metrics:            ctx.Metrics,
 	}
 }
 
@@ -78,7 +82,7 @@ func (v *Vault) Sign(ctx context.Context, cr *v1.CertificateRequest, issuerObj v
 
 	resourceNamespace := v.issuerOptions.ResourceNamespace(issuerObj)
 
-	client, err := v.vaultClientBuilder(resourceNamespace, v.createTokenFn, v.secretsLister, issuerObj)
+	client, err := v.vaultClientBuilder(resourceNamespace, v.createTokenFn, v.secretsLister, issuerObj, v.metrics)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
 
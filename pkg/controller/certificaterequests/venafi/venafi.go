@@ -23,6 +23,7 @@ import (
 
 	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
 	"github.com/Venafi/vcert/v4/pkg/endpoint"
@@ -36,6 +37,7 @@ import (
 	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
 	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/policy"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -80,6 +82,31 @@ func (v *Venafi) Sign(ctx context.Context, cr *cmapi.CertificateRequest, issuerO
 	log := logf.FromContext(ctx, "sign")
 	log = logf.WithRelatedResource(log, issuerObj)
 
+	// Validate the request against the cached zone policy before it is
+	// submitted, so that requests Venafi is likely to reject are reported
+	// with field level reasons. Since the cached policy may be out of date,
+	// a violation is only reported and the request is still submitted,
+	// leaving the final decision to Venafi.
+	if zonePolicy := policy.ForIssuer(issuerObj); zonePolicy != nil && len(cr.Annotations[cmapi.VenafiPickupIDAnnotationKey]) == 0 {
+		csr, err := utilpki.DecodeX509CertificateRequestBytes(cr.Spec.Request)
+		if err != nil {
+			message := "Failed to decode CSR in spec.request"
+
+			v.reporter.Failed(cr, err, "RequestParsingError", message)
+			log.Error(err, message)
+
+			return nil, nil
+		}
+
+		if el := policy.ValidateCertificateRequest(zonePolicy, csr, field.NewPath("spec", "request")); len(el) > 0 {
+			err := el.ToAggregate()
+			message := fmt.Sprintf("Request may not satisfy the cached policy of Venafi zone %q, submitting it to Venafi", zonePolicy.Zone)
+
+			v.reporter.Pending(cr, err, "ZonePolicyViolation", message)
+			log.V(logf.WarnLevel).Info(message, "error", err.Error())
+		}
+	}
+
 	client, err := v.clientBuilder(v.issuerOptions.ResourceNamespace(issuerObj), v.secretsLister, issuerObj, v.metrics, log)
 	if k8sErrors.IsNotFound(err) {
 		message := "Required secret resource not found"
@@ -186,6 +186,14 @@ func TestSign(t *testing.T) {
 		}),
 	)
 
+	tppIssuerWithZonePolicy := gen.IssuerFrom(tppIssuer,
+		gen.SetIssuerVenafiZonePolicy(&cmapi.VenafiZonePolicy{
+			AllowedKeys: []cmapi.VenafiAllowedKey{
+				{Algorithm: cmapi.RSAKeyAlgorithm, Sizes: []int{2048}},
+			},
+		}),
+	)
+
 	tppCRWithCustomFields := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/custom-fields": `[{"name": "cert-manager-test", "value": "test ok"}]`}))
 
 	tppCRWithInvalidCustomFields := gen.CertificateRequestFrom(tppCR, gen.SetCertificateRequestAnnotations(map[string]string{"venafi.cert-manager.io/custom-fields": `[{"name": cert-manager-test}]`}))
@@ -680,6 +688,83 @@ func TestSign(t *testing.T) {
 			fakeSecretLister: failGetSecretLister,
 			fakeClient:       clientReturnsCert,
 		},
+		"tpp: if the request violates the cached zone policy then set pending and leave the decision to Venafi": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuerWithZonePolicy.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal ZonePolicyViolation Request may not satisfy the cached policy of Venafi zone "", submitting it to Venafi: spec.request.privateKey.algorithm: Unsupported value: "ECDSA": supported values: "RSA"`,
+					"Normal CertificateIssued Certificate fetched from issuer successfully",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonPending,
+								Message:            "Venafi certificate is requested",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+						),
+					)),
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionTrue,
+								Reason:             cmapi.CertificateRequestReasonIssued,
+								Message:            "Certificate fetched from issuer successfully",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestCertificate(certPEM),
+							gen.SetCertificateRequestCA(rootPEM),
+							gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiPickupIDAnnotationKey: "test"}),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsCert,
+			expectedErr:      false,
+		},
+		"tpp: if the request violates the cached zone policy and Venafi rejects it then fail": {
+			certificateRequest: tppCR.DeepCopy(),
+			builder: &controllertest.Builder{
+				CertManagerObjects: []runtime.Object{tppCR.DeepCopy(), tppIssuerWithZonePolicy.DeepCopy()},
+				ExpectedEvents: []string{
+					`Normal ZonePolicyViolation Request may not satisfy the cached policy of Venafi zone "", submitting it to Venafi: spec.request.privateKey.algorithm: Unsupported value: "ECDSA": supported values: "RSA"`,
+					"Warning RequestError Failed to request venafi certificate: this is an error",
+				},
+				ExpectedActions: []controllertest.Action{
+					controllertest.NewAction(coretesting.NewUpdateSubresourceAction(
+						cmapi.SchemeGroupVersion.WithResource("certificaterequests"),
+						"status",
+						gen.DefaultTestNamespace,
+						gen.CertificateRequestFrom(tppCR,
+							gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+								Type:               cmapi.CertificateRequestConditionReady,
+								Status:             cmmeta.ConditionFalse,
+								Reason:             cmapi.CertificateRequestReasonFailed,
+								Message:            "Failed to request venafi certificate: this is an error",
+								LastTransitionTime: &metaFixedClockStart,
+							}),
+							gen.SetCertificateRequestFailureTime(metaFixedClockStart),
+						),
+					)),
+				},
+			},
+			fakeSecretLister: failGetSecretLister,
+			fakeClient:       clientReturnsGenericError,
+			expectedErr:      true,
+		},
 		"annotations: Custom Fields": {
 			certificateRequest: tppCRWithCustomFields.DeepCopy(),
 			builder: &controllertest.Builder{
@@ -0,0 +1,433 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctmonitor
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/cert-manager/cert-manager/internal/ct"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	ControllerName = "certificates-ct-monitor"
+
+	reasonUnknownCertificate = "UnknownCertificate"
+
+	// defaultBatchSize is the number of entries requested from a log in a
+	// single call to get-entries.
+	defaultBatchSize = 256
+
+	// defaultMaxEntriesPerSync bounds the number of entries processed in a
+	// single sync, so that a log with a large backlog does not block the
+	// worker for too long. The remaining entries are processed in the next
+	// sync, which is queued immediately.
+	defaultMaxEntriesPerSync = 16384
+)
+
+// controller polls Certificate Transparency logs for certificates of
+// monitored domains, and raises events and metrics for certificates which
+// were not issued by cert-manager. Monitored domains are the dnsNames and
+// commonNames of Certificate resources, and the configured zones.
+//
+// The queue is keyed by log URL. The position of each log is only held in
+// memory, so after a restart monitoring resumes at the current tree size of
+// each log.
+type controller struct {
+	certificateLister        cmlisters.CertificateLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	secretLister             corelisters.SecretLister
+	recorder                 record.EventRecorder
+	metrics                  *metrics.Metrics
+	queue                    workqueue.RateLimitingInterface
+
+	clients           map[string]*ct.Client
+	zones             []string
+	pollInterval      time.Duration
+	batchSize         uint64
+	maxEntriesPerSync uint64
+
+	lock      sync.Mutex
+	positions map[string]uint64
+}
+
+func NewController(
+	log logr.Logger,
+	ctx *controllerpkg.Context,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
+	secretsInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	if len(ctx.CTMonitorOptions.LogURLs) == 0 {
+		log.Info("no Certificate Transparency logs configured, nothing will be monitored")
+	}
+
+	clients := make(map[string]*ct.Client, len(ctx.CTMonitorOptions.LogURLs))
+	for _, logURL := range ctx.CTMonitorOptions.LogURLs {
+		client, err := ct.NewClient(logURL, nil)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		clients[logURL] = client
+		// each log is polled independently, starting as soon as the
+		// informers have synced
+		queue.Add(logURL)
+	}
+
+	var zones []string
+	for _, zone := range ctx.CTMonitorOptions.Zones {
+		zones = append(zones, normalizeName(zone))
+	}
+
+	return &controller{
+		certificateLister:        certificateInformer.Lister(),
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		secretLister:             secretsInformer.Lister(),
+		recorder:                 ctx.Recorder,
+		metrics:                  ctx.Metrics,
+		queue:                    queue,
+		clients:                  clients,
+		zones:                    zones,
+		pollInterval:             ctx.CTMonitorOptions.PollInterval,
+		batchSize:                defaultBatchSize,
+		maxEntriesPerSync:        defaultMaxEntriesPerSync,
+		positions:                make(map[string]uint64),
+	}, queue, mustSync, nil
+}
+
+// ProcessItem checks the entries added to the log with the given URL since
+// the last sync, and schedules the next sync of the log.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("log", key)
+	ctx = logf.NewContext(ctx, log)
+
+	client, ok := c.clients[key]
+	if !ok {
+		log.Error(nil, "unknown log URL passed to ProcessItem")
+		return nil
+	}
+
+	sth, err := client.GetSTH(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	position, ok := c.positions[key]
+	c.lock.Unlock()
+	if !ok {
+		// Only certificates logged from now on are checked, since the
+		// history of a log is too large to scan on every start.
+		log.V(logf.DebugLevel).Info("starting to monitor log", "treeSize", sth.TreeSize)
+		c.setPosition(key, sth.TreeSize)
+		c.queue.AddAfter(key, c.pollInterval)
+		return nil
+	}
+
+	if position >= sth.TreeSize {
+		c.queue.AddAfter(key, c.pollInterval)
+		return nil
+	}
+
+	idx, err := c.buildIndex()
+	if err != nil {
+		return err
+	}
+
+	end := sth.TreeSize
+	if end-position > c.maxEntriesPerSync {
+		end = position + c.maxEntriesPerSync
+	}
+
+	for position < end {
+		last := position + c.batchSize - 1
+		if last >= end {
+			last = end - 1
+		}
+
+		entries, err := client.GetEntries(ctx, position, last)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("log returned no entries for range [%d, %d]", position, last)
+		}
+
+		for _, entry := range entries {
+			c.checkEntry(log, key, idx, entry)
+		}
+		c.metrics.AddCTMonitorEntriesProcessed(key, len(entries))
+
+		position += uint64(len(entries))
+		c.setPosition(key, position)
+	}
+
+	if position < sth.TreeSize {
+		c.queue.Add(key)
+		return nil
+	}
+
+	c.queue.AddAfter(key, c.pollInterval)
+	return nil
+}
+
+func (c *controller) setPosition(key string, position uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.positions[key] = position
+}
+
+// checkEntry raises an event on every Certificate whose names overlap with
+// the names of the logged certificate, if the logged certificate was not
+// issued by cert-manager.
+func (c *controller) checkEntry(log logr.Logger, logURL string, idx *index, entry ct.Entry) {
+	cert := entry.Certificate
+	names := certificateNames(cert)
+
+	var crts []*cmapi.Certificate
+	for _, crt := range idx.certificates {
+		if namesOverlap(names, idx.names[crt]) {
+			crts = append(crts, crt)
+		}
+	}
+	inZone := inZones(names, c.zones)
+
+	if len(crts) == 0 && !inZone {
+		return
+	}
+
+	if idx.serials.Has(serialString(cert)) || idx.publicKeys.Has(publicKeyFingerprint(cert.RawSubjectPublicKeyInfo)) {
+		return
+	}
+
+	kind := "certificate"
+	if entry.Type == ct.PrecertEntry {
+		kind = "precertificate"
+	}
+
+	c.metrics.IncrementCTMonitorUnknownCertificate(logURL)
+	log.Info("found a certificate for a monitored domain which was not issued by cert-manager",
+		"index", entry.Index, "type", kind, "serial", serialString(cert), "issuer", cert.Issuer.String(), "names", names)
+
+	for _, crt := range crts {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonUnknownCertificate,
+			"Certificate Transparency log %q contains a %s for %s with serial %s issued by %q, which was not issued by cert-manager",
+			logURL, kind, strings.Join(names, ", "), serialString(cert), cert.Issuer.String())
+	}
+}
+
+// index holds the monitored Certificates and the serial numbers and public
+// keys of all certificates known to cert-manager.
+type index struct {
+	certificates []*cmapi.Certificate
+	names        map[*cmapi.Certificate][]string
+	serials      sets.String
+	publicKeys   sets.String
+}
+
+// buildIndex builds the index from the Certificates, their Secrets and the
+// CertificateRequests known to the informers. The public keys of
+// CertificateRequests are included so that precertificates logged before
+// the signed certificate has been stored are recognised.
+func (c *controller) buildIndex() (*index, error) {
+	crts, err := c.certificateLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	idx := &index{
+		names:      make(map[*cmapi.Certificate][]string),
+		serials:    sets.NewString(),
+		publicKeys: sets.NewString(),
+	}
+
+	for _, crt := range crts {
+		var names []string
+		for _, name := range append([]string{crt.Spec.CommonName}, crt.Spec.DNSNames...) {
+			if len(name) > 0 {
+				names = append(names, normalizeName(name))
+			}
+		}
+		if len(names) > 0 {
+			idx.certificates = append(idx.certificates, crt)
+			idx.names[crt] = names
+		}
+
+		secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		idx.addCertificate(secret.Data[corev1.TLSCertKey])
+	}
+
+	reqs, err := c.certificateRequestLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range reqs {
+		idx.addCertificate(req.Status.Certificate)
+
+		csr, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+		if err != nil {
+			continue
+		}
+		idx.publicKeys.Insert(publicKeyFingerprint(csr.RawSubjectPublicKeyInfo))
+	}
+
+	return idx, nil
+}
+
+func (idx *index) addCertificate(certPEM []byte) {
+	if len(certPEM) == 0 {
+		return
+	}
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return
+	}
+	idx.serials.Insert(serialString(cert))
+	idx.publicKeys.Insert(publicKeyFingerprint(cert.RawSubjectPublicKeyInfo))
+}
+
+func certificateNames(cert *x509.Certificate) []string {
+	names := sets.NewString()
+	if len(cert.Subject.CommonName) > 0 {
+		names.Insert(normalizeName(cert.Subject.CommonName))
+	}
+	for _, name := range cert.DNSNames {
+		names.Insert(normalizeName(name))
+	}
+	return names.List()
+}
+
+func normalizeName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+func serialString(cert *x509.Certificate) string {
+	if cert.SerialNumber == nil {
+		return ""
+	}
+	return cert.SerialNumber.Text(16)
+}
+
+func publicKeyFingerprint(spki []byte) string {
+	sum := sha256.Sum256(spki)
+	return hex.EncodeToString(sum[:])
+}
+
+// namesOverlap returns true if any name in a could be served by a
+// certificate for any name in b, or vice versa.
+func namesOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y || wildcardMatches(x, y) || wildcardMatches(y, x) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wildcardMatches returns true if pattern is a wildcard name covering name,
+// for example *.example.com covers www.example.com but not example.com or
+// a.b.example.com.
+func wildcardMatches(pattern, name string) bool {
+	if !strings.HasPrefix(pattern, "*.") {
+		return false
+	}
+	i := strings.IndexByte(name, '.')
+	return i > 0 && name[i+1:] == pattern[2:]
+}
+
+// inZones returns true if any of the names is in one of the zones.
+func inZones(names, zones []string) bool {
+	for _, name := range names {
+		name = strings.TrimPrefix(name, "*.")
+		for _, zone := range zones {
+			if name == zone || strings.HasSuffix(name, "."+zone) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync, err := NewController(log, ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ctmonitor
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/cert-manager/cert-manager/internal/ct/fake"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+type testIssuer struct {
+	t    *testing.T
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	key, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	_, cert, err := pki.SignCertificate(template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testIssuer{t: t, cert: cert, key: key}
+}
+
+func (i *testIssuer) issue(serial int64, key crypto.Signer, dnsNames ...string) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	_, cert, err := pki.SignCertificate(template, i.cert, key.Public(), i.key)
+	if err != nil {
+		i.t.Fatal(err)
+	}
+	return cert
+}
+
+func TestProcessItem(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	managedKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pendingKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shadowKey, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	managedCert := issuer.issue(100, managedKey, "www.example.com")
+	managedPEM, err := pki.EncodeX509(managedCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pendingCSR, err := pki.EncodeCSR(&x509.CertificateRequest{DNSNames: []string{"www.example.com"}}, pendingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crt := gen.Certificate("test-cert",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateDNSNames("www.example.com"),
+		gen.SetCertificateSecretName("test-cert-tls"),
+	)
+	wildcardCrt := gen.Certificate("wildcard-cert",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateDNSNames("*.apps.example.com"),
+		gen.SetCertificateSecretName("wildcard-cert-tls"),
+	)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test-cert-tls"},
+		Data:       map[string][]byte{corev1.TLSCertKey: managedPEM},
+	}
+	pendingCR := gen.CertificateRequest("test-cert-2",
+		gen.SetCertificateRequestNamespace("testns"),
+		gen.SetCertificateRequestCSR(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: pendingCSR})),
+	)
+
+	tests := map[string]struct {
+		certificates []*x509.Certificate
+		precerts     []*x509.Certificate
+		kubeObjects  []runtime.Object
+		cmObjects    []runtime.Object
+
+		expectedEvents []string
+	}{
+		"do nothing for a certificate of an unmonitored domain": {
+			certificates: []*x509.Certificate{issuer.issue(200, shadowKey, "www.example.org")},
+			cmObjects:    []runtime.Object{crt},
+		},
+		"fire an event for an unknown certificate of a Certificate's dnsName": {
+			certificates: []*x509.Certificate{issuer.issue(200, shadowKey, "www.example.com")},
+			cmObjects:    []runtime.Object{crt},
+			expectedEvents: []string{
+				`Warning UnknownCertificate Certificate Transparency log "LOG" contains a certificate for www.example.com with serial c8 issued by "CN=test-ca", which was not issued by cert-manager`,
+			},
+		},
+		"fire an event for an unknown precertificate": {
+			precerts:  []*x509.Certificate{issuer.issue(200, shadowKey, "www.example.com")},
+			cmObjects: []runtime.Object{crt},
+			expectedEvents: []string{
+				`Warning UnknownCertificate Certificate Transparency log "LOG" contains a precertificate for www.example.com with serial c8 issued by "CN=test-ca", which was not issued by cert-manager`,
+			},
+		},
+		"fire an event for a certificate covered by a wildcard Certificate": {
+			certificates: []*x509.Certificate{issuer.issue(200, shadowKey, "foo.apps.example.com")},
+			cmObjects:    []runtime.Object{crt, wildcardCrt},
+			expectedEvents: []string{
+				`Warning UnknownCertificate Certificate Transparency log "LOG" contains a certificate for foo.apps.example.com with serial c8 issued by "CN=test-ca", which was not issued by cert-manager`,
+			},
+		},
+		"do nothing for the certificate stored in a Certificate's Secret": {
+			certificates: []*x509.Certificate{managedCert},
+			precerts:     []*x509.Certificate{managedCert},
+			kubeObjects:  []runtime.Object{secret},
+			cmObjects:    []runtime.Object{crt},
+		},
+		"do nothing for a precertificate of a pending CertificateRequest": {
+			precerts:  []*x509.Certificate{issuer.issue(300, pendingKey, "www.example.com")},
+			cmObjects: []runtime.Object{crt, pendingCR},
+		},
+		"do not fire an event for an unknown certificate only in a monitored zone": {
+			certificates: []*x509.Certificate{issuer.issue(200, shadowKey, "shadow.example.com")},
+			cmObjects:    []runtime.Object{crt},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			log := &fake.Log{MaxEntries: 1}
+			log.AddCertificate(issuer.issue(2, shadowKey, "before.example.com"))
+			server := httptest.NewServer(log)
+			defer server.Close()
+			logURL := server.URL + "/"
+
+			for i := range test.expectedEvents {
+				test.expectedEvents[i] = strings.Replace(test.expectedEvents[i], `"LOG"`, fmt.Sprintf("%q", logURL), 1)
+			}
+
+			builder := &testpkg.Builder{
+				T:                  t,
+				KubeObjects:        test.kubeObjects,
+				CertManagerObjects: test.cmObjects,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+			builder.Context.CTMonitorOptions = controllerpkg.CTMonitorOptions{
+				LogURLs:      []string{logURL},
+				Zones:        []string{"Example.com."},
+				PollInterval: time.Hour,
+			}
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			// the first sync only records the current tree size
+			if err := w.controller.ProcessItem(context.Background(), logURL); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, cert := range test.certificates {
+				log.AddCertificate(cert)
+			}
+			for _, cert := range test.precerts {
+				log.AddPrecertificate(cert, issuer.cert)
+			}
+
+			if err := w.controller.ProcessItem(context.Background(), logURL); err != nil {
+				t.Fatal(err)
+			}
+
+			if got, exp := w.controller.positions[logURL], uint64(log.Size()); got != exp {
+				t.Errorf("unexpected log position, exp=%d got=%d", exp, got)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+func TestProcessItemUnknownLog(t *testing.T) {
+	builder := &testpkg.Builder{T: t}
+	builder.Init()
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.controller.ProcessItem(context.Background(), "https://unknown.example.com/"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRegisterInvalidLogURL(t *testing.T) {
+	builder := &testpkg.Builder{T: t}
+	builder.Init()
+	builder.Context.CTMonitorOptions.LogURLs = []string{"ftp://ct.example.com/"}
+
+	w := &controllerWrapper{}
+	if _, _, err := w.Register(builder.Context); err == nil {
+		t.Error("expected an error for an invalid log URL")
+	}
+}
+
+func TestNamesOverlap(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		exp  bool
+	}{
+		{a: []string{"example.com"}, b: []string{"example.com"}, exp: true},
+		{a: []string{"example.com"}, b: []string{"www.example.com"}, exp: false},
+		{a: []string{"*.example.com"}, b: []string{"www.example.com"}, exp: true},
+		{a: []string{"www.example.com"}, b: []string{"*.example.com"}, exp: true},
+		{a: []string{"*.example.com"}, b: []string{"example.com"}, exp: false},
+		{a: []string{"*.example.com"}, b: []string{"a.b.example.com"}, exp: false},
+		{a: []string{"foo.com", "bar.com"}, b: []string{"bar.com"}, exp: true},
+	}
+
+	for _, test := range tests {
+		if got := namesOverlap(test.a, test.b); got != test.exp {
+			t.Errorf("namesOverlap(%v, %v): exp=%t got=%t", test.a, test.b, test.exp, got)
+		}
+	}
+}
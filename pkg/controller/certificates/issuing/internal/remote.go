@@ -0,0 +1,290 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/cert-manager/cert-manager/internal/credentials"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+)
+
+// RemoteSecretHandler is called when a Secret managed by cert-manager changes
+// in a remote cluster. namespace is the namespace of the Certificates which
+// use the given remote target.
+type RemoteSecretHandler func(namespace string, target cmapi.CertificateRemoteTarget, secretName string)
+
+// RemoteCluster is a client and Secret lister for a namespace in a remote
+// cluster.
+type RemoteCluster struct {
+	Client       kubernetes.Interface
+	SecretLister corelisters.SecretLister
+
+	kubeconfig []byte
+	hasSynced  cache.InformerSynced
+	stop       context.CancelFunc
+}
+
+const (
+	// remoteClientTimeout is the timeout of requests made by the client of a
+	// remote cluster.
+	remoteClientTimeout = time.Second * 10
+
+	// remoteCacheSyncTimeout is the maximum time to wait for the Secret
+	// informer of a remote cluster to sync.
+	remoteCacheSyncTimeout = time.Second * 5
+)
+
+// RemoteClusters caches a RemoteCluster for each remote target referenced by
+// Certificates. Entries are keyed on the kubeconfig Secret reference and the
+// remote namespace, are rebuilt when the kubeconfig data changes, and are
+// stopped once no Certificate uses them.
+type RemoteClusters struct {
+	secretLister corelisters.SecretLister
+	handler      RemoteSecretHandler
+
+	// newClient builds a client from kubeconfig data, with the given request
+	// timeout. It is overridden in tests.
+	newClient func(kubeconfig []byte, timeout time.Duration) (kubernetes.Interface, error)
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	lock     sync.Mutex
+	clusters map[string]*RemoteCluster
+	// certificates maps the key of a Certificate to the key of the cluster it
+	// uses.
+	certificates map[string]string
+}
+
+// NewRemoteClusters returns a new RemoteClusters which reads kubeconfig
+// Secrets using the given lister. The handler is called for every change to a
+// cert-manager managed Secret in any of the remote clusters.
+func NewRemoteClusters(secretLister corelisters.SecretLister, handler RemoteSecretHandler) *RemoteClusters {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RemoteClusters{
+		secretLister: secretLister,
+		handler:      handler,
+		newClient:    newClientFromKubeconfig,
+		ctx:          ctx,
+		cancel:       cancel,
+		clusters:     make(map[string]*RemoteCluster),
+		certificates: make(map[string]string),
+	}
+}
+
+// ForCertificate returns the RemoteCluster for the remote target of the given
+// Certificate, which must be non-nil. The first call for a target starts a
+// Secret informer in the remote namespace. Every call waits for the informer
+// to sync, until the context is cancelled or remoteCacheSyncTimeout passes.
+func (r *RemoteClusters) ForCertificate(ctx context.Context, crt *cmapi.Certificate) (*RemoteCluster, error) {
+	target := *crt.Spec.RemoteTarget
+	ref := target.KubeconfigSecretRef
+
+	secret, err := r.secretLister.Secrets(crt.Namespace).Get(ref.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig Secret %s/%s: %w", crt.Namespace, ref.Name, err)
+	}
+	kubeconfig, ok, err := credentials.Value(secret, ref.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig Secret %s/%s: %w", crt.Namespace, ref.Name, err)
+	}
+	if !ok || len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("kubeconfig Secret %s/%s contains no data for key %q", crt.Namespace, ref.Name, ref.Key)
+	}
+
+	cluster, err := r.clusterFor(crt, kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait for the informer outside of the lock, so that a slow or
+	// unreachable remote cluster doesn't block other Certificates.
+	syncCtx, cancel := context.WithTimeout(ctx, remoteCacheSyncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), cluster.hasSynced) {
+		return nil, fmt.Errorf("timed out waiting for the Secrets cache of remote namespace %q to sync", target.Namespace)
+	}
+
+	return cluster, nil
+}
+
+// clusterFor returns the RemoteCluster for the remote target of the given
+// Certificate, starting a new one if none exists for the given kubeconfig,
+// and records that the Certificate uses it.
+func (r *RemoteClusters) clusterFor(crt *cmapi.Certificate, kubeconfig []byte) (*RemoteCluster, error) {
+	target := *crt.Spec.RemoteTarget
+	ref := target.KubeconfigSecretRef
+	key := fmt.Sprintf("%s/%s/%s/%s", crt.Namespace, ref.Name, ref.Key, target.Namespace)
+	crtKey := crt.Namespace + "/" + crt.Name
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// The Certificate may have moved to a different remote target.
+	if prev, ok := r.certificates[crtKey]; ok && prev != key {
+		r.release(crtKey)
+	}
+
+	r.certificates[crtKey] = key
+
+	if cluster, ok := r.clusters[key]; ok {
+		if bytes.Equal(cluster.kubeconfig, kubeconfig) {
+			return cluster, nil
+		}
+		// The kubeconfig has changed, so rebuild the client and informer.
+		cluster.stop()
+		delete(r.clusters, key)
+	}
+
+	client, err := r.newClient(kubeconfig, remoteClientTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client from kubeconfig Secret %s/%s: %w", crt.Namespace, ref.Name, err)
+	}
+	// Watches are long running requests, so the informer gets a client
+	// without a request timeout.
+	informerClient, err := r.newClient(kubeconfig, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build client from kubeconfig Secret %s/%s: %w", crt.Namespace, ref.Name, err)
+	}
+
+	// Only watch the Secrets which cert-manager writes.
+	factory := informers.NewSharedInformerFactoryWithOptions(informerClient, 0,
+		informers.WithNamespace(target.Namespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = cmapi.PartOfCertManagerControllerLabelKey + "=true"
+		}),
+	)
+	secretsInformer := factory.Core().V1().Secrets()
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: func(obj interface{}) {
+			if secret, ok := obj.(*corev1.Secret); ok {
+				r.handler(crt.Namespace, target, secret.Name)
+			}
+		},
+	})
+
+	clusterCtx, stop := context.WithCancel(r.ctx)
+	factory.Start(clusterCtx.Done())
+
+	cluster := &RemoteCluster{
+		Client:       client,
+		SecretLister: secretsInformer.Lister(),
+		kubeconfig:   kubeconfig,
+		hasSynced:    secretsInformer.Informer().HasSynced,
+		stop:         stop,
+	}
+	r.clusters[key] = cluster
+	return cluster, nil
+}
+
+// Release records that the named Certificate no longer uses a remote target,
+// and stops the informer of its RemoteCluster if no other Certificate uses
+// it. It should be called when a Certificate is deleted or its remote target
+// is removed.
+func (r *RemoteClusters) Release(namespace, name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.release(namespace + "/" + name)
+}
+
+// release must be called with the lock held.
+func (r *RemoteClusters) release(crtKey string) {
+	key, ok := r.certificates[crtKey]
+	if !ok {
+		return
+	}
+	delete(r.certificates, crtKey)
+
+	for _, clusterKey := range r.certificates {
+		if clusterKey == key {
+			// The cluster is still used by another Certificate.
+			return
+		}
+	}
+	if cluster, ok := r.clusters[key]; ok {
+		cluster.stop()
+		delete(r.clusters, key)
+	}
+}
+
+// Stop stops the informers of all remote clusters.
+func (r *RemoteClusters) Stop() {
+	r.cancel()
+}
+
+// newClientFromKubeconfig builds a client from the given kubeconfig data.
+// Since the kubeconfig is supplied by users, it may only contain inline
+// credentials: exec plugins, auth providers and file paths would otherwise
+// run commands or read files on the controller's host.
+func newClientFromKubeconfig(kubeconfig []byte, timeout time.Duration) (kubernetes.Interface, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateKubeconfig(config); err != nil {
+		return nil, err
+	}
+
+	restConfig, err := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	restConfig.Timeout = timeout
+	return kubernetes.NewForConfig(restConfig)
+}
+
+// validateKubeconfig returns an error if the given kubeconfig uses anything
+// but inline token or client certificate credentials and inline CA data.
+func validateKubeconfig(config *clientcmdapi.Config) error {
+	for name, authInfo := range config.AuthInfos {
+		switch {
+		case authInfo.Exec != nil:
+			return fmt.Errorf("user %q: exec credential plugins are not supported", name)
+		case authInfo.AuthProvider != nil:
+			return fmt.Errorf("user %q: auth providers are not supported", name)
+		case authInfo.TokenFile != "":
+			return fmt.Errorf("user %q: tokenFile is not supported, use token", name)
+		case authInfo.ClientCertificate != "":
+			return fmt.Errorf("user %q: client-certificate is not supported, use client-certificate-data", name)
+		case authInfo.ClientKey != "":
+			return fmt.Errorf("user %q: client-key is not supported, use client-key-data", name)
+		case authInfo.Username != "" || authInfo.Password != "":
+			return fmt.Errorf("user %q: basic authentication is not supported, use token or client-certificate-data", name)
+		}
+	}
+	for name, cluster := range config.Clusters {
+		if cluster.CertificateAuthority != "" {
+			return fmt.Errorf("cluster %q: certificate-authority is not supported, use certificate-authority-data", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package internal
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/cert-manager/cert-manager/internal/credentials"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+	testcorelisters "github.com/cert-manager/cert-manager/test/unit/listers"
+)
+
+func Test_RemoteClustersForCertificate(t *testing.T) {
+	crt := gen.Certificate("test-certificate",
+		gen.SetCertificateNamespace("test-namespace"),
+		gen.SetCertificateSecretName("test-secret"),
+	)
+	crt.Spec.RemoteTarget = &cmapi.CertificateRemoteTarget{
+		KubeconfigSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "edge-1"}, Key: "kubeconfig"},
+		Namespace:           "edge",
+	}
+	kubeconfigSecret := func(kubeconfig string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-namespace", Name: "edge-1"},
+			Data:       map[string][]byte{"kubeconfig": []byte(kubeconfig)},
+		}
+	}
+
+	type handled struct {
+		namespace  string
+		target     cmapi.CertificateRemoteTarget
+		secretName string
+	}
+
+	t.Run("if the kubeconfig Secret has no data for the key, expect error", func(t *testing.T) {
+		lister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretNamespaceListerGet(&corev1.Secret{}, nil))
+		r := NewRemoteClusters(lister, func(string, cmapi.CertificateRemoteTarget, string) {})
+		defer r.Stop()
+
+		_, err := r.ForCertificate(context.Background(), crt)
+		assert.EqualError(t, err, `kubeconfig Secret test-namespace/edge-1 contains no data for key "kubeconfig"`)
+	})
+
+	t.Run("if the kubeconfig is envelope encrypted and cannot be decrypted, expect error", func(t *testing.T) {
+		lister := testcorelisters.NewFakeSecretLister(testcorelisters.SetFakeSecretNamespaceListerGet(kubeconfigSecret(credentials.EnvelopePrefix+"abc"), nil))
+		r := NewRemoteClusters(lister, func(string, cmapi.CertificateRemoteTarget, string) {})
+		defer r.Stop()
+
+		_, err := r.ForCertificate(context.Background(), crt)
+		assert.EqualError(t, err, `failed to read kubeconfig Secret test-namespace/edge-1: value of key "kubeconfig" in secret 'test-namespace/edge-1' is envelope encrypted, but no KMS plugin or encryption key is configured`)
+	})
+
+	t.Run("clusters are cached, rebuilt on kubeconfig change, and call the handler on remote Secret changes", func(t *testing.T) {
+		var (
+			builtFor     []string
+			handledCh    = make(chan handled, 10)
+			remoteClient = fake.NewSimpleClientset()
+			indexer      = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		)
+		assert.NoError(t, indexer.Add(kubeconfigSecret("kubeconfig-1")))
+
+		r := NewRemoteClusters(corelisters.NewSecretLister(indexer), func(namespace string, target cmapi.CertificateRemoteTarget, secretName string) {
+			handledCh <- handled{namespace, target, secretName}
+		})
+		r.newClient = func(kubeconfig []byte, timeout time.Duration) (kubernetes.Interface, error) {
+			if timeout != 0 {
+				builtFor = append(builtFor, string(kubeconfig))
+			}
+			return remoteClient, nil
+		}
+		defer r.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		cluster, err := r.ForCertificate(ctx, crt)
+		assert.NoError(t, err)
+		cached, err := r.ForCertificate(ctx, crt)
+		assert.NoError(t, err)
+		assert.Same(t, cluster, cached)
+		assert.Equal(t, []string{"kubeconfig-1"}, builtFor)
+
+		_, err = remoteClient.CoreV1().Secrets("edge").Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "edge", Name: "test-secret",
+				Labels: map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"},
+			},
+		}, metav1.CreateOptions{})
+		assert.NoError(t, err)
+
+		select {
+		case got := <-handledCh:
+			assert.Equal(t, handled{"test-namespace", *crt.Spec.RemoteTarget, "test-secret"}, got)
+		case <-ctx.Done():
+			t.Fatal("timed out waiting for the remote Secret handler to be called")
+		}
+
+		assert.NoError(t, indexer.Update(kubeconfigSecret("kubeconfig-2")))
+		rebuilt, err := r.ForCertificate(ctx, crt)
+		assert.NoError(t, err)
+		assert.NotSame(t, cluster, rebuilt)
+		assert.Equal(t, []string{"kubeconfig-1", "kubeconfig-2"}, builtFor)
+	})
+
+	t.Run("clusters are stopped once no Certificate uses them", func(t *testing.T) {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		assert.NoError(t, indexer.Add(kubeconfigSecret("kubeconfig-1")))
+
+		r := NewRemoteClusters(corelisters.NewSecretLister(indexer), func(string, cmapi.CertificateRemoteTarget, string) {})
+		r.newClient = func([]byte, time.Duration) (kubernetes.Interface, error) {
+			return fake.NewSimpleClientset(), nil
+		}
+		defer r.Stop()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		other := crt.DeepCopy()
+		other.Name = "other-certificate"
+		_, err := r.ForCertificate(ctx, crt)
+		assert.NoError(t, err)
+		_, err = r.ForCertificate(ctx, other)
+		assert.NoError(t, err)
+		assert.Len(t, r.clusters, 1)
+
+		r.Release("test-namespace", "test-certificate")
+		assert.Len(t, r.clusters, 1)
+
+		// Moving to another remote namespace releases the previous cluster.
+		other.Spec.RemoteTarget = &cmapi.CertificateRemoteTarget{
+			KubeconfigSecretRef: crt.Spec.RemoteTarget.KubeconfigSecretRef,
+			Namespace:           "other-edge",
+		}
+		_, err = r.ForCertificate(ctx, other)
+		assert.NoError(t, err)
+		assert.Len(t, r.clusters, 1)
+		assert.Contains(t, r.clusters, "test-namespace/edge-1/kubeconfig/other-edge")
+
+		r.Release("test-namespace", "other-certificate")
+		assert.Empty(t, r.clusters)
+		assert.Empty(t, r.certificates)
+	})
+}
+
+func Test_newClientFromKubeconfig(t *testing.T) {
+	pk, err := pki.GenerateECPrivateKey(pki.ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "edge"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certPEM, _, err := pki.SignCertificate(tmpl, tmpl, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM, err := pki.EncodePKCS8PrivateKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certData := base64.StdEncoding.EncodeToString(certPEM)
+	keyData := base64.StdEncoding.EncodeToString(keyPEM)
+
+	kubeconfig := func(cluster, user string) []byte {
+		return []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: edge
+  cluster:
+    server: https://edge.example.com
+` + cluster + `
+users:
+- name: edge
+  user:
+` + user + `
+contexts:
+- name: edge
+  context:
+    cluster: edge
+    user: edge
+current-context: edge
+`)
+	}
+
+	tests := map[string]struct {
+		kubeconfig []byte
+		expErr     string
+	}{
+		"inline token is allowed": {
+			kubeconfig: kubeconfig("", "    token: abc"),
+		},
+		"inline client certificate data is allowed": {
+			kubeconfig: kubeconfig("    certificate-authority-data: "+certData, "    client-certificate-data: "+certData+"\n    client-key-data: "+keyData),
+		},
+		"exec plugins are rejected": {
+			kubeconfig: kubeconfig("", "    exec:\n      apiVersion: client.authentication.k8s.io/v1\n      command: /bin/sh"),
+			expErr:     `user "edge": exec credential plugins are not supported`,
+		},
+		"auth providers are rejected": {
+			kubeconfig: kubeconfig("", "    auth-provider:\n      name: oidc"),
+			expErr:     `user "edge": auth providers are not supported`,
+		},
+		"token files are rejected": {
+			kubeconfig: kubeconfig("", "    tokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token"),
+			expErr:     `user "edge": tokenFile is not supported, use token`,
+		},
+		"client certificate files are rejected": {
+			kubeconfig: kubeconfig("", "    client-certificate: /etc/tls.crt"),
+			expErr:     `user "edge": client-certificate is not supported, use client-certificate-data`,
+		},
+		"client key files are rejected": {
+			kubeconfig: kubeconfig("", "    client-key: /etc/tls.key"),
+			expErr:     `user "edge": client-key is not supported, use client-key-data`,
+		},
+		"basic authentication is rejected": {
+			kubeconfig: kubeconfig("", "    username: admin\n    password: admin"),
+			expErr:     `user "edge": basic authentication is not supported, use token or client-certificate-data`,
+		},
+		"certificate authority files are rejected": {
+			kubeconfig: kubeconfig("    certificate-authority: /etc/ca.crt", "    token: abc"),
+			expErr:     `cluster "edge": certificate-authority is not supported, use certificate-authority-data`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := newClientFromKubeconfig(test.kubeconfig, remoteClientTimeout)
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expErr)
+			}
+		})
+	}
+}
@@ -28,8 +28,10 @@ import (
 	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
 	coreclient "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/conflicts"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -50,32 +52,49 @@ type SecretsManager struct {
 	// fieldManager is the manager name used for the Apply operations on Secrets.
 	fieldManager string
 
+	// recorder is used to record Events on the Certificate when applying the
+	// Secret conflicts with another field manager.
+	recorder record.EventRecorder
+
 	// if true, Secret resources created by the controller will have an
 	// 'owner reference' set, meaning when the Certificate is deleted, the
 	// Secret resource will be automatically deleted.
 	// This option is disabled by default.
 	enableSecretOwnerReferences bool
+
+	// remoteClusters is used to write the Secret of Certificates which set
+	// spec.remoteTarget to the remote cluster.
+	remoteClusters *RemoteClusters
 }
 
 // SecretData is a structure wrapping private key, Certificate and CA data
 type SecretData struct {
 	PrivateKey, Certificate, CA []byte
+
+	// IssuanceReceipt is the signed issuance receipt of the certificate, and
+	// is stored in the Secret's annotations if not empty.
+	IssuanceReceipt string
 }
 
 // NewSecretsManager returns a new SecretsManager. Setting
 // enableSecretOwnerReferences to true will mean that secrets will be deleted
-// when the corresponding Certificate is deleted.
+// when the corresponding Certificate is deleted. remoteClusters may be nil, in
+// which case remote targets are ignored.
 func NewSecretsManager(
 	secretClient coreclient.SecretsGetter,
 	secretLister corelisters.SecretLister,
 	fieldManager string,
+	recorder record.EventRecorder,
 	enableSecretOwnerReferences bool,
+	remoteClusters *RemoteClusters,
 ) *SecretsManager {
 	return &SecretsManager{
 		secretClient:                secretClient,
 		secretLister:                secretLister,
 		fieldManager:                fieldManager,
+		recorder:                    recorder,
 		enableSecretOwnerReferences: enableSecretOwnerReferences,
+		remoteClusters:              remoteClusters,
 	}
 }
 
@@ -96,8 +115,7 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 		return err
 	}
 
-	// Build Secret apply configuration and options.
-	applyOpts := metav1.ApplyOptions{FieldManager: s.fieldManager, Force: true}
+	// Build Secret apply configuration.
 	applyCnf := applycorev1.Secret(secret.Name, secret.Namespace).
 		WithAnnotations(secret.Annotations).WithLabels(secret.Labels).
 		WithData(secret.Data).WithType(secret.Type)
@@ -116,11 +134,59 @@ func (s *SecretsManager) UpdateData(ctx context.Context, crt *cmapi.Certificate,
 
 	log.V(logf.DebugLevel).Info("applying secret")
 
-	_, err = s.secretClient.Secrets(secret.Namespace).Apply(ctx, applyCnf, applyOpts)
+	// The Secret may also be written to by other tools, such as GitOps
+	// controllers. Conflicts are surfaced as Events on the Certificate before
+	// ownership of the fields is taken.
+	err = conflicts.Apply(s.recorder, crt, s.fieldManager, func(force bool) error {
+		_, err := s.secretClient.Secrets(secret.Namespace).Apply(ctx, applyCnf, metav1.ApplyOptions{FieldManager: s.fieldManager, Force: force})
+		return err
+	})
 	if err != nil {
 		return fmt.Errorf("failed to apply secret %s/%s: %w", secret.Namespace, secret.Name, err)
 	}
 
+	if crt.Spec.RemoteTarget != nil && s.remoteClusters != nil &&
+		utilfeature.DefaultFeatureGate.Enabled(feature.RemoteSecretTargets) {
+		if err := s.applyRemoteSecret(ctx, crt, secret); err != nil {
+			s.recorder.Eventf(crt, corev1.EventTypeWarning, "RemoteSecretError", "Failed to write the Secret to the remote cluster: %v", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyRemoteSecret applies the given Secret to the namespace of the
+// Certificate's remote target. Owner references are never set on the remote
+// Secret, since the Certificate does not exist in the remote cluster.
+func (s *SecretsManager) applyRemoteSecret(ctx context.Context, crt *cmapi.Certificate, secret *corev1.Secret) error {
+	cluster, err := s.remoteClusters.ForCertificate(ctx, crt)
+	if err != nil {
+		return err
+	}
+
+	namespace := crt.Spec.RemoteTarget.Namespace
+
+	// Type is immutable, so keep the type of an existing remote Secret.
+	secretType := secret.Type
+	if existing, err := cluster.SecretLister.Secrets(namespace).Get(secret.Name); err == nil {
+		secretType = existing.Type
+	}
+
+	applyCnf := applycorev1.Secret(secret.Name, namespace).
+		WithAnnotations(secret.Annotations).WithLabels(secret.Labels).
+		WithData(secret.Data).WithType(secretType)
+
+	logf.FromContext(ctx).V(logf.DebugLevel).Info("applying remote secret", "remote_namespace", namespace)
+
+	err = conflicts.Apply(s.recorder, crt, s.fieldManager, func(force bool) error {
+		_, err := cluster.Client.CoreV1().Secrets(namespace).Apply(ctx, applyCnf, metav1.ApplyOptions{FieldManager: s.fieldManager, Force: force})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply remote secret %s/%s: %w", namespace, secret.Name, err)
+	}
+
 	return nil
 }
 
@@ -166,6 +232,10 @@ func (s *SecretsManager) setValues(crt *cmapi.Certificate, secret *corev1.Secret
 		return err
 	}
 
+	if len(data.IssuanceReceipt) > 0 {
+		secret.Annotations[cmapi.IssuanceReceiptAnnotationKey] = data.IssuanceReceipt
+	}
+
 	if secret.Labels == nil {
 		secret.Labels = make(map[string]string)
 	}
@@ -104,7 +104,8 @@ func Test_SecretsManager(t *testing.T) {
 		secretData SecretData
 		applyFn    func(t *testing.T) testcoreclients.ApplyFn
 
-		expectedErr bool
+		expectedEvents []string
+		expectedErr    bool
 	}{
 		"if secret does not exists and unable to decode certificate, then error": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: false},
@@ -146,7 +147,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeTLS)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -182,7 +183,7 @@ func Test_SecretsManager(t *testing.T) {
 						})
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -227,7 +228,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeTLS)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -277,7 +278,7 @@ func Test_SecretsManager(t *testing.T) {
 						})
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -324,7 +325,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeTLS)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -371,7 +372,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeTLS)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -415,7 +416,7 @@ func Test_SecretsManager(t *testing.T) {
 						})
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -452,7 +453,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeTLS)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -489,7 +490,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeTLS)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -527,7 +528,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeTLS)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -583,7 +584,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeOpaque)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -640,7 +641,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeOpaque)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -697,7 +698,7 @@ func Test_SecretsManager(t *testing.T) {
 						WithType(corev1.SecretTypeOpaque)
 					assert.Equal(t, expCnf, gotCnf)
 
-					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: true}
+					expOpts := metav1.ApplyOptions{FieldManager: "cert-manager-test", Force: false}
 					assert.Equal(t, expOpts, gotOpts)
 
 					return nil, nil
@@ -705,6 +706,30 @@ func Test_SecretsManager(t *testing.T) {
 			},
 			expectedErr: false,
 		},
+		"if apply conflicts with another field manager, expect event and force apply": {
+			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
+			certificate:        baseCertWithSecretTemplate,
+			existingSecret:     nil,
+			secretData:         SecretData{Certificate: baseCertBundle.CertBytes, CA: []byte("test-ca"), PrivateKey: []byte("test-key")},
+			applyFn: func(t *testing.T) testcoreclients.ApplyFn {
+				var calls int
+				return func(_ context.Context, gotCnf *applycorev1.SecretApplyConfiguration, gotOpts metav1.ApplyOptions) (*corev1.Secret, error) {
+					calls++
+					if calls == 1 {
+						assert.False(t, gotOpts.Force)
+						return nil, apierrors.NewApplyConflict([]metav1.StatusCause{{
+							Type:    metav1.CauseTypeFieldManagerConflict,
+							Message: `conflict with "argocd-controller"`,
+							Field:   ".data.tls.crt",
+						}}, "Apply failed with 1 conflict")
+					}
+					assert.True(t, gotOpts.Force)
+					return nil, nil
+				}
+			},
+			expectedEvents: []string{`Warning FieldManagerConflict Write by field manager "cert-manager-test" conflicted with field manager(s) "argocd-controller": Apply failed with 1 conflict`},
+			expectedErr:    false,
+		},
 		"if apply errors, expect error response": {
 			certificateOptions: controllerpkg.CertificateOptions{EnableOwnerRef: true},
 			certificate:        baseCertWithSecretTemplate,
@@ -733,10 +758,11 @@ func Test_SecretsManager(t *testing.T) {
 			}
 			secretLister := testcorelisters.NewFakeSecretLister(mod)
 
+			recorder := new(testpkg.FakeRecorder)
 			testManager := NewSecretsManager(
 				secretClient, secretLister,
-				"cert-manager-test",
-				test.certificateOptions.EnableOwnerRef,
+				"cert-manager-test", recorder,
+				test.certificateOptions.EnableOwnerRef, nil,
 			)
 
 			err := testManager.UpdateData(context.Background(), test.certificate, test.secretData)
@@ -746,6 +772,7 @@ func Test_SecretsManager(t *testing.T) {
 			if err == nil && test.expectedErr {
 				t.Errorf("expected to get an error but did not get one")
 			}
+			assert.Equal(t, test.expectedEvents, recorder.Events)
 		})
 	}
 }
@@ -82,6 +82,10 @@ type controller struct {
 	// metadata and output formats are kept are present and correct.
 	postIssuancePolicyChain policies.Chain
 
+	// remoteClusters holds the clients and Secret listers of the remote
+	// clusters referenced by Certificates' spec.remoteTarget.
+	remoteClusters *internal.RemoteClusters
+
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
 	// Apply API calls.
@@ -89,6 +93,12 @@ type controller struct {
 
 	// localTemporarySigner signs a certificate that is stored temporarily
 	localTemporarySigner localTemporarySignerFn
+
+	// receiptSigningKeySecretNamespace and receiptSigningKeySecretName
+	// identify the Secret holding the key used to sign issuance receipts.
+	// Issuance receipts are not created if the name is empty.
+	receiptSigningKeySecretNamespace string
+	receiptSigningKeySecretName      string
 }
 
 func NewController(
@@ -126,6 +136,11 @@ func NewController(
 		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
 			predicate.ExtractResourceName(predicate.CertificateSecretName)),
 	})
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Issuer reconciles on changes to the kubeconfig Secret of `spec.remoteTarget`
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ExtractResourceName(predicate.CertificateRemoteTargetKubeconfigSecretName)),
+	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
@@ -135,9 +150,15 @@ func NewController(
 		certificateInformer.Informer().HasSynced,
 	}
 
+	// Issuer reconciles on changes to the Secret in the remote cluster of
+	// `spec.remoteTarget`.
+	remoteClusters := internal.NewRemoteClusters(secretsInformer.Lister(),
+		enqueueCertificatesForRemoteSecret(log, queue, certificateInformer.Lister()))
+
 	secretsManager := internal.NewSecretsManager(
 		kubeClient.CoreV1(), secretsInformer.Lister(),
-		fieldManager, certificateControllerOptions.EnableOwnerRef,
+		fieldManager, recorder, certificateControllerOptions.EnableOwnerRef,
+		remoteClusters,
 	)
 
 	return &controller{
@@ -152,8 +173,12 @@ func NewController(
 			certificateControllerOptions.EnableOwnerRef,
 			fieldManager,
 		),
+		remoteClusters:       remoteClusters,
 		fieldManager:         fieldManager,
 		localTemporarySigner: pki.GenerateLocallySignedTemporaryCertificate,
+
+		receiptSigningKeySecretNamespace: certificateControllerOptions.IssuanceReceiptSigningKeySecretNamespace,
+		receiptSigningKeySecretName:      certificateControllerOptions.IssuanceReceiptSigningKeySecretName,
 	}, queue, mustSync
 }
 
@@ -173,12 +198,18 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	crt, err := c.certificateLister.Certificates(namespace).Get(name)
 	if apierrors.IsNotFound(err) {
 		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		// Stop watching the remote cluster the Certificate may have used.
+		c.remoteClusters.Release(namespace, name)
 		return nil
 	}
 	if err != nil {
 		return err
 	}
 
+	if crt.Spec.RemoteTarget == nil {
+		c.remoteClusters.Release(namespace, name)
+	}
+
 	log = logf.WithResource(log, crt)
 	ctx = logf.NewContext(ctx, log)
 
@@ -218,7 +249,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		logf.WithResource(log, nextPrivateKeySecret).Error(err, "failed to parse next private key, waiting for keymanager controller")
 		return nil
 	}
-	pkViolations, err := pki.PrivateKeyMatchesSpec(pk, crt.Spec)
+	pkViolations, err := pki.PrivateKeyMatchesSpec(pk, pki.CertificateSpecWithPrivateKeyFallback(crt))
 	if err != nil {
 		return err
 	}
@@ -387,16 +418,31 @@ func (c *controller) issueCertificate(ctx context.Context, nextRevision int, crt
 	if err != nil {
 		return err
 	}
+	// A missing or invalid receipt signing key must not block issuance, so
+	// the revision is issued without a receipt instead.
+	issuanceReceipt, err := c.signIssuanceReceipt(nextRevision, crt, req)
+	if err != nil {
+		c.recorder.Eventf(crt, corev1.EventTypeWarning, "IssuanceReceiptError", "Failed to sign the issuance receipt, issuing the certificate without one: %v", err)
+		issuanceReceipt = ""
+	}
+
 	secretData := internal.SecretData{
-		PrivateKey:  pkData,
-		Certificate: req.Status.Certificate,
-		CA:          req.Status.CA,
+		PrivateKey:      pkData,
+		Certificate:     req.Status.Certificate,
+		CA:              req.Status.CA,
+		IssuanceReceipt: issuanceReceipt,
 	}
 
 	if err := c.secretsUpdateData(ctx, crt, secretData); err != nil {
 		return err
 	}
 
+	if len(issuanceReceipt) > 0 {
+		if err := c.annotateCertificateRequestReceipt(ctx, req, issuanceReceipt); err != nil {
+			return err
+		}
+	}
+
 	//Set status.revision to revision of the CertificateRequest
 	crt.Status.Revision = &nextRevision
 
@@ -458,6 +504,30 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 	}
 }
 
+// enqueueCertificatesForRemoteSecret returns a handler which enqueues the
+// Certificates in the given namespace which write the named Secret to the
+// given remote target.
+func enqueueCertificatesForRemoteSecret(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateLister) internal.RemoteSecretHandler {
+	return func(namespace string, target cmapi.CertificateRemoteTarget, secretName string) {
+		crts, err := lister.Certificates(namespace).List(labels.Everything())
+		if err != nil {
+			log.Error(err, "failed listing Certificates for remote Secret")
+			return
+		}
+		for _, crt := range crts {
+			if crt.Spec.SecretName != secretName || crt.Spec.RemoteTarget == nil || *crt.Spec.RemoteTarget != target {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "error computing key for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
 // controllerWrapper wraps the `controller` structure to make it implement
 // the controllerpkg.queueingController interface
 type controllerWrapper struct {
@@ -480,6 +550,12 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 	)
 	c.controller = ctrl
 
+	// Stop the remote cluster informers when the controller shuts down.
+	go func() {
+		<-ctx.RootContext.Done()
+		ctrl.remoteClusters.Stop()
+	}()
+
 	return queue, mustSync, nil
 }
 
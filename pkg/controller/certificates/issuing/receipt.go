@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
+	"github.com/cert-manager/cert-manager/internal/controller/conflicts"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	utilkube "github.com/cert-manager/cert-manager/pkg/util/kube"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/receipt"
+)
+
+// signIssuanceReceipt returns the signed issuance receipt of the given
+// revision, or an empty string if no receipt signing key is configured.
+func (c *controller) signIssuanceReceipt(revision int, crt *cmapi.Certificate, req *cmapi.CertificateRequest) (string, error) {
+	if len(c.receiptSigningKeySecretName) == 0 {
+		return "", nil
+	}
+
+	keySecret, err := c.secretLister.Secrets(c.receiptSigningKeySecretNamespace).Get(c.receiptSigningKeySecretName)
+	if err != nil {
+		return "", fmt.Errorf("failed to get issuance receipt signing key Secret %s/%s: %w",
+			c.receiptSigningKeySecretNamespace, c.receiptSigningKeySecretName, err)
+	}
+	key, _, err := utilkube.ParseTLSKeyFromSecret(keySecret, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse issuance receipt signing key: %w", err)
+	}
+
+	cert, err := utilpki.DecodeX509CertificateBytes(req.Status.Certificate)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode the certificate of CertificateRequest %q: %w", req.Name, err)
+	}
+
+	return receipt.Sign(receipt.New(crt, req, cert, revision, c.clock.Now()), key)
+}
+
+// annotateCertificateRequestReceipt stores the given issuance receipt in the
+// annotations of the CertificateRequest.
+func (c *controller) annotateCertificateRequestReceipt(ctx context.Context, req *cmapi.CertificateRequest, issuanceReceipt string) error {
+	if req.Annotations[cmapi.IssuanceReceiptAnnotationKey] == issuanceReceipt {
+		return nil
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		return internalcertificaterequests.ApplyMetadata(ctx, c.client, c.fieldManager, &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   req.Namespace,
+				Name:        req.Name,
+				Annotations: map[string]string{cmapi.IssuanceReceiptAnnotationKey: issuanceReceipt},
+			},
+		})
+	}
+
+	req = req.DeepCopy()
+	if req.Annotations == nil {
+		req.Annotations = make(map[string]string)
+	}
+	req.Annotations[cmapi.IssuanceReceiptAnnotationKey] = issuanceReceipt
+	_, err := c.client.CertmanagerV1().CertificateRequests(req.Namespace).Update(ctx, req, metav1.UpdateOptions{FieldManager: c.fieldManager})
+	conflicts.RecordEvent(c.recorder, req, c.fieldManager, err)
+	return err
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package issuing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/pkg/util/receipt"
+	testcrypto "github.com/cert-manager/cert-manager/test/unit/crypto"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestIssuingControllerIssuanceReceipt(t *testing.T) {
+	const (
+		nextPrivateKeySecretName = "next-private-key"
+		signingKeySecretName     = "receipt-signing-key"
+		signingKeySecretNS       = "cert-manager"
+	)
+
+	// RSA PKCS#1 v1.5 signatures are deterministic, so that the expected
+	// receipt can be computed by the test.
+	signingKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	signingKeySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: signingKeySecretName, Namespace: signingKeySecretNS},
+		Data:       map[string][]byte{corev1.TLSPrivateKeyKey: pki.EncodePKCS1PrivateKey(signingKey)},
+	}
+
+	metaFixedClockStart := metav1.NewTime(fixedClockStart)
+	baseCert := gen.Certificate("test",
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca-issuer", Kind: "Issuer", Group: "foo.io"}),
+		gen.SetCertificateGeneration(3),
+		gen.SetCertificateSecretName("output"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateRevision(1),
+		gen.SetCertificateNextPrivateKeySecretName(nextPrivateKeySecretName),
+	)
+	bundle := testcrypto.MustCreateCryptoBundle(t, baseCert.DeepCopy(), fixedClock)
+	issuingCert := gen.CertificateFrom(baseCert,
+		gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+			Type:               cmapi.CertificateConditionIssuing,
+			Status:             cmmeta.ConditionTrue,
+			ObservedGeneration: 3,
+			LastTransitionTime: &metaFixedClockStart,
+		}),
+	)
+	req := gen.CertificateRequestFrom(bundle.CertificateRequestReady,
+		gen.AddCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestRevisionAnnotationKey: "2", // Current Certificate revision=1
+		}),
+		gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+			Type:    cmapi.CertificateRequestConditionApproved,
+			Status:  cmmeta.ConditionTrue,
+			Reason:  "cert-manager.io",
+			Message: "Certificate request has been approved by cert-manager.io",
+		}),
+	)
+
+	expectedReceipt, err := receipt.Sign(receipt.New(issuingCert, req, bundle.Cert, 2, fixedClockStart), signingKey)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		signingKeySecretName string
+		kubeObjects          []runtime.Object
+
+		expectedActions         []testpkg.Action
+		expectedEvents          []string
+		expSecretUpdateDataCall *internal.SecretData
+		expectedErr             bool
+	}{
+		"should not create a receipt if no signing key is configured": {
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"), "status", bundle.Certificate.Namespace,
+					gen.CertificateFrom(bundle.Certificate, gen.SetCertificateRevision(2)),
+				)),
+			},
+			expectedEvents: []string{"Normal Issuing The certificate has been successfully issued"},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: req.Status.Certificate,
+				PrivateKey:  bundle.PrivateKeyBytes,
+			},
+		},
+		"should store the signed receipt on the Secret and the CertificateRequest": {
+			signingKeySecretName: signingKeySecretName,
+			kubeObjects:          []runtime.Object{signingKeySecret},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(
+					cmapi.SchemeGroupVersion.WithResource("certificaterequests"), req.Namespace,
+					gen.CertificateRequestFrom(req, gen.AddCertificateRequestAnnotations(map[string]string{
+						cmapi.IssuanceReceiptAnnotationKey: expectedReceipt,
+					})),
+				)),
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"), "status", bundle.Certificate.Namespace,
+					gen.CertificateFrom(bundle.Certificate, gen.SetCertificateRevision(2)),
+				)),
+			},
+			expectedEvents: []string{"Normal Issuing The certificate has been successfully issued"},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate:     req.Status.Certificate,
+				PrivateKey:      bundle.PrivateKeyBytes,
+				IssuanceReceipt: expectedReceipt,
+			},
+		},
+		"should issue the certificate without a receipt if the signing key Secret does not exist": {
+			signingKeySecretName: signingKeySecretName,
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"), "status", bundle.Certificate.Namespace,
+					gen.CertificateFrom(bundle.Certificate, gen.SetCertificateRevision(2)),
+				)),
+			},
+			expectedEvents: []string{
+				`Warning IssuanceReceiptError Failed to sign the issuance receipt, issuing the certificate without one: failed to get issuance receipt signing key Secret cert-manager/receipt-signing-key: secret "receipt-signing-key" not found`,
+				"Normal Issuing The certificate has been successfully issued",
+			},
+			expSecretUpdateDataCall: &internal.SecretData{
+				Certificate: req.Status.Certificate,
+				PrivateKey:  bundle.PrivateKeyBytes,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fixedClock.SetTime(fixedClockStart)
+			builder := &testpkg.Builder{
+				T:     t,
+				Clock: fixedClock,
+				CertManagerObjects: []runtime.Object{
+					issuingCert.DeepCopy(),
+					req.DeepCopy(),
+				},
+				KubeObjects: append([]runtime.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: nextPrivateKeySecretName, Namespace: bundle.Certificate.Namespace},
+						Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle.PrivateKeyBytes},
+					},
+				}, test.kubeObjects...),
+				ExpectedActions: test.expectedActions,
+				ExpectedEvents:  test.expectedEvents,
+			}
+			builder.InitWithRESTConfig()
+			defer builder.Stop()
+			builder.CertificateOptions.IssuanceReceiptSigningKeySecretNamespace = signingKeySecretNS
+			builder.CertificateOptions.IssuanceReceiptSigningKeySecretName = test.signingKeySecretName
+
+			w := controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			require.NoError(t, err)
+
+			var secretsUpdateDataCalled bool
+			w.controller.secretsUpdateData = func(_ context.Context, _ *cmapi.Certificate, secretData internal.SecretData) error {
+				secretsUpdateDataCalled = true
+				assert.Equal(t, *test.expSecretUpdateDataCall, secretData)
+				return nil
+			}
+
+			builder.Start()
+
+			key, err := cache.MetaNamespaceKeyFunc(issuingCert)
+			require.NoError(t, err)
+
+			err = w.controller.ProcessItem(context.Background(), key)
+			if test.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expSecretUpdateDataCall != nil, secretsUpdateDataCalled)
+			builder.CheckAndFinish(err)
+		})
+	}
+}
+
+func TestIssuanceReceiptVerifiesAgainstIssuedCertificate(t *testing.T) {
+	signingKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	keyPEM, err := pki.EncodeECPrivateKey(signingKey)
+	require.NoError(t, err)
+
+	crt := gen.Certificate("test", gen.SetCertificateDNSNames("example.com"), gen.SetCertificateIPs("10.0.0.1"))
+	bundle := testcrypto.MustCreateCryptoBundle(t, crt, fixedClock)
+
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	require.NoError(t, secretIndexer.Add(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "receipt-signing-key", Namespace: "cert-manager"},
+		Data:       map[string][]byte{corev1.TLSPrivateKeyKey: keyPEM},
+	}))
+
+	c := &controller{
+		secretLister:                     corelisters.NewSecretLister(secretIndexer),
+		clock:                            fixedClock,
+		receiptSigningKeySecretNamespace: "cert-manager",
+		receiptSigningKeySecretName:      "receipt-signing-key",
+	}
+
+	token, err := c.signIssuanceReceipt(3, crt, bundle.CertificateRequestReady)
+	require.NoError(t, err)
+
+	r, err := receipt.Verify(token, signingKey.Public())
+	require.NoError(t, err)
+	assert.NoError(t, r.MatchesCertificate(bundle.Cert))
+	assert.Equal(t, 3, r.Revision)
+	assert.Equal(t, bundle.CertificateRequestReady.Name, r.CertificateRequest)
+	assert.Equal(t, []string{"10.0.0.1"}, r.IPAddresses)
+	assert.Equal(t, fixedClockStart.UTC().Truncate(time.Second), r.IssuedAt.Truncate(time.Second))
+}
@@ -25,16 +25,18 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing/internal"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 // ensureSecretData ensures that the Certificate's Secret is up to date with
 // non-issuing condition related data.
-// Reconciles over the Certificate's SecretTemplate, and
-// AdditionalOutputFormats.
+// Reconciles over the Certificate's SecretTemplate, AdditionalOutputFormats,
+// and the Secret of its RemoteTarget.
 func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt *cmapi.Certificate) error {
 	// Retrieve the Secret which is associated with this Certificate.
 	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
@@ -69,13 +71,30 @@ func (c *controller) ensureSecretData(ctx context.Context, log logr.Logger, crt
 		PrivateKey:  secret.Data[corev1.TLSPrivateKeyKey],
 		Certificate: secret.Data[corev1.TLSCertKey],
 		CA:          secret.Data[cmmeta.TLSCAKey],
+		// Keep the issuance receipt of the current revision, if any.
+		IssuanceReceipt: secret.Annotations[cmapi.IssuanceReceiptAnnotationKey],
+	}
+
+	// Fetch the Secret in the remote cluster, if the Certificate has a remote
+	// target.
+	var remoteSecret *corev1.Secret
+	if crt.Spec.RemoteTarget != nil && utilfeature.DefaultFeatureGate.Enabled(feature.RemoteSecretTargets) {
+		cluster, err := c.remoteClusters.ForCertificate(ctx, crt)
+		if err != nil {
+			return err
+		}
+		remoteSecret, err = cluster.SecretLister.Secrets(crt.Spec.RemoteTarget.Namespace).Get(crt.Spec.SecretName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
 	}
 
 	// Check whether the Certificate's Secret has correct output format and
 	// metadata.
 	reason, message, isViolation := c.postIssuancePolicyChain.Evaluate(policies.Input{
-		Certificate: crt,
-		Secret:      secret,
+		Certificate:  crt,
+		Secret:       secret,
+		RemoteSecret: remoteSecret,
 	})
 
 	if isViolation {
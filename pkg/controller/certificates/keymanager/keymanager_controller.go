@@ -38,6 +38,8 @@ import (
 	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -45,6 +47,7 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -56,6 +59,7 @@ const (
 	reasonDecodeFailed        = "DecodeFailed"
 	reasonCannotRegenerateKey = "CannotRegenerateKey"
 	reasonDeleted             = "Deleted"
+	reasonPrivateKeyRejected  = "PrivateKeyRejected"
 )
 
 var (
@@ -63,11 +67,13 @@ var (
 )
 
 type controller struct {
-	certificateLister cmlisters.CertificateLister
-	secretLister      corelisters.SecretLister
-	client            cmclient.Interface
-	coreClient        kubernetes.Interface
-	recorder          record.EventRecorder
+	certificateLister        cmlisters.CertificateLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	secretLister             corelisters.SecretLister
+	helper                   issuerpkg.Helper
+	client                   cmclient.Interface
+	coreClient               kubernetes.Interface
+	recorder                 record.EventRecorder
 
 	// fieldManager is the string which will be used as the Field Manager on
 	// fields created or edited by the cert-manager Kubernetes client during
@@ -81,6 +87,7 @@ func NewController(
 	coreClient kubernetes.Interface,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
+	namespace string,
 	recorder record.EventRecorder,
 	fieldManager string,
 ) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
@@ -89,9 +96,18 @@ func NewController(
 
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
+	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
 	secretsInformer := factory.Core().V1().Secrets()
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to any 'owned' CertificateRequest
+		// resources, to observe private keys rejected by the issuer
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ResourceOwnerOf,
+		),
+	})
 
 	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
 		// Trigger reconciles on changes to any 'owned' secret resources
@@ -110,16 +126,29 @@ func NewController(
 	// the controller will only begin processing items once all of these informers have synced.
 	mustSync := []cache.InformerSynced{
 		secretsInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+	}
+
+	// ClusterIssuers can only be read if cert-manager is not scoped to a
+	// single namespace.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
 	}
 
 	return &controller{
-		certificateLister: certificateInformer.Lister(),
-		secretLister:      secretsInformer.Lister(),
-		client:            client,
-		coreClient:        coreClient,
-		recorder:          recorder,
-		fieldManager:      fieldManager,
+		certificateLister:        certificateInformer.Lister(),
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		secretLister:             secretsInformer.Lister(),
+		helper:                   issuerpkg.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		client:                   client,
+		coreClient:               coreClient,
+		recorder:                 recorder,
+		fieldManager:             fieldManager,
 	}, queue, mustSync
 }
 
@@ -154,6 +183,15 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	// A private key fallback only applies to the generation of the
+	// Certificate it was chosen for.
+	if fallback := crt.Status.PrivateKeyFallback; fallback != nil && fallback.ObservedGeneration != crt.Generation {
+		log.V(logf.DebugLevel).Info("Discarding private key fallback chosen for a previous generation of the Certificate")
+		crt = crt.DeepCopy()
+		crt.Status.PrivateKeyFallback = nil
+		return c.updateOrApplyStatus(ctx, crt)
+	}
+
 	// Discover all 'owned' secrets that have the `next-private-key` label
 	secrets, err := certificates.ListSecretsMatchingPredicates(c.secretLister.Secrets(crt.Namespace), isNextPrivateKeyLabelSelector, predicate.ResourceOwnedBy(crt))
 	if err != nil {
@@ -168,7 +206,12 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		if err := c.deleteSecretResources(ctx, secrets); err != nil {
 			return err
 		}
-		return c.setNextPrivateKeySecretName(ctx, crt, nil)
+		if crt.Status.NextPrivateKeySecretName != nil {
+			return c.setNextPrivateKeySecretName(ctx, crt, nil)
+		}
+		// Any failure of the last issuance has been recorded, choose a
+		// private key fallback if the issuer rejected the private key.
+		return c.setPrivateKeyFallbackIfRejected(ctx, crt)
 	}
 
 	// if there is no existing Secret resource, create a new one
@@ -220,7 +263,7 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return c.deleteSecretResources(ctx, secrets)
 	}
 
-	violations, err := pki.PrivateKeyMatchesSpec(pk, crt.Spec)
+	violations, err := pki.PrivateKeyMatchesSpec(pk, pki.CertificateSpecWithPrivateKeyFallback(crt))
 	if err != nil {
 		log.Error(err, "Internal error verifying if private key matches spec - please open an issue.")
 		return nil
@@ -234,6 +277,133 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 	return nil
 }
 
+// setPrivateKeyFallbackIfRejected will set status.privateKeyFallback to the
+// next private key fallback configured on the ACME issuer of the Certificate,
+// if the issuer rejected the private key of the CertificateRequest for the
+// next revision of the Certificate.
+func (c *controller) setPrivateKeyFallbackIfRejected(ctx context.Context, crt *cmapi.Certificate) error {
+	log := logf.FromContext(ctx)
+
+	// CertificateRequest revisions begin from 1. If no revision is set on the
+	// status then assume no revision yet set.
+	nextRevision := 1
+	if crt.Status.Revision != nil {
+		nextRevision = *crt.Status.Revision + 1
+	}
+	reqs, err := certificates.ListCertificateRequestsMatchingPredicates(c.certificateRequestLister.CertificateRequests(crt.Namespace),
+		labels.Everything(),
+		predicate.CertificateRequestRevision(nextRevision),
+		predicate.ResourceOwnedBy(crt),
+	)
+	if err != nil || len(reqs) != 1 {
+		return err
+	}
+	req := reqs[0]
+	if !apiutil.CertificateRequestHasCondition(req, cmapi.CertificateRequestCondition{
+		Type:   cmapi.CertificateRequestConditionPrivateKeyRejected,
+		Status: cmmeta.ConditionTrue,
+	}) {
+		return nil
+	}
+
+	// If the rejected private key doesn't match the private key currently
+	// requested, a fallback has already been chosen for it.
+	csr, err := pki.DecodeX509CertificateRequestBytes(req.Spec.Request)
+	if err != nil {
+		return nil
+	}
+	spec := pki.CertificateSpecWithPrivateKeyFallback(crt)
+	violations, err := pki.PublicKeyMatchesSpec(csr.PublicKey, spec)
+	if err != nil || len(violations) > 0 {
+		return nil
+	}
+
+	fallbacks, err := c.privateKeyFallbacks(crt)
+	if err != nil {
+		return err
+	}
+	rejectedAlgorithm, rejectedSize := pki.PrivateKeyAlgorithmAndSize(spec)
+	fallback := nextPrivateKeyFallback(fallbacks, crt.Status.PrivateKeyFallback, rejectedAlgorithm, rejectedSize)
+	if fallback == nil {
+		log.V(logf.DebugLevel).Info("Issuer rejected the private key but no private key fallback is left, retrying issuance with the same private key")
+		return nil
+	}
+	fallback.ObservedGeneration = crt.Generation
+
+	crt = crt.DeepCopy()
+	crt.Status.PrivateKeyFallback = fallback
+	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+		return err
+	}
+
+	c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonPrivateKeyRejected, "Issuer rejected the %s private key, generating a %s private key instead",
+		describePrivateKey(rejectedAlgorithm, rejectedSize), describePrivateKey(fallback.Algorithm, fallback.Size))
+
+	return nil
+}
+
+// privateKeyFallbacks returns the private key fallbacks configured on the
+// issuer of the Certificate. Only ACME issuers support private key fallbacks.
+func (c *controller) privateKeyFallbacks(crt *cmapi.Certificate) ([]cmacme.ACMEPrivateKeyFallback, error) {
+	if group := crt.Spec.IssuerRef.Group; group != "" && group != certmanager.GroupName {
+		return nil, nil
+	}
+
+	issuerObj, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if acme := issuerObj.GetSpec().ACME; acme != nil {
+		return acme.PrivateKeyFallbacks, nil
+	}
+	return nil, nil
+}
+
+// nextPrivateKeyFallback returns the fallback following the current one in
+// the list of fallbacks, or the first fallback if none is currently used.
+// Fallbacks for the same algorithm and size as the rejected private key are
+// skipped. It returns nil if no fallback is left.
+func nextPrivateKeyFallback(fallbacks []cmacme.ACMEPrivateKeyFallback, current *cmapi.CertificatePrivateKeyFallback, rejectedAlgorithm cmapi.PrivateKeyAlgorithm, rejectedSize int) *cmapi.CertificatePrivateKeyFallback {
+	normalize := func(algorithm cmapi.PrivateKeyAlgorithm, size int) (cmapi.PrivateKeyAlgorithm, int) {
+		return pki.PrivateKeyAlgorithmAndSize(cmapi.CertificateSpec{
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: algorithm, Size: size},
+		})
+	}
+
+	start := 0
+	if current != nil {
+		currentAlgorithm, currentSize := normalize(current.Algorithm, current.Size)
+		for i, fallback := range fallbacks {
+			if algorithm, size := normalize(cmapi.PrivateKeyAlgorithm(fallback.Algorithm), fallback.Size); algorithm == currentAlgorithm && size == currentSize {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	for _, fallback := range fallbacks[start:] {
+		algorithm, size := normalize(cmapi.PrivateKeyAlgorithm(fallback.Algorithm), fallback.Size)
+		if algorithm == rejectedAlgorithm && size == rejectedSize {
+			continue
+		}
+		return &cmapi.CertificatePrivateKeyFallback{Algorithm: algorithm, Size: size}
+	}
+	return nil
+}
+
+// describePrivateKey returns a human readable description of a private key
+// algorithm and size, for use in events.
+func describePrivateKey(algorithm cmapi.PrivateKeyAlgorithm, size int) string {
+	if algorithm == cmapi.Ed25519KeyAlgorithm {
+		return string(algorithm)
+	}
+	return fmt.Sprintf("%d bit %s", size, algorithm)
+}
+
 func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context, crt *cmapi.Certificate) error {
 	log := logf.FromContext(ctx)
 	s, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
@@ -254,7 +424,7 @@ func (c *controller) createNextPrivateKeyRotationPolicyNever(ctx context.Context
 		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonDecodeFailed, "Failed to decode private key stored in Secret %q - generating new key", crt.Spec.SecretName)
 		return c.createAndSetNextPrivateKey(ctx, crt)
 	}
-	violations, err := pki.PrivateKeyMatchesSpec(pk, crt.Spec)
+	violations, err := pki.PrivateKeyMatchesSpec(pk, pki.CertificateSpecWithPrivateKeyFallback(crt))
 	if err != nil {
 		c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonDecodeFailed, "Failed to check if private key stored in Secret %q is up to date - generating new key", crt.Spec.SecretName)
 		return c.createAndSetNextPrivateKey(ctx, crt)
@@ -324,7 +494,10 @@ func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certifi
 	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
 		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
 			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
-			Status:     cmapi.CertificateStatus{NextPrivateKeySecretName: crt.Status.NextPrivateKeySecretName},
+			Status: cmapi.CertificateStatus{
+				NextPrivateKeySecretName: crt.Status.NextPrivateKeySecretName,
+				PrivateKeyFallback:       crt.Status.PrivateKeyFallback,
+			},
 		})
 	} else {
 		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
@@ -363,7 +536,7 @@ func (c *controller) createNewPrivateKeySecret(ctx context.Context, crt *cmapi.C
 		// TODO: handle certificate resources that have especially long names
 		s.GenerateName = crt.Name + "-"
 	}
-	s, err = c.coreClient.CoreV1().Secrets(s.Namespace).Create(ctx, s, metav1.CreateOptions{})
+	s, err = c.coreClient.CoreV1().Secrets(s.Namespace).Create(ctx, s, metav1.CreateOptions{FieldManager: c.fieldManager})
 	if err != nil {
 		return nil, err
 	}
@@ -385,6 +558,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.Client,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
+		ctx.Namespace,
 		ctx.Recorder,
 		ctx.FieldManager,
 	)
@@ -18,6 +18,7 @@ package keymanager
 
 import (
 	"context"
+	"crypto"
 	"fmt"
 	"reflect"
 	"testing"
@@ -30,11 +31,13 @@ import (
 	coretesting "k8s.io/client-go/testing"
 	"k8s.io/utils/pointer"
 
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
 func mustGenerateRSA(t *testing.T, keySize int) []byte {
@@ -94,6 +97,64 @@ func TestProcessItem(t *testing.T) {
 			Data: data,
 		}
 	}
+
+	fallbackIssuer := gen.Issuer("acme",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerACME(cmacme.ACMEIssuer{
+			PrivateKeyFallbacks: []cmacme.ACMEPrivateKeyFallback{
+				{Algorithm: "ECDSA"},
+				{Algorithm: "ECDSA", Size: 384},
+			},
+		}),
+	)
+	// failedCertificate returns a Certificate whose last issuance failed,
+	// using the given private key fallback.
+	failedCertificate := func(fallback *cmapi.CertificatePrivateKeyFallback) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+			Spec: cmapi.CertificateSpec{
+				IssuerRef: cmmeta.ObjectReference{Name: "acme"},
+			},
+			Status: cmapi.CertificateStatus{
+				PrivateKeyFallback: fallback,
+				Conditions: []cmapi.CertificateCondition{
+					{
+						Type:   cmapi.CertificateConditionIssuing,
+						Status: cmmeta.ConditionFalse,
+					},
+				},
+			},
+		}
+	}
+	// rejectedRequest returns the next CertificateRequest of the Certificate
+	// returned by failedCertificate, for which the issuer rejected the
+	// private key.
+	rejectedRequest := func(pk crypto.Signer) *cmapi.CertificateRequest {
+		csr, err := gen.CSRWithSigner(pk, gen.SetCSRCommonName("example.com"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return gen.CertificateRequest("test-1",
+			gen.SetCertificateRequestNamespace("testns"),
+			gen.SetCertificateRequestCSR(csr),
+			gen.SetCertificateRequestRevision("1"),
+			gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(failedCertificate(nil), certificateGvk)),
+			gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+				Type:   cmapi.CertificateRequestConditionPrivateKeyRejected,
+				Status: cmmeta.ConditionTrue,
+				Reason: "BadCSR",
+			}),
+		)
+	}
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem.
 		// if not set, the 'namespace/name' of the 'Certificate' field will be used.
@@ -109,6 +170,9 @@ func TestProcessItem(t *testing.T) {
 		// Request, if set, will exist in the apiserver before the test is run.
 		requests []*cmapi.CertificateRequest
 
+		// Issuer, if set, will exist in the apiserver before the test is run.
+		issuer *cmapi.Issuer
+
 		expectedActions []testpkg.Action
 
 		expectedEvents []string
@@ -462,6 +526,104 @@ func TestProcessItem(t *testing.T) {
 				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)}),
 			},
 		},
+		"set the first private key fallback if the issuer rejected the private key requested in the spec": {
+			certificate: failedCertificate(nil),
+			requests:    []*cmapi.CertificateRequest{rejectedRequest(rsaKey)},
+			issuer:      fallbackIssuer,
+			expectedEvents: []string{
+				"Warning PrivateKeyRejected Issuer rejected the 2048 bit RSA private key, generating a 256 bit ECDSA private key instead",
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					failedCertificate(&cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256}),
+				)),
+			},
+		},
+		"set the next private key fallback if the issuer rejected the current fallback": {
+			certificate: failedCertificate(&cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256}),
+			requests:    []*cmapi.CertificateRequest{rejectedRequest(ecdsaKey)},
+			issuer:      fallbackIssuer,
+			expectedEvents: []string{
+				"Warning PrivateKeyRejected Issuer rejected the 256 bit ECDSA private key, generating a 384 bit ECDSA private key instead",
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					failedCertificate(&cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384}),
+				)),
+			},
+		},
+		"do nothing if the rejected private key has already been replaced by a fallback": {
+			certificate: failedCertificate(&cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256}),
+			requests:    []*cmapi.CertificateRequest{rejectedRequest(rsaKey)},
+			issuer:      fallbackIssuer,
+		},
+		"do nothing if no private key fallback is left": {
+			certificate: failedCertificate(&cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384}),
+			requests: []*cmapi.CertificateRequest{rejectedRequest(func() crypto.Signer {
+				pk, err := pki.GenerateECPrivateKey(384)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return pk
+			}())},
+			issuer: fallbackIssuer,
+		},
+		"do nothing if the issuer has no private key fallbacks": {
+			certificate: failedCertificate(nil),
+			requests:    []*cmapi.CertificateRequest{rejectedRequest(rsaKey)},
+			issuer:      gen.IssuerFrom(fallbackIssuer, gen.SetIssuerACME(cmacme.ACMEIssuer{})),
+		},
+		"discard a private key fallback chosen for a previous generation of the Certificate": {
+			certificate: func() *cmapi.Certificate {
+				crt := failedCertificate(&cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256, ObservedGeneration: 1})
+				crt.Generation = 2
+				return crt
+			}(),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+					cmapi.SchemeGroupVersion.WithResource("certificates"),
+					"status",
+					"testns",
+					func() *cmapi.Certificate {
+						crt := failedCertificate(nil)
+						crt.Generation = 2
+						return crt
+					}(),
+				)),
+			},
+		},
+		"if an owned secret exists and does not match the private key fallback, delete it": {
+			certificate: &cmapi.Certificate{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "test", UID: types.UID("test")},
+				Status: cmapi.CertificateStatus{
+					NextPrivateKeySecretName: pointer.StringPtr("fixed-name"),
+					PrivateKeyFallback:       &cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256},
+					Conditions: []cmapi.CertificateCondition{
+						{
+							Type:   cmapi.CertificateConditionIssuing,
+							Status: cmmeta.ConditionTrue,
+						},
+					},
+				},
+			},
+			secrets: []runtime.Object{
+				ownedSecretWithName("testns", "fixed-name", "test", map[string][]byte{"tls.key": mustGenerateRSA(t, 2048)}),
+			},
+			expectedEvents: []string{`Normal Deleted Regenerating private key due to change in fields: [spec.privateKey.algorithm]`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewDeleteAction(
+					corev1.SchemeGroupVersion.WithResource("secrets"),
+					"testns",
+					"fixed-name",
+				)),
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -481,6 +643,9 @@ func TestProcessItem(t *testing.T) {
 			for _, req := range test.requests {
 				builder.CertManagerObjects = append(builder.CertManagerObjects, req)
 			}
+			if test.issuer != nil {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, test.issuer)
+			}
 			builder.Init()
 
 			// Register informers used by the controller using the registration wrapper
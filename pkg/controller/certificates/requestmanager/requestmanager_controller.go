@@ -38,8 +38,10 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
 
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -47,6 +49,7 @@ import (
 	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -57,6 +60,8 @@ const (
 	ControllerName      = "certificates-request-manager"
 	reasonRequestFailed = "RequestFailed"
 	reasonRequested     = "Requested"
+
+	reasonLiteralSubjectNotSupported = "LiteralSubjectNotSupported"
 )
 
 var (
@@ -67,6 +72,7 @@ type controller struct {
 	certificateLister        cmlisters.CertificateLister
 	certificateRequestLister cmlisters.CertificateRequestLister
 	secretLister             corelisters.SecretLister
+	helper                   issuerpkg.Helper
 	client                   cmclient.Interface
 	recorder                 record.EventRecorder
 	clock                    clock.Clock
@@ -83,6 +89,7 @@ func NewController(
 	client cmclient.Interface,
 	factory informers.SharedInformerFactory,
 	cmFactory cminformers.SharedInformerFactory,
+	namespace string,
 	recorder record.EventRecorder,
 	clock clock.Clock,
 	certificateControllerOptions controllerpkg.CertificateOptions,
@@ -94,6 +101,7 @@ func NewController(
 	// obtain references to all the informers used by this controller
 	certificateInformer := cmFactory.Certmanager().V1().Certificates()
 	certificateRequestInformer := cmFactory.Certmanager().V1().CertificateRequests()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
 	secretsInformer := factory.Core().V1().Secrets()
 
 	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
@@ -109,6 +117,11 @@ func NewController(
 			predicate.ResourceOwnerOf,
 		),
 	})
+	// Issuers are watched so that Certificates which failed because their
+	// issuer does not support literal subjects are retried once it does.
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueCertificatesForGenericIssuer(log, queue, certificateInformer.Lister()),
+	})
 
 	// build a list of InformerSynced functions that will be returned by the Register method.
 	// the controller will only begin processing items once all of these informers have synced.
@@ -116,12 +129,26 @@ func NewController(
 		secretsInformer.Informer().HasSynced,
 		certificateRequestInformer.Informer().HasSynced,
 		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+	}
+
+	// ClusterIssuers can only be read if cert-manager is not scoped to a
+	// single namespace.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+			WorkFunc: enqueueCertificatesForGenericIssuer(log, queue, certificateInformer.Lister()),
+		})
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
 	}
 
 	return &controller{
 		certificateLister:        certificateInformer.Lister(),
 		certificateRequestLister: certificateRequestInformer.Lister(),
 		secretLister:             secretsInformer.Lister(),
+		helper:                   issuerpkg.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
 		client:                   client,
 		recorder:                 recorder,
 		clock:                    clock,
@@ -149,6 +176,14 @@ func (c *controller) ProcessItem(ctx context.Context, key string) error {
 		return err
 	}
 
+	if apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
+		Type:   cmapi.CertificateConditionIssuing,
+		Status: cmmeta.ConditionFalse,
+		Reason: reasonLiteralSubjectNotSupported,
+	}) {
+		return c.retryLiteralSubjectIssuance(ctx, crt)
+	}
+
 	if !apiutil.CertificateHasCondition(crt, cmapi.CertificateCondition{
 		Type:   cmapi.CertificateConditionIssuing,
 		Status: cmmeta.ConditionTrue,
@@ -359,6 +394,18 @@ func (c *controller) deleteRequestsNotMatchingSpec(ctx context.Context, crt *cma
 
 func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi.Certificate, pk crypto.Signer, nextRevision int, nextPrivateKeySecretName string) error {
 	log := logf.FromContext(ctx)
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.LiteralCertificateSubject) && len(crt.Spec.LiteralSubject) > 0 {
+		supported, err := c.issuerSupportsLiteralSubject(crt)
+		if err != nil {
+			return err
+		}
+		if !supported {
+			log.V(logf.DebugLevel).Info("issuer does not support literal subjects, not creating CertificateRequest")
+			return c.failLiteralSubjectIssuance(ctx, crt)
+		}
+	}
+
 	x509CSR, err := pki.GenerateCSR(crt)
 	if err != nil {
 		log.Error(err, "Failed to generate CSR - will not retry")
@@ -423,6 +470,155 @@ func (c *controller) createNewCertificateRequest(ctx context.Context, crt *cmapi
 	return nil
 }
 
+// issuerSupportsLiteralSubject returns false if the issuer referenced by the
+// given Certificate is known to not preserve a literal subject.
+// External issuers, and issuers which do not exist yet, are assumed to support
+// it, since their CertificateRequests will report any failures.
+func (c *controller) issuerSupportsLiteralSubject(crt *cmapi.Certificate) (bool, error) {
+	if group := crt.Spec.IssuerRef.Group; group != "" && group != certmanager.GroupName {
+		return true, nil
+	}
+
+	issuerObj, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return apiutil.IssuerSupportsLiteralSubject(issuerObj), nil
+}
+
+// failLiteralSubjectIssuance fails the issuance of a Certificate whose issuer
+// does not support its literal subject, in the same way as the issuing
+// controller fails an issuance, so that the trigger controller backs off
+// before retrying it.
+func (c *controller) failLiteralSubjectIssuance(ctx context.Context, crt *cmapi.Certificate) error {
+	crt = crt.DeepCopy()
+	nowTime := metav1.NewTime(c.clock.Now())
+	crt.Status.LastFailureTime = &nowTime
+
+	failedIssuanceAttempts := 1
+	if crt.Status.FailedIssuanceAttempts != nil {
+		failedIssuanceAttempts = *crt.Status.FailedIssuanceAttempts + 1
+	}
+	crt.Status.FailedIssuanceAttempts = &failedIssuanceAttempts
+
+	message := fmt.Sprintf("The %s %q does not support the literalSubject field, use the commonName and subject fields instead",
+		apiutil.IssuerKind(crt.Spec.IssuerRef), crt.Spec.IssuerRef.Name)
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionFalse, reasonLiteralSubjectNotSupported, message)
+
+	if err := c.updateOrApplyStatus(ctx, crt); err != nil {
+		return err
+	}
+
+	c.recorder.Event(crt, corev1.EventTypeWarning, reasonLiteralSubjectNotSupported, message)
+	return nil
+}
+
+// retryLiteralSubjectIssuance clears the failure recorded by
+// failLiteralSubjectIssuance once the literal subject has been removed or the
+// issuer supports it, so that the trigger controller retries the issuance
+// without waiting for its back-off.
+func (c *controller) retryLiteralSubjectIssuance(ctx context.Context, crt *cmapi.Certificate) error {
+	if crt.Status.LastFailureTime == nil {
+		return nil
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.LiteralCertificateSubject) && len(crt.Spec.LiteralSubject) > 0 {
+		supported, err := c.issuerSupportsLiteralSubject(crt)
+		if err != nil {
+			return err
+		}
+		if !supported {
+			return nil
+		}
+	}
+
+	logf.FromContext(ctx).V(logf.DebugLevel).Info("issuer now supports the literal subject, clearing the failed issuance")
+
+	crt = crt.DeepCopy()
+	crt.Status.LastFailureTime = nil
+	crt.Status.FailedIssuanceAttempts = nil
+	return c.updateOrApplyStatus(ctx, crt)
+}
+
+// updateOrApplyStatus will update the controller status. If the
+// ServerSideApply feature is enabled, the managed fields will instead get
+// applied using the relevant Patch API call.
+func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.Certificate) error {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		var conditions []cmapi.CertificateCondition
+		if cond := apiutil.GetCertificateCondition(crt, cmapi.CertificateConditionIssuing); cond != nil {
+			conditions = []cmapi.CertificateCondition{*cond}
+		}
+		return internalcertificates.ApplyStatus(ctx, c.client, c.fieldManager, &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+			Status: cmapi.CertificateStatus{
+				LastFailureTime:        crt.Status.LastFailureTime,
+				FailedIssuanceAttempts: crt.Status.FailedIssuanceAttempts,
+				Conditions:             conditions,
+			},
+		})
+	} else {
+		_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+// enqueueCertificatesForGenericIssuer returns a handler which enqueues the
+// Certificates with a literal subject which reference the given Issuer or
+// ClusterIssuer, if it supports literal subjects. These are the only
+// Certificates whose issuance may have failed because of their issuer, so
+// other Certificates are not enqueued on changes to issuers.
+func enqueueCertificatesForGenericIssuer(log logr.Logger, queue workqueue.Interface, lister cmlisters.CertificateLister) func(obj interface{}) {
+	return func(obj interface{}) {
+		iss, ok := obj.(cmapi.GenericIssuer)
+		if !ok {
+			log.Error(nil, "object does not implement GenericIssuer")
+			return
+		}
+		if !utilfeature.DefaultFeatureGate.Enabled(feature.LiteralCertificateSubject) || !apiutil.IssuerSupportsLiteralSubject(iss) {
+			return
+		}
+		_, isClusterIssuer := iss.(*cmapi.ClusterIssuer)
+
+		var crts []*cmapi.Certificate
+		var err error
+		if isClusterIssuer {
+			crts, err = lister.List(labels.Everything())
+		} else {
+			crts, err = lister.Certificates(iss.GetObjectMeta().Namespace).List(labels.Everything())
+		}
+		if err != nil {
+			log.Error(err, "failed listing Certificates for issuer")
+			return
+		}
+		for _, crt := range crts {
+			if len(crt.Spec.LiteralSubject) == 0 {
+				continue
+			}
+			ref := crt.Spec.IssuerRef
+			if ref.Name != iss.GetObjectMeta().Name {
+				continue
+			}
+			if group := ref.Group; group != "" && group != certmanager.GroupName {
+				continue
+			}
+			if isClusterIssuer != (ref.Kind == cmapi.ClusterIssuerKind) {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "error computing key for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
 func (c *controller) waitForCertificateRequestToExist(namespace, name string) error {
 	return wait.Poll(time.Millisecond*100, time.Second*5, func() (bool, error) {
 		_, err := c.certificateRequestLister.CertificateRequests(namespace).Get(name)
@@ -450,6 +646,7 @@ func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.Rate
 		ctx.CMClient,
 		ctx.KubeSharedInformerFactory,
 		ctx.SharedInformerFactory,
+		ctx.Namespace,
 		ctx.Recorder,
 		ctx.Clock,
 		ctx.CertificateOptions,
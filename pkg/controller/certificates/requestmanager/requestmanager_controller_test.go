@@ -23,18 +23,23 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/kr/pretty"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	coretesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/component-base/featuregate"
 	featuregatetesting "k8s.io/component-base/featuregate/testing"
 	fakeclock "k8s.io/utils/clock/testing"
+	"k8s.io/utils/pointer"
 
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
@@ -90,6 +95,18 @@ func TestProcessItem(t *testing.T) {
 		},
 		Spec: cmapi.CertificateSpec{CommonName: "test-bundle-3"}},
 	)
+	bundle4 := mustCreateCryptoBundle(t, &cmapi.Certificate{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "testns",
+			Name:      "test",
+			UID:       "test",
+		},
+		Spec: cmapi.CertificateSpec{
+			LiteralSubject: "CN=test-bundle-4,O=cert-manager",
+			DNSNames:       []string{"test-bundle-4.example.com"},
+			IssuerRef:      cmmeta.ObjectReference{Name: "issuer"},
+		}},
+	)
 	fixedNow := metav1.NewTime(time.Now())
 	fixedClock := fakeclock.NewFakeClock(fixedNow.Time)
 	failedCRConditionPreviousIssuance := cmapi.CertificateRequestCondition{
@@ -104,6 +121,12 @@ func TestProcessItem(t *testing.T) {
 		Reason:             cmapi.CertificateRequestReasonFailed,
 		LastTransitionTime: &metav1.Time{Time: fixedNow.Time.Add(1 * time.Minute)},
 	}
+	literalSubjectNotSupportedCondition := cmapi.CertificateCondition{
+		Type:               cmapi.CertificateConditionIssuing,
+		Status:             cmmeta.ConditionFalse,
+		Reason:             "LiteralSubjectNotSupported",
+		LastTransitionTime: &fixedNow,
+	}
 	tests := map[string]struct {
 		// key that should be passed to ProcessItem.
 		// if not set, the 'namespace/name' of the 'Certificate' field will be used.
@@ -122,6 +145,9 @@ func TestProcessItem(t *testing.T) {
 		// Request, if set, will exist in the apiserver before the test is run.
 		requests []runtime.Object
 
+		// Issuers, if set, will exist in the apiserver before the test is run.
+		issuers []runtime.Object
+
 		expectedActions []testpkg.Action
 
 		expectedEvents []string
@@ -225,6 +251,94 @@ func TestProcessItem(t *testing.T) {
 					)), relaxedCertificateRequestMatcher),
 			},
 		},
+		"create a CertificateRequest for a literal subject if the issuer supports it": {
+			featuresToEnable: []featuregate.Feature{feature.LiteralCertificateSubject},
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle4.privateKeyBytes},
+				},
+			},
+			issuers: []runtime.Object{
+				gen.Issuer("issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+			},
+			certificate: gen.CertificateFrom(bundle4.certificate,
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			expectedEvents: []string{`Normal Requested Created new CertificateRequest resource "test-notrandom"`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequestFrom(bundle4.certificateRequest,
+						gen.SetCertificateRequestAnnotations(map[string]string{
+							cmapi.CertificateRequestPrivateKeyAnnotationKey: "exists",
+							cmapi.CertificateRequestRevisionAnnotationKey:   "1",
+						}),
+					)), relaxedCertificateRequestMatcher),
+			},
+		},
+		"do not create a CertificateRequest for a literal subject if the issuer does not support it": {
+			featuresToEnable: []featuregate.Feature{feature.LiteralCertificateSubject},
+			secrets: []runtime.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "exists"},
+					Data:       map[string][]byte{corev1.TLSPrivateKeyKey: bundle4.privateKeyBytes},
+				},
+			},
+			issuers: []runtime.Object{
+				gen.Issuer("issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerVault(cmapi.VaultIssuer{})),
+			},
+			certificate: gen.CertificateFrom(bundle4.certificate,
+				gen.SetCertificateNextPrivateKeySecretName("exists"),
+				gen.SetCertificateStatusCondition(cmapi.CertificateCondition{Type: cmapi.CertificateConditionIssuing, Status: cmmeta.ConditionTrue}),
+			),
+			expectedEvents: []string{`Warning LiteralSubjectNotSupported The Issuer "issuer" does not support the literalSubject field, use the commonName and subject fields instead`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(bundle4.certificate,
+						gen.SetCertificateNextPrivateKeySecretName("exists"),
+						gen.SetCertificateStatusCondition(cmapi.CertificateCondition{
+							Type:               cmapi.CertificateConditionIssuing,
+							Status:             cmmeta.ConditionFalse,
+							Reason:             "LiteralSubjectNotSupported",
+							Message:            `The Issuer "issuer" does not support the literalSubject field, use the commonName and subject fields instead`,
+							LastTransitionTime: &fixedNow,
+						}),
+						gen.SetCertificateLastFailureTime(fixedNow),
+						gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+					),
+				)),
+			},
+		},
+		"clear the failed issuance of a literal subject once the issuer supports it": {
+			featuresToEnable: []featuregate.Feature{feature.LiteralCertificateSubject},
+			issuers: []runtime.Object{
+				gen.Issuer("issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+			},
+			certificate: gen.CertificateFrom(bundle4.certificate,
+				gen.SetCertificateStatusCondition(literalSubjectNotSupportedCondition),
+				gen.SetCertificateLastFailureTime(fixedNow),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "status", "testns",
+					gen.CertificateFrom(bundle4.certificate,
+						gen.SetCertificateStatusCondition(literalSubjectNotSupportedCondition),
+					),
+				)),
+			},
+		},
+		"do not clear the failed issuance of a literal subject if the issuer still does not support it": {
+			featuresToEnable: []featuregate.Feature{feature.LiteralCertificateSubject},
+			issuers: []runtime.Object{
+				gen.Issuer("issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerVault(cmapi.VaultIssuer{})),
+			},
+			certificate: gen.CertificateFrom(bundle4.certificate,
+				gen.SetCertificateStatusCondition(literalSubjectNotSupportedCondition),
+				gen.SetCertificateLastFailureTime(fixedNow),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+			),
+		},
 		"delete the owned CertificateRequest and create a new one if existing one does not have the annotation": {
 			secrets: []runtime.Object{
 				&corev1.Secret{
@@ -646,6 +760,7 @@ func TestProcessItem(t *testing.T) {
 				builder.KubeObjects = append(builder.KubeObjects, test.secrets...)
 			}
 			builder.CertManagerObjects = append(builder.CertManagerObjects, test.requests...)
+			builder.CertManagerObjects = append(builder.CertManagerObjects, test.issuers...)
 			builder.Init()
 
 			// Register informers used by the controller using the registration wrapper
@@ -697,3 +812,59 @@ func TestProcessItem(t *testing.T) {
 		})
 	}
 }
+
+func Test_enqueueCertificatesForGenericIssuer(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultFeatureGate, feature.LiteralCertificateSubject, true)()
+
+	withLiteralSubject := func(crt *cmapi.Certificate) {
+		crt.Spec.LiteralSubject = "CN=example.com"
+	}
+	crts := []*cmapi.Certificate{
+		gen.Certificate("literal", gen.SetCertificateNamespace("ns"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"}), withLiteralSubject),
+		gen.Certificate("no-literal", gen.SetCertificateNamespace("ns"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"})),
+		gen.Certificate("other-namespace", gen.SetCertificateNamespace("other"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer"}), withLiteralSubject),
+		gen.Certificate("cluster-issuer", gen.SetCertificateNamespace("other"), gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "issuer", Kind: cmapi.ClusterIssuerKind}), withLiteralSubject),
+	}
+
+	tests := map[string]struct {
+		issuer       cmapi.GenericIssuer
+		expectedKeys []string
+	}{
+		"should enqueue the Certificates with a literal subject in the namespace of an Issuer supporting literal subjects": {
+			issuer:       gen.Issuer("issuer", gen.SetIssuerNamespace("ns"), gen.SetIssuerCASecretName("ca")),
+			expectedKeys: []string{"ns/literal"},
+		},
+		"should enqueue the Certificates with a literal subject referencing a ClusterIssuer supporting literal subjects": {
+			issuer:       gen.ClusterIssuer("issuer", gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+			expectedKeys: []string{"other/cluster-issuer"},
+		},
+		"should not enqueue any Certificate for an issuer not supporting literal subjects": {
+			issuer: gen.Issuer("issuer", gen.SetIssuerNamespace("ns"), gen.SetIssuerACMEURL("https://acme.example.com")),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			for _, crt := range crts {
+				if err := indexer.Add(crt); err != nil {
+					t.Fatal(err)
+				}
+			}
+			queue := workqueue.New()
+			defer queue.ShutDown()
+
+			enqueueCertificatesForGenericIssuer(logr.Discard(), queue, cmlisters.NewCertificateLister(indexer))(test.issuer)
+
+			var keys []string
+			for queue.Len() > 0 {
+				key, _ := queue.Get()
+				keys = append(keys, key.(string))
+				queue.Done(key)
+			}
+			if !reflect.DeepEqual(keys, test.expectedKeys) {
+				t.Errorf("unexpected enqueued keys, exp=%v got=%v", test.expectedKeys, keys)
+			}
+		})
+	}
+}
@@ -268,6 +268,23 @@ func shouldBackoffReissuingOnFailure(log logr.Logger, c clock.Clock, crt *cmapi.
 			log.V(logf.ExtendedInfoLevel).WithValues("mismatches", mismatches).Info("Certificate is failing but the Certificate differs from CertificateRequest, backoff is not required")
 			return false, 0
 		}
+
+		// When the issuer rejected the private key of the "next" CR, the
+		// keymanager chooses a private key fallback. A key generated for
+		// the fallback won't match the key of the rejected CR, and the
+		// issuance should be retried with it right away.
+		if crt.Status.PrivateKeyFallback != nil {
+			csr, err := pki.DecodeX509CertificateRequestBytes(nextCR.Spec.Request)
+			if err != nil {
+				log.V(logf.InfoLevel).Info("next CertificateRequest cannot be decoded, skipping checking if Certificate matches the CertificateRequest")
+				return false, 0
+			}
+			mismatches, err := pki.PublicKeyMatchesSpec(csr.PublicKey, pki.CertificateSpecWithPrivateKeyFallback(crt))
+			if err == nil && len(mismatches) > 0 {
+				log.V(logf.ExtendedInfoLevel).WithValues("mismatches", mismatches).Info("Certificate is failing but the private key fallback differs from CertificateRequest, backoff is not required")
+				return false, 0
+			}
+		}
 	}
 
 	now := c.Now()
@@ -375,6 +375,39 @@ func Test_shouldBackoffReissuingOnFailure(t *testing.T) {
 			wantBackoff: true,
 			wantDelay:   1 * time.Minute,
 		},
+		"should not back off from reissuing when the private key fallback differs from the failed request": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(clock.Now().Add(-1*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+				gen.SetCertificatePrivateKeyFallback(cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 256}),
+			),
+			givenNextCR: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+			)),
+			wantBackoff: false,
+		},
+		"should back off from reissuing when the failed request already used the private key fallback": {
+			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+				gen.SetCertificateLastFailureTime(metav1.NewTime(clock.Now().Add(-59*time.Minute))),
+				gen.SetCertificateIssuanceAttempts(pointer.Int(1)),
+				gen.SetCertificatePrivateKeyFallback(cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.RSAKeyAlgorithm, Size: 2048}),
+			),
+			givenNextCR: createCertificateRequestOrPanic(gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
+				gen.SetCertificateUID("cert-1-uid"),
+				gen.SetCertificateRevision(1),
+				gen.SetCertificateDNSNames("example.com"),
+			)),
+			wantBackoff: true,
+			wantDelay:   1 * time.Minute,
+		},
 		"should back off from reissuing for 1 hour if there was 1 failed issuance 0 minutes ago": {
 			givenCert: gen.Certificate("cert-1", gen.SetCertificateNamespace("testns"),
 				gen.SetCertificateUID("cert-1-uid"),
@@ -0,0 +1,413 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafiretirement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	internalcertificaterequests "github.com/cert-manager/cert-manager/internal/controller/certificaterequests"
+	internalcertificates "github.com/cert-manager/cert-manager/internal/controller/certificates"
+	"github.com/cert-manager/cert-manager/internal/controller/conflicts"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/predicate"
+)
+
+const (
+	ControllerName = "certificates-venafi-retirement"
+
+	reasonRetired           = "Retired"
+	reasonRetirementFailed  = "RetirementFailed"
+	reasonRetirementSkipped = "RetirementSkipped"
+
+	// retirementTimeout is how long after a Certificate has been deleted the
+	// retirement of its certificates is retried, before the finalizer is
+	// removed without retiring them.
+	retirementTimeout = time.Hour
+)
+
+// errRetirementUnavailable is returned by retire if a certificate can never be
+// retired, because its issuer or the issuer's credentials no longer exist.
+var errRetirementUnavailable = errors.New("retirement unavailable")
+
+// controller retires certificates in the Venafi inventory when the
+// Certificate they were issued for is deleted, and optionally when they have
+// been superseded by a new revision.
+type controller struct {
+	certificateLister        cmlisters.CertificateLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	secretLister             corelisters.SecretLister
+	helper                   issuerpkg.Helper
+	client                   cmclient.Interface
+	recorder                 record.EventRecorder
+	clock                    clock.Clock
+
+	clientBuilder venaficlient.VenafiClientBuilder
+	metrics       *metrics.Metrics
+
+	issuerOptions    controllerpkg.IssuerOptions
+	retireSuperseded bool
+	skipZones        sets.String
+	fieldManager     string
+}
+
+func NewController(
+	log logr.Logger,
+	ctx *controllerpkg.Context,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	certificateInformer := ctx.SharedInformerFactory.Certmanager().V1().Certificates()
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	secretsInformer := ctx.KubeSharedInformerFactory.Core().V1().Secrets()
+
+	certificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		// Trigger reconciles on changes to any 'owned' CertificateRequest resources
+		WorkFunc: certificates.EnqueueCertificatesForResourceUsingPredicates(log, queue, certificateInformer.Lister(), labels.Everything(),
+			predicate.ResourceOwnerOf,
+		),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		certificateRequestInformer.Informer().HasSynced,
+		certificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return &controller{
+		certificateLister:        certificateInformer.Lister(),
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		secretLister:             secretsInformer.Lister(),
+		helper:                   issuerpkg.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		client:                   ctx.CMClient,
+		recorder:                 ctx.Recorder,
+		clock:                    ctx.Clock,
+		clientBuilder:            venaficlient.New,
+		metrics:                  ctx.Metrics,
+		issuerOptions:            ctx.IssuerOptions,
+		retireSuperseded:         ctx.VenafiOptions.RetireSupersededCertificates,
+		skipZones:                sets.NewString(ctx.VenafiOptions.RetirementSkipZones...),
+		fieldManager:             ctx.FieldManager,
+	}, queue, mustSync
+}
+
+// ProcessItem ensures that Certificates using a Venafi issuer carry the
+// retirement finalizer, retires the certificates of superseded revisions if
+// enabled, and retires all certificates issued for a Certificate before
+// removing the finalizer once the Certificate is being deleted. If a
+// certificate cannot be retired because its issuer or credentials are gone,
+// or retirement keeps failing for longer than retirementTimeout, the
+// finalizer is removed anyway so that the deletion is not blocked forever.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.certificateLister.Certificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("certificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	log = logf.WithResource(log, crt)
+	ctx = logf.NewContext(ctx, log)
+
+	hasFinalizer := sets.NewString(crt.Finalizers...).Has(cmapi.VenafiRetirementFinalizer)
+
+	if crt.DeletionTimestamp != nil {
+		if !hasFinalizer {
+			return nil
+		}
+
+		requests, err := c.ownedRequests(crt)
+		if err != nil {
+			return err
+		}
+		for _, req := range requests {
+			err := c.retire(ctx, crt, req)
+			switch {
+			case err == nil:
+			case errors.Is(err, errRetirementUnavailable):
+				c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRetirementSkipped,
+					"Not retiring certificate of CertificateRequest %q in Venafi: %v", req.Name, err)
+			case c.clock.Since(crt.DeletionTimestamp.Time) > retirementTimeout:
+				c.recorder.Eventf(crt, corev1.EventTypeWarning, reasonRetirementFailed,
+					"Giving up retiring certificate of CertificateRequest %q in Venafi, %s after the Certificate was deleted: %v", req.Name, retirementTimeout, err)
+			default:
+				return err
+			}
+		}
+
+		return c.setFinalizer(ctx, crt, false)
+	}
+
+	issuerObj, ok, err := c.venafiIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if !ok {
+		if hasFinalizer {
+			log.V(logf.DebugLevel).Info("removing retirement finalizer as the certificate no longer uses a Venafi issuer with retirement enabled")
+			return c.setFinalizer(ctx, crt, false)
+		}
+		return nil
+	}
+
+	if !hasFinalizer {
+		log.V(logf.DebugLevel).Info("adding retirement finalizer", "issuer", issuerObj.GetName())
+		return c.setFinalizer(ctx, crt, true)
+	}
+
+	if !c.retireSuperseded || crt.Status.Revision == nil {
+		return nil
+	}
+
+	requests, err := c.ownedRequests(crt)
+	if err != nil {
+		return err
+	}
+	for _, req := range requests {
+		revision, err := strconv.Atoi(req.Annotations[cmapi.CertificateRequestRevisionAnnotationKey])
+		if err != nil || revision >= *crt.Status.Revision {
+			continue
+		}
+		err = c.retire(ctx, crt, req)
+		if errors.Is(err, errRetirementUnavailable) {
+			log.V(logf.DebugLevel).Info("skipping retirement of superseded certificate", "request", req.Name, "reason", err.Error())
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retire retires the certificate of the given CertificateRequest in Venafi,
+// and records that it has been retired on the CertificateRequest. Requests
+// without a certificate, or that were not issued by a Venafi issuer with
+// retirement enabled, are skipped. errRetirementUnavailable is returned if the
+// issuer or its credentials no longer exist.
+func (c *controller) retire(ctx context.Context, crt *cmapi.Certificate, req *cmapi.CertificateRequest) error {
+	if len(req.Status.Certificate) == 0 || req.Annotations[cmapi.VenafiRetiredAnnotationKey] == "true" {
+		return nil
+	}
+
+	log := logf.WithRelatedResource(logf.FromContext(ctx), req)
+
+	issuerObj, ok, err := c.venafiIssuer(req.Spec.IssuerRef, req.Namespace)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: issuer %q not found", errRetirementUnavailable, req.Spec.IssuerRef.Name)
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if issuerObj != nil {
+			log.V(logf.DebugLevel).Info("skipping retirement as the Venafi zone is excluded", "zone", issuerObj.GetSpec().Venafi.Zone)
+		}
+		return nil
+	}
+
+	client, err := c.clientBuilder(c.issuerOptions.ResourceNamespace(issuerObj), c.secretLister, issuerObj, c.metrics, log)
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: credentials of issuer %q not found: %v", errRetirementUnavailable, issuerObj.GetName(), err)
+	}
+	if err != nil {
+		message := fmt.Sprintf("Failed to initialise Venafi client to retire certificate of CertificateRequest %q: %v", req.Name, err)
+		c.recorder.Event(crt, corev1.EventTypeWarning, reasonRetirementFailed, message)
+		return err
+	}
+
+	err = client.RetireCertificate(req.Status.Certificate)
+	switch {
+	case errors.Is(err, venaficlient.ErrCertificateNotFound):
+		log.V(logf.DebugLevel).Info("certificate not found in Venafi inventory, assuming it has already been removed")
+	case err != nil:
+		message := fmt.Sprintf("Failed to retire certificate of CertificateRequest %q in Venafi: %v", req.Name, err)
+		c.recorder.Event(crt, corev1.EventTypeWarning, reasonRetirementFailed, message)
+		return err
+	default:
+		c.recorder.Eventf(crt, corev1.EventTypeNormal, reasonRetired, "Retired certificate of CertificateRequest %q in Venafi", req.Name)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		err = internalcertificaterequests.ApplyMetadata(ctx, c.client, c.fieldManager, &cmapi.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   req.Namespace,
+				Name:        req.Name,
+				Annotations: map[string]string{cmapi.VenafiRetiredAnnotationKey: "true"},
+			},
+		})
+	} else {
+		req = req.DeepCopy()
+		if req.Annotations == nil {
+			req.Annotations = make(map[string]string)
+		}
+		req.Annotations[cmapi.VenafiRetiredAnnotationKey] = "true"
+		_, err = c.client.CertmanagerV1().CertificateRequests(req.Namespace).Update(ctx, req, metav1.UpdateOptions{FieldManager: c.fieldManager})
+		conflicts.RecordEvent(c.recorder, req, c.fieldManager, err)
+	}
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// venafiIssuer returns the issuer referenced by ref, and whether it is a
+// Venafi issuer whose zone has not been excluded from retirement. A NotFound
+// error is returned if the issuer does not exist.
+func (c *controller) venafiIssuer(ref cmmeta.ObjectReference, namespace string) (cmapi.GenericIssuer, bool, error) {
+	if len(ref.Group) > 0 && ref.Group != certmanager.GroupName {
+		return nil, false, nil
+	}
+
+	issuerObj, err := c.helper.GetGenericIssuer(ref, namespace)
+	if err != nil {
+		return nil, false, err
+	}
+
+	venafi := issuerObj.GetSpec().Venafi
+	if venafi == nil {
+		return nil, false, nil
+	}
+
+	if c.skipZones.Has(venafi.Zone) {
+		return issuerObj, false, nil
+	}
+
+	return issuerObj, true, nil
+}
+
+func (c *controller) ownedRequests(crt *cmapi.Certificate) ([]*cmapi.CertificateRequest, error) {
+	return certificates.ListCertificateRequestsMatchingPredicates(
+		c.certificateRequestLister.CertificateRequests(crt.Namespace), labels.Everything(), predicate.ResourceOwnedBy(crt))
+}
+
+// setFinalizer adds or removes the retirement finalizer on the Certificate.
+// If the ServerSideApply feature is enabled, only the retirement finalizer is
+// applied, which is removed again once it is no longer applied by this
+// controller's field manager.
+func (c *controller) setFinalizer(ctx context.Context, crt *cmapi.Certificate, present bool) error {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		applyCrt := &cmapi.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+		}
+		if present {
+			applyCrt.Finalizers = []string{cmapi.VenafiRetirementFinalizer}
+		}
+		err := internalcertificates.ApplyMetadata(ctx, c.client, c.fieldManager, applyCrt)
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	crt = crt.DeepCopy()
+
+	var finalizers []string
+	for _, f := range crt.Finalizers {
+		if f != cmapi.VenafiRetirementFinalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	if present {
+		finalizers = append(finalizers, cmapi.VenafiRetirementFinalizer)
+	}
+	crt.Finalizers = finalizers
+
+	_, err := c.client.CertmanagerV1().Certificates(crt.Namespace).Update(ctx, crt, metav1.UpdateOptions{FieldManager: c.fieldManager})
+	conflicts.RecordEvent(c.recorder, crt, c.fieldManager, err)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log, ctx)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
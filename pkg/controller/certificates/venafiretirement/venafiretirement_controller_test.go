@@ -0,0 +1,302 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package venafiretirement
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	fakevenaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/fake"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	fixedClock := fakeclock.NewFakeClock(metav1.Now().Time)
+	deletionTime := metav1.NewTime(fixedClock.Now())
+	expiredDeletionTime := metav1.NewTime(fixedClock.Now().Add(-retirementTimeout - time.Minute))
+
+	venafiIssuer := gen.Issuer("venafi",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "prod"}),
+	)
+	skippedIssuer := gen.Issuer("venafi-skipped",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "lab"}),
+	)
+	caIssuer := gen.Issuer("ca",
+		gen.SetIssuerNamespace("testns"),
+		gen.SetIssuerCA(cmapi.CAIssuer{SecretName: "ca"}),
+	)
+
+	baseCrt := gen.Certificate("test-cert",
+		gen.SetCertificateNamespace("testns"),
+		gen.SetCertificateUID("uid-1"),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "venafi"}),
+	)
+	baseCR := gen.CertificateRequest("test-cert-1",
+		gen.SetCertificateRequestNamespace("testns"),
+		gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "venafi"}),
+		gen.SetCertificateRequestRevision("1"),
+		gen.SetCertificateRequestCertificate([]byte("cert-1")),
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(
+			baseCrt, cmapi.SchemeGroupVersion.WithKind("Certificate")),
+		),
+	)
+	retiredCR := gen.CertificateRequestFrom(baseCR,
+		gen.AddCertificateRequestAnnotations(map[string]string{cmapi.VenafiRetiredAnnotationKey: "true"}),
+	)
+
+	tests := map[string]struct {
+		certificate      *cmapi.Certificate
+		objects          []runtime.Object
+		retireSuperseded bool
+		retireErr        error
+		builderErr       error
+
+		expectedRetired []string
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+		expectedErr     bool
+	}{
+		"add the finalizer to a Certificate using a Venafi issuer": {
+			certificate: baseCrt,
+			objects:     []runtime.Object{venafiIssuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt, gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer)),
+				)),
+			},
+		},
+		"do nothing for a Certificate using a non-Venafi issuer": {
+			certificate: gen.CertificateFrom(baseCrt, gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "ca"})),
+			objects:     []runtime.Object{caIssuer},
+		},
+		"remove the finalizer from a Certificate using a Venafi issuer with a skipped zone": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "venafi-skipped"}),
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+			),
+			objects: []runtime.Object{skippedIssuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "venafi-skipped"})),
+				)),
+			},
+		},
+		"retire the certificates of a deleted Certificate and remove the finalizer": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+				gen.SetCertificateDeletionTimestamp(deletionTime),
+			),
+			objects:         []runtime.Object{venafiIssuer, baseCR},
+			expectedRetired: []string{"cert-1"},
+			expectedEvents:  []string{`Normal Retired Retired certificate of CertificateRequest "test-cert-1" in Venafi`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					retiredCR,
+				)),
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateDeletionTimestamp(deletionTime)),
+				)),
+			},
+		},
+		"do not retire already retired certificates of a deleted Certificate": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+				gen.SetCertificateDeletionTimestamp(deletionTime),
+			),
+			objects: []runtime.Object{venafiIssuer, retiredCR},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateDeletionTimestamp(deletionTime)),
+				)),
+			},
+		},
+		"keep the finalizer and fire an event if retirement fails": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+				gen.SetCertificateDeletionTimestamp(deletionTime),
+			),
+			objects:         []runtime.Object{venafiIssuer, baseCR},
+			retireErr:       errors.New("boom"),
+			expectedRetired: []string{"cert-1"},
+			expectedEvents:  []string{`Warning RetirementFailed Failed to retire certificate of CertificateRequest "test-cert-1" in Venafi: boom`},
+			expectedErr:     true,
+		},
+		"remove the finalizer and fire an event if the issuer of a deleted Certificate no longer exists": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+				gen.SetCertificateDeletionTimestamp(deletionTime),
+			),
+			objects:        []runtime.Object{baseCR},
+			expectedEvents: []string{`Warning RetirementSkipped Not retiring certificate of CertificateRequest "test-cert-1" in Venafi: retirement unavailable: issuer "venafi" not found`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateDeletionTimestamp(deletionTime)),
+				)),
+			},
+		},
+		"remove the finalizer and fire an event if the credentials of the issuer of a deleted Certificate no longer exist": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+				gen.SetCertificateDeletionTimestamp(deletionTime),
+			),
+			objects:    []runtime.Object{venafiIssuer, baseCR},
+			builderErr: apierrors.NewNotFound(corev1.Resource("secrets"), "venafi-credentials"),
+			expectedEvents: []string{`Warning RetirementSkipped Not retiring certificate of CertificateRequest "test-cert-1" in Venafi: ` +
+				`retirement unavailable: credentials of issuer "venafi" not found: secrets "venafi-credentials" not found`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateDeletionTimestamp(deletionTime)),
+				)),
+			},
+		},
+		"remove the finalizer and fire an event if retirement keeps failing after the retirement timeout": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+				gen.SetCertificateDeletionTimestamp(expiredDeletionTime),
+			),
+			objects:         []runtime.Object{venafiIssuer, baseCR},
+			retireErr:       errors.New("boom"),
+			expectedRetired: []string{"cert-1"},
+			expectedEvents: []string{
+				`Warning RetirementFailed Failed to retire certificate of CertificateRequest "test-cert-1" in Venafi: boom`,
+				`Warning RetirementFailed Giving up retiring certificate of CertificateRequest "test-cert-1" in Venafi, 1h0m0s after the Certificate was deleted: boom`,
+			},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateDeletionTimestamp(expiredDeletionTime)),
+				)),
+			},
+		},
+		"treat certificates missing from the Venafi inventory as retired": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+				gen.SetCertificateDeletionTimestamp(deletionTime),
+			),
+			objects:         []runtime.Object{venafiIssuer, baseCR},
+			retireErr:       venaficlient.ErrCertificateNotFound,
+			expectedRetired: []string{"cert-1"},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					retiredCR,
+				)),
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificates"), "testns",
+					gen.CertificateFrom(baseCrt, gen.SetCertificateDeletionTimestamp(deletionTime)),
+				)),
+			},
+		},
+		"retire the certificates of superseded revisions if enabled": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+				gen.SetCertificateRevision(2),
+			),
+			objects: []runtime.Object{venafiIssuer, baseCR,
+				gen.CertificateRequestFrom(baseCR,
+					gen.SetCertificateRequestName("test-cert-2"),
+					gen.SetCertificateRequestRevision("2"),
+					gen.SetCertificateRequestCertificate([]byte("cert-2")),
+				),
+			},
+			retireSuperseded: true,
+			expectedRetired:  []string{"cert-1"},
+			expectedEvents:   []string{`Normal Retired Retired certificate of CertificateRequest "test-cert-1" in Venafi`},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewUpdateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					retiredCR,
+				)),
+			},
+		},
+		"do not retire the certificates of superseded revisions if not enabled": {
+			certificate: gen.CertificateFrom(baseCrt,
+				gen.AddCertificateFinalizers(cmapi.VenafiRetirementFinalizer),
+				gen.SetCertificateRevision(2),
+			),
+			objects: []runtime.Object{venafiIssuer, baseCR},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: append([]runtime.Object{test.certificate}, test.objects...),
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			var retired []string
+			w.controller.retireSuperseded = test.retireSuperseded
+			w.controller.skipZones = sets.NewString("lab")
+			w.controller.clientBuilder = func(string, corelisters.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger) (venaficlient.Interface, error) {
+				if test.builderErr != nil {
+					return nil, test.builderErr
+				}
+				return &fakevenaficlient.Venafi{
+					RetireCertificateFn: func(certPEM []byte) error {
+						retired = append(retired, string(certPEM))
+						return test.retireErr
+					},
+				}, nil
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.certificate)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = w.controller.ProcessItem(context.Background(), key)
+			if (err != nil) != test.expectedErr {
+				t.Errorf("unexpected error, exp=%t got=%v", test.expectedErr, err)
+			}
+
+			if !sets.NewString(retired...).Equal(sets.NewString(test.expectedRetired...)) {
+				t.Errorf("unexpected retired certificates, exp=%v got=%v", test.expectedRetired, retired)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
@@ -1014,6 +1014,33 @@ func Test_buildOrder(t *testing.T) {
 		})
 	}
 
+	t.Run("Builds an order carrying the labels and annotations of the CSR", func(t *testing.T) {
+		csr := gen.CertificateSigningRequestFrom(csr,
+			gen.AddCertificateSigningRequestAnnotations(map[string]string{
+				"example.com/team": "nodes",
+			}),
+		)
+		csr.Labels = map[string]string{"example.com/pool": "workers"}
+
+		a := &ACME{copiedAnnotationPrefixes: []string{"*"}}
+		order, err := a.buildOrder(csr, req, gen.Issuer("test-name", gen.SetIssuerACME(cmacme.ACMEIssuer{})))
+		if err != nil {
+			t.Fatalf("buildOrder() received error %v", err)
+		}
+
+		if !reflect.DeepEqual(order.Labels, csr.Labels) {
+			t.Errorf("unexpected order labels, exp=%v got=%v", csr.Labels, order.Labels)
+		}
+
+		expAnnotations := map[string]string{
+			"example.com/team": "nodes",
+			"experimental.cert-manager.io/request-duration": "1h",
+		}
+		if !reflect.DeepEqual(order.Annotations, expAnnotations) {
+			t.Errorf("unexpected order annotations, exp=%v got=%v", expAnnotations, order.Annotations)
+		}
+	})
+
 	longCSROne := gen.CertificateSigningRequest(
 		"test-comparison-that-is-at-the-fifty-two-character-l",
 		gen.SetCertificateSigningRequestDuration("1h"),
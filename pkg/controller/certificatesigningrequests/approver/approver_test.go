@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/approval"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	// now time is the current time at the start of the test (the clock is fixed)
+	now := time.Now()
+	metaNow := metav1.NewTime(now)
+	fakeClock := fakeclock.NewFakeClock(now)
+	util.Clock = fakeClock
+
+	csrPEM, _, err := gen.CSR(x509.ECDSA, gen.SetCSRDNSNames("foo.example.com"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &approval.Config{Policies: []approval.Policy{{
+		Name:            "example",
+		SignerNames:     []string{"clusterissuers.cert-manager.io/*"},
+		AllowedDNSNames: []string{"*.example.com"},
+		AllowedUsages:   []cmapi.KeyUsage{cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageServerAuth},
+	}}}
+
+	baseCSR := gen.CertificateSigningRequest("test",
+		gen.SetCertificateSigningRequestRequest(csrPEM),
+		gen.SetCertificateSigningRequestSignerName("clusterissuers.cert-manager.io/ca"),
+		gen.SetCertificateSigningRequestUsages([]certificatesv1.KeyUsage{certificatesv1.UsageServerAuth}),
+	)
+
+	tests := map[string]struct {
+		// CertificateSigningRequest to be synced for the test.
+		csr *certificatesv1.CertificateSigningRequest
+
+		// expectedEvent, if set, is an 'event string' that is expected to be fired.
+		expectedEvent string
+
+		// expectedCondition, if set, is the condition expected to be added to
+		// the CertificateSigningRequest in an UpdateApproval call.
+		expectedCondition *certificatesv1.CertificateSigningRequestCondition
+	}{
+		"do nothing if CertificateSigningRequest is already approved": {
+			csr: gen.CertificateSigningRequestFrom(baseCSR,
+				gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+					Type:   certificatesv1.CertificateApproved,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+		},
+		"do nothing if CertificateSigningRequest is already denied": {
+			csr: gen.CertificateSigningRequestFrom(baseCSR,
+				gen.SetCertificateSigningRequestStatusCondition(certificatesv1.CertificateSigningRequestCondition{
+					Type:   certificatesv1.CertificateDenied,
+					Status: corev1.ConditionTrue,
+				}),
+			),
+		},
+		"do nothing if CertificateSigningRequest does not reference a cert-manager signer": {
+			csr: gen.CertificateSigningRequestFrom(baseCSR,
+				gen.SetCertificateSigningRequestSignerName("kubernetes.io/kube-apiserver-client"),
+			),
+		},
+		"do nothing if no approval policy applies to the signer name": {
+			csr: gen.CertificateSigningRequestFrom(baseCSR,
+				gen.SetCertificateSigningRequestSignerName("issuers.cert-manager.io/default.ca"),
+			),
+		},
+		"approve CertificateSigningRequest that satisfies the approval policy": {
+			csr: baseCSR,
+			expectedCondition: &certificatesv1.CertificateSigningRequestCondition{
+				Type:               certificatesv1.CertificateApproved,
+				Status:             corev1.ConditionTrue,
+				Reason:             ApprovedReason,
+				Message:            `Certificate signing request has been approved by policy "example"`,
+				LastTransitionTime: metaNow,
+				LastUpdateTime:     metaNow,
+			},
+			expectedEvent: `Normal cert-manager.io Certificate signing request has been approved by policy "example"`,
+		},
+		"deny CertificateSigningRequest that requests a disallowed usage and CA": {
+			csr: gen.CertificateSigningRequestFrom(baseCSR,
+				gen.SetCertificateSigningRequestUsages([]certificatesv1.KeyUsage{certificatesv1.UsageClientAuth}),
+				gen.SetCertificateSigningRequestIsCA(true),
+			),
+			expectedCondition: &certificatesv1.CertificateSigningRequestCondition{
+				Type:               certificatesv1.CertificateDenied,
+				Status:             corev1.ConditionTrue,
+				Reason:             DeniedReason,
+				Message:            `Certificate signing request has been denied by policy "example": usage "client auth" is not allowed, CA certificates are not allowed`,
+				LastTransitionTime: metaNow,
+				LastUpdateTime:     metaNow,
+			},
+			expectedEvent: `Warning PolicyViolation Certificate signing request has been denied by policy "example": usage "client auth" is not allowed, CA certificates are not allowed`,
+		},
+		"deny CertificateSigningRequest with a malformed duration annotation": {
+			csr: gen.CertificateSigningRequestFrom(baseCSR,
+				gen.SetCertificateSigningRequestDuration("not-a-duration"),
+			),
+			expectedCondition: &certificatesv1.CertificateSigningRequestCondition{
+				Type:               certificatesv1.CertificateDenied,
+				Status:             corev1.ConditionTrue,
+				Reason:             DeniedReason,
+				Message:            `Certificate signing request has been denied by policy "example": failed to decode request: failed to parse requested duration on annotation "experimental.cert-manager.io/request-duration": time: invalid duration "not-a-duration"`,
+				LastTransitionTime: metaNow,
+				LastUpdateTime:     metaNow,
+			},
+			expectedEvent: `Warning PolicyViolation Certificate signing request has been denied by policy "example": failed to decode request: failed to parse requested duration on annotation "experimental.cert-manager.io/request-duration": time: invalid duration "not-a-duration"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:           t,
+				Clock:       fakeClock,
+				KubeObjects: []runtime.Object{test.csr},
+			}
+			builder.Init()
+			builder.ApprovalPolicy = policy
+
+			c := new(Controller)
+			if _, _, err := c.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+
+			if test.expectedCondition != nil {
+				expectedCSR := test.csr.DeepCopy()
+				expectedCSR.Status.Conditions = append(expectedCSR.Status.Conditions, *test.expectedCondition)
+				builder.ExpectedActions = append(builder.ExpectedActions,
+					testpkg.NewAction(coretesting.NewUpdateSubresourceAction(
+						certificatesv1.SchemeGroupVersion.WithResource("certificatesigningrequests"),
+						"approval",
+						"",
+						expectedCSR,
+					)),
+				)
+			}
+			if test.expectedEvent != "" {
+				builder.ExpectedEvents = []string{test.expectedEvent}
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key, err := controllerpkg.KeyFunc(test.csr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := c.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package approver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/internal/controller/approval"
+	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+const (
+	// ApprovedReason is the reason used on the Approved condition set by this
+	// controller.
+	ApprovedReason = "cert-manager.io"
+
+	// DeniedReason is the reason used on the Denied condition set by this
+	// controller.
+	DeniedReason = "PolicyViolation"
+)
+
+// Sync will evaluate the CertificateSigningRequest against the approval policy
+// that applies to its signer name, and set either the "Approved" or "Denied"
+// condition. If no policy applies, or the request has already been approved,
+// denied or has failed, exit early.
+func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSigningRequest) error {
+	log := logf.FromContext(ctx, "approver").WithValues("signerName", csr.Spec.SignerName)
+	dbg := log.V(logf.DebugLevel)
+
+	switch {
+	case
+		util.CertificateSigningRequestIsApproved(csr),
+		util.CertificateSigningRequestIsDenied(csr),
+		util.CertificateSigningRequestIsFailed(csr),
+		len(csr.Status.Certificate) > 0:
+		return nil
+	}
+
+	ref, ok := util.SignerIssuerRefFromSignerName(csr.Spec.SignerName)
+	if !ok || ref.Group != certmanager.GroupName {
+		dbg.Info("certificate signing request does not reference a cert-manager signer so skipping processing")
+		return nil
+	}
+
+	policy := c.approvalPolicy.PolicyFor(csr.Spec.SignerName)
+	if policy == nil {
+		dbg.Info("no approval policy applies to signer name so skipping processing")
+		return nil
+	}
+
+	var violations []string
+	req, err := approval.RequestFromCertificateSigningRequest(csr)
+	if err != nil {
+		// A malformed request will never become valid, so deny it rather than
+		// retrying.
+		violations = []string{fmt.Sprintf("failed to decode request: %s", err)}
+	} else {
+		violations = policy.Evaluate(req)
+	}
+
+	csr = csr.DeepCopy()
+	nowTime := metav1.NewTime(util.Clock.Now())
+
+	if len(violations) > 0 {
+		message := fmt.Sprintf("Certificate signing request has been denied by policy %q: %s", policy.Name, strings.Join(violations, ", "))
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+			Type:               certificatesv1.CertificateDenied,
+			Status:             corev1.ConditionTrue,
+			Reason:             DeniedReason,
+			Message:            message,
+			LastTransitionTime: nowTime,
+			LastUpdateTime:     nowTime,
+		})
+		if _, err := c.certClient.UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		c.recorder.Event(csr, corev1.EventTypeWarning, DeniedReason, message)
+		dbg.Info("denied certificate signing request", "policy", policy.Name, "violations", violations)
+		return nil
+	}
+
+	message := fmt.Sprintf("Certificate signing request has been approved by policy %q", policy.Name)
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:               certificatesv1.CertificateApproved,
+		Status:             corev1.ConditionTrue,
+		Reason:             ApprovedReason,
+		Message:            message,
+		LastTransitionTime: nowTime,
+		LastUpdateTime:     nowTime,
+	})
+	if _, err := c.certClient.UpdateApproval(ctx, csr.Name, csr, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	c.recorder.Event(csr, corev1.EventTypeNormal, ApprovedReason, message)
+	dbg.Info("approved certificate signing request", "policy", policy.Name)
+
+	return nil
+}
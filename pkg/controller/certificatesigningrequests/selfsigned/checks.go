@@ -28,7 +28,7 @@ import (
 	"k8s.io/client-go/util/workqueue"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
-	cmexperimental "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
@@ -39,7 +39,7 @@ import (
 // handleSecretReferenceWorkFunc is a function that returns am informer event
 // handler work function, which is used to sync CertificateSigningRequests that
 // reference the synced Secret through the
-// "experimental.cert-manager.io/private-key-secret-name" annotation.
+// "cert-manager.io/private-key-secret-name" annotation.
 func handleSecretReferenceWorkFunc(log logr.Logger,
 	lister clientv1.CertificateSigningRequestLister,
 	helper issuer.Helper,
@@ -74,7 +74,7 @@ func handleSecretReferenceWorkFunc(log logr.Logger,
 // certificateSigningRequestsForSecret returns a list of
 // CertificateSigningRequests which reference an issuer in the same Namespace
 // as the Secret (the resource Namespace in the case of ClusterIssuer) via the
-// "experimental.cert-manager.io/private-key-secret-name" annotation, and the
+// "cert-manager.io/private-key-secret-name" annotation, and the
 // request targets a SelfSigned Issuer or Cluster Issuer.
 func certificateSigningRequestsForSecret(log logr.Logger,
 	lister clientv1.CertificateSigningRequestLister,
@@ -133,8 +133,8 @@ func certificateSigningRequestsForSecret(log logr.Logger,
 			continue
 		}
 
-		if issuerType == apiutil.IssuerSelfSigned &&
-			request.GetAnnotations()[cmexperimental.CertificateSigningRequestPrivateKeyAnnotationKey] == secret.Name {
+		secretName, _, _ := apiutil.CertificateSigningRequestAnnotation(request, cmapi.CertificateSigningRequestPrivateKeyAnnotationKey)
+		if issuerType == apiutil.IssuerSelfSigned && secretName == secret.Name {
 			dbg.Info("certificate request references secret, syncing")
 			affected = append(affected, request)
 		}
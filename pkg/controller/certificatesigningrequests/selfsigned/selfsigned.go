@@ -34,7 +34,6 @@ import (
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
@@ -117,15 +116,16 @@ func NewSelfSigned(ctx *controllerpkg.Context) certificatesigningrequests.Signer
 // resource if signing was successful. Returns an error which, if not nil,
 // should trigger a retry.
 // CertificateSigningRequests must have the
-// "experimental.cert-manager.io/private-key-secret-name" annotation present to
-// be signed. This annotation must reference a valid Secret containing a
+// "cert-manager.io/private-key-secret-name" annotation (or the deprecated
+// "experimental.cert-manager.io/private-key-secret-name" annotation) present
+// to be signed. This annotation must reference a valid Secret containing a
 // private key for signing.
 func (s *SelfSigned) Sign(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, issuerObj cmapi.GenericIssuer) error {
 	log := logf.FromContext(ctx, "sign")
 
-	secretName, ok := csr.GetAnnotations()[experimentalapi.CertificateSigningRequestPrivateKeyAnnotationKey]
+	secretName, _, ok := apiutil.CertificateSigningRequestAnnotation(csr, cmapi.CertificateSigningRequestPrivateKeyAnnotationKey)
 	if !ok || len(secretName) == 0 {
-		message := fmt.Sprintf("Missing private key reference annotation: %q", experimentalapi.CertificateSigningRequestPrivateKeyAnnotationKey)
+		message := fmt.Sprintf("Missing private key reference annotation: %q", cmapi.CertificateSigningRequestPrivateKeyAnnotationKey)
 		log.Error(errors.New(message), "")
 		s.recorder.Event(csr, corev1.EventTypeWarning, "MissingAnnotation", message)
 		util.CertificateSigningRequestSetFailed(csr, "MissingAnnotation", message)
@@ -158,7 +158,7 @@ func TestProcessItem(t *testing.T) {
 			builder: &testpkg.Builder{
 				CertManagerObjects: []runtime.Object{baseIssuer.DeepCopy()},
 				ExpectedEvents: []string{
-					`Warning MissingAnnotation Missing private key reference annotation: "experimental.cert-manager.io/private-key-secret-name"`,
+					`Warning MissingAnnotation Missing private key reference annotation: "cert-manager.io/private-key-secret-name"`,
 				},
 
 				ExpectedActions: []testpkg.Action{
@@ -198,7 +198,7 @@ func TestProcessItem(t *testing.T) {
 								Type:               certificatesv1.CertificateFailed,
 								Status:             corev1.ConditionTrue,
 								Reason:             "MissingAnnotation",
-								Message:            `Missing private key reference annotation: "experimental.cert-manager.io/private-key-secret-name"`,
+								Message:            `Missing private key reference annotation: "cert-manager.io/private-key-secret-name"`,
 								LastTransitionTime: metaFixedClockStart,
 								LastUpdateTime:     metaFixedClockStart,
 							}),
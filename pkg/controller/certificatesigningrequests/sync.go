@@ -29,7 +29,6 @@ import (
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	"github.com/cert-manager/cert-manager/pkg/apis/certmanager"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -139,8 +138,8 @@ func (c *Controller) Sync(ctx context.Context, csr *certificatesv1.CertificateSi
 	// Enforce minimum duration of certificate to be 600s to ensure
 	// compatibility with Certificate Signing Requests's
 	// spec.expirationSeconds
-	if duration < experimentalapi.CertificateSigningRequestMinimumDuration {
-		message := fmt.Sprintf("CertificateSigningRequest minimum allowed duration is %s, requested %s", experimentalapi.CertificateSigningRequestMinimumDuration, duration)
+	if duration < cmapi.CertificateSigningRequestMinimumDuration {
+		message := fmt.Sprintf("CertificateSigningRequest minimum allowed duration is %s, requested %s", cmapi.CertificateSigningRequestMinimumDuration, duration)
 		c.recorder.Event(csr, corev1.EventTypeWarning, "InvalidDuration", message)
 		util.CertificateSigningRequestSetFailed(csr, "InvalidDuration", message)
 		_, err := util.UpdateOrApplyStatus(ctx, c.certClient, csr, certificatesv1.CertificateFailed, c.fieldManager)
@@ -23,7 +23,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	certificatesapply "k8s.io/client-go/applyconfigurations/certificates/v1"
 	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
+	"k8s.io/client-go/tools/record"
 
+	"github.com/cert-manager/cert-manager/internal/controller/conflicts"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
@@ -62,3 +64,40 @@ func UpdateOrApplyStatus(ctx context.Context,
 		return cl.UpdateStatus(ctx, csr, metav1.UpdateOptions{})
 	}
 }
+
+// UpdateOrApplyAnnotations will update a CertificateSigningRequest, or Apply
+// if the ServerSideApply feature gate is enabled.
+// When the ServerSideApply feature is enabled, only the annotations with the
+// given keys are applied, so that the fieldManager does not take ownership of
+// any other annotations. Conflicts with other field managers are recorded as
+// Events on the CertificateSigningRequest.
+func UpdateOrApplyAnnotations(ctx context.Context,
+	cl certificatesclient.CertificateSigningRequestInterface,
+	rec record.EventRecorder,
+	csr *certificatesv1.CertificateSigningRequest,
+	fieldManager string,
+	keys ...string,
+) (*certificatesv1.CertificateSigningRequest, error) {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		annotations := make(map[string]string, len(keys))
+		for _, key := range keys {
+			if value, ok := csr.Annotations[key]; ok {
+				annotations[key] = value
+			}
+		}
+
+		var updated *certificatesv1.CertificateSigningRequest
+		err := conflicts.Apply(rec, csr, fieldManager, func(force bool) error {
+			var err error
+			updated, err = cl.Apply(ctx, certificatesapply.CertificateSigningRequest(csr.Name).WithAnnotations(annotations),
+				metav1.ApplyOptions{Force: force, FieldManager: fieldManager},
+			)
+			return err
+		})
+		return updated, err
+	} else {
+		updated, err := cl.Update(ctx, csr, metav1.UpdateOptions{FieldManager: fieldManager})
+		conflicts.RecordEvent(rec, csr, fieldManager, err)
+		return updated, err
+	}
+}
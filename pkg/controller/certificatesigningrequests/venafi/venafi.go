@@ -25,14 +25,12 @@ import (
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	certificatesclient "k8s.io/client-go/kubernetes/typed/certificates/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests"
 	"github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
@@ -115,10 +113,10 @@ func (v *Venafi) Sign(ctx context.Context, csr *certificatesv1.CertificateSignin
 	}
 
 	var customFields []venafiapi.CustomField
-	if annotation, exists := csr.GetAnnotations()[experimentalapi.CertificateSigningRequestVenafiCustomFieldsAnnotationKey]; exists && annotation != "" {
+	if annotation, annotationKey, exists := apiutil.CertificateSigningRequestAnnotation(csr, cmapi.VenafiCustomFieldsAnnotationKey); exists && annotation != "" {
 		err := json.Unmarshal([]byte(annotation), &customFields)
 		if err != nil {
-			message := fmt.Sprintf("Failed to parse %q annotation: %s", experimentalapi.CertificateSigningRequestVenafiCustomFieldsAnnotationKey, err)
+			message := fmt.Sprintf("Failed to parse %q annotation: %s", annotationKey, err)
 			v.recorder.Event(csr, corev1.EventTypeWarning, "ErrorCustomFields", message)
 			util.CertificateSigningRequestSetFailed(csr, "ErrorCustomFields", message)
 			_, userr := util.UpdateOrApplyStatus(ctx, v.certClient, csr, certificatesv1.CertificateFailed, v.fieldManager)
@@ -139,7 +137,7 @@ func (v *Venafi) Sign(ctx context.Context, csr *certificatesv1.CertificateSignin
 	// The signing process with Venafi is slow. The "pickupID" allows us to track
 	// the progress of the certificate signing. It is set as an annotation the
 	// first time the Certificate is reconciled.
-	pickupID := csr.GetAnnotations()[experimentalapi.CertificateSigningRequestVenafiPickupIDAnnotationKey]
+	pickupID, _, _ := apiutil.CertificateSigningRequestAnnotation(csr, cmapi.VenafiPickupIDAnnotationKey)
 
 	// check if the pickup ID annotation is there, if not set it up.
 	if len(pickupID) == 0 {
@@ -168,8 +166,8 @@ func (v *Venafi) Sign(ctx context.Context, csr *certificatesv1.CertificateSignin
 		if csr.Annotations == nil {
 			csr.Annotations = make(map[string]string)
 		}
-		csr.Annotations[experimentalapi.CertificateSigningRequestVenafiPickupIDAnnotationKey] = pickupID
-		_, uerr := v.certClient.Update(ctx, csr, metav1.UpdateOptions{})
+		csr.Annotations[cmapi.VenafiPickupIDAnnotationKey] = pickupID
+		_, uerr := util.UpdateOrApplyAnnotations(ctx, v.certClient, v.recorder, csr, v.fieldManager, cmapi.VenafiPickupIDAnnotationKey)
 		return uerr
 	}
 
@@ -26,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/cache"
 	"k8s.io/client-go/discovery"
 	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -41,6 +42,7 @@ import (
 	gwscheme "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/scheme"
 	gwinformers "sigs.k8s.io/gateway-api/pkg/client/informers/externalversions"
 
+	"github.com/cert-manager/cert-manager/internal/controller/approval"
 	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	clientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
@@ -136,6 +138,10 @@ type ContextOptions struct {
 	IngressShimOptions
 	CertificateOptions
 	SchedulerOptions
+	ApproverOptions
+	VenafiOptions
+	CTMonitorOptions
+	CanaryOptions
 }
 
 type IssuerOptions struct {
@@ -191,6 +197,12 @@ type ACMEOptions struct {
 
 	// DNS01CheckRetryPeriod is the time the controller should wait between checking if a ACME dns entry exists.
 	DNS01CheckRetryPeriod time.Duration
+
+	// VerifiedCloudflareTokens is used as a cache of the Cloudflare API
+	// tokens verified by ACME issuers, so that they are not verified on
+	// every resync. Entries expire so that tokens are verified again
+	// periodically. If nil, tokens are verified on every resync.
+	VerifiedCloudflareTokens *cache.LRUExpireCache
 }
 
 // IngressShimOptions contain default Issuer GVK config for the certificate-shim controllers.
@@ -210,6 +222,13 @@ type CertificateOptions struct {
 	// CopiedAnnotationPrefixes defines which annotations should be copied
 	// Certificate -> CertificateRequest, CertificateRequest -> Order.
 	CopiedAnnotationPrefixes []string
+
+	// IssuanceReceiptSigningKeySecretNamespace and
+	// IssuanceReceiptSigningKeySecretName identify the Secret holding the
+	// private key used to sign issuance receipts. If the name is empty,
+	// issuance receipts are not created.
+	IssuanceReceiptSigningKeySecretNamespace string
+	IssuanceReceiptSigningKeySecretName      string
 }
 
 type SchedulerOptions struct {
@@ -218,6 +237,51 @@ type SchedulerOptions struct {
 	MaxConcurrentChallenges int
 }
 
+type ApproverOptions struct {
+	// ApprovalPolicy is the set of policies that the approver controllers
+	// evaluate CertificateRequests and CertificateSigningRequests against.
+	// If nil, no policy is configured.
+	ApprovalPolicy *approval.Config
+}
+
+type VenafiOptions struct {
+	// RetireSupersededCertificates controls whether the certificate of a
+	// previous revision is retired in Venafi once a Certificate has been
+	// reissued.
+	RetireSupersededCertificates bool
+
+	// RetirementSkipZones is the list of Venafi zones for which certificates
+	// are never retired.
+	RetirementSkipZones []string
+}
+
+type CTMonitorOptions struct {
+	// LogURLs is the list of Certificate Transparency logs that are polled
+	// for certificates of monitored domains.
+	LogURLs []string
+
+	// Zones is a list of additional DNS zones to monitor, besides the
+	// dnsNames of Certificate resources.
+	Zones []string
+
+	// PollInterval is the interval at which each log is polled for new
+	// entries.
+	PollInterval time.Duration
+}
+
+type CanaryOptions struct {
+	// Interval is the interval at which a canary CertificateRequest is
+	// created for each selected issuer.
+	Interval time.Duration
+
+	// Timeout is the time after which a canary CertificateRequest that has
+	// not been issued is counted as failed and deleted.
+	Timeout time.Duration
+
+	// CertificateDuration is the duration requested for canary certificates.
+	CertificateDuration time.Duration
+}
+
 // ContextFactory is used for constructing new Contexts who's clients have been
 // configured with a User Agent built from the component name.
 type ContextFactory struct {
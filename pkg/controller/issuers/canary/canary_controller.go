@@ -0,0 +1,516 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	ControllerName = "issuers-canary"
+
+	// MinimumACMEInterval is the minimum interval between the canaries of an
+	// ACME issuer. Every canary of an ACME issuer is a new order for the same
+	// identifiers, so more frequent canaries would exhaust the duplicate
+	// certificate rate limit of ACME servers such as Let's Encrypt (5
+	// certificates per week) and compete with the renewals of Certificates.
+	MinimumACMEInterval = 72 * time.Hour
+
+	reasonCanaryFailed = "CanaryFailed"
+	reasonBadConfig    = "BadConfig"
+
+	resultSuccess = "success"
+	resultFailed  = "failed"
+	resultDenied  = "denied"
+	resultTimeout = "timeout"
+)
+
+// controller periodically creates a short-lived CertificateRequest for each
+// Issuer and ClusterIssuer annotated with a canary common name or canary DNS
+// names, and records whether and how quickly it was issued. Canary
+// CertificateRequests are deleted once they have completed or timed out.
+//
+// The queue is keyed by the issuer key as returned by controllerpkg.KeyFunc,
+// so keys without a namespace refer to ClusterIssuers. The time of the last
+// canary of each issuer is recorded in an annotation on the issuer, so that
+// the canary interval is kept across restarts and leader elections, and is
+// also held in memory until the informer observes the annotation.
+type controller struct {
+	issuerLister             cmlisters.IssuerLister
+	clusterIssuerLister      cmlisters.ClusterIssuerLister
+	certificateRequestLister cmlisters.CertificateRequestLister
+	client                   cmclient.Interface
+	recorder                 record.EventRecorder
+	metrics                  *metrics.Metrics
+	queue                    workqueue.RateLimitingInterface
+	clock                    clock.Clock
+
+	clusterResourceNamespace string
+	fieldManager             string
+	options                  controllerpkg.CanaryOptions
+
+	lock        sync.Mutex
+	lastCreated map[string]time.Time
+}
+
+func NewController(
+	log logr.Logger,
+	ctx *controllerpkg.Context,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*5, time.Minute*5), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	issuerInformer := ctx.SharedInformerFactory.Certmanager().V1().Issuers()
+	certificateRequestInformer := ctx.SharedInformerFactory.Certmanager().V1().CertificateRequests()
+
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a canary CertificateRequest changes, enqueue the issuer it was
+	// created for.
+	certificateRequestInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueIssuerForRequest(log, queue),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		issuerInformer.Informer().HasSynced,
+		certificateRequestInformer.Informer().HasSynced,
+	}
+
+	// ClusterIssuers can only be read if cert-manager is not scoped to a
+	// single namespace.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if ctx.Namespace == "" {
+		clusterIssuerInformer := ctx.SharedInformerFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	if ctx.CanaryOptions.Interval < MinimumACMEInterval {
+		log.Info("canary interval is shorter than the minimum interval for ACME issuers, the canaries of ACME issuers will be created less often",
+			"interval", ctx.CanaryOptions.Interval, "acme_interval", MinimumACMEInterval)
+	}
+
+	return &controller{
+		issuerLister:             issuerInformer.Lister(),
+		clusterIssuerLister:      clusterIssuerLister,
+		certificateRequestLister: certificateRequestInformer.Lister(),
+		client:                   ctx.CMClient,
+		recorder:                 ctx.Recorder,
+		metrics:                  ctx.Metrics,
+		queue:                    queue,
+		clock:                    ctx.Clock,
+		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
+		fieldManager:             ctx.FieldManager,
+		options:                  ctx.CanaryOptions,
+		lastCreated:              make(map[string]time.Time),
+	}, queue, mustSync
+}
+
+// enqueueIssuerForRequest returns a function which enqueues the issuer that
+// a canary CertificateRequest was created for.
+func enqueueIssuerForRequest(log logr.Logger, queue workqueue.Interface) func(obj interface{}) {
+	return func(obj interface{}) {
+		req, ok := obj.(*cmapi.CertificateRequest)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-CertificateRequest type resource passed to enqueueIssuerForRequest")
+			return
+		}
+		if req.Labels[cmapi.CanaryLabelKey] != "true" {
+			return
+		}
+		if req.Spec.IssuerRef.Kind == cmapi.ClusterIssuerKind {
+			queue.Add(req.Spec.IssuerRef.Name)
+			return
+		}
+		queue.Add(req.Namespace + "/" + req.Spec.IssuerRef.Name)
+	}
+}
+
+// ProcessItem completes the in-flight canary CertificateRequests of the
+// issuer with the given key, and creates a new one once the canary interval
+// has passed since the last one was created.
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("issuer", key)
+	ctx = logf.NewContext(ctx, log)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	kind, requestNamespace := cmapi.IssuerKind, namespace
+	var issuer cmapi.GenericIssuer
+	if namespace == "" {
+		if c.clusterIssuerLister == nil {
+			return nil
+		}
+		kind, requestNamespace = cmapi.ClusterIssuerKind, c.clusterResourceNamespace
+		issuer, err = c.clusterIssuerLister.Get(name)
+	} else {
+		issuer, err = c.issuerLister.Issuers(namespace).Get(name)
+	}
+	if apierrors.IsNotFound(err) {
+		issuer = nil
+	} else if err != nil {
+		return err
+	}
+
+	reqs, err := c.canaryRequests(requestNamespace, name, kind)
+	if err != nil {
+		return err
+	}
+
+	if issuer == nil || !isSelected(issuer) {
+		// The issuer was deleted or is no longer selected, so clean up any
+		// canary which is still in flight and forget about it.
+		for _, req := range reqs {
+			if err := c.deleteRequest(ctx, req); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+		c.lock.Lock()
+		_, known := c.lastCreated[key]
+		delete(c.lastCreated, key)
+		c.lock.Unlock()
+		if known {
+			c.metrics.RemoveCanary(name, namespace, kind)
+		}
+		return nil
+	}
+
+	now := c.clock.Now()
+	var requeueAfter time.Duration
+	for _, req := range reqs {
+		remaining, err := c.checkRequest(ctx, issuer, kind, req, now)
+		if err != nil {
+			return err
+		}
+		if remaining > 0 && (requeueAfter == 0 || remaining < requeueAfter) {
+			requeueAfter = remaining
+		}
+	}
+	if requeueAfter > 0 {
+		// A canary is still in flight, check it again once it times out.
+		c.queue.AddAfter(key, requeueAfter)
+		return nil
+	}
+
+	lastCreated, ok := c.getLastCreated(key, issuer)
+	if next := lastCreated.Add(canaryInterval(issuer, c.options.Interval)); ok && now.Before(next) {
+		c.queue.AddAfter(key, next.Sub(now))
+		return nil
+	}
+
+	// Record the attempt before creating the request, so that a new canary
+	// is not created before the informer has observed this one, nor after
+	// a restart before the interval has passed.
+	if err := c.recordLastCreated(ctx, issuer, kind, now); err != nil {
+		return err
+	}
+	c.setLastCreated(key, now)
+	c.queue.AddAfter(key, c.options.Timeout)
+	return c.createRequest(ctx, issuer, kind, requestNamespace)
+}
+
+// getLastCreated returns the time the last canary of the issuer with the
+// given key was created, which is the later of the time held in memory and
+// the time recorded in the annotation of the issuer.
+func (c *controller) getLastCreated(key string, issuer cmapi.GenericIssuer) (time.Time, bool) {
+	c.lock.Lock()
+	lastCreated, ok := c.lastCreated[key]
+	c.lock.Unlock()
+
+	if value, found := issuer.GetAnnotations()[cmapi.CanaryLastCreatedAnnotationKey]; found {
+		recorded, err := time.Parse(time.RFC3339, value)
+		if err == nil && (!ok || recorded.After(lastCreated)) {
+			lastCreated, ok = recorded, true
+		}
+	}
+	return lastCreated, ok
+}
+
+// recordLastCreated records the time the last canary of the issuer was
+// created in an annotation on the issuer.
+func (c *controller) recordLastCreated(ctx context.Context, issuer cmapi.GenericIssuer, kind string, t time.Time) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]string{
+				cmapi.CanaryLastCreatedAnnotationKey: t.UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	opts := metav1.PatchOptions{FieldManager: c.fieldManager}
+	if kind == cmapi.ClusterIssuerKind {
+		_, err = c.client.CertmanagerV1().ClusterIssuers().Patch(ctx, issuer.GetName(), types.MergePatchType, patch, opts)
+	} else {
+		_, err = c.client.CertmanagerV1().Issuers(issuer.GetNamespace()).Patch(ctx, issuer.GetName(), types.MergePatchType, patch, opts)
+	}
+	return err
+}
+
+// checkRequest records the result of the given canary CertificateRequest and
+// deletes it if it has completed or timed out. Otherwise, the time remaining
+// until it times out is returned.
+func (c *controller) checkRequest(ctx context.Context, issuer cmapi.GenericIssuer, kind string, req *cmapi.CertificateRequest, now time.Time) (time.Duration, error) {
+	log := logf.WithRelatedResource(logf.FromContext(ctx), req)
+
+	created := req.CreationTimestamp.Time
+	ready := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady)
+
+	var result, message string
+	switch {
+	case apiutil.CertificateRequestIsDenied(req):
+		result = resultDenied
+		message = fmt.Sprintf("Canary CertificateRequest %q was denied", req.Name)
+	case ready != nil && ready.Status == cmmeta.ConditionTrue:
+		result = resultSuccess
+	case ready != nil && ready.Reason == cmapi.CertificateRequestReasonFailed,
+		apiutil.CertificateRequestHasInvalidRequest(req):
+		result = resultFailed
+		message = fmt.Sprintf("Canary CertificateRequest %q failed", req.Name)
+		if ready != nil && ready.Message != "" {
+			message = fmt.Sprintf("%s: %s", message, ready.Message)
+		}
+	case !now.Before(created.Add(c.options.Timeout)):
+		result = resultTimeout
+		message = fmt.Sprintf("Canary CertificateRequest %q was not issued within %s", req.Name, c.options.Timeout)
+	default:
+		return created.Add(c.options.Timeout).Sub(now), nil
+	}
+
+	// Results are only recorded once the request has been deleted, so that
+	// a request still present in the lister is not counted twice.
+	if err := c.deleteRequest(ctx, req); apierrors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	if result == resultSuccess {
+		issuedAfter := now.Sub(created)
+		if ready.LastTransitionTime != nil {
+			issuedAfter = ready.LastTransitionTime.Sub(created)
+		}
+		log.V(logf.DebugLevel).Info("canary certificate issued", "duration", issuedAfter)
+		c.metrics.ObserveCanarySuccess(issuer.GetName(), issuer.GetNamespace(), kind, issuedAfter, now)
+		return 0, nil
+	}
+
+	log.Info("canary certificate was not issued", "result", result)
+	c.metrics.IncrementCanaryFailure(issuer.GetName(), issuer.GetNamespace(), kind, result)
+	c.recorder.Event(issuer, corev1.EventTypeWarning, reasonCanaryFailed, message)
+	return 0, nil
+}
+
+// createRequest creates a new canary CertificateRequest for the issuer with
+// a freshly generated private key, which is discarded.
+func (c *controller) createRequest(ctx context.Context, issuer cmapi.GenericIssuer, kind, namespace string) error {
+	log := logf.FromContext(ctx)
+
+	crt, err := canaryCertificate(issuer, kind, c.options.CertificateDuration)
+	if err != nil {
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonBadConfig, "Invalid canary configuration: %v", err)
+		return nil
+	}
+
+	pk, err := pki.GeneratePrivateKeyForCertificate(crt)
+	if err != nil {
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonBadConfig, "Invalid canary configuration: %v", err)
+		return nil
+	}
+	x509CSR, err := pki.GenerateCSR(crt)
+	if err != nil {
+		c.recorder.Eventf(issuer, corev1.EventTypeWarning, reasonBadConfig, "Invalid canary configuration: %v", err)
+		return nil
+	}
+	csrDER, err := pki.EncodeCSR(x509CSR, pk)
+	if err != nil {
+		return err
+	}
+	csrPEM := bytes.NewBuffer([]byte{})
+	if err := pem.Encode(csrPEM, &pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER}); err != nil {
+		return err
+	}
+
+	req := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    namespace,
+			GenerateName: apiutil.DNSSafeShortenTo52Characters("canary-"+issuer.GetName()) + "-",
+			Labels: map[string]string{
+				cmapi.CanaryLabelKey: "true",
+			},
+		},
+		Spec: cmapi.CertificateRequestSpec{
+			Duration:  crt.Spec.Duration,
+			IssuerRef: crt.Spec.IssuerRef,
+			Request:   csrPEM.Bytes(),
+		},
+	}
+
+	req, err = c.client.CertmanagerV1().CertificateRequests(namespace).Create(ctx, req, metav1.CreateOptions{FieldManager: c.fieldManager})
+	if err != nil {
+		return err
+	}
+
+	log.V(logf.DebugLevel).Info("created canary CertificateRequest", "name", req.Name)
+	return nil
+}
+
+func (c *controller) deleteRequest(ctx context.Context, req *cmapi.CertificateRequest) error {
+	return c.client.CertmanagerV1().CertificateRequests(req.Namespace).Delete(ctx, req.Name, metav1.DeleteOptions{})
+}
+
+// canaryRequests returns the canary CertificateRequests of the issuer with
+// the given name and kind.
+func (c *controller) canaryRequests(namespace, name, kind string) ([]*cmapi.CertificateRequest, error) {
+	reqs, err := c.certificateRequestLister.CertificateRequests(namespace).List(labels.SelectorFromSet(labels.Set{cmapi.CanaryLabelKey: "true"}))
+	if err != nil {
+		return nil, err
+	}
+	var matching []*cmapi.CertificateRequest
+	for _, req := range reqs {
+		ref := req.Spec.IssuerRef
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = cmapi.IssuerKind
+		}
+		if ref.Name == name && refKind == kind {
+			matching = append(matching, req)
+		}
+	}
+	return matching, nil
+}
+
+func (c *controller) setLastCreated(key string, t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.lastCreated[key] = t
+}
+
+// canaryInterval returns the interval between the canaries of the issuer,
+// which is at least MinimumACMEInterval for ACME issuers.
+func canaryInterval(issuer cmapi.GenericIssuer, interval time.Duration) time.Duration {
+	if issuer.GetSpec().ACME != nil && interval < MinimumACMEInterval {
+		return MinimumACMEInterval
+	}
+	return interval
+}
+
+// isSelected returns true if the issuer is annotated with a canary common
+// name or canary DNS names.
+func isSelected(issuer cmapi.GenericIssuer) bool {
+	annotations := issuer.GetAnnotations()
+	return len(annotations[cmapi.CanaryCommonNameAnnotationKey]) > 0 || len(annotations[cmapi.CanaryDNSNamesAnnotationKey]) > 0
+}
+
+// canaryCertificate builds the Certificate from which the CSR of a canary
+// CertificateRequest for the issuer is generated, using the canary
+// annotations of the issuer.
+func canaryCertificate(issuer cmapi.GenericIssuer, kind string, duration time.Duration) (*cmapi.Certificate, error) {
+	annotations := issuer.GetAnnotations()
+
+	var dnsNames []string
+	for _, name := range strings.Split(annotations[cmapi.CanaryDNSNamesAnnotationKey], ",") {
+		if name = strings.TrimSpace(name); len(name) > 0 {
+			dnsNames = append(dnsNames, name)
+		}
+	}
+
+	algorithm := cmapi.ECDSAKeyAlgorithm
+	if value, ok := annotations[cmapi.CanaryPrivateKeyAlgorithmAnnotationKey]; ok {
+		switch cmapi.PrivateKeyAlgorithm(value) {
+		case cmapi.RSAKeyAlgorithm, cmapi.ECDSAKeyAlgorithm, cmapi.Ed25519KeyAlgorithm:
+			algorithm = cmapi.PrivateKeyAlgorithm(value)
+		default:
+			return nil, fmt.Errorf("%s annotation has unsupported value %q", cmapi.CanaryPrivateKeyAlgorithmAnnotationKey, value)
+		}
+	}
+
+	return &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			CommonName: strings.TrimSpace(annotations[cmapi.CanaryCommonNameAnnotationKey]),
+			DNSNames:   dnsNames,
+			Duration:   &metav1.Duration{Duration: duration},
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: algorithm},
+			IssuerRef: cmmeta.ObjectReference{
+				Name:  issuer.GetName(),
+				Kind:  kind,
+				Group: cmapi.SchemeGroupVersion.Group,
+			},
+		},
+	}, nil
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log, ctx)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
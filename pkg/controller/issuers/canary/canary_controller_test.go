@@ -0,0 +1,276 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canary
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func TestProcessItem(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	fixedClock := fakeclock.NewFakeClock(now)
+
+	withAnnotations := func(annotations map[string]string) gen.IssuerModifier {
+		return func(iss cmapi.GenericIssuer) {
+			iss.SetAnnotations(annotations)
+		}
+	}
+	canaryAnnotations := withAnnotations(map[string]string{
+		cmapi.CanaryDNSNamesAnnotationKey: "canary.example.com, www.canary.example.com",
+	})
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerNamespace("testns"), canaryAnnotations)
+	clusterIssuer := gen.ClusterIssuer("test-cluster-issuer", canaryAnnotations)
+
+	issuerRef := cmmeta.ObjectReference{Name: "test-issuer", Kind: cmapi.IssuerKind, Group: "cert-manager.io"}
+	canaryRequest := func(created time.Time, mods ...gen.CertificateRequestModifier) *cmapi.CertificateRequest {
+		req := gen.CertificateRequest("canary-test-issuer-abcde",
+			append([]gen.CertificateRequestModifier{
+				gen.SetCertificateRequestNamespace("testns"),
+				gen.SetCertificateRequestIssuer(issuerRef),
+			}, mods...)...)
+		req.Labels = map[string]string{cmapi.CanaryLabelKey: "true"}
+		req.CreationTimestamp = metav1.NewTime(created)
+		return req
+	}
+	lastCreatedPatch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{"cert-manager.io/canary-last-created":%q}}}`, now.UTC().Format(time.RFC3339)))
+	deleteAction := testpkg.NewAction(coretesting.NewDeleteAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns", "canary-test-issuer-abcde"))
+
+	tests := map[string]struct {
+		key         string
+		cmObjects   []runtime.Object
+		lastCreated time.Time
+
+		expectedActions []testpkg.Action
+		expectedEvents  []string
+	}{
+		"do nothing for an issuer without canary annotations": {
+			key:       "testns/test-issuer",
+			cmObjects: []runtime.Object{gen.Issuer("test-issuer", gen.SetIssuerNamespace("testns"))},
+		},
+		"do nothing if the issuer does not exist": {
+			key: "testns/test-issuer",
+		},
+		"create a canary CertificateRequest for a selected Issuer": {
+			key:       "testns/test-issuer",
+			cmObjects: []runtime.Object{issuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewPatchAction(cmapi.SchemeGroupVersion.WithResource("issuers"), "testns", "test-issuer", types.MergePatchType, lastCreatedPatch)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "testns",
+					gen.CertificateRequest("",
+						gen.SetCertificateRequestNamespace("testns"),
+						gen.SetCertificateRequestGenerateName("canary-test-issuer-"),
+						gen.SetCertificateRequestIssuer(issuerRef),
+						gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+					)), canaryRequestMatcher("canary.example.com", "www.canary.example.com")),
+			},
+		},
+		"create a canary CertificateRequest for a selected ClusterIssuer in the cluster resource namespace": {
+			key:       "test-cluster-issuer",
+			cmObjects: []runtime.Object{clusterIssuer},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewRootPatchAction(cmapi.SchemeGroupVersion.WithResource("clusterissuers"), "test-cluster-issuer", types.MergePatchType, lastCreatedPatch)),
+				testpkg.NewCustomMatch(coretesting.NewCreateAction(cmapi.SchemeGroupVersion.WithResource("certificaterequests"), "cert-manager",
+					gen.CertificateRequest("",
+						gen.SetCertificateRequestNamespace("cert-manager"),
+						gen.SetCertificateRequestGenerateName("canary-test-cluster-issuer-"),
+						gen.SetCertificateRequestIssuer(cmmeta.ObjectReference{Name: "test-cluster-issuer", Kind: cmapi.ClusterIssuerKind, Group: "cert-manager.io"}),
+						gen.SetCertificateRequestDuration(&metav1.Duration{Duration: time.Hour}),
+					)), canaryRequestMatcher("canary.example.com", "www.canary.example.com")),
+			},
+		},
+		"do not create a canary CertificateRequest before the interval has passed": {
+			key:         "testns/test-issuer",
+			cmObjects:   []runtime.Object{issuer},
+			lastCreated: now.Add(-30 * time.Minute),
+		},
+		"do not create a canary CertificateRequest for an ACME issuer before the minimum ACME interval has passed": {
+			key:         "testns/test-issuer",
+			cmObjects:   []runtime.Object{gen.IssuerFrom(issuer, gen.SetIssuerACMEURL("https://acme.example.com"))},
+			lastCreated: now.Add(-2 * time.Hour),
+		},
+		"do not create a canary CertificateRequest before the interval recorded on the issuer has passed": {
+			key: "testns/test-issuer",
+			cmObjects: []runtime.Object{gen.IssuerFrom(issuer, withAnnotations(map[string]string{
+				cmapi.CanaryDNSNamesAnnotationKey:    "canary.example.com",
+				cmapi.CanaryLastCreatedAnnotationKey: now.Add(-30 * time.Minute).UTC().Format(time.RFC3339),
+			}))},
+		},
+		"fire an event for an invalid canary private key algorithm": {
+			key: "testns/test-issuer",
+			cmObjects: []runtime.Object{gen.IssuerFrom(issuer, withAnnotations(map[string]string{
+				cmapi.CanaryCommonNameAnnotationKey:          "canary.example.com",
+				cmapi.CanaryPrivateKeyAlgorithmAnnotationKey: "DSA",
+			}))},
+			expectedActions: []testpkg.Action{
+				testpkg.NewAction(coretesting.NewPatchAction(cmapi.SchemeGroupVersion.WithResource("issuers"), "testns", "test-issuer", types.MergePatchType, lastCreatedPatch)),
+			},
+			expectedEvents: []string{
+				`Warning BadConfig Invalid canary configuration: cert-manager.io/canary-private-key-algorithm annotation has unsupported value "DSA"`,
+			},
+		},
+		"do nothing while a canary CertificateRequest is in flight": {
+			key:       "testns/test-issuer",
+			cmObjects: []runtime.Object{issuer, canaryRequest(now.Add(-time.Minute))},
+		},
+		"delete an issued canary CertificateRequest": {
+			key: "testns/test-issuer",
+			cmObjects: []runtime.Object{issuer, canaryRequest(now.Add(-time.Minute),
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:               cmapi.CertificateRequestConditionReady,
+					Status:             cmmeta.ConditionTrue,
+					Reason:             cmapi.CertificateRequestReasonIssued,
+					LastTransitionTime: &metav1.Time{Time: now.Add(-30 * time.Second)},
+				}),
+			)},
+			lastCreated:     now.Add(-time.Minute),
+			expectedActions: []testpkg.Action{deleteAction},
+		},
+		"delete a failed canary CertificateRequest and fire an event": {
+			key: "testns/test-issuer",
+			cmObjects: []runtime.Object{issuer, canaryRequest(now.Add(-time.Minute),
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:    cmapi.CertificateRequestConditionReady,
+					Status:  cmmeta.ConditionFalse,
+					Reason:  cmapi.CertificateRequestReasonFailed,
+					Message: "rate limited",
+				}),
+			)},
+			lastCreated:     now.Add(-time.Minute),
+			expectedActions: []testpkg.Action{deleteAction},
+			expectedEvents: []string{
+				`Warning CanaryFailed Canary CertificateRequest "canary-test-issuer-abcde" failed: rate limited`,
+			},
+		},
+		"delete a denied canary CertificateRequest and fire an event": {
+			key: "testns/test-issuer",
+			cmObjects: []runtime.Object{issuer, canaryRequest(now.Add(-time.Minute),
+				gen.SetCertificateRequestStatusCondition(cmapi.CertificateRequestCondition{
+					Type:   cmapi.CertificateRequestConditionDenied,
+					Status: cmmeta.ConditionTrue,
+				}),
+			)},
+			lastCreated:     now.Add(-time.Minute),
+			expectedActions: []testpkg.Action{deleteAction},
+			expectedEvents: []string{
+				`Warning CanaryFailed Canary CertificateRequest "canary-test-issuer-abcde" was denied`,
+			},
+		},
+		"delete a timed out canary CertificateRequest and fire an event": {
+			key:             "testns/test-issuer",
+			cmObjects:       []runtime.Object{issuer, canaryRequest(now.Add(-10 * time.Minute))},
+			lastCreated:     now.Add(-10 * time.Minute),
+			expectedActions: []testpkg.Action{deleteAction},
+			expectedEvents: []string{
+				`Warning CanaryFailed Canary CertificateRequest "canary-test-issuer-abcde" was not issued within 10m0s`,
+			},
+		},
+		"delete the canary CertificateRequest of an issuer which is no longer selected": {
+			key: "testns/test-issuer",
+			cmObjects: []runtime.Object{
+				gen.Issuer("test-issuer", gen.SetIssuerNamespace("testns")),
+				canaryRequest(now.Add(-time.Minute)),
+			},
+			expectedActions: []testpkg.Action{deleteAction},
+		},
+		"ignore CertificateRequests which are not canaries": {
+			key:       "testns/test-issuer",
+			cmObjects: []runtime.Object{gen.Issuer("test-issuer", gen.SetIssuerNamespace("testns")), gen.CertificateRequest("test-cr", gen.SetCertificateRequestNamespace("testns"), gen.SetCertificateRequestIssuer(issuerRef))},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				CertManagerObjects: test.cmObjects,
+				ExpectedActions:    test.expectedActions,
+				ExpectedEvents:     test.expectedEvents,
+			}
+			builder.Init()
+			builder.Context.IssuerOptions.ClusterResourceNamespace = "cert-manager"
+			builder.Context.CanaryOptions = controllerpkg.CanaryOptions{
+				Interval:            time.Hour,
+				Timeout:             10 * time.Minute,
+				CertificateDuration: time.Hour,
+			}
+
+			w := &controllerWrapper{}
+			if _, _, err := w.Register(builder.Context); err != nil {
+				t.Fatal(err)
+			}
+			if !test.lastCreated.IsZero() {
+				w.controller.lastCreated[test.key] = test.lastCreated
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			if err := w.controller.ProcessItem(context.Background(), test.key); err != nil {
+				t.Fatal(err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
+
+// canaryRequestMatcher matches the created canary CertificateRequest, and
+// checks that its CSR requests the given DNS names.
+func canaryRequestMatcher(dnsNames ...string) testpkg.ActionMatchFn {
+	return func(exp, act coretesting.Action) error {
+		expReq := exp.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest)
+		actReq := act.(coretesting.CreateAction).GetObject().(*cmapi.CertificateRequest)
+
+		if actReq.Labels[cmapi.CanaryLabelKey] != "true" {
+			return fmt.Errorf("expected canary label, got labels %v", actReq.Labels)
+		}
+		csr, err := pki.DecodeX509CertificateRequestBytes(actReq.Spec.Request)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(csr.DNSNames, dnsNames) {
+			return fmt.Errorf("unexpected CSR DNS names, exp=%v got=%v", dnsNames, csr.DNSNames)
+		}
+
+		actReq = actReq.DeepCopy()
+		actReq.Labels = nil
+		actReq.Spec.Request = nil
+		if !reflect.DeepEqual(expReq, actReq) {
+			return fmt.Errorf("unexpected difference between CertificateRequests, exp=%#v got=%#v", expReq, actReq)
+		}
+		return nil
+	}
+}
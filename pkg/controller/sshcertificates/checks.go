@@ -0,0 +1,270 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshcertificates
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"fmt"
+	"reflect"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// backdate is how long before the time of signing certificates are valid
+// from, to allow for clock skew between the signer and SSH servers.
+const backdate = time.Minute
+
+// defaultUserExtensions are the extensions `ssh-keygen` adds to user
+// certificates by default.
+var defaultUserExtensions = map[string]string{
+	"permit-X11-forwarding":   "",
+	"permit-agent-forwarding": "",
+	"permit-port-forwarding":  "",
+	"permit-pty":              "",
+	"permit-user-rc":          "",
+}
+
+// shouldReissue returns the certificate stored in the given Secret, and
+// whether a new certificate must be issued for the SSHCertificate along with
+// the reason and message why. The Secret may be nil if it does not exist.
+func shouldReissue(crt *cmapi.SSHCertificate, secret *corev1.Secret, caPublicKey ssh.PublicKey, now time.Time) (*ssh.Certificate, string, string, bool) {
+	if secret == nil {
+		return nil, policies.DoesNotExist, "Issuing certificate as Secret does not exist", true
+	}
+
+	certData := secret.Data[cmapi.SSHCertificateSecretKey]
+	if len(certData) == 0 {
+		return nil, policies.MissingData, "Issuing certificate as Secret does not contain a certificate", true
+	}
+	pub, _, _, _, err := ssh.ParseAuthorizedKey(certData)
+	if err != nil {
+		return nil, policies.InvalidCertificate, fmt.Sprintf("Issuing certificate as Secret contains an invalid certificate: %v", err), true
+	}
+	cert, ok := pub.(*ssh.Certificate)
+	if !ok {
+		return nil, policies.InvalidCertificate, "Issuing certificate as Secret contains a public key rather than a certificate", true
+	}
+
+	if crt.Spec.PublicKey != "" {
+		publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(crt.Spec.PublicKey))
+		if err != nil {
+			return cert, policies.SecretMismatch, fmt.Sprintf("Issuing certificate as spec.publicKey is invalid: %v", err), true
+		}
+		if !publicKeysEqual(cert.Key, publicKey) {
+			return cert, policies.SecretMismatch, "Issuing certificate as the certified public key does not match spec.publicKey", true
+		}
+	} else {
+		signer, err := existingPrivateKey(crt, secret)
+		if err != nil {
+			return cert, policies.SecretMismatch, fmt.Sprintf("Issuing certificate as %v", err), true
+		}
+		publicKey, err := ssh.NewPublicKey(signer.Public())
+		if err != nil {
+			return cert, policies.InvalidKeyPair, fmt.Sprintf("Issuing certificate as the private key is not supported: %v", err), true
+		}
+		if !publicKeysEqual(cert.Key, publicKey) {
+			return cert, policies.InvalidKeyPair, "Issuing certificate as the certified public key does not match the private key in the Secret", true
+		}
+	}
+
+	if !publicKeysEqual(cert.SignatureKey, caPublicKey) {
+		return cert, policies.IncorrectIssuer, "Issuing certificate as it was not signed by the CA of the referenced issuer", true
+	}
+
+	if violations := certificateMatchesSpec(cert, crt); len(violations) > 0 {
+		return cert, policies.SecretMismatch, fmt.Sprintf("Issuing certificate as the existing certificate does not match the spec: %v", violations), true
+	}
+
+	notAfter := time.Unix(int64(cert.ValidBefore), 0)
+	if !now.Before(notAfter) {
+		return cert, policies.Expired, fmt.Sprintf("Certificate expired on %s", notAfter.Format(time.RFC1123)), true
+	}
+	renewalTime := renewalTimeForCertificate(crt, cert)
+	if !now.Before(renewalTime.Time) {
+		return cert, policies.Renewing, fmt.Sprintf("Renewing certificate as renewal was scheduled at %s", renewalTime), true
+	}
+
+	return cert, "", "", false
+}
+
+// certificateMatchesSpec returns the fields of the SSHCertificate spec which
+// the given certificate does not match.
+func certificateMatchesSpec(cert *ssh.Certificate, crt *cmapi.SSHCertificate) []string {
+	var violations []string
+	if cert.CertType != certificateType(crt) {
+		violations = append(violations, "spec.type")
+	}
+	if cert.KeyId != keyID(crt) {
+		violations = append(violations, "spec.keyID")
+	}
+	if !reflect.DeepEqual(cert.ValidPrincipals, crt.Spec.Principals) {
+		violations = append(violations, "spec.principals")
+	}
+	if !mapsEqual(cert.CriticalOptions, criticalOptions(crt)) {
+		violations = append(violations, "spec.criticalOptions")
+	}
+	if !mapsEqual(cert.Extensions, extensions(crt)) {
+		violations = append(violations, "spec.extensions")
+	}
+	// Validity times have a resolution of one second, so a duration with a
+	// fractional second is matched to within a second.
+	if diff := time.Duration(cert.ValidBefore-cert.ValidAfter)*time.Second - (duration(crt) + backdate); diff <= -time.Second || diff >= time.Second {
+		violations = append(violations, "spec.duration")
+	}
+	return violations
+}
+
+// existingPrivateKey returns the private key stored in the Secret, or an
+// error if it is missing, invalid or does not match `spec.privateKey`.
+func existingPrivateKey(crt *cmapi.SSHCertificate, secret *corev1.Secret) (crypto.Signer, error) {
+	keyData := secret.Data[cmapi.SSHPrivateKeySecretKey]
+	if len(keyData) == 0 {
+		return nil, fmt.Errorf("Secret does not contain a private key")
+	}
+	key, err := ssh.ParseRawPrivateKey(keyData)
+	if err != nil {
+		return nil, fmt.Errorf("Secret contains an invalid private key: %v", err)
+	}
+
+	var signer crypto.Signer
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		signer = k
+	case *ecdsa.PrivateKey:
+		signer = k
+	case *ed25519.PrivateKey:
+		signer = *k
+	default:
+		return nil, fmt.Errorf("Secret contains an unsupported private key of type %T", key)
+	}
+
+	algorithm, size := privateKeyAlgorithmAndSize(crt)
+	switch k := signer.(type) {
+	case *rsa.PrivateKey:
+		if algorithm != cmapi.RSAKeyAlgorithm || k.N.BitLen() != size {
+			return nil, fmt.Errorf("private key in the Secret does not match spec.privateKey")
+		}
+	case *ecdsa.PrivateKey:
+		if algorithm != cmapi.ECDSAKeyAlgorithm || k.Curve.Params().BitSize != size {
+			return nil, fmt.Errorf("private key in the Secret does not match spec.privateKey")
+		}
+	case ed25519.PrivateKey:
+		if algorithm != cmapi.Ed25519KeyAlgorithm {
+			return nil, fmt.Errorf("private key in the Secret does not match spec.privateKey")
+		}
+	}
+
+	return signer, nil
+}
+
+// privateKeyAlgorithmAndSize returns the algorithm and size of the private
+// key generated for the SSHCertificate, with defaults applied.
+func privateKeyAlgorithmAndSize(crt *cmapi.SSHCertificate) (cmapi.PrivateKeyAlgorithm, int) {
+	algorithm, size := cmapi.Ed25519KeyAlgorithm, 0
+	if pk := crt.Spec.PrivateKey; pk != nil {
+		if pk.Algorithm != "" {
+			algorithm = pk.Algorithm
+		}
+		size = pk.Size
+	}
+	switch algorithm {
+	case cmapi.RSAKeyAlgorithm:
+		if size == 0 {
+			size = pki.MinRSAKeySize
+		}
+	case cmapi.ECDSAKeyAlgorithm:
+		if size == 0 {
+			size = pki.ECCurve256
+		}
+	default:
+		size = 0
+	}
+	return algorithm, size
+}
+
+// renewalTimeForCertificate returns the time at which the given certificate
+// should be renewed.
+func renewalTimeForCertificate(crt *cmapi.SSHCertificate, cert *ssh.Certificate) *metav1.Time {
+	return pki.RenewalTime(time.Unix(int64(cert.ValidAfter), 0), time.Unix(int64(cert.ValidBefore), 0), crt.Spec.RenewBefore)
+}
+
+func certificateType(crt *cmapi.SSHCertificate) uint32 {
+	if crt.Spec.Type == cmapi.SSHHostCertificate {
+		return ssh.HostCert
+	}
+	return ssh.UserCert
+}
+
+func keyID(crt *cmapi.SSHCertificate) string {
+	if crt.Spec.KeyID != "" {
+		return crt.Spec.KeyID
+	}
+	return crt.Namespace + "/" + crt.Name
+}
+
+func duration(crt *cmapi.SSHCertificate) time.Duration {
+	if crt.Spec.Duration != nil {
+		return crt.Spec.Duration.Duration
+	}
+	return cmapi.DefaultSSHCertificateDuration
+}
+
+func criticalOptions(crt *cmapi.SSHCertificate) map[string]string {
+	if crt.Spec.Type == cmapi.SSHHostCertificate {
+		return nil
+	}
+	return crt.Spec.CriticalOptions
+}
+
+func extensions(crt *cmapi.SSHCertificate) map[string]string {
+	if crt.Spec.Type == cmapi.SSHHostCertificate {
+		return nil
+	}
+	if crt.Spec.Extensions == nil {
+		return defaultUserExtensions
+	}
+	return crt.Spec.Extensions
+}
+
+// mapsEqual returns true if the given maps contain the same entries, treating
+// nil and empty maps as equal.
+func mapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func publicKeysEqual(a, b ssh.PublicKey) bool {
+	return bytes.Equal(a.Marshal(), b.Marshal())
+}
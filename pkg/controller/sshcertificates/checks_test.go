@@ -0,0 +1,202 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshcertificates
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/cert-manager/cert-manager/internal/controller/certificates/policies"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func Test_shouldReissue(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	caSigner := mustNewSSHSigner(t, mustGenerateEd25519Key(t))
+	otherCASigner := mustNewSSHSigner(t, mustGenerateEd25519Key(t))
+
+	userKey := mustGenerateEd25519Key(t)
+	userPublicKey, err := ssh.NewPublicKey(userKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crt := &cmapi.SSHCertificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "ssh-1"},
+		Spec: cmapi.SSHCertificateSpec{
+			SecretName: "ssh-1-cert",
+			Principals: []string{"alice"},
+		},
+	}
+	withSpec := func(mod func(*cmapi.SSHCertificateSpec)) *cmapi.SSHCertificate {
+		crt := crt.DeepCopy()
+		mod(&crt.Spec)
+		return crt
+	}
+
+	cert := mustSignCertificate(t, crt, caSigner, userPublicKey, now)
+	secret := mustCreateSecret(t, crt, cert, userKey)
+
+	hostCrt := withSpec(func(spec *cmapi.SSHCertificateSpec) {
+		spec.Type = cmapi.SSHHostCertificate
+		spec.PublicKey = string(ssh.MarshalAuthorizedKey(userPublicKey))
+	})
+	hostSecret := mustCreateSecret(t, hostCrt, mustSignCertificate(t, hostCrt, caSigner, userPublicKey, now), nil)
+
+	tests := map[string]struct {
+		crt       *cmapi.SSHCertificate
+		secret    *corev1.Secret
+		now       time.Time
+		expReason string
+	}{
+		"issue if the Secret does not exist": {
+			crt:       crt,
+			now:       now,
+			expReason: policies.DoesNotExist,
+		},
+		"issue if the Secret does not contain a certificate": {
+			crt:       crt,
+			secret:    &corev1.Secret{},
+			now:       now,
+			expReason: policies.MissingData,
+		},
+		"issue if the Secret contains a public key rather than a certificate": {
+			crt: crt,
+			secret: &corev1.Secret{Data: map[string][]byte{
+				cmapi.SSHCertificateSecretKey: ssh.MarshalAuthorizedKey(userPublicKey),
+			}},
+			now:       now,
+			expReason: policies.InvalidCertificate,
+		},
+		"do nothing if the certificate is up to date": {
+			crt:    crt,
+			secret: secret,
+			now:    now,
+		},
+		"do nothing if a host certificate for a given public key is up to date": {
+			crt:    hostCrt,
+			secret: hostSecret,
+			now:    now,
+		},
+		"issue if the certificate was signed by another CA": {
+			crt:       crt,
+			secret:    mustCreateSecret(t, crt, mustSignCertificate(t, crt, otherCASigner, userPublicKey, now), userKey),
+			now:       now,
+			expReason: policies.IncorrectIssuer,
+		},
+		"issue if the private key does not match spec.privateKey": {
+			crt: withSpec(func(spec *cmapi.SSHCertificateSpec) {
+				spec.PrivateKey = &cmapi.SSHCertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm}
+			}),
+			secret:    secret,
+			now:       now,
+			expReason: policies.SecretMismatch,
+		},
+		"issue if the certified public key does not match spec.publicKey": {
+			crt: withSpec(func(spec *cmapi.SSHCertificateSpec) {
+				pub, err := ssh.NewPublicKey(mustGenerateEd25519Key(t).Public())
+				if err != nil {
+					t.Fatal(err)
+				}
+				spec.PublicKey = string(ssh.MarshalAuthorizedKey(pub))
+			}),
+			secret:    secret,
+			now:       now,
+			expReason: policies.SecretMismatch,
+		},
+		"issue if the certified public key does not match the private key": {
+			crt: crt,
+			secret: func() *corev1.Secret {
+				secret := secret.DeepCopy()
+				pkData, err := pki.EncodeOpenSSHPrivateKey(mustGenerateEd25519Key(t))
+				if err != nil {
+					t.Fatal(err)
+				}
+				secret.Data[cmapi.SSHPrivateKeySecretKey] = pkData
+				return secret
+			}(),
+			now:       now,
+			expReason: policies.InvalidKeyPair,
+		},
+		"issue if the extensions changed": {
+			crt: withSpec(func(spec *cmapi.SSHCertificateSpec) {
+				spec.Extensions = map[string]string{"permit-pty": ""}
+			}),
+			secret:    secret,
+			now:       now,
+			expReason: policies.SecretMismatch,
+		},
+		"issue if the duration changed": {
+			crt: withSpec(func(spec *cmapi.SSHCertificateSpec) {
+				spec.Duration = &metav1.Duration{Duration: 2 * time.Hour}
+			}),
+			secret:    secret,
+			now:       now,
+			expReason: policies.SecretMismatch,
+		},
+		"do nothing if the duration has a fractional second": {
+			crt: withSpec(func(spec *cmapi.SSHCertificateSpec) {
+				spec.Duration = &metav1.Duration{Duration: 2*time.Hour + 500*time.Millisecond}
+			}),
+			secret: func() *corev1.Secret {
+				crt := withSpec(func(spec *cmapi.SSHCertificateSpec) {
+					spec.Duration = &metav1.Duration{Duration: 2*time.Hour + 500*time.Millisecond}
+				})
+				return mustCreateSecret(t, crt, mustSignCertificate(t, crt, caSigner, userPublicKey, now.Add(700*time.Millisecond)), userKey)
+			}(),
+			now: now,
+		},
+		"renew once the renewal time is reached": {
+			crt:       crt,
+			secret:    secret,
+			now:       now.Add(17 * time.Hour),
+			expReason: policies.Renewing,
+		},
+		"renew earlier if renewBefore is set": {
+			crt: withSpec(func(spec *cmapi.SSHCertificateSpec) {
+				spec.RenewBefore = &metav1.Duration{Duration: 12 * time.Hour}
+			}),
+			secret:    secret,
+			now:       now.Add(13 * time.Hour),
+			expReason: policies.Renewing,
+		},
+		"issue if the certificate expired": {
+			crt:       crt,
+			secret:    secret,
+			now:       now.Add(25 * time.Hour),
+			expReason: policies.Expired,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, reason, message, reissue := shouldReissue(test.crt, test.secret, caSigner.PublicKey(), test.now)
+			if reissue != (test.expReason != "") {
+				t.Fatalf("unexpected reissue %t, reason=%q message=%q", reissue, reason, message)
+			}
+			if reason != test.expReason {
+				t.Errorf("unexpected reason, exp=%q got=%q (message=%q)", test.expReason, reason, message)
+			}
+		})
+	}
+}
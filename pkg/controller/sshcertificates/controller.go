@@ -0,0 +1,236 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshcertificates
+
+import (
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/clock"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
+	cmlisters "github.com/cert-manager/cert-manager/pkg/client/listers/certmanager/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	issuerpkg "github.com/cert-manager/cert-manager/pkg/issuer"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/scheduler"
+)
+
+const (
+	ControllerName = "sshcertificates"
+)
+
+var (
+	sshCertificateGvk = cmapi.SchemeGroupVersion.WithKind(cmapi.SSHCertificateKind)
+)
+
+// This controller signs OpenSSH certificates for SSHCertificate resources
+// using the CA key of the referenced CA issuer, and stores them in the Secret
+// named in `spec.secretName`.
+// Certificates are re-issued when they no longer match the spec of the
+// SSHCertificate, or when their renewal time is reached.
+type controller struct {
+	sshCertificateLister cmlisters.SSHCertificateLister
+	secretLister         corelisters.SecretLister
+	helper               issuerpkg.Helper
+	client               cmclient.Interface
+	coreClient           kubernetes.Interface
+	recorder             record.EventRecorder
+	scheduledWorkQueue   scheduler.ScheduledWorkQueue
+	issuerOptions        controllerpkg.IssuerOptions
+
+	// if true, Secret resources created by the controller will have an
+	// 'owner reference' set, meaning when the SSHCertificate is deleted, the
+	// Secret resource will be automatically deleted.
+	enableSecretOwnerReferences bool
+
+	// fieldManager is the string which will be used as the Field Manager on
+	// fields created or edited by the cert-manager Kubernetes client during
+	// Apply API calls.
+	fieldManager string
+
+	// The following are used for testing purposes.
+	clock clock.Clock
+}
+
+func NewController(
+	log logr.Logger,
+	client cmclient.Interface,
+	coreClient kubernetes.Interface,
+	factory informers.SharedInformerFactory,
+	cmFactory cminformers.SharedInformerFactory,
+	namespace string,
+	recorder record.EventRecorder,
+	clock clock.Clock,
+	issuerOptions controllerpkg.IssuerOptions,
+	enableSecretOwnerReferences bool,
+	fieldManager string,
+) (*controller, workqueue.RateLimitingInterface, []cache.InformerSynced) {
+	// create a queue used to queue up items to be processed
+	queue := workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(time.Second*1, time.Second*30), ControllerName)
+
+	// obtain references to all the informers used by this controller
+	sshCertificateInformer := cmFactory.Certmanager().V1().SSHCertificates()
+	issuerInformer := cmFactory.Certmanager().V1().Issuers()
+	secretsInformer := factory.Core().V1().Secrets()
+
+	sshCertificateInformer.Informer().AddEventHandler(&controllerpkg.QueuingEventHandler{Queue: queue})
+	// When a Secret resource changes, enqueue any SSHCertificate resources
+	// that name it as spec.secretName.
+	secretsInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueSSHCertificatesMatching(log, queue, sshCertificateInformer.Lister(), func(crt *cmapi.SSHCertificate, obj metav1.Object) bool {
+			return crt.Spec.SecretName == obj.GetName()
+		}),
+	})
+	// When an Issuer resource changes, enqueue any SSHCertificate resources
+	// that reference it, so that they are issued once the Issuer is ready.
+	issuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+		WorkFunc: enqueueSSHCertificatesMatching(log, queue, sshCertificateInformer.Lister(), referencesIssuer(cmapi.IssuerKind)),
+	})
+
+	// build a list of InformerSynced functions that will be returned by the Register method.
+	// the controller will only begin processing items once all of these informers have synced.
+	mustSync := []cache.InformerSynced{
+		sshCertificateInformer.Informer().HasSynced,
+		issuerInformer.Informer().HasSynced,
+		secretsInformer.Informer().HasSynced,
+	}
+
+	// ClusterIssuers can only be read if cert-manager is not scoped to a
+	// single namespace.
+	var clusterIssuerLister cmlisters.ClusterIssuerLister
+	if namespace == "" {
+		clusterIssuerInformer := cmFactory.Certmanager().V1().ClusterIssuers()
+		clusterIssuerInformer.Informer().AddEventHandler(&controllerpkg.BlockingEventHandler{
+			WorkFunc: enqueueSSHCertificatesMatching(log, queue, sshCertificateInformer.Lister(), referencesIssuer(cmapi.ClusterIssuerKind)),
+		})
+		clusterIssuerLister = clusterIssuerInformer.Lister()
+		mustSync = append(mustSync, clusterIssuerInformer.Informer().HasSynced)
+	}
+
+	return &controller{
+		sshCertificateLister:        sshCertificateInformer.Lister(),
+		secretLister:                secretsInformer.Lister(),
+		helper:                      issuerpkg.NewHelper(issuerInformer.Lister(), clusterIssuerLister),
+		client:                      client,
+		coreClient:                  coreClient,
+		recorder:                    recorder,
+		scheduledWorkQueue:          scheduler.NewScheduledWorkQueue(clock, queue.Add),
+		issuerOptions:               issuerOptions,
+		enableSecretOwnerReferences: enableSecretOwnerReferences,
+		fieldManager:                fieldManager,
+		clock:                       clock,
+	}, queue, mustSync
+}
+
+// enqueueSSHCertificatesMatching returns a function which enqueues all
+// SSHCertificates matching the given object. Namespaced objects only match
+// SSHCertificates in the same namespace.
+func enqueueSSHCertificatesMatching(log logr.Logger, queue workqueue.Interface, lister cmlisters.SSHCertificateLister, matches func(*cmapi.SSHCertificate, metav1.Object) bool) func(obj interface{}) {
+	return func(obj interface{}) {
+		o, ok := obj.(metav1.Object)
+		if !ok {
+			log.V(logf.ErrorLevel).Info("Non-Object type resource passed to enqueueSSHCertificatesMatching")
+			return
+		}
+
+		var crts []*cmapi.SSHCertificate
+		var err error
+		if o.GetNamespace() == "" {
+			crts, err = lister.List(labels.Everything())
+		} else {
+			crts, err = lister.SSHCertificates(o.GetNamespace()).List(labels.Everything())
+		}
+		if err != nil {
+			log.Error(err, "Failed listing SSHCertificate resources")
+			return
+		}
+
+		for _, crt := range crts {
+			if !matches(crt, o) {
+				continue
+			}
+			key, err := controllerpkg.KeyFunc(crt)
+			if err != nil {
+				log.Error(err, "Error determining 'key' for resource")
+				continue
+			}
+			queue.Add(key)
+		}
+	}
+}
+
+// referencesIssuer returns a function matching SSHCertificates which
+// reference an issuer of the given kind and name.
+func referencesIssuer(kind string) func(*cmapi.SSHCertificate, metav1.Object) bool {
+	return func(crt *cmapi.SSHCertificate, obj metav1.Object) bool {
+		ref := crt.Spec.IssuerRef
+		if ref.Group != "" && ref.Group != cmapi.SchemeGroupVersion.Group {
+			return false
+		}
+		refKind := ref.Kind
+		if refKind == "" {
+			refKind = cmapi.IssuerKind
+		}
+		return refKind == kind && ref.Name == obj.GetName()
+	}
+}
+
+// controllerWrapper wraps the `controller` structure to make it implement
+// the controllerpkg.queueingController interface
+type controllerWrapper struct {
+	*controller
+}
+
+func (c *controllerWrapper) Register(ctx *controllerpkg.Context) (workqueue.RateLimitingInterface, []cache.InformerSynced, error) {
+	// construct a new named logger to be reused throughout the controller
+	log := logf.FromContext(ctx.RootContext, ControllerName)
+
+	ctrl, queue, mustSync := NewController(log,
+		ctx.CMClient,
+		ctx.Client,
+		ctx.KubeSharedInformerFactory,
+		ctx.SharedInformerFactory,
+		ctx.Namespace,
+		ctx.Recorder,
+		ctx.Clock,
+		ctx.IssuerOptions,
+		ctx.CertificateOptions.EnableOwnerRef,
+		ctx.FieldManager,
+	)
+	c.controller = ctrl
+
+	return queue, mustSync, nil
+}
+
+func init() {
+	controllerpkg.Register(ControllerName, func(ctx *controllerpkg.ContextFactory) (controllerpkg.Interface, error) {
+		return controllerpkg.NewBuilder(ctx, ControllerName).
+			For(&controllerWrapper{}).
+			Complete()
+	})
+}
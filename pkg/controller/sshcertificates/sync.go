@@ -0,0 +1,364 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshcertificates
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	applycorev1 "k8s.io/client-go/applyconfigurations/core/v1"
+	applymetav1 "k8s.io/client-go/applyconfigurations/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/pointer"
+
+	"github.com/cert-manager/cert-manager/internal/controller/conflicts"
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	"github.com/cert-manager/cert-manager/pkg/util/kube"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const (
+	reasonIssuing            = "Issuing"
+	reasonIssued             = "Issued"
+	reasonReady              = "Ready"
+	reasonIssuerNotFound     = "IssuerNotFound"
+	reasonIssuerNotSupported = "IssuerNotSupported"
+	reasonIssuerNotAllowed   = "IssuerNotAllowed"
+	reasonSecretMissing      = "SecretMissing"
+	reasonSecretInvalidData  = "SecretInvalidData"
+	reasonSigningError       = "SigningError"
+)
+
+func (c *controller) ProcessItem(ctx context.Context, key string) error {
+	log := logf.FromContext(ctx).WithValues("key", key)
+	ctx = logf.NewContext(ctx, log)
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Error(err, "invalid resource key passed to ProcessItem")
+		return nil
+	}
+
+	crt, err := c.sshCertificateLister.SSHCertificates(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		log.V(logf.DebugLevel).Info("sshcertificate not found for key", "error", err.Error())
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	issuerObj, err := c.helper.GetGenericIssuer(crt.Spec.IssuerRef, crt.Namespace)
+	if apierrors.IsNotFound(err) {
+		return c.setNotReady(ctx, crt, reasonIssuerNotFound, fmt.Sprintf("Referenced %q not found: %v", apiutil.IssuerKind(crt.Spec.IssuerRef), err))
+	}
+	if err != nil {
+		return err
+	}
+	if issuerObj.GetSpec().CA == nil {
+		return c.setNotReady(ctx, crt, reasonIssuerNotSupported, "Referenced issuer is not a CA issuer, only CA issuers can sign SSH certificates")
+	}
+	if reason, message := checkIssuerAllowsSSHCertificate(issuerObj, crt); reason != "" {
+		return c.setNotReady(ctx, crt, reason, message)
+	}
+
+	resourceNamespace := c.issuerOptions.ResourceNamespace(issuerObj)
+	caSecretName := issuerObj.GetSpec().CA.SecretName
+	caKey, err := kube.SecretTLSKey(ctx, c.secretLister, resourceNamespace, caSecretName)
+	if apierrors.IsNotFound(err) {
+		return c.setNotReady(ctx, crt, reasonSecretMissing, fmt.Sprintf("Referenced CA secret %s/%s not found", resourceNamespace, caSecretName))
+	}
+	if cmerrors.IsInvalidData(err) {
+		return c.setNotReady(ctx, crt, reasonSecretInvalidData, fmt.Sprintf("Failed to parse CA private key from secret %s/%s: %v", resourceNamespace, caSecretName, err))
+	}
+	if err != nil {
+		return err
+	}
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	if err != nil {
+		return c.setNotReady(ctx, crt, reasonSecretInvalidData, fmt.Sprintf("CA private key in secret %s/%s cannot be used to sign SSH certificates: %v", resourceNamespace, caSecretName, err))
+	}
+
+	secret, err := c.secretLister.Secrets(crt.Namespace).Get(crt.Spec.SecretName)
+	if apierrors.IsNotFound(err) {
+		secret = nil
+	} else if err != nil {
+		return err
+	}
+
+	cert, reason, message, reissue := shouldReissue(crt, secret, caSigner.PublicKey(), c.clock.Now())
+	if reissue {
+		// Although the below recorder.Event already logs the event, the log
+		// line is quite unreadable (very long). Since this information is very
+		// important for the user and the operator, we log the following
+		// message.
+		log.V(logf.InfoLevel).Info("SSHCertificate must be issued", "reason", reason, "message", message)
+		c.recorder.Event(crt, corev1.EventTypeNormal, reasonIssuing, message)
+
+		cert, err = c.issue(ctx, crt, secret, issuerObj, caSigner)
+		if err != nil {
+			if statusErr := c.setNotReady(ctx, crt, reasonSigningError, fmt.Sprintf("Failed to issue certificate: %v", err)); statusErr != nil {
+				return statusErr
+			}
+			return err
+		}
+		c.recorder.Event(crt, corev1.EventTypeNormal, reasonIssued, "Certificate issued successfully")
+	}
+
+	renewalTime := renewalTimeForCertificate(crt, cert)
+	// ensure a resync is scheduled in the future so that we re-check
+	// SSHCertificate resources and renew them near expiry time
+	c.scheduleRenewal(log, key, renewalTime.Time.Sub(c.clock.Now()))
+
+	crtCopy := crt.DeepCopy()
+	crtCopy.Status.SerialNumber = strconv.FormatUint(cert.Serial, 10)
+	crtCopy.Status.NotBefore = &metav1.Time{Time: time.Unix(int64(cert.ValidAfter), 0)}
+	crtCopy.Status.NotAfter = &metav1.Time{Time: time.Unix(int64(cert.ValidBefore), 0)}
+	crtCopy.Status.RenewalTime = renewalTime
+	apiutil.SetSSHCertificateCondition(crtCopy, crt.Generation, cmapi.SSHCertificateConditionReady, cmmeta.ConditionTrue, reasonReady, "Certificate is up to date and has not expired")
+	if apiequality.Semantic.DeepEqual(crt.Status, crtCopy.Status) {
+		return nil
+	}
+	return c.updateOrApplyStatus(ctx, crtCopy)
+}
+
+// checkIssuerAllowsSSHCertificate returns a reason and message if the issuer
+// does not allow the SSHCertificate to be signed with its CA key. Since SSH
+// certificates are signed without a CertificateRequest, they are not subject
+// to approval, so CA issuers have to opt in explicitly and may restrict the
+// principals that can be certified.
+func checkIssuerAllowsSSHCertificate(issuerObj cmapi.GenericIssuer, crt *cmapi.SSHCertificate) (string, string) {
+	annotations := issuerObj.GetObjectMeta().Annotations
+	if annotations[cmapi.AllowSSHCertificatesAnnotationKey] != "true" {
+		return reasonIssuerNotAllowed, fmt.Sprintf("Referenced issuer does not allow signing SSH certificates, the %q annotation must be set to \"true\" on the issuer",
+			cmapi.AllowSSHCertificatesAnnotationKey)
+	}
+
+	allowed, ok := annotations[cmapi.SSHAllowedPrincipalsAnnotationKey]
+	if !ok {
+		return "", ""
+	}
+	allowedPrincipals := sets.NewString()
+	for _, principal := range strings.Split(allowed, ",") {
+		allowedPrincipals.Insert(strings.TrimSpace(principal))
+	}
+	var denied []string
+	for _, principal := range crt.Spec.Principals {
+		if !allowedPrincipals.Has(principal) {
+			denied = append(denied, principal)
+		}
+	}
+	if len(denied) > 0 {
+		return reasonIssuerNotAllowed, fmt.Sprintf("Referenced issuer does not allow signing SSH certificates for the principals %v", denied)
+	}
+	return "", ""
+}
+
+// issue signs a new certificate for the SSHCertificate and stores it in the
+// Secret named in `spec.secretName`, along with the certified public key, the
+// public key of the CA and the private key if one is generated.
+// A private key already stored in the Secret is reused if it matches
+// `spec.privateKey`.
+func (c *controller) issue(ctx context.Context, crt *cmapi.SSHCertificate, secret *corev1.Secret, issuerObj cmapi.GenericIssuer, caSigner ssh.Signer) (*ssh.Certificate, error) {
+	log := logf.FromContext(ctx)
+
+	var publicKey ssh.PublicKey
+	var privateKeyData []byte
+	if crt.Spec.PublicKey != "" {
+		var err error
+		publicKey, _, _, _, err = ssh.ParseAuthorizedKey([]byte(crt.Spec.PublicKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse spec.publicKey: %w", err)
+		}
+	} else {
+		var signer crypto.Signer
+		if secret != nil {
+			if existing, err := existingPrivateKey(crt, secret); err == nil {
+				signer = existing
+				privateKeyData = secret.Data[cmapi.SSHPrivateKeySecretKey]
+			}
+		}
+		if signer == nil {
+			log.V(logf.DebugLevel).Info("generating new private key")
+			pk, err := pki.GeneratePrivateKeyForSSHCertificate(crt)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate private key: %w", err)
+			}
+			privateKeyData, err = pki.EncodeOpenSSHPrivateKey(pk)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode private key: %w", err)
+			}
+			signer = pk
+		}
+		var err error
+		publicKey, err = ssh.NewPublicKey(signer.Public())
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert public key: %w", err)
+		}
+	}
+
+	var serial [8]byte
+	if _, err := rand.Read(serial[:]); err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	now := c.clock.Now()
+	cert := &ssh.Certificate{
+		Key:             publicKey,
+		Serial:          binary.BigEndian.Uint64(serial[:]),
+		CertType:        certificateType(crt),
+		KeyId:           keyID(crt),
+		ValidPrincipals: crt.Spec.Principals,
+		ValidAfter:      uint64(now.Add(-backdate).Unix()),
+		ValidBefore:     uint64(now.Add(duration(crt)).Unix()),
+		Permissions: ssh.Permissions{
+			CriticalOptions: criticalOptions(crt),
+			Extensions:      extensions(crt),
+		},
+	}
+	if err := cert.SignCert(rand.Reader, caSigner); err != nil {
+		return nil, fmt.Errorf("failed to sign certificate: %w", err)
+	}
+
+	data := map[string][]byte{
+		cmapi.SSHPublicKeySecretKey:   ssh.MarshalAuthorizedKey(publicKey),
+		cmapi.SSHCertificateSecretKey: ssh.MarshalAuthorizedKey(cert),
+		cmapi.SSHCAPublicKeySecretKey: ssh.MarshalAuthorizedKey(caSigner.PublicKey()),
+	}
+	if privateKeyData != nil {
+		data[cmapi.SSHPrivateKeySecretKey] = privateKeyData
+	}
+
+	if err := c.applySecret(ctx, crt, issuerObj, data); err != nil {
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+// applySecret ensures the Secret named in `spec.secretName` contains the
+// given data, using an Apply call.
+func (c *controller) applySecret(ctx context.Context, crt *cmapi.SSHCertificate, issuerObj cmapi.GenericIssuer, data map[string][]byte) error {
+	issuerGroup := crt.Spec.IssuerRef.Group
+	if issuerGroup == "" {
+		issuerGroup = cmapi.SchemeGroupVersion.Group
+	}
+
+	applyCnf := applycorev1.Secret(crt.Spec.SecretName, crt.Namespace).
+		WithAnnotations(map[string]string{
+			cmapi.IssuerNameAnnotationKey:  issuerObj.GetObjectMeta().Name,
+			cmapi.IssuerKindAnnotationKey:  apiutil.IssuerKind(crt.Spec.IssuerRef),
+			cmapi.IssuerGroupAnnotationKey: issuerGroup,
+		}).
+		WithLabels(map[string]string{cmapi.PartOfCertManagerControllerLabelKey: "true"}).
+		WithData(data).WithType(corev1.SecretTypeOpaque)
+
+	// If Secret owner reference is enabled, set it on the Secret. This results
+	// in a no-op if the Secret already exists and has the owner reference set,
+	// and visa-versa.
+	if c.enableSecretOwnerReferences {
+		ref := *metav1.NewControllerRef(crt, sshCertificateGvk)
+		applyCnf = applyCnf.WithOwnerReferences(&applymetav1.OwnerReferenceApplyConfiguration{
+			APIVersion: &ref.APIVersion, Kind: &ref.Kind,
+			Name: &ref.Name, UID: &ref.UID,
+			Controller: ref.Controller, BlockOwnerDeletion: ref.BlockOwnerDeletion,
+		})
+	}
+
+	err := conflicts.Apply(c.recorder, crt, c.fieldManager, func(force bool) error {
+		_, err := c.coreClient.CoreV1().Secrets(crt.Namespace).Apply(ctx, applyCnf, metav1.ApplyOptions{FieldManager: c.fieldManager, Force: force})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply secret %s/%s: %w", crt.Namespace, crt.Spec.SecretName, err)
+	}
+
+	return nil
+}
+
+// setNotReady sets the Ready condition of the SSHCertificate to False with
+// the given reason and message, and records a warning Event.
+func (c *controller) setNotReady(ctx context.Context, crt *cmapi.SSHCertificate, reason, message string) error {
+	logf.FromContext(ctx).V(logf.InfoLevel).Info("SSHCertificate is not ready", "reason", reason, "message", message)
+
+	if cond := apiutil.GetSSHCertificateCondition(crt, cmapi.SSHCertificateConditionReady); cond != nil &&
+		cond.Status == cmmeta.ConditionFalse && cond.Reason == reason && cond.Message == message && cond.ObservedGeneration == crt.Generation {
+		return nil
+	}
+
+	c.recorder.Event(crt, corev1.EventTypeWarning, reason, message)
+	crt = crt.DeepCopy()
+	apiutil.SetSSHCertificateCondition(crt, crt.Generation, cmapi.SSHCertificateConditionReady, cmmeta.ConditionFalse, reason, message)
+	return c.updateOrApplyStatus(ctx, crt)
+}
+
+// updateOrApplyStatus will update the controller status. If the
+// ServerSideApply feature is enabled, the managed fields will instead get
+// applied using the relevant Patch API call.
+func (c *controller) updateOrApplyStatus(ctx context.Context, crt *cmapi.SSHCertificate) error {
+	if utilfeature.DefaultFeatureGate.Enabled(feature.ServerSideApply) {
+		crtData, err := json.Marshal(&cmapi.SSHCertificate{
+			TypeMeta:   metav1.TypeMeta{Kind: cmapi.SSHCertificateKind, APIVersion: cmapi.SchemeGroupVersion.Identifier()},
+			ObjectMeta: metav1.ObjectMeta{Namespace: crt.Namespace, Name: crt.Name},
+			Status:     crt.Status,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal sshcertificate object: %w", err)
+		}
+		_, err = c.client.CertmanagerV1().SSHCertificates(crt.Namespace).Patch(
+			ctx, crt.Name, apitypes.ApplyPatchType, crtData,
+			metav1.PatchOptions{Force: pointer.Bool(true), FieldManager: c.fieldManager}, "status",
+		)
+		return err
+	} else {
+		_, err := c.client.CertmanagerV1().SSHCertificates(crt.Namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{})
+		return err
+	}
+}
+
+// scheduleRenewal will schedule the resource with the given key to be
+// re-queued for processing after the given amount of time has elapsed.
+func (c *controller) scheduleRenewal(log logr.Logger, key string, durationUntilRenewalTime time.Duration) {
+	// don't schedule a re-queue if the time is in the past.
+	if durationUntilRenewalTime < 0 {
+		return
+	}
+
+	log.V(logf.DebugLevel).Info("scheduling renewal", "duration_until_renewal", durationUntilRenewalTime.String())
+
+	c.scheduledWorkQueue.Add(key, durationUntilRenewalTime)
+}
@@ -0,0 +1,357 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sshcertificates
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	coretesting "k8s.io/client-go/testing"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func mustGenerateEd25519Key(t *testing.T) crypto.Signer {
+	pk, err := pki.GenerateEd25519PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pk
+}
+
+func mustNewSSHSigner(t *testing.T, pk crypto.Signer) ssh.Signer {
+	signer, err := ssh.NewSignerFromKey(pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+// mustSignCertificate signs a certificate for the given SSHCertificate in the
+// same way as the controller does.
+func mustSignCertificate(t *testing.T, crt *cmapi.SSHCertificate, caSigner ssh.Signer, publicKey ssh.PublicKey, now time.Time) *ssh.Certificate {
+	cert := &ssh.Certificate{
+		Key:             publicKey,
+		Serial:          1234,
+		CertType:        certificateType(crt),
+		KeyId:           keyID(crt),
+		ValidPrincipals: crt.Spec.Principals,
+		ValidAfter:      uint64(now.Add(-backdate).Unix()),
+		ValidBefore:     uint64(now.Add(duration(crt)).Unix()),
+		Permissions: ssh.Permissions{
+			CriticalOptions: criticalOptions(crt),
+			Extensions:      extensions(crt),
+		},
+	}
+	if err := cert.SignCert(rand.Reader, caSigner); err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+// mustCreateSecret returns a Secret holding the given certificate and private
+// key, as written by the controller.
+func mustCreateSecret(t *testing.T, crt *cmapi.SSHCertificate, cert *ssh.Certificate, pk crypto.Signer) *corev1.Secret {
+	data := map[string][]byte{
+		cmapi.SSHPublicKeySecretKey:   ssh.MarshalAuthorizedKey(cert.Key),
+		cmapi.SSHCertificateSecretKey: ssh.MarshalAuthorizedKey(cert),
+		cmapi.SSHCAPublicKeySecretKey: ssh.MarshalAuthorizedKey(cert.SignatureKey),
+	}
+	if pk != nil {
+		pkData, err := pki.EncodeOpenSSHPrivateKey(pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data[cmapi.SSHPrivateKeySecretKey] = pkData
+	}
+	return gen.Secret(crt.Spec.SecretName, gen.SetSecretNamespace(crt.Namespace), gen.SetSecretData(data))
+}
+
+func Test_controller_ProcessItem(t *testing.T) {
+	fixedNow := time.Now().Truncate(time.Second)
+	fixedClock := fakeclock.NewFakeClock(fixedNow)
+
+	caKey := mustGenerateEd25519Key(t)
+	caSigner := mustNewSSHSigner(t, caKey)
+	caKeyPEM, err := pki.EncodePKCS8PrivateKey(caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caSecret := gen.Secret("ca", gen.SetSecretNamespace("testns"), gen.SetSecretData(map[string][]byte{
+		corev1.TLSPrivateKeyKey: caKeyPEM,
+	}))
+	caIssuer := gen.Issuer("ca-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerCASecretName("ca"),
+		gen.SetIssuerAnnotations(map[string]string{cmapi.AllowSSHCertificatesAnnotationKey: "true"}))
+
+	baseCrt := &cmapi.SSHCertificate{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "testns", Name: "ssh-1", Generation: 3},
+		Spec: cmapi.SSHCertificateSpec{
+			SecretName: "ssh-1-cert",
+			Principals: []string{"alice"},
+			IssuerRef:  cmmeta.ObjectReference{Name: "ca-issuer"},
+		},
+	}
+
+	userKey := mustGenerateEd25519Key(t)
+	userPublicKey, err := ssh.NewPublicKey(userKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	upToDateCert := mustSignCertificate(t, baseCrt, caSigner, userPublicKey, fixedNow)
+	upToDateSecret := mustCreateSecret(t, baseCrt, upToDateCert, userKey)
+	upToDateCrt := baseCrt.DeepCopy()
+	upToDateCrt.Status = cmapi.SSHCertificateStatus{
+		Conditions: []cmapi.SSHCertificateCondition{{
+			Type:               cmapi.SSHCertificateConditionReady,
+			Status:             cmmeta.ConditionTrue,
+			Reason:             "Ready",
+			Message:            "Certificate is up to date and has not expired",
+			LastTransitionTime: &metav1.Time{Time: fixedNow},
+			ObservedGeneration: 3,
+		}},
+		SerialNumber: "1234",
+		NotBefore:    &metav1.Time{Time: fixedNow.Add(-backdate)},
+		NotAfter:     &metav1.Time{Time: fixedNow.Add(24 * time.Hour)},
+		RenewalTime:  renewalTimeForCertificate(baseCrt, upToDateCert),
+	}
+
+	changedPrincipalsCrt := upToDateCrt.DeepCopy()
+	changedPrincipalsCrt.Spec.Principals = []string{"alice", "bob"}
+
+	// expectIssued matches an Apply of the Secret holding a certificate for
+	// the given SSHCertificate signed by the CA.
+	expectIssued := func(crt *cmapi.SSHCertificate, expPublicKey ssh.PublicKey) testpkg.Action {
+		return testpkg.NewCustomMatch(coretesting.NewPatchAction(corev1.SchemeGroupVersion.WithResource("secrets"), crt.Namespace, crt.Spec.SecretName, "", nil),
+			func(_, got coretesting.Action) error {
+				var secret corev1.Secret
+				if err := json.Unmarshal(got.(coretesting.PatchAction).GetPatch(), &secret); err != nil {
+					return err
+				}
+				pub, _, _, _, err := ssh.ParseAuthorizedKey(secret.Data[cmapi.SSHCertificateSecretKey])
+				if err != nil {
+					return fmt.Errorf("failed to parse certificate: %w", err)
+				}
+				cert, ok := pub.(*ssh.Certificate)
+				if !ok {
+					return fmt.Errorf("expected a certificate, got %T", pub)
+				}
+				if !publicKeysEqual(cert.SignatureKey, caSigner.PublicKey()) {
+					return fmt.Errorf("certificate is not signed by the CA")
+				}
+				if violations := certificateMatchesSpec(cert, crt); len(violations) > 0 {
+					return fmt.Errorf("certificate does not match the spec: %v", violations)
+				}
+				if expPublicKey != nil && !publicKeysEqual(cert.Key, expPublicKey) {
+					return fmt.Errorf("unexpected certified public key")
+				}
+				if secret.Labels[cmapi.PartOfCertManagerControllerLabelKey] != "true" {
+					return fmt.Errorf("missing base label")
+				}
+				if secret.Annotations[cmapi.IssuerNameAnnotationKey] != "ca-issuer" {
+					return fmt.Errorf("unexpected issuer annotations: %v", secret.Annotations)
+				}
+				return nil
+			})
+	}
+
+	// expectStatus matches a status update of the SSHCertificate with the
+	// given Ready condition status and reason.
+	expectStatus := func(crt *cmapi.SSHCertificate, status cmmeta.ConditionStatus, reason string) testpkg.Action {
+		return testpkg.NewCustomMatch(coretesting.NewUpdateSubresourceAction(cmapi.SchemeGroupVersion.WithResource("sshcertificates"), "status", crt.Namespace, crt),
+			func(_, got coretesting.Action) error {
+				gotCrt := got.(coretesting.UpdateAction).GetObject().(*cmapi.SSHCertificate)
+				cond := apiutil.GetSSHCertificateCondition(gotCrt, cmapi.SSHCertificateConditionReady)
+				if cond == nil || cond.Status != status || cond.Reason != reason || cond.ObservedGeneration != crt.Generation {
+					return fmt.Errorf("unexpected Ready condition: %+v", cond)
+				}
+				if status == cmmeta.ConditionTrue {
+					if _, err := strconv.ParseUint(gotCrt.Status.SerialNumber, 10, 64); err != nil {
+						return fmt.Errorf("unexpected serial number %q", gotCrt.Status.SerialNumber)
+					}
+					if gotCrt.Status.NotAfter == nil || !gotCrt.Status.NotAfter.Time.Equal(fixedNow.Add(duration(crt))) {
+						return fmt.Errorf("unexpected notAfter %v", gotCrt.Status.NotAfter)
+					}
+				}
+				return nil
+			})
+	}
+
+	tests := map[string]struct {
+		existingSSHCertificate *cmapi.SSHCertificate
+		existingObjects        []runtime.Object
+		existingKubeObjects    []runtime.Object
+
+		expectedEvents  []string
+		expectedActions []testpkg.Action
+	}{
+		"do nothing if the SSHCertificate does not exist": {},
+		"issue a certificate with a generated key when the Secret does not exist": {
+			existingSSHCertificate: baseCrt,
+			existingObjects:        []runtime.Object{caIssuer},
+			existingKubeObjects:    []runtime.Object{caSecret},
+			expectedEvents: []string{
+				"Normal Issuing Issuing certificate as Secret does not exist",
+				"Normal Issued Certificate issued successfully",
+			},
+			expectedActions: []testpkg.Action{
+				expectIssued(baseCrt, nil),
+				expectStatus(baseCrt, cmmeta.ConditionTrue, "Ready"),
+			},
+		},
+		"do nothing if the certificate in the Secret is up to date": {
+			existingSSHCertificate: upToDateCrt,
+			existingObjects:        []runtime.Object{caIssuer},
+			existingKubeObjects:    []runtime.Object{caSecret, upToDateSecret},
+		},
+		"re-issue the certificate for the existing key if the principals changed": {
+			existingSSHCertificate: changedPrincipalsCrt,
+			existingObjects:        []runtime.Object{caIssuer},
+			existingKubeObjects:    []runtime.Object{caSecret, upToDateSecret},
+			expectedEvents: []string{
+				"Normal Issuing Issuing certificate as the existing certificate does not match the spec: [spec.principals]",
+				"Normal Issued Certificate issued successfully",
+			},
+			expectedActions: []testpkg.Action{
+				expectIssued(changedPrincipalsCrt, userPublicKey),
+				expectStatus(changedPrincipalsCrt, cmmeta.ConditionTrue, "Ready"),
+			},
+		},
+		"set the Ready condition to False if the issuer does not exist": {
+			existingSSHCertificate: baseCrt,
+			existingKubeObjects:    []runtime.Object{caSecret},
+			expectedEvents: []string{
+				`Warning IssuerNotFound Referenced "Issuer" not found: issuer.cert-manager.io "ca-issuer" not found`,
+			},
+			expectedActions: []testpkg.Action{
+				expectStatus(baseCrt, cmmeta.ConditionFalse, "IssuerNotFound"),
+			},
+		},
+		"set the Ready condition to False if the issuer is not a CA issuer": {
+			existingSSHCertificate: baseCrt,
+			existingObjects: []runtime.Object{
+				gen.Issuer("ca-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerSelfSigned(cmapi.SelfSignedIssuer{})),
+			},
+			expectedEvents: []string{
+				"Warning IssuerNotSupported Referenced issuer is not a CA issuer, only CA issuers can sign SSH certificates",
+			},
+			expectedActions: []testpkg.Action{
+				expectStatus(baseCrt, cmmeta.ConditionFalse, "IssuerNotSupported"),
+			},
+		},
+		"set the Ready condition to False if the issuer does not allow signing SSH certificates": {
+			existingSSHCertificate: baseCrt,
+			existingObjects: []runtime.Object{
+				gen.Issuer("ca-issuer", gen.SetIssuerNamespace("testns"), gen.SetIssuerCASecretName("ca")),
+			},
+			existingKubeObjects: []runtime.Object{caSecret},
+			expectedEvents: []string{
+				`Warning IssuerNotAllowed Referenced issuer does not allow signing SSH certificates, the "cert-manager.io/allow-ssh-certificates" annotation must be set to "true" on the issuer`,
+			},
+			expectedActions: []testpkg.Action{
+				expectStatus(baseCrt, cmmeta.ConditionFalse, "IssuerNotAllowed"),
+			},
+		},
+		"set the Ready condition to False if the issuer does not allow the principals": {
+			existingSSHCertificate: changedPrincipalsCrt,
+			existingObjects: []runtime.Object{
+				gen.IssuerFrom(caIssuer, gen.SetIssuerAnnotations(map[string]string{
+					cmapi.AllowSSHCertificatesAnnotationKey: "true",
+					cmapi.SSHAllowedPrincipalsAnnotationKey: "alice, carol",
+				})),
+			},
+			existingKubeObjects: []runtime.Object{caSecret, upToDateSecret},
+			expectedEvents: []string{
+				"Warning IssuerNotAllowed Referenced issuer does not allow signing SSH certificates for the principals [bob]",
+			},
+			expectedActions: []testpkg.Action{
+				expectStatus(changedPrincipalsCrt, cmmeta.ConditionFalse, "IssuerNotAllowed"),
+			},
+		},
+		"set the Ready condition to False if the CA secret does not exist": {
+			existingSSHCertificate: baseCrt,
+			existingObjects:        []runtime.Object{caIssuer},
+			expectedEvents: []string{
+				"Warning SecretMissing Referenced CA secret testns/ca not found",
+			},
+			expectedActions: []testpkg.Action{
+				expectStatus(baseCrt, cmmeta.ConditionFalse, "SecretMissing"),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			builder := &testpkg.Builder{
+				T:                  t,
+				Clock:              fixedClock,
+				KubeObjects:        test.existingKubeObjects,
+				CertManagerObjects: test.existingObjects,
+				ExpectedEvents:     test.expectedEvents,
+				ExpectedActions:    test.expectedActions,
+			}
+			if test.existingSSHCertificate != nil {
+				builder.CertManagerObjects = append(builder.CertManagerObjects, test.existingSSHCertificate)
+			}
+			builder.Init()
+
+			// The fake clientset does not support Apply calls.
+			builder.FakeKubeClient().PrependReactor("patch", "secrets", func(action coretesting.Action) (bool, runtime.Object, error) {
+				return true, &corev1.Secret{}, nil
+			})
+
+			w := &controllerWrapper{}
+			_, _, err := w.Register(builder.Context)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			builder.Start()
+			defer builder.Stop()
+
+			key := "testns/ssh-1"
+			if test.existingSSHCertificate != nil {
+				key, err = controllerpkg.KeyFunc(test.existingSSHCertificate)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := w.controller.ProcessItem(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			builder.CheckAndFinish()
+		})
+	}
+}
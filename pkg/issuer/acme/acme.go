@@ -19,17 +19,23 @@ package acme
 import (
 	"context"
 	"crypto"
+	"crypto/sha256"
 	"fmt"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/cache"
 	core "k8s.io/client-go/kubernetes/typed/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/record"
 
+	"github.com/cert-manager/cert-manager/internal/credentials"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/controller"
 	"github.com/cert-manager/cert-manager/pkg/issuer"
+	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/cloudflare"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
 	"github.com/cert-manager/cert-manager/pkg/util/kube"
 )
@@ -50,6 +56,10 @@ type Acme struct {
 	// clientBuilder builds a new ACME client.
 	clientBuilder accounts.NewClientFunc
 
+	// verifyCloudflareToken checks the Cloudflare API token of a DNS01 solver.
+	// It can be stubbed in unit tests.
+	verifyCloudflareToken verifyCloudflareTokenFunc
+
 	// namespace of referenced resources when the given issuer is a ClusterIssuer
 	clusterResourceNamespace string
 	// used as a cache for ACME clients
@@ -74,6 +84,7 @@ func New(ctx *controller.Context, issuer v1.GenericIssuer) (issuer.Interface, er
 		issuer:                   issuer,
 		keyFromSecret:            newKeyFromSecret(secretsLister),
 		clientBuilder:            accounts.NewClient,
+		verifyCloudflareToken:    newVerifyCloudflareToken(secretsLister, ctx.ACMEOptions.VerifiedCloudflareTokens, verifyCloudflareTokenWithAPI(ctx.RESTConfig.UserAgent)),
 		secretsClient:            ctx.Client.CoreV1(),
 		recorder:                 ctx.Recorder,
 		clusterResourceNamespace: ctx.IssuerOptions.ClusterResourceNamespace,
@@ -96,6 +107,71 @@ func newKeyFromSecret(secretLister corelisters.SecretLister) keyFromSecretFunc {
 	}
 }
 
+// verifyCloudflareTokenFunc verifies that the Cloudflare API token referenced
+// by a DNS01 solver, which is loaded from the given namespace, is allowed to
+// manage DNS records.
+type verifyCloudflareTokenFunc func(ctx context.Context, namespace string, cf *cmacme.ACMEIssuerDNS01ProviderCloudflare) error
+
+const (
+	// CloudflareTokenCacheSize is the maximum number of verified Cloudflare
+	// API tokens recorded in the cache shared by ACME issuers.
+	CloudflareTokenCacheSize = 1000
+
+	// cloudflareTokenVerificationTTL is the time after which a verified
+	// Cloudflare API token is verified again, so that tokens which are
+	// revoked or lose their permissions are reported on the issuer.
+	cloudflareTokenVerificationTTL = 3 * time.Hour
+)
+
+// newVerifyCloudflareToken returns an implementation of
+// verifyCloudflareTokenFunc for a secrets lister. Verified tokens are
+// recorded in the given cache, keyed by a hash of the token and the zone ID
+// of the solver, so that tokens are not verified on every resync of an
+// issuer. If the cache is nil, tokens are verified every time.
+func newVerifyCloudflareToken(secretLister corelisters.SecretLister, verified *cache.LRUExpireCache, verify func(token, zoneID string) error) verifyCloudflareTokenFunc {
+	return func(ctx context.Context, namespace string, cf *cmacme.ACMEIssuerDNS01ProviderCloudflare) error {
+		secret, err := secretLister.Secrets(namespace).Get(cf.APIToken.Name)
+		if err != nil {
+			return err
+		}
+		token, ok, err := credentials.Value(secret, cf.APIToken.Key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("no key %q in secret %q", cf.APIToken.Key, namespace+"/"+cf.APIToken.Name)
+		}
+
+		key := sha256.Sum256([]byte(cf.ZoneID + "\x00" + string(token)))
+		if verified != nil {
+			if _, ok := verified.Get(key); ok {
+				return nil
+			}
+		}
+
+		if err := verify(string(token), cf.ZoneID); err != nil {
+			return err
+		}
+
+		if verified != nil {
+			verified.Add(key, struct{}{}, cloudflareTokenVerificationTTL)
+		}
+		return nil
+	}
+}
+
+// verifyCloudflareTokenWithAPI verifies the given Cloudflare API token using
+// the Cloudflare API.
+func verifyCloudflareTokenWithAPI(userAgent string) func(token, zoneID string) error {
+	return func(token, zoneID string) error {
+		provider, err := cloudflare.NewDNSProviderCredentials("", "", token, zoneID, nil, userAgent)
+		if err != nil {
+			return err
+		}
+		return provider.VerifyToken()
+	}
+}
+
 // Register this Issuer with the issuer factory
 func init() {
 	issuer.RegisterIssuer(apiutil.IssuerACME, New)
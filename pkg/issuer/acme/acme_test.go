@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package acme
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/cache"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	clientcache "k8s.io/client-go/tools/cache"
+	fakeclock "k8s.io/utils/clock/testing"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+)
+
+func Test_newVerifyCloudflareToken(t *testing.T) {
+	indexer := clientcache.NewIndexer(clientcache.MetaNamespaceKeyFunc, clientcache.Indexers{})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cloudflare"},
+		Data:       map[string][]byte{"api-token": []byte("token-1")},
+	}
+	if err := indexer.Add(secret); err != nil {
+		t.Fatal(err)
+	}
+
+	clock := fakeclock.NewFakeClock(time.Now())
+	var verified []string
+	verify := newVerifyCloudflareToken(corelisters.NewSecretLister(indexer), cache.NewLRUExpireCacheWithClock(CloudflareTokenCacheSize, clock), func(token, zoneID string) error {
+		verified = append(verified, token)
+		return nil
+	})
+
+	cf := &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+		APIToken: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "cloudflare"}, Key: "api-token"},
+		ZoneID:   "zone",
+	}
+	assertVerified := func(step string, expected ...string) {
+		t.Helper()
+		if err := verify(context.Background(), "ns", cf); err != nil {
+			t.Fatalf("%s: unexpected error: %v", step, err)
+		}
+		if len(verified) != len(expected) {
+			t.Fatalf("%s: expected tokens %v to be verified, got %v", step, expected, verified)
+		}
+		for i := range expected {
+			if verified[i] != expected[i] {
+				t.Fatalf("%s: expected tokens %v to be verified, got %v", step, expected, verified)
+			}
+		}
+	}
+
+	assertVerified("first verification", "token-1")
+	assertVerified("verified token is cached", "token-1")
+
+	clock.Step(cloudflareTokenVerificationTTL + time.Second)
+	assertVerified("token is verified again once the cache entry expires", "token-1", "token-1")
+
+	secret = secret.DeepCopy()
+	secret.Data["api-token"] = []byte("token-2")
+	if err := indexer.Update(secret); err != nil {
+		t.Fatal(err)
+	}
+	assertVerified("rotated token is verified", "token-1", "token-1", "token-2")
+	assertVerified("rotated token is cached", "token-1", "token-1", "token-2")
+}
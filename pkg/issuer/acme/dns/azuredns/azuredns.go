@@ -20,6 +20,7 @@ import (
 	"github.com/go-logr/logr"
 
 	"github.com/Azure/azure-sdk-for-go/services/dns/mgmt/2017-10-01/dns"
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2020-06-01/privatedns"
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
@@ -38,11 +39,21 @@ type DNSProvider struct {
 	resourceGroupName string
 	zoneName          string
 	log               logr.Logger
+
+	// private is true if records are managed in an Azure Private DNS zone,
+	// optionally restricted to zones linked to virtualNetworkID.
+	private             bool
+	virtualNetworkID    string
+	privateRecordClient privatedns.RecordSetsClient
+	privateZoneClient   privatedns.PrivateZonesClient
+	linkClient          privatedns.VirtualNetworkLinksClient
 }
 
 // NewDNSProviderCredentials returns a DNSProvider instance configured for the Azure
-// DNS service using static credentials from its parameters
-func NewDNSProviderCredentials(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, zoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*DNSProvider, error) {
+// DNS service using static credentials from its parameters. If zoneVisibility
+// is Private, records are managed in Azure Private DNS zones instead, which
+// must be linked to virtualNetworkID if it is set.
+func NewDNSProviderCredentials(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, zoneName, zoneVisibility, virtualNetworkID string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*DNSProvider, error) {
 	env := azure.PublicCloud
 	if environment != "" {
 		var err error
@@ -63,13 +74,27 @@ func NewDNSProviderCredentials(environment, clientID, clientSecret, subscription
 	zc := dns.NewZonesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
 	zc.Authorizer = autorest.NewBearerAuthorizer(spt)
 
+	prc := privatedns.NewRecordSetsClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	prc.Authorizer = autorest.NewBearerAuthorizer(spt)
+
+	pzc := privatedns.NewPrivateZonesClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	pzc.Authorizer = autorest.NewBearerAuthorizer(spt)
+
+	lc := privatedns.NewVirtualNetworkLinksClientWithBaseURI(env.ResourceManagerEndpoint, subscriptionID)
+	lc.Authorizer = autorest.NewBearerAuthorizer(spt)
+
 	return &DNSProvider{
-		dns01Nameservers:  dns01Nameservers,
-		recordClient:      rc,
-		zoneClient:        zc,
-		resourceGroupName: resourceGroupName,
-		zoneName:          zoneName,
-		log:               logf.Log.WithName("azure-dns"),
+		dns01Nameservers:    dns01Nameservers,
+		recordClient:        rc,
+		zoneClient:          zc,
+		resourceGroupName:   resourceGroupName,
+		zoneName:            zoneName,
+		log:                 logf.Log.WithName("azure-dns"),
+		private:             zoneVisibility == string(cmacme.DNSZoneVisibilityPrivate),
+		virtualNetworkID:    virtualNetworkID,
+		privateRecordClient: prc,
+		privateZoneClient:   pzc,
+		linkClient:          lc,
 	}, nil
 }
 
@@ -222,12 +247,22 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 		return err
 	}
 
-	_, err = c.recordClient.Delete(
-		context.TODO(),
-		c.resourceGroupName,
-		z,
-		c.trimFqdn(fqdn, z),
-		dns.TXT, "")
+	if c.private {
+		_, err = c.privateRecordClient.Delete(
+			context.TODO(),
+			c.resourceGroupName,
+			z,
+			privatedns.TXT,
+			c.trimFqdn(fqdn, z),
+			"")
+	} else {
+		_, err = c.recordClient.Delete(
+			context.TODO(),
+			c.resourceGroupName,
+			z,
+			c.trimFqdn(fqdn, z),
+			dns.TXT, "")
+	}
 
 	if err != nil {
 		return err
@@ -235,7 +270,17 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	return nil
 }
 
+// HostedZoneForFQDN returns the name of the Azure DNS or Azure Private DNS
+// zone in which the TXT record for the given fqdn is presented.
+func (c *DNSProvider) HostedZoneForFQDN(fqdn string) (string, error) {
+	return c.getHostedZoneName(fqdn)
+}
+
 func (c *DNSProvider) createRecord(fqdn, value string, ttl int) error {
+	if c.private {
+		return c.createPrivateRecord(fqdn, value, ttl)
+	}
+
 	rparams := &dns.RecordSet{
 		RecordSetProperties: &dns.RecordSetProperties{
 			TTL: to.Int64Ptr(int64(ttl)),
@@ -266,26 +311,105 @@ func (c *DNSProvider) createRecord(fqdn, value string, ttl int) error {
 	return nil
 }
 
+func (c *DNSProvider) createPrivateRecord(fqdn, value string, ttl int) error {
+	rparams := privatedns.RecordSet{
+		RecordSetProperties: &privatedns.RecordSetProperties{
+			TTL: to.Int64Ptr(int64(ttl)),
+			TxtRecords: &[]privatedns.TxtRecord{
+				{Value: &[]string{value}},
+			},
+		},
+	}
+
+	z, err := c.getHostedZoneName(fqdn)
+	if err != nil {
+		c.log.Error(err, "Error getting hosted zone name for:", fqdn)
+		return err
+	}
+
+	_, err = c.privateRecordClient.CreateOrUpdate(
+		context.TODO(),
+		c.resourceGroupName,
+		z,
+		privatedns.TXT,
+		c.trimFqdn(fqdn, z),
+		rparams, "", "")
+
+	if err != nil {
+		c.log.Error(err, "Error creating TXT:", z)
+		return err
+	}
+	return nil
+}
+
 func (c *DNSProvider) getHostedZoneName(fqdn string) (string, error) {
-	if c.zoneName != "" {
+	if c.zoneName != "" && !(c.private && c.virtualNetworkID != "") {
 		return c.zoneName, nil
 	}
-	z, err := util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
-	if err != nil {
-		return "", err
+
+	z := c.zoneName
+	if z == "" {
+		var err error
+		z, err = util.FindZoneByFqdn(fqdn, c.dns01Nameservers)
+		if err != nil {
+			return "", err
+		}
+
+		if len(z) == 0 {
+			return "", fmt.Errorf("Zone %s not found for domain %s", z, fqdn)
+		}
 	}
 
-	if len(z) == 0 {
-		return "", fmt.Errorf("Zone %s not found for domain %s", z, fqdn)
+	var id *string
+	if c.private {
+		zone, err := c.privateZoneClient.Get(context.TODO(), c.resourceGroupName, util.UnFqdn(z))
+		if err != nil {
+			return "", fmt.Errorf("Zone %s not found in Azure Private DNS for domain %s. Err: %v", z, fqdn, err)
+		}
+		id = zone.ID
+
+		if c.virtualNetworkID != "" {
+			linked, err := c.isLinkedToVirtualNetwork(util.UnFqdn(z))
+			if err != nil {
+				return "", err
+			}
+			if !linked {
+				return "", fmt.Errorf("Zone %s in Azure Private DNS is not linked to virtual network %s", z, c.virtualNetworkID)
+			}
+		}
+	} else {
+		zone, err := c.zoneClient.Get(context.TODO(), c.resourceGroupName, util.UnFqdn(z))
+		if err != nil {
+			return "", fmt.Errorf("Zone %s not found in AzureDNS for domain %s. Err: %v", z, fqdn, err)
+		}
+		id = zone.ID
 	}
 
-	_, err = c.zoneClient.Get(context.TODO(), c.resourceGroupName, util.UnFqdn(z))
+	c.log.V(logf.InfoLevel).Info("selected hosted zone", "fqdn", fqdn, "zone", util.UnFqdn(z), "zoneID", to.String(id), "private", c.private)
 
+	return util.UnFqdn(z), nil
+}
+
+// isLinkedToVirtualNetwork returns true if the private zone with the given
+// name has a link to the virtual network configured on the provider.
+func (c *DNSProvider) isLinkedToVirtualNetwork(zoneName string) (bool, error) {
+	links, err := c.linkClient.ListComplete(context.TODO(), c.resourceGroupName, zoneName, nil)
 	if err != nil {
-		return "", fmt.Errorf("Zone %s not found in AzureDNS for domain %s. Err: %v", z, fqdn, err)
+		return false, fmt.Errorf("failed to list virtual network links of zone %s: %v", zoneName, err)
 	}
 
-	return util.UnFqdn(z), nil
+	for links.NotDone() {
+		link := links.Value()
+		if link.VirtualNetworkLinkProperties != nil && link.VirtualNetwork != nil &&
+			strings.EqualFold(to.String(link.VirtualNetwork.ID), c.virtualNetworkID) {
+			return true, nil
+		}
+		if err := links.NextWithContext(context.TODO()); err != nil {
+			return false, fmt.Errorf("failed to list virtual network links of zone %s: %v", zoneName, err)
+		}
+	}
+
+	return false, nil
 }
 
 // Trims DNS zone from the fqdn. Defaults to DNSProvider.zoneName if it is specified.
@@ -14,13 +14,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2020-06-01/privatedns"
 	"github.com/Azure/go-autorest/autorest/adal"
 	"github.com/Azure/go-autorest/autorest/azure"
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -52,7 +55,7 @@ func TestLiveAzureDnsPresent(t *testing.T) {
 	if !azureLiveTest {
 		t.Skip("skipping live test")
 	}
-	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
 	assert.NoError(t, err)
 
 	err = provider.Present(azureDomain, "_acme-challenge."+azureDomain+".", "123d==")
@@ -66,7 +69,7 @@ func TestLiveAzureDnsCleanUp(t *testing.T) {
 
 	time.Sleep(time.Second * 5)
 
-	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	provider, err := NewDNSProviderCredentials("", azureClientID, azureClientSecret, azuresubscriptionID, azureTenantID, azureResourceGroupName, azureHostedZoneName, "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(azureDomain, "_acme-challenge."+azureDomain+".", "123d==")
@@ -76,11 +79,11 @@ func TestLiveAzureDnsCleanUp(t *testing.T) {
 func TestInvalidAzureDns(t *testing.T) {
 	validEnv := []string{"", "AzurePublicCloud", "AzureChinaCloud", "AzureGermanCloud", "AzureUSGovernmentCloud"}
 	for _, env := range validEnv {
-		_, err := NewDNSProviderCredentials(env, "cid", "secret", "", "", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+		_, err := NewDNSProviderCredentials(env, "cid", "secret", "", "", "", "", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
 		assert.NoError(t, err)
 	}
 
-	_, err := NewDNSProviderCredentials("invalid env", "cid", "secret", "", "", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
+	_, err := NewDNSProviderCredentials("invalid env", "cid", "secret", "", "", "", "", "", "", util.RecursiveNameservers, false, &v1.AzureManagedIdentity{})
 	assert.Error(t, err)
 }
 
@@ -199,3 +202,40 @@ func TestGetAuthorizationFederatedSPT(t *testing.T) {
 		assert.NoError(t, spt.Refresh(), "Token refresh failed")
 	})
 }
+
+func TestGetHostedZoneNamePrivate(t *testing.T) {
+	const vnetID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/virtualNetworks/vnet"
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateDnsZones/example.com":
+			_, _ = io.WriteString(w, `{"id": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateDnsZones/example.com", "name": "example.com"}`)
+		case "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/privateDnsZones/example.com/virtualNetworkLinks":
+			_, _ = io.WriteString(w, `{"value": [{"name": "link", "properties": {"virtualNetwork": {"id": "`+strings.ToUpper(vnetID)+`"}}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error": {"code": "NotFound"}}`)
+		}
+	}))
+	defer ts.Close()
+
+	newProvider := func(virtualNetworkID string) *DNSProvider {
+		return &DNSProvider{
+			resourceGroupName: "rg",
+			zoneName:          "example.com",
+			log:               logf.Log.WithName("azure-dns"),
+			private:           true,
+			virtualNetworkID:  virtualNetworkID,
+			privateZoneClient: privatedns.NewPrivateZonesClientWithBaseURI(ts.URL, "sub"),
+			linkClient:        privatedns.NewVirtualNetworkLinksClientWithBaseURI(ts.URL, "sub"),
+		}
+	}
+
+	zone, err := newProvider(vnetID).HostedZoneForFQDN("_acme-challenge.example.com.")
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", zone)
+
+	_, err = newProvider(vnetID + "-other").HostedZoneForFQDN("_acme-challenge.example.com.")
+	assert.EqualError(t, err, "Zone example.com in Azure Private DNS is not linked to virtual network "+vnetID+"-other")
+}
@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
@@ -34,10 +35,31 @@ type DNSProvider struct {
 	project          string
 	client           *dns.Service
 	log              logr.Logger
+
+	// zoneVisibility and network select the managed zone if hostedZoneName
+	// is not set.
+	zoneVisibility string
+	network        string
+
+	// hostedZones caches the managed zone chosen for each fqdn, so that the
+	// zone reported by HostedZoneForFQDN is the one that records are changed in.
+	hostedZones map[string]string
+}
+
+// NewDNSProvider returns a new DNSProvider Instance with configuration.
+// If hostedZoneName is not set, a managed zone is chosen according to
+// zoneVisibility and, for private zones, network.
+func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName, zoneVisibility, network string) (*DNSProvider, error) {
+	provider, err := newDNSProvider(project, saBytes, dns01Nameservers, ambient, hostedZoneName)
+	if err != nil {
+		return nil, err
+	}
+	provider.zoneVisibility = zoneVisibility
+	provider.network = network
+	return provider, nil
 }
 
-// NewDNSProvider returns a new DNSProvider Instance with configuration
-func NewDNSProvider(project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*DNSProvider, error) {
+func newDNSProvider(project string, saBytes []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*DNSProvider, error) {
 	// project is a required field
 	if project == "" {
 		return nil, fmt.Errorf("Google Cloud project name missing")
@@ -187,6 +209,12 @@ func (c *DNSProvider) Present(domain, fqdn, value string) error {
 	return nil
 }
 
+// HostedZoneForFQDN returns the name of the managed zone in which the TXT
+// record for the given fqdn is presented.
+func (c *DNSProvider) HostedZoneForFQDN(fqdn string) (string, error) {
+	return c.getHostedZone(fqdn)
+}
+
 // CleanUp removes the TXT record matching the specified parameters.
 func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	zone, err := c.getHostedZone(fqdn)
@@ -217,6 +245,10 @@ func (c *DNSProvider) getHostedZone(domain string) (string, error) {
 		return c.hostedZoneName, nil
 	}
 
+	if zone, ok := c.hostedZones[domain]; ok {
+		return zone, nil
+	}
+
 	authZone, err := util.FindZoneByFqdn(util.ToFqdn(domain), c.dns01Nameservers)
 	if err != nil {
 		return "", err
@@ -234,16 +266,71 @@ func (c *DNSProvider) getHostedZone(domain string) (string, error) {
 		return "", fmt.Errorf("No matching GoogleCloud domain found for domain %s", authZone)
 	}
 
+	zone, err := c.selectManagedZone(authZone, zones.ManagedZones)
+	if err != nil {
+		return "", err
+	}
+
+	c.log.V(logf.InfoLevel).Info("selected managed-zone", "fqdn", domain, "zone", zone.Name, "zoneID", zone.Id, "visibility", zone.Visibility)
+	if c.hostedZones == nil {
+		c.hostedZones = make(map[string]string)
+	}
+	c.hostedZones[domain] = zone.Name
+
+	return zone.Name, nil
+}
+
+// selectManagedZone chooses one of the managed zones for authZone according to
+// the configured zone visibility and network.
+func (c *DNSProvider) selectManagedZone(authZone string, zones []*dns.ManagedZone) (*dns.ManagedZone, error) {
+	switch c.zoneVisibility {
+	case "Public":
+		for _, zone := range zones {
+			if zone.Visibility == "public" {
+				return zone, nil
+			}
+		}
+		return nil, fmt.Errorf("No matching public GoogleCloud managed-zone found for domain %s", authZone)
+	case "Private":
+		for _, zone := range zones {
+			if zone.Visibility == "private" && c.isVisibleToNetwork(zone) {
+				return zone, nil
+			}
+		}
+		if c.network != "" {
+			return nil, fmt.Errorf("No matching private GoogleCloud managed-zone visible to network %s found for domain %s", c.network, authZone)
+		}
+		return nil, fmt.Errorf("No matching private GoogleCloud managed-zone found for domain %s", authZone)
+	}
+
 	// attempt to get the first public zone
-	for _, zone := range zones.ManagedZones {
+	for _, zone := range zones {
 		if zone.Visibility == "public" {
-			return zone.Name, nil
+			return zone, nil
 		}
 	}
 
-	c.log.V(logf.DebugLevel).Info("No matching public GoogleCloud managed-zone for domain, falling back to a private managed-zone", authZone)
+	c.log.V(logf.DebugLevel).Info("No matching public GoogleCloud managed-zone for domain, falling back to a private managed-zone", "domain", authZone)
 	// fall back to first available zone, if none public
-	return zones.ManagedZones[0].Name, nil
+	return zones[0], nil
+}
+
+// isVisibleToNetwork returns true if no network is configured, or if the
+// private zone is visible to the configured network. The network may be given
+// by its URL or by its name.
+func (c *DNSProvider) isVisibleToNetwork(zone *dns.ManagedZone) bool {
+	if c.network == "" {
+		return true
+	}
+	if zone.PrivateVisibilityConfig == nil {
+		return false
+	}
+	for _, network := range zone.PrivateVisibilityConfig.Networks {
+		if network.NetworkUrl == c.network || strings.HasSuffix(network.NetworkUrl, "/networks/"+c.network) {
+			return true
+		}
+	}
+	return false
 }
 
 func (c *DNSProvider) findTxtRecords(zone, fqdn string) ([]*dns.ResourceRecordSet, error) {
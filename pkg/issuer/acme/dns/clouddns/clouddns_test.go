@@ -18,6 +18,7 @@ import (
 	"google.golang.org/api/dns/v1"
 
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -148,3 +149,86 @@ func TestDNSProvider_getHostedZone(t *testing.T) {
 		})
 	}
 }
+
+func TestDNSProvider_selectManagedZone(t *testing.T) {
+	networkURL := "https://www.googleapis.com/compute/v1/projects/my-project/global/networks/my-network"
+	public := &dns.ManagedZone{Name: "public-zone", Visibility: "public"}
+	private := &dns.ManagedZone{Name: "private-zone", Visibility: "private"}
+	privateInNetwork := &dns.ManagedZone{
+		Name:       "private-zone-in-network",
+		Visibility: "private",
+		PrivateVisibilityConfig: &dns.ManagedZonePrivateVisibilityConfig{
+			Networks: []*dns.ManagedZonePrivateVisibilityConfigNetwork{{NetworkUrl: networkURL}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		zoneVisibility string
+		network        string
+		zones          []*dns.ManagedZone
+		want           string
+		wantErr        bool
+	}{
+		{
+			name:  "prefer a public zone by default",
+			zones: []*dns.ManagedZone{private, public},
+			want:  "public-zone",
+		},
+		{
+			name:  "fall back to a private zone by default",
+			zones: []*dns.ManagedZone{private, privateInNetwork},
+			want:  "private-zone",
+		},
+		{
+			name:           "only choose a public zone if visibility is Public",
+			zoneVisibility: "Public",
+			zones:          []*dns.ManagedZone{private},
+			wantErr:        true,
+		},
+		{
+			name:           "choose a private zone if visibility is Private",
+			zoneVisibility: "Private",
+			zones:          []*dns.ManagedZone{public, private},
+			want:           "private-zone",
+		},
+		{
+			name:           "choose the private zone visible to the network given by URL",
+			zoneVisibility: "Private",
+			network:        networkURL,
+			zones:          []*dns.ManagedZone{public, private, privateInNetwork},
+			want:           "private-zone-in-network",
+		},
+		{
+			name:           "choose the private zone visible to the network given by name",
+			zoneVisibility: "Private",
+			network:        "my-network",
+			zones:          []*dns.ManagedZone{public, private, privateInNetwork},
+			want:           "private-zone-in-network",
+		},
+		{
+			name:           "error if no private zone is visible to the network",
+			zoneVisibility: "Private",
+			network:        "other-network",
+			zones:          []*dns.ManagedZone{public, private, privateInNetwork},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &DNSProvider{
+				zoneVisibility: tt.zoneVisibility,
+				network:        tt.network,
+				log:            logf.Log.WithName("clouddns"),
+			}
+			got, err := c.selectManagedZone("example.com.", tt.zones)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("selectManagedZone() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Name != tt.want {
+				t.Errorf("selectManagedZone() got = %v, want %v", got.Name, tt.want)
+			}
+		})
+	}
+}
@@ -39,8 +39,12 @@ type DNSProvider struct {
 	authEmail        string
 	authKey          string
 	authToken        string
+	zoneID           string
 
 	userAgent string
+
+	// apiURL overrides CloudFlareAPIURL, and is only set in tests.
+	apiURL string
 }
 
 // DNSZone is the Zone-Record returned from Cloudflare (we`ll ignore everything we don't need)
@@ -56,12 +60,13 @@ type DNSZone struct {
 func NewDNSProvider(dns01Nameservers []string, userAgent string) (*DNSProvider, error) {
 	email := os.Getenv("CLOUDFLARE_EMAIL")
 	key := os.Getenv("CLOUDFLARE_API_KEY")
-	return NewDNSProviderCredentials(email, key, "", dns01Nameservers, userAgent)
+	return NewDNSProviderCredentials(email, key, "", "", dns01Nameservers, userAgent)
 }
 
 // NewDNSProviderCredentials uses the supplied credentials to return a
-// DNSProvider instance configured for cloudflare.
-func NewDNSProviderCredentials(email, key, token string, dns01Nameservers []string, userAgent string) (*DNSProvider, error) {
+// DNSProvider instance configured for cloudflare. If zoneID is set, records are
+// created in that zone instead of the zone found by FindNearestZoneForFQDN.
+func NewDNSProviderCredentials(email, key, token, zoneID string, dns01Nameservers []string, userAgent string) (*DNSProvider, error) {
 	if (email == "" && key != "") || (key == "" && token == "") {
 		return nil, fmt.Errorf("no Cloudflare credential has been given (can be either an API key or an API token)")
 	}
@@ -86,6 +91,7 @@ func NewDNSProviderCredentials(email, key, token string, dns01Nameservers []stri
 		authEmail:        email,
 		authKey:          key,
 		authToken:        token,
+		zoneID:           zoneID,
 		dns01Nameservers: dns01Nameservers,
 		userAgent:        userAgent,
 	}, nil
@@ -202,7 +208,60 @@ func (c *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	return nil
 }
 
+// VerifyToken checks that the API token is active and allowed to read the
+// zone that records will be created in. It returns nil without any requests
+// when an API key is used instead of a token.
+// Tokens are only allowed to list zones if they are not scoped to specific
+// zones, so a token without this permission can only be used with zoneID.
+func (c *DNSProvider) VerifyToken() error {
+	if c.authToken == "" {
+		return nil
+	}
+
+	result, err := c.makeRequest("GET", "/user/tokens/verify", nil)
+	if err != nil {
+		return err
+	}
+	var token struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(result, &token); err != nil {
+		return err
+	}
+	if token.Status != "active" {
+		return fmt.Errorf("the Cloudflare API token is %s", token.Status)
+	}
+
+	if c.zoneID != "" {
+		if _, err := c.makeRequest("GET", fmt.Sprintf("/zones/%s", c.zoneID), nil); err != nil {
+			return fmt.Errorf("the Cloudflare API token cannot read zone %s: %v", c.zoneID, err)
+		}
+		if _, err := c.makeRequest("GET", fmt.Sprintf("/zones/%s/dns_records?per_page=1&type=TXT", c.zoneID), nil); err != nil {
+			return fmt.Errorf("the Cloudflare API token cannot read DNS records of zone %s: %v", c.zoneID, err)
+		}
+		return nil
+	}
+
+	result, err = c.makeRequest("GET", "/zones?per_page=1", nil)
+	if err != nil {
+		return fmt.Errorf("the Cloudflare API token cannot list zones, set zoneID to use a token scoped to a single zone: %v", err)
+	}
+	var zones []DNSZone
+	if err := json.Unmarshal(result, &zones); err != nil {
+		return err
+	}
+	if len(zones) == 0 {
+		return fmt.Errorf("the Cloudflare API token cannot list any zones, set zoneID to use a token scoped to a single zone")
+	}
+
+	return nil
+}
+
 func (c *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
+	if c.zoneID != "" {
+		return c.zoneID, nil
+	}
+
 	hostedZone, err := FindNearestZoneForFQDN(c, fqdn)
 	if err != nil {
 		return "", err
@@ -257,7 +316,12 @@ func (c *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawM
 		Result  json.RawMessage `json:"result"`
 	}
 
-	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", CloudFlareAPIURL, uri), body)
+	apiURL := CloudFlareAPIURL
+	if c.apiURL != "" {
+		apiURL = c.apiURL
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", apiURL, uri), body)
 	if err != nil {
 		return nil, err
 	}
@@ -275,13 +339,23 @@ func (c *DNSProvider) makeRequest(method, uri string, body io.Reader) (json.RawM
 	client := http.Client{
 		Timeout: 30 * time.Second,
 	}
+
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("while querying the Cloudflare API for %s %q: %v", method, uri, err)
 	}
-
 	defer resp.Body.Close()
 
+	// Rate limited requests are not retried here, so that a worker is not
+	// blocked while waiting. The error causes the challenge to be retried
+	// with backoff.
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			return nil, fmt.Errorf("while querying the Cloudflare API for %s %q: rate limit exceeded, retry after %s seconds", method, uri, retryAfter)
+		}
+		return nil, fmt.Errorf("while querying the Cloudflare API for %s %q: rate limit exceeded", method, uri)
+	}
+
 	var r APIResponse
 	err = json.NewDecoder(resp.Body).Decode(&r)
 	if err != nil {
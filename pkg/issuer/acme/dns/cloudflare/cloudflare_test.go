@@ -58,7 +58,7 @@ func restoreCloudFlareEnv() {
 func TestNewDNSProviderValidAPIKey(t *testing.T) {
 	os.Setenv("CLOUDFLARE_EMAIL", "")
 	os.Setenv("CLOUDFLARE_API_KEY", "")
-	_, err := NewDNSProviderCredentials("123", "123", "", util.RecursiveNameservers, "cert-manager-test")
+	_, err := NewDNSProviderCredentials("123", "123", "", "", util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err)
 	restoreCloudFlareEnv()
 }
@@ -66,7 +66,7 @@ func TestNewDNSProviderValidAPIKey(t *testing.T) {
 func TestNewDNSProviderValidAPIToken(t *testing.T) {
 	os.Setenv("CLOUDFLARE_EMAIL", "")
 	os.Setenv("CLOUDFLARE_API_KEY", "")
-	_, err := NewDNSProviderCredentials("123", "", "123", util.RecursiveNameservers, "cert-manager-test")
+	_, err := NewDNSProviderCredentials("123", "", "123", "", util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err)
 	restoreCloudFlareEnv()
 }
@@ -74,7 +74,7 @@ func TestNewDNSProviderValidAPIToken(t *testing.T) {
 func TestNewDNSProviderKeyAndTokenProvided(t *testing.T) {
 	os.Setenv("CLOUDFLARE_EMAIL", "")
 	os.Setenv("CLOUDFLARE_API_KEY", "")
-	_, err := NewDNSProviderCredentials("123", "123", "123", util.RecursiveNameservers, "cert-manager-test")
+	_, err := NewDNSProviderCredentials("123", "123", "123", "", util.RecursiveNameservers, "cert-manager-test")
 	assert.EqualError(t, err, "the Cloudflare API key and API token cannot be both present simultaneously")
 	restoreCloudFlareEnv()
 }
@@ -136,7 +136,7 @@ func TestCloudFlarePresent(t *testing.T) {
 		t.Skip("skipping live test")
 	}
 
-	provider, err := NewDNSProviderCredentials(cflareEmail, cflareAPIKey, cflareAPIToken, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProviderCredentials(cflareEmail, cflareAPIKey, cflareAPIToken, "", util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err)
 
 	err = provider.Present(cflareDomain, "_acme-challenge."+cflareDomain+".", "123d==")
@@ -150,9 +150,113 @@ func TestCloudFlareCleanUp(t *testing.T) {
 
 	time.Sleep(time.Second * 2)
 
-	provider, err := NewDNSProviderCredentials(cflareEmail, cflareAPIKey, cflareAPIToken, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProviderCredentials(cflareEmail, cflareAPIKey, cflareAPIToken, "", util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err)
 
 	err = provider.CleanUp(cflareDomain, "_acme-challenge."+cflareDomain+".", "123d==")
 	assert.NoError(t, err)
 }
+
+func TestCloudFlarePresentWithZoneID(t *testing.T) {
+	api := &fakeAPI{
+		zones:  map[string]string{"zone-1": "example.com", "zone-2": "example.org"},
+		tokens: map[string]fakeToken{"scoped": {zoneIDs: []string{"zone-1"}}},
+	}
+
+	provider := newFakeAPIProvider(t, api, "scoped", "zone-1")
+
+	fqdn := "_acme-challenge.www.example.com."
+	assert.NoError(t, provider.Present("www.example.com", fqdn, "123d=="))
+	assert.Equal(t, []cloudFlareRecord{{Name: "_acme-challenge.www.example.com", Type: "TXT", Content: "123d==", ID: "record-2", TTL: 120, ZoneID: "zone-1"}}, api.records)
+
+	assert.NoError(t, provider.Present("www.example.com", fqdn, "456d=="))
+	assert.Len(t, api.records, 1)
+	assert.Equal(t, "456d==", api.records[0].Content)
+
+	assert.NoError(t, provider.CleanUp("www.example.com", fqdn, "456d=="))
+	assert.Empty(t, api.records)
+
+	// the zone cannot be looked up with a token scoped to a single zone
+	provider = newFakeAPIProvider(t, api, "scoped", "")
+	err := provider.Present("www.example.com", fqdn, "123d==")
+	assert.ErrorContains(t, err, "9109: Unauthorized to access requested resource")
+}
+
+func TestCloudFlareVerifyToken(t *testing.T) {
+	api := &fakeAPI{
+		zones: map[string]string{"zone-1": "example.com", "zone-2": "example.org"},
+		tokens: map[string]fakeToken{
+			"unscoped": {},
+			"scoped":   {zoneIDs: []string{"zone-1"}},
+			"disabled": {status: "disabled"},
+		},
+	}
+
+	tests := map[string]struct {
+		token  string
+		zoneID string
+		expErr string
+	}{
+		"unscoped token": {
+			token: "unscoped",
+		},
+		"unscoped token with zone ID": {
+			token:  "unscoped",
+			zoneID: "zone-2",
+		},
+		"scoped token with zone ID": {
+			token:  "scoped",
+			zoneID: "zone-1",
+		},
+		"scoped token without zone ID": {
+			token:  "scoped",
+			expErr: "the Cloudflare API token cannot list zones, set zoneID to use a token scoped to a single zone",
+		},
+		"scoped token with a zone ID outside of its scope": {
+			token:  "scoped",
+			zoneID: "zone-2",
+			expErr: "the Cloudflare API token cannot read zone zone-2",
+		},
+		"disabled token": {
+			token:  "disabled",
+			expErr: "the Cloudflare API token is disabled",
+		},
+		"unknown token": {
+			token:  "unknown",
+			expErr: "6003: Invalid request headers",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := newFakeAPIProvider(t, api, test.token, test.zoneID).VerifyToken()
+			if test.expErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expErr)
+			}
+		})
+	}
+
+	t.Run("API keys are not verified", func(t *testing.T) {
+		provider, err := NewDNSProviderCredentials("test@example.com", "key", "", "", nil, "cert-manager-test")
+		assert.NoError(t, err)
+		provider.apiURL = "http://localhost:0"
+		assert.NoError(t, provider.VerifyToken())
+	})
+}
+
+func TestCloudFlareRateLimit(t *testing.T) {
+	api := &fakeAPI{
+		zones:       map[string]string{"zone-1": "example.com"},
+		tokens:      map[string]fakeToken{"token": {}},
+		rateLimited: 1,
+	}
+	provider := newFakeAPIProvider(t, api, "token", "")
+
+	err := provider.VerifyToken()
+	assert.EqualError(t, err, `while querying the Cloudflare API for GET "/user/tokens/verify": rate limit exceeded, retry after 0 seconds`)
+	assert.Equal(t, 1, api.requests)
+
+	assert.NoError(t, provider.VerifyToken())
+}
@@ -0,0 +1,174 @@
+// +skip_license_check
+
+/*
+This file contains portions of code directly taken from the 'xenolf/lego' project.
+A copy of the license for this code can be found in the file named LICENSE in
+this directory.
+*/
+
+package cloudflare
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeToken describes the permissions of an API token known to fakeAPI.
+type fakeToken struct {
+	// status is returned by /user/tokens/verify, and is "active" if empty.
+	status string
+	// zoneIDs are the zones the token is scoped to. A token without zones
+	// may access all zones.
+	zoneIDs []string
+}
+
+// fakeAPI is a minimal stand-in for the Cloudflare API, which serves the
+// endpoints used by DNSProvider. Like the real API, tokens scoped to specific
+// zones may not list zones.
+type fakeAPI struct {
+	// zones maps zone IDs to zone names.
+	zones map[string]string
+	// tokens maps API tokens to their permissions.
+	tokens map[string]fakeToken
+	// rateLimited is the number of following requests that are answered with
+	// 429 Too Many Requests.
+	rateLimited int
+
+	lock     sync.Mutex
+	records  []cloudFlareRecord
+	requests int
+}
+
+func newFakeAPIProvider(t *testing.T, api *fakeAPI, token, zoneID string) *DNSProvider {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	provider, err := NewDNSProviderCredentials("", "", token, zoneID, nil, "cert-manager-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	provider.apiURL = server.URL
+	return provider
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	f.requests++
+	if f.rateLimited > 0 {
+		f.rateLimited--
+		w.Header().Set("Retry-After", "0")
+		writeFakeError(w, http.StatusTooManyRequests, 971, "Please wait and consider throttling your request speed")
+		return
+	}
+
+	token, ok := f.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	if !ok {
+		writeFakeError(w, http.StatusBadRequest, 6003, "Invalid request headers")
+		return
+	}
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.URL.Path == "/user/tokens/verify":
+		status := token.status
+		if status == "" {
+			status = "active"
+		}
+		writeFakeResult(w, map[string]string{"id": "token", "status": status})
+	case r.URL.Path == "/zones":
+		if len(token.zoneIDs) > 0 {
+			writeFakeError(w, http.StatusForbidden, 9109, "Unauthorized to access requested resource")
+			return
+		}
+		zones := []DNSZone{}
+		for id, name := range f.zones {
+			if q := r.URL.Query().Get("name"); q == "" || q == name {
+				zones = append(zones, DNSZone{ID: id, Name: name})
+			}
+		}
+		writeFakeResult(w, zones)
+	case len(path) >= 2 && path[0] == "zones":
+		zoneID := path[1]
+		if _, ok := f.zones[zoneID]; !ok || !token.canAccess(zoneID) {
+			writeFakeError(w, http.StatusForbidden, 9109, "Unauthorized to access requested resource")
+			return
+		}
+		f.serveZone(w, r, zoneID, path[2:])
+	default:
+		writeFakeError(w, http.StatusNotFound, 7003, "Could not route to "+r.URL.Path)
+	}
+}
+
+func (f *fakeAPI) serveZone(w http.ResponseWriter, r *http.Request, zoneID string, path []string) {
+	switch {
+	case len(path) == 0 && r.Method == http.MethodGet:
+		writeFakeResult(w, DNSZone{ID: zoneID, Name: f.zones[zoneID]})
+	case len(path) == 1 && path[0] == "dns_records" && r.Method == http.MethodGet:
+		records := []cloudFlareRecord{}
+		for _, rec := range f.records {
+			if rec.ZoneID == zoneID && (r.URL.Query().Get("name") == "" || r.URL.Query().Get("name") == rec.Name) {
+				records = append(records, rec)
+			}
+		}
+		writeFakeResult(w, records)
+	case len(path) == 1 && path[0] == "dns_records" && r.Method == http.MethodPost:
+		var rec cloudFlareRecord
+		if err := json.NewDecoder(r.Body).Decode(&rec); err != nil {
+			writeFakeError(w, http.StatusBadRequest, 1004, err.Error())
+			return
+		}
+		rec.ID = fmt.Sprintf("record-%d", f.requests)
+		rec.ZoneID = zoneID
+		f.records = append(f.records, rec)
+		writeFakeResult(w, rec)
+	case len(path) == 2 && path[0] == "dns_records" && r.Method == http.MethodDelete:
+		for i, rec := range f.records {
+			if rec.ZoneID == zoneID && rec.ID == path[1] {
+				f.records = append(f.records[:i], f.records[i+1:]...)
+				writeFakeResult(w, map[string]string{"id": rec.ID})
+				return
+			}
+		}
+		writeFakeError(w, http.StatusNotFound, 81044, "Record does not exist.")
+	default:
+		writeFakeError(w, http.StatusNotFound, 7003, "Could not route to "+r.URL.Path)
+	}
+}
+
+func (t fakeToken) canAccess(zoneID string) bool {
+	if len(t.zoneIDs) == 0 {
+		return true
+	}
+	for _, id := range t.zoneIDs {
+		if id == zoneID {
+			return true
+		}
+	}
+	return false
+}
+
+func writeFakeResult(w http.ResponseWriter, result interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": true,
+		"errors":  []interface{}{},
+		"result":  result,
+	})
+}
+
+func writeFakeError(w http.ResponseWriter, status, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"success": false,
+		"errors":  []map[string]interface{}{{"code": code, "message": message}},
+		"result":  nil,
+	})
+}
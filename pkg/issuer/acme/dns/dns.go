@@ -24,9 +24,11 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	corev1listers "k8s.io/client-go/listers/core/v1"
 
+	"github.com/cert-manager/cert-manager/internal/credentials"
 	"github.com/cert-manager/cert-manager/pkg/acme/webhook"
 	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
@@ -53,14 +55,23 @@ type solver interface {
 	CleanUp(domain, fqdn, value string) error
 }
 
+// zoneReporter is implemented by solvers which look up the zone that the
+// challenge record is presented in, so that the chosen zone can be recorded
+// on the Challenge.
+type zoneReporter interface {
+	HostedZoneForFQDN(fqdn string) (string, error)
+}
+
+const reasonZoneSelected = "ZoneSelected"
+
 // dnsProviderConstructors defines how each provider may be constructed.
 // It is useful for mocking out a given provider since an alternate set of
 // constructors may be set.
 type dnsProviderConstructors struct {
-	cloudDNS     func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName string) (*clouddns.DNSProvider, error)
-	cloudFlare   func(email, apikey, apiToken string, dns01Nameservers []string, userAgent string) (*cloudflare.DNSProvider, error)
-	route53      func(accessKey, secretKey, hostedZoneID, region, role string, ambient bool, dns01Nameservers []string, userAgent string) (*route53.DNSProvider, error)
-	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*azuredns.DNSProvider, error)
+	cloudDNS     func(project string, serviceAccount []byte, dns01Nameservers []string, ambient bool, hostedZoneName, zoneVisibility, network string) (*clouddns.DNSProvider, error)
+	cloudFlare   func(email, apikey, apiToken, zoneID string, dns01Nameservers []string, userAgent string) (*cloudflare.DNSProvider, error)
+	route53      func(accessKey, secretKey, hostedZoneID, region, role, zoneVisibility, vpcID string, ambient bool, dns01Nameservers []string, userAgent string) (*route53.DNSProvider, error)
+	azureDNS     func(environment, clientID, clientSecret, subscriptionID, tenantID, resourceGroupName, hostedZoneName, zoneVisibility, virtualNetworkID string, dns01Nameservers []string, ambient bool, managedIdentity *cmacme.AzureManagedIdentity) (*azuredns.DNSProvider, error)
 	acmeDNS      func(host string, accountJson []byte, dns01Nameservers []string) (*acmedns.DNSProvider, error)
 	digitalOcean func(token string, dns01Nameservers []string) (*digitalocean.DNSProvider, error)
 }
@@ -99,6 +110,20 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 		return err
 	}
 
+	if zr, ok := slv.(zoneReporter); ok {
+		zone, err := zr.HostedZoneForFQDN(fqdn)
+		if err != nil {
+			return err
+		}
+		log.V(logf.InfoLevel).Info("presenting DNS01 challenge record in zone", "fqdn", fqdn, "zone", zone)
+		// The selected zone is recorded in the status of the Challenge, which
+		// is persisted by the challenges controller.
+		if ch.Status.SelectedZone != zone {
+			ch.Status.SelectedZone = zone
+			s.Recorder.Eventf(ch, corev1.EventTypeNormal, reasonZoneSelected, "Presenting DNS01 challenge record %s in zone %s", fqdn, zone)
+		}
+	}
+
 	log.V(logf.DebugLevel).Info("presenting DNS01 challenge for domain")
 
 	return slv.Present(ch.Spec.DNSName, fqdn, ch.Spec.Key)
@@ -229,14 +254,17 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			}
 
 			saKey := providerConfig.CloudDNS.ServiceAccount.Key
-			keyData = saSecret.Data[saKey]
+			keyData, _, err = credentials.Value(saSecret, saKey)
+			if err != nil {
+				return nil, nil, err
+			}
 			if len(keyData) == 0 {
 				return nil, nil, fmt.Errorf("specified key %q not found in secret %s/%s", saKey, saSecret.Namespace, saSecret.Name)
 			}
 		}
 
 		// attempt to construct the cloud dns provider
-		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName)
+		impl, err = s.dnsProviderConstructors.cloudDNS(providerConfig.CloudDNS.Project, keyData, s.DNS01Nameservers, s.CanUseAmbientCredentials(issuer), providerConfig.CloudDNS.HostedZoneName, string(providerConfig.CloudDNS.ZoneVisibility), providerConfig.CloudDNS.Network)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating google clouddns challenge solver: %s", err)
 		}
@@ -260,7 +288,10 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			return nil, nil, fmt.Errorf("error getting cloudflare secret: %s", err)
 		}
 
-		keyData, ok := saSecret.Data[saSecretKey]
+		keyData, ok, err := credentials.Value(saSecret, saSecretKey)
+		if err != nil {
+			return nil, nil, err
+		}
 		if !ok {
 			return nil, nil, fmt.Errorf("specified key %q not found in secret %s/%s", saSecretKey, saSecret.Namespace, saSecret.Name)
 		}
@@ -273,7 +304,7 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 		}
 
 		email := providerConfig.Cloudflare.Email
-		impl, err = s.dnsProviderConstructors.cloudFlare(email, apiKey, apiToken, s.DNS01Nameservers, s.RESTConfig.UserAgent)
+		impl, err = s.dnsProviderConstructors.cloudFlare(email, apiKey, apiToken, providerConfig.Cloudflare.ZoneID, s.DNS01Nameservers, s.RESTConfig.UserAgent)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error instantiating cloudflare challenge solver: %s", err)
 		}
@@ -284,7 +315,11 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			return nil, nil, fmt.Errorf("error getting digitalocean token: %s", err)
 		}
 
-		apiToken := string(apiTokenSecret.Data[providerConfig.DigitalOcean.Token.Key])
+		apiTokenBytes, _, err := credentials.Value(apiTokenSecret, providerConfig.DigitalOcean.Token.Key)
+		if err != nil {
+			return nil, nil, err
+		}
+		apiToken := string(apiTokenBytes)
 
 		impl, err = s.dnsProviderConstructors.digitalOcean(strings.TrimSpace(apiToken), s.DNS01Nameservers)
 		if err != nil {
@@ -320,7 +355,10 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 				return nil, nil, fmt.Errorf("error getting route53 secret access key id: %s", err)
 			}
 
-			secretAccessKeyIDBytes, ok := secretAccessKeyIDSecret.Data[providerConfig.Route53.SecretAccessKeyID.Key]
+			secretAccessKeyIDBytes, ok, err := credentials.Value(secretAccessKeyIDSecret, providerConfig.Route53.SecretAccessKeyID.Key)
+			if err != nil {
+				return nil, nil, err
+			}
 			if !ok {
 				return nil, nil, fmt.Errorf("no data found in Secret %q at Key %q",
 					providerConfig.Route53.SecretAccessKeyID.Name,
@@ -337,7 +375,10 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 				return nil, nil, fmt.Errorf("error getting route53 secret access key: %s", err)
 			}
 
-			secretAccessKeyBytes, ok := secretAccessKeySecret.Data[providerConfig.Route53.SecretAccessKey.Key]
+			secretAccessKeyBytes, ok, err := credentials.Value(secretAccessKeySecret, providerConfig.Route53.SecretAccessKey.Key)
+			if err != nil {
+				return nil, nil, err
+			}
 			if !ok {
 				return nil, nil, fmt.Errorf("error getting route53 secret access key: key '%s' not found in secret", providerConfig.Route53.SecretAccessKey.Key)
 			}
@@ -350,6 +391,8 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			providerConfig.Route53.HostedZoneID,
 			providerConfig.Route53.Region,
 			providerConfig.Route53.Role,
+			string(providerConfig.Route53.ZoneVisibility),
+			providerConfig.Route53.VPCID,
 			canUseAmbientCredentials,
 			s.DNS01Nameservers,
 			s.RESTConfig.UserAgent,
@@ -368,7 +411,10 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 				return nil, nil, fmt.Errorf("error getting azuredns client secret: %s", err)
 			}
 
-			clientSecretBytes, ok := clientSecret.Data[providerConfig.AzureDNS.ClientSecret.Key]
+			clientSecretBytes, ok, err := credentials.Value(clientSecret, providerConfig.AzureDNS.ClientSecret.Key)
+			if err != nil {
+				return nil, nil, err
+			}
 			if !ok {
 				return nil, nil, fmt.Errorf("error getting azure dns client secret: key '%s' not found in secret", providerConfig.AzureDNS.ClientSecret.Key)
 			}
@@ -382,6 +428,8 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			providerConfig.AzureDNS.TenantID,
 			providerConfig.AzureDNS.ResourceGroupName,
 			providerConfig.AzureDNS.HostedZoneName,
+			string(providerConfig.AzureDNS.ZoneVisibility),
+			providerConfig.AzureDNS.VirtualNetworkID,
 			s.DNS01Nameservers,
 			canUseAmbientCredentials,
 			providerConfig.AzureDNS.ManagedIdentity,
@@ -396,7 +444,10 @@ func (s *Solver) solverForChallenge(ctx context.Context, issuer v1.GenericIssuer
 			return nil, nil, fmt.Errorf("error getting acmedns accounts secret: %s", err)
 		}
 
-		accountSecretBytes, ok := accountSecret.Data[providerConfig.AcmeDNS.AccountSecret.Key]
+		accountSecretBytes, ok, err := credentials.Value(accountSecret, providerConfig.AcmeDNS.AccountSecret.Key)
+		if err != nil {
+			return nil, nil, err
+		}
 		if !ok {
 			return nil, nil, fmt.Errorf("error getting acmedns accounts secret: key '%s' not found in secret", providerConfig.AcmeDNS.AccountSecret.Key)
 		}
@@ -530,7 +581,11 @@ func (s *Solver) loadSecretData(selector *cmmeta.SecretKeySelector, ns string) (
 		return nil, errors.Wrapf(err, "failed to load secret %q", ns+"/"+selector.Name)
 	}
 
-	if data, ok := secret.Data[selector.Key]; ok {
+	data, ok, err := credentials.Value(secret, selector.Key)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
 		return data, nil
 	}
 
@@ -395,7 +395,7 @@ func TestRoute53TrimCreds(t *testing.T) {
 	expectedR53Call := []fakeDNSProviderCall{
 		{
 			name: "route53",
-			args: []interface{}{"test_with_spaces", "AKIENDINNEWLINE", "", "us-west-2", "", false, util.RecursiveNameservers},
+			args: []interface{}{"test_with_spaces", "AKIENDINNEWLINE", "", "us-west-2", "", "", "", false, util.RecursiveNameservers},
 		},
 	}
 
@@ -453,7 +453,61 @@ func TestRoute53SecretAccessKey(t *testing.T) {
 	expectedR53Call := []fakeDNSProviderCall{
 		{
 			name: "route53",
-			args: []interface{}{"AWSACCESSKEYID", "AKIENDINNEWLINE", "", "us-west-2", "", false, util.RecursiveNameservers},
+			args: []interface{}{"AWSACCESSKEYID", "AKIENDINNEWLINE", "", "us-west-2", "", "", "", false, util.RecursiveNameservers},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedR53Call, f.dnsProviders.calls) {
+		t.Fatalf("expected %+v == %+v", expectedR53Call, f.dnsProviders.calls)
+	}
+}
+
+func TestRoute53PrivateZone(t *testing.T) {
+	f := &solverFixture{
+		Builder: &test.Builder{
+			KubeObjects: []runtime.Object{
+				newSecret("route53", "default", map[string][]byte{
+					"secret": []byte("AKIENDINNEWLINE"),
+				}),
+			},
+		},
+		Issuer: newIssuer("test", "default"),
+		Challenge: &cmacme.Challenge{
+			Spec: cmacme.ChallengeSpec{
+				Solver: cmacme.ACMEChallengeSolver{
+					DNS01: &cmacme.ACMEChallengeSolverDNS01{
+						Route53: &cmacme.ACMEIssuerDNS01ProviderRoute53{
+							AccessKeyID: "AWSACCESSKEYID",
+							Region:      "us-west-2",
+							SecretAccessKey: cmmeta.SecretKeySelector{
+								LocalObjectReference: cmmeta.LocalObjectReference{
+									Name: "route53",
+								},
+								Key: "secret",
+							},
+							ZoneVisibility: cmacme.DNSZoneVisibilityPrivate,
+							VPCID:          "vpc-11111111",
+						},
+					},
+				},
+			},
+		},
+		dnsProviders: newFakeDNSProviders(),
+	}
+
+	f.Setup(t)
+	defer f.Finish(t)
+
+	s := f.Solver
+	_, _, err := s.solverForChallenge(context.Background(), f.Issuer, f.Challenge)
+	if err != nil {
+		t.Fatalf("expected solverFor to not error, but got: %s", err)
+	}
+
+	expectedR53Call := []fakeDNSProviderCall{
+		{
+			name: "route53",
+			args: []interface{}{"AWSACCESSKEYID", "AKIENDINNEWLINE", "", "us-west-2", "", "Private", "vpc-11111111", false, util.RecursiveNameservers},
 		},
 	}
 
@@ -501,7 +555,7 @@ func TestRoute53AmbientCreds(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "", true, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "", "", "", true, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -534,7 +588,7 @@ func TestRoute53AmbientCreds(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "", false, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "", "", "", false, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -598,7 +652,7 @@ func TestRoute53AssumeRole(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "my-role", true, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "my-role", "", "", true, util.RecursiveNameservers},
 				},
 			},
 		},
@@ -632,7 +686,7 @@ func TestRoute53AssumeRole(t *testing.T) {
 			result{
 				expectedCall: &fakeDNSProviderCall{
 					name: "route53",
-					args: []interface{}{"", "", "", "us-west-2", "my-other-role", false, util.RecursiveNameservers},
+					args: []interface{}{"", "", "", "us-west-2", "my-other-role", "", "", false, util.RecursiveNameservers},
 				},
 			},
 		},
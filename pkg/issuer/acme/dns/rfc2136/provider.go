@@ -27,6 +27,7 @@ import (
 	corelisters "k8s.io/client-go/listers/core/v1"
 	restclient "k8s.io/client-go/rest"
 
+	"github.com/cert-manager/cert-manager/internal/credentials"
 	whapi "github.com/cert-manager/cert-manager/pkg/acme/webhook/apis/acme/v1alpha1"
 	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
@@ -163,7 +164,11 @@ func loadSecretKeySelector(l corelisters.SecretNamespaceLister, sks cmmeta.Secre
 	if err != nil {
 		return nil, err
 	}
-	if d, ok := secret.Data[key]; ok {
+	d, ok, err := credentials.Value(secret, key)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
 		return d, nil
 	}
 	return nil, fmt.Errorf("data entry with key %q not found in secret", key)
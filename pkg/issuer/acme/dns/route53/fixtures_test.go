@@ -75,3 +75,83 @@ var ChangeResourceRecordSets403Response = `<?xml version="1.0"?>
   </Error>
   <RequestId>SOMEREQUESTID</RequestId>
 </ErrorResponse>`
+
+var ListHostedZonesByNameSplitHorizonResponse = `<?xml version="1.0" encoding="UTF-8"?>
+<ListHostedZonesByNameResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+   <HostedZones>
+      <HostedZone>
+         <Id>/hostedzone/PRIVATE1</Id>
+         <Name>example.com.</Name>
+         <CallerReference>D2224C5B-684A-DB4A-BB9A-E09E3BAFEA7A</CallerReference>
+         <Config>
+            <Comment>Test comment</Comment>
+            <PrivateZone>true</PrivateZone>
+         </Config>
+         <ResourceRecordSetCount>10</ResourceRecordSetCount>
+      </HostedZone>
+      <HostedZone>
+         <Id>/hostedzone/ABCDEFG</Id>
+         <Name>example.com.</Name>
+         <CallerReference>D2224C5B-684A-DB4A-BB9A-E09E3BAFEA7A</CallerReference>
+         <Config>
+            <Comment>Test comment</Comment>
+            <PrivateZone>false</PrivateZone>
+         </Config>
+         <ResourceRecordSetCount>10</ResourceRecordSetCount>
+      </HostedZone>
+      <HostedZone>
+         <Id>/hostedzone/PRIVATE2</Id>
+         <Name>example.com.</Name>
+         <CallerReference>D2224C5B-684A-DB4A-BB9A-E09E3BAFEA7A</CallerReference>
+         <Config>
+            <Comment>Test comment</Comment>
+            <PrivateZone>true</PrivateZone>
+         </Config>
+         <ResourceRecordSetCount>10</ResourceRecordSetCount>
+      </HostedZone>
+   </HostedZones>
+   <IsTruncated>false</IsTruncated>
+   <MaxItems>100</MaxItems>
+</ListHostedZonesByNameResponse>`
+
+var GetHostedZonePrivate1Response = `<?xml version="1.0" encoding="UTF-8"?>
+<GetHostedZoneResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+   <HostedZone>
+      <Id>/hostedzone/PRIVATE1</Id>
+      <Name>example.com.</Name>
+      <CallerReference>D2224C5B-684A-DB4A-BB9A-E09E3BAFEA7A</CallerReference>
+      <Config>
+         <PrivateZone>true</PrivateZone>
+      </Config>
+      <ResourceRecordSetCount>10</ResourceRecordSetCount>
+   </HostedZone>
+   <VPCs>
+      <VPC>
+         <VPCRegion>us-east-1</VPCRegion>
+         <VPCId>vpc-11111111</VPCId>
+      </VPC>
+   </VPCs>
+</GetHostedZoneResponse>`
+
+var GetHostedZonePrivate2Response = `<?xml version="1.0" encoding="UTF-8"?>
+<GetHostedZoneResponse xmlns="https://route53.amazonaws.com/doc/2013-04-01/">
+   <HostedZone>
+      <Id>/hostedzone/PRIVATE2</Id>
+      <Name>example.com.</Name>
+      <CallerReference>D2224C5B-684A-DB4A-BB9A-E09E3BAFEA7A</CallerReference>
+      <Config>
+         <PrivateZone>true</PrivateZone>
+      </Config>
+      <ResourceRecordSetCount>10</ResourceRecordSetCount>
+   </HostedZone>
+   <VPCs>
+      <VPC>
+         <VPCRegion>us-east-1</VPCRegion>
+         <VPCId>vpc-22222222</VPCId>
+      </VPC>
+      <VPC>
+         <VPCRegion>us-east-1</VPCRegion>
+         <VPCId>vpc-33333333</VPCId>
+      </VPC>
+   </VPCs>
+</GetHostedZoneResponse>`
@@ -27,6 +27,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/acme/dns/util"
 )
 
@@ -39,8 +40,18 @@ type DNSProvider struct {
 	dns01Nameservers []string
 	client           *route53.Route53
 	hostedZoneID     string
+	zoneVisibility   string
+	vpcID            string
 	log              logr.Logger
 
+	// hostedZoneIDs caches the hosted zone chosen for each fqdn, so that the
+	// zone reported by HostedZoneForFQDN is the one that records are changed in.
+	hostedZoneIDs map[string]string
+
+	// findZoneByFqdn looks up the authoritative zone of an fqdn. It can be
+	// stubbed in unit tests.
+	findZoneByFqdn func(fqdn string, nameservers []string) (string, error)
+
 	userAgent string
 }
 
@@ -144,7 +155,11 @@ func defaultSTSProvider(sess *session.Session) stsiface.STSAPI {
 // NewDNSProvider returns a DNSProvider instance configured for the AWS
 // Route 53 service using static credentials from its parameters or, if they're
 // unset and the 'ambient' option is set, credentials from the environment.
+// If hostedZoneID is unset, the hosted zone is looked up by name, choosing a
+// public or private zone according to zoneVisibility and, for private zones,
+// only zones associated with vpcID if it is set.
 func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role string,
+	zoneVisibility, vpcID string,
 	ambient bool,
 	dns01Nameservers []string,
 	userAgent string,
@@ -164,8 +179,11 @@ func NewDNSProvider(accessKeyID, secretAccessKey, hostedZoneID, region, role str
 	return &DNSProvider{
 		client:           client,
 		hostedZoneID:     hostedZoneID,
+		zoneVisibility:   zoneVisibility,
+		vpcID:            vpcID,
 		dns01Nameservers: dns01Nameservers,
 		log:              logf.Log.WithName("route53"),
+		findZoneByFqdn:   util.FindZoneByFqdn,
 		userAgent:        userAgent,
 	}, nil
 }
@@ -182,6 +200,12 @@ func (r *DNSProvider) CleanUp(domain, fqdn, value string) error {
 	return r.changeRecord(route53.ChangeActionDelete, fqdn, value, route53TTL)
 }
 
+// HostedZoneForFQDN returns the ID of the hosted zone in which the TXT record
+// for the given fqdn is presented.
+func (r *DNSProvider) HostedZoneForFQDN(fqdn string) (string, error) {
+	return r.getHostedZoneID(fqdn)
+}
+
 func (r *DNSProvider) changeRecord(action, fqdn, value string, ttl int) error {
 	hostedZoneID, err := r.getHostedZoneID(fqdn)
 	if err != nil {
@@ -238,7 +262,11 @@ func (r *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 		return r.hostedZoneID, nil
 	}
 
-	authZone, err := util.FindZoneByFqdn(fqdn, r.dns01Nameservers)
+	if hostedZoneID, ok := r.hostedZoneIDs[fqdn]; ok {
+		return hostedZoneID, nil
+	}
+
+	authZone, err := r.findZoneByFqdn(fqdn, r.dns01Nameservers)
 	if err != nil {
 		return "", fmt.Errorf("error finding zone from fqdn: %v", err)
 	}
@@ -252,33 +280,82 @@ func (r *DNSProvider) getHostedZoneID(fqdn string) (string, error) {
 		return "", removeReqID(err)
 	}
 
+	private := r.zoneVisibility == string(cmacme.DNSZoneVisibilityPrivate)
 	zoneToID := make(map[string]string)
 	var hostedZones []string
 	for _, hostedZone := range resp.HostedZones {
+		if hostedZone.Config == nil || aws.BoolValue(hostedZone.Config.PrivateZone) != private {
+			continue
+		}
+
 		// .Name has a trailing dot
-		if !*hostedZone.Config.PrivateZone {
-			zoneToID[*hostedZone.Name] = *hostedZone.Id
-			hostedZones = append(hostedZones, *hostedZone.Name)
+		if _, err := util.FindBestMatch(fqdn, *hostedZone.Name); err != nil {
+			continue
 		}
+
+		if private && r.vpcID != "" {
+			associated, err := r.isAssociatedWithVPC(*hostedZone.Id)
+			if err != nil {
+				return "", err
+			}
+			if !associated {
+				continue
+			}
+		}
+
+		zoneToID[*hostedZone.Name] = *hostedZone.Id
+		hostedZones = append(hostedZones, *hostedZone.Name)
 	}
-	authZone, err = util.FindBestMatch(fqdn, hostedZones...)
+	hostedZone, err := util.FindBestMatch(fqdn, hostedZones...)
 	if err != nil {
-		return "", fmt.Errorf("zone %s not found in Route 53 for domain %s", authZone, fqdn)
+		return "", fmt.Errorf("%s zone %s not found in Route 53 for domain %s", r.visibility(), authZone, fqdn)
 	}
 
-	hostedZoneID, ok := zoneToID[authZone]
+	hostedZoneID, ok := zoneToID[hostedZone]
 
 	if len(hostedZoneID) == 0 || !ok {
-		return "", fmt.Errorf("zone %s not found in Route 53 for domain %s", authZone, fqdn)
+		return "", fmt.Errorf("%s zone %s not found in Route 53 for domain %s", r.visibility(), authZone, fqdn)
 	}
 
 	if strings.HasPrefix(hostedZoneID, "/hostedzone/") {
 		hostedZoneID = strings.TrimPrefix(hostedZoneID, "/hostedzone/")
 	}
 
+	r.log.V(logf.InfoLevel).Info("selected hosted zone", "fqdn", fqdn, "zone", hostedZone, "hostedZoneID", hostedZoneID, "private", private)
+	if r.hostedZoneIDs == nil {
+		r.hostedZoneIDs = make(map[string]string)
+	}
+	r.hostedZoneIDs[fqdn] = hostedZoneID
+
 	return hostedZoneID, nil
 }
 
+// isAssociatedWithVPC returns true if the private hosted zone with the given ID
+// is associated with the VPC configured on the provider.
+func (r *DNSProvider) isAssociatedWithVPC(hostedZoneID string) (bool, error) {
+	resp, err := r.client.GetHostedZone(&route53.GetHostedZoneInput{
+		Id: aws.String(hostedZoneID),
+	})
+	if err != nil {
+		return false, removeReqID(err)
+	}
+
+	for _, vpc := range resp.VPCs {
+		if aws.StringValue(vpc.VPCId) == r.vpcID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (r *DNSProvider) visibility() string {
+	if r.zoneVisibility == string(cmacme.DNSZoneVisibilityPrivate) {
+		return "private"
+	}
+	return "public"
+}
+
 func newTXTRecordSet(fqdn, value string, ttl int) *route53.ResourceRecordSet {
 	return &route53.ResourceRecordSet{
 		Name:             aws.String(fqdn),
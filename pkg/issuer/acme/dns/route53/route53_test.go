@@ -61,7 +61,7 @@ func makeRoute53Provider(ts *httptest.Server) (*DNSProvider, error) {
 		return nil, err
 	}
 	client := route53.New(sess)
-	return &DNSProvider{client: client, dns01Nameservers: util.RecursiveNameservers}, nil
+	return &DNSProvider{client: client, dns01Nameservers: util.RecursiveNameservers, findZoneByFqdn: util.FindZoneByFqdn, log: logf.Log.WithName("route53")}, nil
 }
 
 func TestAmbientCredentialsFromEnv(t *testing.T) {
@@ -70,7 +70,7 @@ func TestAmbientCredentialsFromEnv(t *testing.T) {
 	os.Setenv("AWS_REGION", "us-east-1")
 	defer restoreRoute53Env()
 
-	provider, err := NewDNSProvider("", "", "", "", "", true, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProvider("", "", "", "", "", "", "", true, util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 
 	_, err = provider.client.Config.Credentials.Get()
@@ -84,7 +84,7 @@ func TestNoCredentialsFromEnv(t *testing.T) {
 	os.Setenv("AWS_REGION", "us-east-1")
 	defer restoreRoute53Env()
 
-	_, err := NewDNSProvider("", "", "", "", "", false, util.RecursiveNameservers, "cert-manager-test")
+	_, err := NewDNSProvider("", "", "", "", "", "", "", false, util.RecursiveNameservers, "cert-manager-test")
 	assert.Error(t, err, "Expected error constructing DNSProvider with no credentials and not ambient")
 }
 
@@ -92,7 +92,7 @@ func TestAmbientRegionFromEnv(t *testing.T) {
 	os.Setenv("AWS_REGION", "us-east-1")
 	defer restoreRoute53Env()
 
-	provider, err := NewDNSProvider("", "", "", "", "", true, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProvider("", "", "", "", "", "", "", true, util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 
 	assert.Equal(t, "us-east-1", *provider.client.Config.Region, "Expected Region to be set from environment")
@@ -102,7 +102,7 @@ func TestNoRegionFromEnv(t *testing.T) {
 	os.Setenv("AWS_REGION", "us-east-1")
 	defer restoreRoute53Env()
 
-	provider, err := NewDNSProvider("marx", "swordfish", "", "", "", false, util.RecursiveNameservers, "cert-manager-test")
+	provider, err := NewDNSProvider("marx", "swordfish", "", "", "", "", "", false, util.RecursiveNameservers, "cert-manager-test")
 	assert.NoError(t, err, "Expected no error constructing DNSProvider")
 
 	assert.Equal(t, "", *provider.client.Config.Region, "Expected Region to not be set from environment")
@@ -149,6 +149,71 @@ func TestRoute53Present(t *testing.T) {
 	assert.Equal(t, `failed to change Route 53 record set: AccessDenied: User: arn:aws:iam::0123456789:user/test-cert-manager is not authorized to perform: route53:ChangeResourceRecordSets on resource: arn:aws:route53:::hostedzone/OPQRSTU`, err.Error())
 }
 
+func TestRoute53HostedZoneVisibility(t *testing.T) {
+	mockResponses := MockResponseMap{
+		"/2013-04-01/hostedzonesbyname":   MockResponse{StatusCode: 200, Body: ListHostedZonesByNameSplitHorizonResponse},
+		"/2013-04-01/hostedzone/PRIVATE1": MockResponse{StatusCode: 200, Body: GetHostedZonePrivate1Response},
+		"/2013-04-01/hostedzone/PRIVATE2": MockResponse{StatusCode: 200, Body: GetHostedZonePrivate2Response},
+	}
+
+	ts := newMockServer(t, mockResponses)
+	defer ts.Close()
+
+	tests := map[string]struct {
+		zoneVisibility string
+		vpcID          string
+		expID          string
+		expErr         string
+	}{
+		"public zone is chosen by default": {
+			expID: "ABCDEFG",
+		},
+		"public zone is chosen if visibility is Public": {
+			zoneVisibility: "Public",
+			expID:          "ABCDEFG",
+		},
+		"a private zone is chosen if visibility is Private": {
+			zoneVisibility: "Private",
+			expID:          "PRIVATE2",
+		},
+		"private zone associated with the VPC is chosen": {
+			zoneVisibility: "Private",
+			vpcID:          "vpc-11111111",
+			expID:          "PRIVATE1",
+		},
+		"private zone associated with one of several VPCs is chosen": {
+			zoneVisibility: "Private",
+			vpcID:          "vpc-33333333",
+			expID:          "PRIVATE2",
+		},
+		"error if no private zone is associated with the VPC": {
+			zoneVisibility: "Private",
+			vpcID:          "vpc-44444444",
+			expErr:         "private zone example.com. not found in Route 53 for domain _acme-challenge.example.com.",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider, err := makeRoute53Provider(ts)
+			require.NoError(t, err, "Expected to make a Route 53 provider without error")
+			provider.findZoneByFqdn = func(string, []string) (string, error) {
+				return "example.com.", nil
+			}
+			provider.zoneVisibility = test.zoneVisibility
+			provider.vpcID = test.vpcID
+
+			id, err := provider.HostedZoneForFQDN("_acme-challenge.example.com.")
+			if test.expErr != "" {
+				assert.EqualError(t, err, test.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expID, id)
+		})
+	}
+}
+
 func TestAssumeRole(t *testing.T) {
 	creds := &sts.Credentials{
 		AccessKeyId:     aws.String("foo"),
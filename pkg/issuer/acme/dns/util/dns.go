@@ -18,7 +18,7 @@ import (
 // challenge
 // TODO: move this into the pkg/acme package
 func DNS01LookupFQDN(domain string, followCNAME bool, nameservers ...string) (string, error) {
-	fqdn := fmt.Sprintf("_acme-challenge.%s.", domain)
+	fqdn := fmt.Sprintf("_acme-challenge.%s", ToFqdn(domain))
 
 	// Check if the domain has CNAME then return that
 	if followCNAME {
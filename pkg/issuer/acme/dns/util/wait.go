@@ -18,6 +18,7 @@ import (
 	"github.com/miekg/dns"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
 )
 
 type preCheckDNSFunc func(fqdn, value string, nameservers []string,
@@ -400,11 +401,16 @@ func dnsMsgContainsCNAME(msg *dns.Msg) bool {
 }
 
 // ToFqdn converts the name into a fqdn appending a trailing dot.
+// Internationalized names are converted to their ASCII form, as used in DNS
+// records.
 func ToFqdn(name string) string {
-	n := len(name)
-	if n == 0 || name[n-1] == '.' {
+	if len(name) == 0 {
 		return name
 	}
+	name = UnFqdn(name)
+	if ascii, err := pki.DNSNameToASCII(name); err == nil {
+		name = ascii
+	}
 	return name + "."
 }
 
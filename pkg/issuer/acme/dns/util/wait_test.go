@@ -391,3 +391,20 @@ func Test_followCNAMEs(t *testing.T) {
 		})
 	}
 }
+
+func TestToFqdn(t *testing.T) {
+	tests := map[string]string{
+		"":                      "",
+		".":                     ".",
+		"example.com":           "example.com.",
+		"example.com.":          "example.com.",
+		"bücher.example":        "xn--bcher-kva.example.",
+		"bücher.example.":       "xn--bcher-kva.example.",
+		"xn--bcher-kva.example": "xn--bcher-kva.example.",
+	}
+	for name, expected := range tests {
+		if got := ToFqdn(name); got != expected {
+			t.Errorf("ToFqdn(%q): expected %q; got %q", name, expected, got)
+		}
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -63,9 +64,15 @@ type Solver struct {
 	serviceLister   corev1listers.ServiceLister
 	ingressLister   networkingv1listers.IngressLister
 	httpRouteLister gwapilisters.HTTPRouteLister
+	secretLister    corev1listers.SecretLister
 
 	testReachability reachabilityTest
 	requiredPasses   int
+
+	// publishWebhookClients are the HTTP clients used to call publish
+	// webhooks, keyed by the CA bundle used to verify the webhook.
+	publishWebhookClientsLock sync.Mutex
+	publishWebhookClients     map[string]*http.Client
 }
 
 type reachabilityTest func(ctx context.Context, url *url.URL, key string, dnsServers []string, userAgent string) error
@@ -78,6 +85,7 @@ func NewSolver(ctx *controller.Context) (*Solver, error) {
 		serviceLister:    ctx.KubeSharedInformerFactory.Core().V1().Services().Lister(),
 		ingressLister:    ctx.KubeSharedInformerFactory.Networking().V1().Ingresses().Lister(),
 		httpRouteLister:  ctx.GWShared.Gateway().V1beta1().HTTPRoutes().Lister(),
+		secretLister:     ctx.KubeSharedInformerFactory.Core().V1().Secrets().Lister(),
 		testReachability: testReachability,
 		requiredPasses:   5,
 	}, nil
@@ -108,6 +116,12 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 	log := logf.FromContext(ctx).WithName(loggerName)
 	ctx = logf.NewContext(ctx, log)
 
+	// Publish solvers hand the token to an external server, so no pods,
+	// services or ingresses are needed.
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.Publish != nil {
+		return s.publishToken(ctx, issuer, ch)
+	}
+
 	_, podErr := s.ensurePod(ctx, ch)
 	svc, svcErr := s.ensureService(ctx, ch)
 	if svcErr != nil {
@@ -130,7 +144,7 @@ func (s *Solver) Present(ctx context.Context, issuer v1.GenericIssuer, ch *cmacm
 			svcErr,
 			ingressErr,
 			gatewayErr,
-			fmt.Errorf("couldn't Present challenge %s/%s: no Ingress nor Gateway HTTP01 solvers were specified", ch.Namespace, ch.Name),
+			fmt.Errorf("couldn't Present challenge %s/%s: no Ingress, Gateway nor Publish HTTP01 solvers were specified", ch.Namespace, ch.Name),
 		},
 	)
 }
@@ -177,6 +191,10 @@ func (s *Solver) Check(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.
 // CleanUp will ensure the created service, ingress and pod are clean/deleted of any
 // cert-manager created data.
 func (s *Solver) CleanUp(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	if ch.Spec.Solver.HTTP01 != nil && ch.Spec.Solver.HTTP01.Publish != nil {
+		return s.unpublishToken(ctx, issuer, ch)
+	}
+
 	var errs []error
 	errs = append(errs, s.cleanupPods(ctx, ch))
 	errs = append(errs, s.cleanupServices(ctx, ch))
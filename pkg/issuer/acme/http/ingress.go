@@ -179,8 +179,10 @@ func buildIngressResource(ch *cmacme.Challenge, svcName string) (*networkingv1.I
 			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(ch, challengeGvk)},
 		},
 		Spec: networkingv1.IngressSpec{
+			// Only set when explicitly configured, as the class annotation
+			// takes precedence over this field.
 			// https://github.com/cert-manager/cert-manager/issues/4537
-			IngressClassName: nil,
+			IngressClassName: http01IngressCfg.IngressClassName,
 			Rules: []networkingv1.IngressRule{
 				{
 					Host: httpHost,
@@ -198,18 +200,21 @@ func buildIngressResource(ch *cmacme.Challenge, svcName string) (*networkingv1.I
 }
 
 // Merge object meta from the ingress template. Fall back to default values.
+// The labels cert-manager adds to the ingress are applied last, so that they
+// cannot be overwritten by the template.
 func (s *Solver) mergeIngressObjectMetaWithIngressResourceTemplate(ingress *networkingv1.Ingress, ingressTempl *cmacme.ACMEChallengeSolverHTTP01IngressTemplate) *networkingv1.Ingress {
 	if ingressTempl == nil {
 		return ingress
 	}
 
-	if ingress.Labels == nil {
-		ingress.Labels = make(map[string]string)
-	}
-
+	labels := make(map[string]string, len(ingress.Labels)+len(ingressTempl.Labels))
 	for k, v := range ingressTempl.Labels {
-		ingress.Labels[k] = v
+		labels[k] = v
+	}
+	for k, v := range ingress.Labels {
+		labels[k] = v
 	}
+	ingress.Labels = labels
 
 	if ingress.Annotations == nil {
 		ingress.Annotations = make(map[string]string)
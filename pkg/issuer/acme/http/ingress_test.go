@@ -523,12 +523,9 @@ func TestMergeIngressObjectMetaWithIngressResourceTemplate(t *testing.T) {
 				if err != nil {
 					t.Errorf("error preparing test: %v", err)
 				}
-				expectedIngress.Labels = map[string]string{
-					"this is a":                         "label",
-					cmacme.DomainLabelKey:               "44655555555",
-					cmacme.TokenLabelKey:                "1",
-					cmacme.SolverIdentificationLabelKey: "true",
-				}
+				// the labels added by cert-manager take precedence over
+				// the ones in the template
+				expectedIngress.Labels["this is a"] = "label"
 				expectedIngress.Annotations = map[string]string{
 					"nginx.ingress.kubernetes.io/whitelist-source-range":  "0.0.0.0/0,::/0",
 					"nginx.org/mergeable-ingress-type":                    "minion",
@@ -601,12 +598,9 @@ func TestOverrideNginxIngressWhitelistAnnotation(t *testing.T) {
 				if err != nil {
 					t.Errorf("error preparing test: %v", err)
 				}
-				expectedIngress.Labels = map[string]string{
-					"this is a":                         "label",
-					cmacme.DomainLabelKey:               "44655555555",
-					cmacme.TokenLabelKey:                "1",
-					cmacme.SolverIdentificationLabelKey: "true",
-				}
+				// the labels added by cert-manager take precedence over
+				// the ones in the template
+				expectedIngress.Labels["this is a"] = "label"
 				expectedIngress.Annotations = map[string]string{
 					"ingress.kubernetes.io/whitelist-source-range":        "0.0.0.0/0,::/0",
 					"nginx.org/mergeable-ingress-type":                    "minion",
@@ -644,3 +638,48 @@ func TestOverrideNginxIngressWhitelistAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildIngressResourceIngressClassName(t *testing.T) {
+	tests := map[string]struct {
+		ingress *cmacme.ACMEChallengeSolverHTTP01Ingress
+
+		expectedClassAnnotation *string
+		expectedClassName       *string
+	}{
+		"should not set the ingress class if neither class nor ingressClassName are specified": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+		},
+		"should set the ingress class annotation if class is specified": {
+			ingress:                 &cmacme.ACMEChallengeSolverHTTP01Ingress{Class: strPtr("nginx")},
+			expectedClassAnnotation: strPtr("nginx"),
+		},
+		"should set spec.ingressClassName if ingressClassName is specified": {
+			ingress:           &cmacme.ACMEChallengeSolverHTTP01Ingress{IngressClassName: strPtr("nginx")},
+			expectedClassName: strPtr("nginx"),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ch := &cmacme.Challenge{
+				Spec: cmacme.ChallengeSpec{
+					DNSName: "example.com",
+					Solver: cmacme.ACMEChallengeSolver{
+						HTTP01: &cmacme.ACMEChallengeSolverHTTP01{Ingress: test.ingress},
+					},
+				},
+			}
+			ing, err := buildIngressResource(ch, "fakeservice")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			classAnnotation, hasClassAnnotation := ing.Annotations[annotationIngressClass]
+			if hasClassAnnotation != (test.expectedClassAnnotation != nil) ||
+				(hasClassAnnotation && classAnnotation != *test.expectedClassAnnotation) {
+				t.Errorf("unexpected ingress class annotation, exp=%v got=%v", test.expectedClassAnnotation, ing.Annotations)
+			}
+			if !reflect.DeepEqual(ing.Spec.IngressClassName, test.expectedClassName) {
+				t.Errorf("unexpected spec.ingressClassName, exp=%v got=%v", test.expectedClassName, ing.Spec.IngressClassName)
+			}
+		})
+	}
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/cert-manager/cert-manager/pkg/apis/acme"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+// reservedKeyPrefix is the prefix of the labels cert-manager adds to the
+// resources of the HTTP01 solver, which may not be overridden.
+const reservedKeyPrefix = acme.GroupName + "/"
+
+// ApplyIngressOverrides merges the HTTP01 ingress overrides found in the given
+// annotations into the given solver configuration. The solver configuration
+// provides the Issuer scoped defaults, and any value found in the annotations
+// takes precedence over it. The annotations are those of an Order, which are
+// copied from the originating Certificate.
+//
+// The following annotations are supported:
+//
+//	acme.cert-manager.io/http01-override-ingress-name
+//	acme.cert-manager.io/http01-override-ingress-class
+//	acme.cert-manager.io/http01-override-ingress-ingressclassname
+//	acme.cert-manager.io/http01-override-ingress-service-type
+//	acme.cert-manager.io/http01-override-ingress-annotations
+//	acme.cert-manager.io/http01-override-ingress-labels
+//
+// Only one of the name, class or ingressclassname overrides may be specified.
+// The annotations and labels overrides hold JSON objects which are merged into
+// the solver's ingressTemplate. Keys with the 'acme.cert-manager.io/' prefix
+// may not be overridden, and only the annotations matching one of the
+// allowedAnnotations keys, which are configured on the issuer, may be
+// overridden. A key in allowedAnnotations ending in '*' matches all
+// annotations with the given prefix.
+func ApplyIngressOverrides(ingress *cmacme.ACMEChallengeSolverHTTP01Ingress, annotations map[string]string, allowedAnnotations []string) error {
+	if ingress == nil || annotations == nil {
+		return nil
+	}
+
+	manualIngressName, hasManualIngressName := annotations[cmacme.ACMECertificateHTTP01IngressNameOverride]
+	manualIngressClass, hasManualIngressClass := annotations[cmacme.ACMECertificateHTTP01IngressClassOverride]
+	manualIngressClassName, hasManualIngressClassName := annotations[cmacme.ACMECertificateHTTP01IngressClassNameOverride]
+	numDefined := 0
+	for _, defined := range []bool{hasManualIngressName, hasManualIngressClass, hasManualIngressClassName} {
+		if defined {
+			numDefined++
+		}
+	}
+	// don't allow more than one of the override annotations to be specified at once
+	if numDefined > 1 {
+		return fmt.Errorf("more than one of the ingress name, ingress class and ingress class name overrides specified - only one may be specified at a time")
+	}
+	// if an override annotation is specified, clear out the existing solver
+	// config
+	if numDefined > 0 {
+		ingress.Class = nil
+		ingress.IngressClassName = nil
+		ingress.Name = ""
+	}
+	if hasManualIngressName {
+		ingress.Name = manualIngressName
+	}
+	if hasManualIngressClass {
+		ingress.Class = &manualIngressClass
+	}
+	if hasManualIngressClassName {
+		ingress.IngressClassName = &manualIngressClassName
+	}
+
+	if serviceType, ok := annotations[cmacme.ACMECertificateHTTP01IngressServiceTypeOverride]; ok {
+		switch corev1.ServiceType(serviceType) {
+		case corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort:
+			ingress.ServiceType = corev1.ServiceType(serviceType)
+		default:
+			return fmt.Errorf("invalid value %q for annotation %q: must be %q or %q", serviceType,
+				cmacme.ACMECertificateHTTP01IngressServiceTypeOverride, corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort)
+		}
+	}
+
+	ingressAnnotations, err := parseStringMapAnnotation(annotations, cmacme.ACMECertificateHTTP01IngressAnnotationsOverride)
+	if err != nil {
+		return err
+	}
+	ingressLabels, err := parseStringMapAnnotation(annotations, cmacme.ACMECertificateHTTP01IngressLabelsOverride)
+	if err != nil {
+		return err
+	}
+	if err := validateOverrideKeys(ingressLabels, cmacme.ACMECertificateHTTP01IngressLabelsOverride, nil); err != nil {
+		return err
+	}
+	if err := validateOverrideKeys(ingressAnnotations, cmacme.ACMECertificateHTTP01IngressAnnotationsOverride, allowedAnnotations); err != nil {
+		return err
+	}
+	if len(ingressAnnotations) == 0 && len(ingressLabels) == 0 {
+		return nil
+	}
+
+	if ingress.IngressTemplate == nil {
+		ingress.IngressTemplate = &cmacme.ACMEChallengeSolverHTTP01IngressTemplate{}
+	}
+	ingress.IngressTemplate.Annotations = mergeStringMaps(ingress.IngressTemplate.Annotations, ingressAnnotations)
+	ingress.IngressTemplate.Labels = mergeStringMaps(ingress.IngressTemplate.Labels, ingressLabels)
+
+	return nil
+}
+
+// parseStringMapAnnotation decodes the JSON object stored in the annotation
+// with the given key. It returns nil if the annotation is not present.
+func parseStringMapAnnotation(annotations map[string]string, key string) (map[string]string, error) {
+	value, ok := annotations[key]
+	if !ok {
+		return nil, nil
+	}
+	var m map[string]string
+	if err := json.Unmarshal([]byte(value), &m); err != nil {
+		return nil, fmt.Errorf("invalid value for annotation %q: must be a JSON object of string values: %w", key, err)
+	}
+	return m, nil
+}
+
+// validateOverrideKeys checks that none of the keys of the given overrides
+// are reserved by cert-manager. If allowed is not nil, every key must also
+// match one of the allowed keys.
+func validateOverrideKeys(overrides map[string]string, annotation string, allowed []string) error {
+	keys := make([]string, 0, len(overrides))
+	for k := range overrides {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if strings.HasPrefix(k, reservedKeyPrefix) {
+			return fmt.Errorf("invalid value for annotation %q: key %q is reserved by cert-manager", annotation, k)
+		}
+		if allowed != nil && !keyAllowed(k, allowed) {
+			return fmt.Errorf("invalid value for annotation %q: key %q is not allowed to be overridden by the issuer's %q annotation",
+				annotation, k, cmacme.ACMEIssuerHTTP01AllowedIngressAnnotationOverridesKey)
+		}
+	}
+	return nil
+}
+
+// keyAllowed returns whether key matches one of the allowed keys. Allowed
+// keys ending in '*' match any key with the given prefix.
+func keyAllowed(key string, allowed []string) bool {
+	for _, a := range allowed {
+		if strings.HasSuffix(a, "*") {
+			if strings.HasPrefix(key, strings.TrimSuffix(a, "*")) {
+				return true
+			}
+			continue
+		}
+		if key == a {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedIngressAnnotationOverrides returns the solver ingress annotations
+// which the given issuer annotations allow to be overridden, as configured by
+// the ACMEIssuerHTTP01AllowedIngressAnnotationOverridesKey annotation. An
+// empty, non-nil list is returned if none are allowed.
+func AllowedIngressAnnotationOverrides(issuerAnnotations map[string]string) []string {
+	allowed := []string{}
+	for _, k := range strings.Split(issuerAnnotations[cmacme.ACMEIssuerHTTP01AllowedIngressAnnotationOverridesKey], ",") {
+		if k = strings.TrimSpace(k); len(k) > 0 {
+			allowed = append(allowed, k)
+		}
+	}
+	return allowed
+}
+
+// mergeStringMaps returns a new map containing the entries of base, overridden
+// by the entries of overrides.
+func mergeStringMaps(base, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return base
+	}
+	merged := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overrides {
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+)
+
+func TestAllowedIngressAnnotationOverrides(t *testing.T) {
+	assert.Equal(t, []string{}, AllowedIngressAnnotationOverrides(nil))
+	assert.Equal(t, []string{"a", "nginx.ingress.kubernetes.io/*"}, AllowedIngressAnnotationOverrides(map[string]string{
+		cmacme.ACMEIssuerHTTP01AllowedIngressAnnotationOverridesKey: " a, ,nginx.ingress.kubernetes.io/*",
+	}))
+}
+
+func TestApplyIngressOverrides(t *testing.T) {
+	issuerTemplate := func() *cmacme.ACMEChallengeSolverHTTP01IngressTemplate {
+		return &cmacme.ACMEChallengeSolverHTTP01IngressTemplate{
+			ACMEChallengeSolverHTTP01IngressObjectMeta: cmacme.ACMEChallengeSolverHTTP01IngressObjectMeta{
+				Annotations: map[string]string{"issuer": "annotation", "shared": "issuer"},
+				Labels:      map[string]string{"issuer": "label"},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		ingress            *cmacme.ACMEChallengeSolverHTTP01Ingress
+		annotations        map[string]string
+		allowedAnnotations []string
+
+		expectedIngress *cmacme.ACMEChallengeSolverHTTP01Ingress
+		expectedError   string
+	}{
+		"should keep the issuer defaults if no override annotations are specified": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				Class:           strPtr("nginx"),
+				ServiceType:     corev1.ServiceTypeNodePort,
+				IngressTemplate: issuerTemplate(),
+			},
+			annotations: map[string]string{"unrelated": "annotation"},
+			expectedIngress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				Class:           strPtr("nginx"),
+				ServiceType:     corev1.ServiceTypeNodePort,
+				IngressTemplate: issuerTemplate(),
+			},
+		},
+		"should replace the issuer ingress class with the ingress class name override": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				Class: strPtr("nginx"),
+			},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressClassNameOverride: "traefik",
+			},
+			expectedIngress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				IngressClassName: strPtr("traefik"),
+			},
+		},
+		"should replace the issuer ingress class name with the ingress name override": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				IngressClassName: strPtr("nginx"),
+			},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressNameOverride: "my-ingress",
+			},
+			expectedIngress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				Name: "my-ingress",
+			},
+		},
+		"should override the service type": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				ServiceType: corev1.ServiceTypeNodePort,
+			},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressServiceTypeOverride: "ClusterIP",
+			},
+			expectedIngress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				ServiceType: corev1.ServiceTypeClusterIP,
+			},
+		},
+		"should merge the annotations and labels overrides into the issuer ingress template": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				IngressTemplate: issuerTemplate(),
+			},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressAnnotationsOverride: `{"shared":"certificate","certificate":"annotation"}`,
+				cmacme.ACMECertificateHTTP01IngressLabelsOverride:      `{"certificate":"label"}`,
+			},
+			allowedAnnotations: []string{"shared", "certificate"},
+			expectedIngress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				IngressTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressTemplate{
+					ACMEChallengeSolverHTTP01IngressObjectMeta: cmacme.ACMEChallengeSolverHTTP01IngressObjectMeta{
+						Annotations: map[string]string{"issuer": "annotation", "shared": "certificate", "certificate": "annotation"},
+						Labels:      map[string]string{"issuer": "label", "certificate": "label"},
+					},
+				},
+			},
+		},
+		"should create an ingress template if the issuer doesn't define one": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressLabelsOverride: `{"certificate":"label"}`,
+			},
+			expectedIngress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				IngressTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressTemplate{
+					ACMEChallengeSolverHTTP01IngressObjectMeta: cmacme.ACMEChallengeSolverHTTP01IngressObjectMeta{
+						Labels: map[string]string{"certificate": "label"},
+					},
+				},
+			},
+		},
+		"should allow annotations matching an allowed prefix to be overridden": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressAnnotationsOverride: `{"nginx.ingress.kubernetes.io/whitelist-source-range":"10.0.0.0/8"}`,
+			},
+			allowedAnnotations: []string{"nginx.ingress.kubernetes.io/*"},
+			expectedIngress: &cmacme.ACMEChallengeSolverHTTP01Ingress{
+				IngressTemplate: &cmacme.ACMEChallengeSolverHTTP01IngressTemplate{
+					ACMEChallengeSolverHTTP01IngressObjectMeta: cmacme.ACMEChallengeSolverHTTP01IngressObjectMeta{
+						Annotations: map[string]string{"nginx.ingress.kubernetes.io/whitelist-source-range": "10.0.0.0/8"},
+					},
+				},
+			},
+		},
+		"should error if an annotation not allowed by the issuer is overridden": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressAnnotationsOverride: `{"certificate":"annotation"}`,
+			},
+			allowedAnnotations: []string{"nginx.ingress.kubernetes.io/*"},
+			expectedError: `invalid value for annotation "acme.cert-manager.io/http01-override-ingress-annotations": key "certificate" is not allowed to be overridden ` +
+				`by the issuer's "acme.cert-manager.io/http01-allowed-ingress-annotation-overrides" annotation`,
+		},
+		"should error if the labels override contains a solver label": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressLabelsOverride: `{"acme.cert-manager.io/http01-solver":"false"}`,
+			},
+			expectedError: `invalid value for annotation "acme.cert-manager.io/http01-override-ingress-labels": key "acme.cert-manager.io/http01-solver" is reserved by cert-manager`,
+		},
+		"should error if the annotations override contains a reserved key, even if allowed": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressAnnotationsOverride: `{"acme.cert-manager.io/http-token":"x"}`,
+			},
+			allowedAnnotations: []string{"*"},
+			expectedError:      `invalid value for annotation "acme.cert-manager.io/http01-override-ingress-annotations": key "acme.cert-manager.io/http-token" is reserved by cert-manager`,
+		},
+		"should error if both the ingress class and ingress class name overrides are specified": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressClassOverride:     "nginx",
+				cmacme.ACMECertificateHTTP01IngressClassNameOverride: "nginx",
+			},
+			expectedError: "more than one of the ingress name, ingress class and ingress class name overrides specified - only one may be specified at a time",
+		},
+		"should error if the service type override is not supported": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressServiceTypeOverride: "LoadBalancer",
+			},
+			expectedError: `invalid value "LoadBalancer" for annotation "acme.cert-manager.io/http01-override-ingress-service-type": must be "ClusterIP" or "NodePort"`,
+		},
+		"should error if the labels override is not a JSON object": {
+			ingress: &cmacme.ACMEChallengeSolverHTTP01Ingress{},
+			annotations: map[string]string{
+				cmacme.ACMECertificateHTTP01IngressLabelsOverride: "certificate=label",
+			},
+			expectedError: `invalid value for annotation "acme.cert-manager.io/http01-override-ingress-labels": must be a JSON object of string values: invalid character 'c' looking for beginning of value`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ApplyIngressOverrides(test.ingress, test.annotations, test.allowedAnnotations)
+			if test.expectedError != "" {
+				assert.EqualError(t, err, test.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedIngress, test.ingress)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/cert-manager/cert-manager/internal/credentials"
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+)
+
+// publishToken publishes the token and key authorization of the given
+// challenge to the target of its HTTP01 publish solver.
+func (s *Solver) publishToken(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	publish := ch.Spec.Solver.HTTP01.Publish
+	namespace := s.IssuerOptions.ResourceNamespace(issuer)
+	log := logf.FromContext(ctx).WithValues("token", ch.Spec.Token)
+
+	switch {
+	case publish.ConfigMap != nil:
+		log.V(logf.DebugLevel).Info("publishing token to configmap", "resource_namespace", namespace, "resource_name", publish.ConfigMap.Name)
+		return s.updateConfigMap(ctx, namespace, publish.ConfigMap.Name, true, func(data map[string]string) bool {
+			if data[ch.Spec.Token] == ch.Spec.Key {
+				return false
+			}
+			data[ch.Spec.Token] = ch.Spec.Key
+			return true
+		})
+	case publish.Secret != nil:
+		log.V(logf.DebugLevel).Info("publishing token to secret", "resource_namespace", namespace, "resource_name", publish.Secret.Name)
+		return s.updateSecret(ctx, namespace, publish.Secret.Name, true, func(data map[string][]byte) bool {
+			if string(data[ch.Spec.Token]) == ch.Spec.Key {
+				return false
+			}
+			data[ch.Spec.Token] = []byte(ch.Spec.Key)
+			return true
+		})
+	case publish.Webhook != nil:
+		log.V(logf.DebugLevel).Info("publishing token to webhook", "url", publish.Webhook.URL)
+		return s.callPublishWebhook(ctx, namespace, publish.Webhook, http.MethodPut, ch)
+	}
+
+	return fmt.Errorf("couldn't publish token of challenge %s/%s: no publish target was specified", ch.Namespace, ch.Name)
+}
+
+// unpublishToken removes the token of the given challenge from the target of
+// its HTTP01 publish solver.
+func (s *Solver) unpublishToken(ctx context.Context, issuer v1.GenericIssuer, ch *cmacme.Challenge) error {
+	publish := ch.Spec.Solver.HTTP01.Publish
+	namespace := s.IssuerOptions.ResourceNamespace(issuer)
+
+	switch {
+	case publish.ConfigMap != nil:
+		return s.updateConfigMap(ctx, namespace, publish.ConfigMap.Name, false, func(data map[string]string) bool {
+			if _, ok := data[ch.Spec.Token]; !ok {
+				return false
+			}
+			delete(data, ch.Spec.Token)
+			return true
+		})
+	case publish.Secret != nil:
+		return s.updateSecret(ctx, namespace, publish.Secret.Name, false, func(data map[string][]byte) bool {
+			if _, ok := data[ch.Spec.Token]; !ok {
+				return false
+			}
+			delete(data, ch.Spec.Token)
+			return true
+		})
+	case publish.Webhook != nil:
+		return s.callPublishWebhook(ctx, namespace, publish.Webhook, http.MethodDelete, ch)
+	}
+
+	return nil
+}
+
+// updateConfigMap calls mutate with the data of the named ConfigMap, and
+// updates the ConfigMap if mutate returns true. If the ConfigMap does not
+// exist, it is created if create is true.
+func (s *Solver) updateConfigMap(ctx context.Context, namespace, name string, create bool, mutate func(map[string]string) bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm, err := s.Client.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			if !create {
+				return nil
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Data:       make(map[string]string),
+			}
+			mutate(cm.Data)
+			_, err = s.Client.CoreV1().ConfigMaps(namespace).Create(ctx, cm, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		if !mutate(cm.Data) {
+			return nil
+		}
+		_, err = s.Client.CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// updateSecret calls mutate with the data of the named Secret, and updates
+// the Secret if mutate returns true. If the Secret does not exist, it is
+// created if create is true.
+func (s *Solver) updateSecret(ctx context.Context, namespace, name string, create bool, mutate func(map[string][]byte) bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := s.Client.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			if !create {
+				return nil
+			}
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Data:       make(map[string][]byte),
+			}
+			mutate(secret.Data)
+			_, err = s.Client.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+		if !mutate(secret.Data) {
+			return nil
+		}
+		_, err = s.Client.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// callPublishWebhook sends a request with the given method to
+// `<url>/<token>` of the webhook. The body of PUT requests is the key
+// authorization of the challenge. A DELETE request for a token which is not
+// found is treated as a success.
+func (s *Solver) callPublishWebhook(ctx context.Context, namespace string, webhook *cmacme.ACMEChallengeSolverHTTP01PublishWebhook, method string, ch *cmacme.Challenge) error {
+	url := strings.TrimSuffix(webhook.URL, "/") + "/" + ch.Spec.Token
+
+	var body *strings.Reader
+	if method == http.MethodPut {
+		body = strings.NewReader(ch.Spec.Key)
+	} else {
+		body = strings.NewReader("")
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	if s.RESTConfig != nil {
+		req.Header.Set("User-Agent", s.RESTConfig.UserAgent)
+	}
+
+	if ref := webhook.AuthorizationSecretRef; ref != nil {
+		secret, err := s.secretLister.Secrets(namespace).Get(ref.Name)
+		if err != nil {
+			return fmt.Errorf("failed to get publish webhook authorization secret %s/%s: %w", namespace, ref.Name, err)
+		}
+		token, ok, err := credentials.Value(secret, ref.Key)
+		if err != nil {
+			return fmt.Errorf("failed to read publish webhook authorization secret %s/%s: %w", namespace, ref.Name, err)
+		}
+		if !ok {
+			return fmt.Errorf("no data for %q in publish webhook authorization secret %s/%s", ref.Key, namespace, ref.Name)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	client, err := s.publishWebhookClient(webhook.CABundle)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call publish webhook %s %q: %w", method, url, err)
+	}
+	defer resp.Body.Close()
+
+	if method == http.MethodDelete && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("publish webhook %s %q returned unexpected status code %d", method, url, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// publishWebhookClient returns the HTTP client used to call publish webhooks
+// verified with the given CA bundle. Clients are built once per CA bundle, so
+// that connections to webhooks are reused.
+func (s *Solver) publishWebhookClient(caBundle []byte) (*http.Client, error) {
+	s.publishWebhookClientsLock.Lock()
+	defer s.publishWebhookClientsLock.Unlock()
+
+	if client, ok := s.publishWebhookClients[string(caBundle)]; ok {
+		return client, nil
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("publish webhook CA bundle doesn't contain any valid certificates")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   time.Second * 10,
+	}
+
+	if s.publishWebhookClients == nil {
+		s.publishWebhookClients = make(map[string]*http.Client)
+	}
+	s.publishWebhookClients[string(caBundle)] = client
+
+	return client, nil
+}
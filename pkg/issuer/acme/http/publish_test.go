@@ -0,0 +1,200 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	cmacme "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	testpkg "github.com/cert-manager/cert-manager/pkg/controller/test"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+func publishChallenge(publish *cmacme.ACMEChallengeSolverHTTP01Publish) *cmacme.Challenge {
+	return &cmacme.Challenge{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-challenge", Namespace: defaultTestNamespace},
+		Spec: cmacme.ChallengeSpec{
+			DNSName: "example.com",
+			Token:   "test-token",
+			Key:     "test-key",
+			Solver: cmacme.ACMEChallengeSolver{
+				HTTP01: &cmacme.ACMEChallengeSolverHTTP01{Publish: publish},
+			},
+		},
+	}
+}
+
+func TestPublishToken(t *testing.T) {
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerNamespace(defaultTestNamespace))
+	configMapPublish := &cmacme.ACMEChallengeSolverHTTP01Publish{
+		ConfigMap: &cmacme.ACMEChallengeSolverHTTP01PublishObject{Name: "edge-tokens"},
+	}
+	secretPublish := &cmacme.ACMEChallengeSolverHTTP01Publish{
+		Secret: &cmacme.ACMEChallengeSolverHTTP01PublishObject{Name: "edge-tokens"},
+	}
+
+	tests := map[string]struct {
+		publish     *cmacme.ACMEChallengeSolverHTTP01Publish
+		kubeObjects []runtime.Object
+		cleanup     bool
+
+		expectedConfigMapData map[string]string
+		expectedSecretData    map[string][]byte
+	}{
+		"should create the configmap if it does not exist": {
+			publish:               configMapPublish,
+			expectedConfigMapData: map[string]string{"test-token": "test-key"},
+		},
+		"should add the token to an existing configmap": {
+			publish: configMapPublish,
+			kubeObjects: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "edge-tokens", Namespace: defaultTestNamespace},
+				Data:       map[string]string{"other-token": "other-key"},
+			}},
+			expectedConfigMapData: map[string]string{"other-token": "other-key", "test-token": "test-key"},
+		},
+		"should remove the token from the configmap on cleanup": {
+			publish: configMapPublish,
+			kubeObjects: []runtime.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "edge-tokens", Namespace: defaultTestNamespace},
+				Data:       map[string]string{"other-token": "other-key", "test-token": "test-key"},
+			}},
+			cleanup:               true,
+			expectedConfigMapData: map[string]string{"other-token": "other-key"},
+		},
+		"should create the secret if it does not exist": {
+			publish:            secretPublish,
+			expectedSecretData: map[string][]byte{"test-token": []byte("test-key")},
+		},
+		"should remove the token from the secret on cleanup": {
+			publish: secretPublish,
+			kubeObjects: []runtime.Object{&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "edge-tokens", Namespace: defaultTestNamespace},
+				Data:       map[string][]byte{"test-token": []byte("test-key")},
+			}},
+			cleanup:            true,
+			expectedSecretData: map[string][]byte{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			f := &solverFixture{Builder: &testpkg.Builder{KubeObjects: test.kubeObjects}}
+			f.Setup(t)
+			defer f.Builder.Stop()
+
+			ch := publishChallenge(test.publish)
+			var err error
+			if test.cleanup {
+				err = f.Solver.CleanUp(context.TODO(), issuer, ch)
+			} else {
+				err = f.Solver.Present(context.TODO(), issuer, ch)
+			}
+			assert.NoError(t, err)
+
+			if test.expectedConfigMapData != nil {
+				cm, err := f.FakeKubeClient().CoreV1().ConfigMaps(defaultTestNamespace).Get(context.TODO(), "edge-tokens", metav1.GetOptions{})
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedConfigMapData, cm.Data)
+			}
+			if test.expectedSecretData != nil {
+				secret, err := f.FakeKubeClient().CoreV1().Secrets(defaultTestNamespace).Get(context.TODO(), "edge-tokens", metav1.GetOptions{})
+				assert.NoError(t, err)
+				assert.Equal(t, test.expectedSecretData, secret.Data)
+			}
+
+			// No pods, services or ingresses should ever be created.
+			for _, action := range f.FakeKubeClient().Actions() {
+				switch action.GetResource().Resource {
+				case "pods", "services", "ingresses":
+					if action.GetVerb() == "create" {
+						t.Errorf("unexpected %s of %s", action.GetVerb(), action.GetResource().Resource)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPublishTokenWebhook(t *testing.T) {
+	type request struct {
+		method, path, body, authorization string
+	}
+	var (
+		lock     sync.Mutex
+		requests []request
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lock.Lock()
+		requests = append(requests, request{r.Method, r.URL.Path, string(body), r.Header.Get("Authorization")})
+		lock.Unlock()
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	issuer := gen.Issuer("test-issuer", gen.SetIssuerNamespace(defaultTestNamespace))
+	ch := publishChallenge(&cmacme.ACMEChallengeSolverHTTP01Publish{
+		Webhook: &cmacme.ACMEChallengeSolverHTTP01PublishWebhook{
+			URL: server.URL + "/tokens/",
+			AuthorizationSecretRef: &cmmeta.SecretKeySelector{
+				LocalObjectReference: cmmeta.LocalObjectReference{Name: "edge-auth"},
+				Key:                  "token",
+			},
+		},
+	})
+
+	f := &solverFixture{Builder: &testpkg.Builder{KubeObjects: []runtime.Object{&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge-auth", Namespace: defaultTestNamespace},
+		Data:       map[string][]byte{"token": []byte("secret-token\n")},
+	}}}}
+	f.Setup(t)
+	defer f.Builder.Stop()
+
+	assert.NoError(t, f.Solver.Present(context.TODO(), issuer, ch))
+	assert.NoError(t, f.Solver.CleanUp(context.TODO(), issuer, ch))
+	assert.Equal(t, []request{
+		{http.MethodPut, "/tokens/test-token", "test-key", "Bearer secret-token"},
+		{http.MethodDelete, "/tokens/test-token", "", "Bearer secret-token"},
+	}, requests)
+}
+
+func TestPublishWebhookClient(t *testing.T) {
+	s := &Solver{}
+
+	client, err := s.publishWebhookClient(nil)
+	assert.NoError(t, err)
+	cached, err := s.publishWebhookClient(nil)
+	assert.NoError(t, err)
+	assert.Same(t, client, cached)
+
+	_, err = s.publishWebhookClient([]byte("not a certificate"))
+	assert.EqualError(t, err, "publish webhook CA bundle doesn't contain any valid certificates")
+}
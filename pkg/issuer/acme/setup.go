@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/rsa"
 	"encoding/base64"
+	stderrors "errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -29,6 +30,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/cert-manager/cert-manager/internal/credentials"
 	"github.com/cert-manager/cert-manager/pkg/acme"
 	"github.com/cert-manager/cert-manager/pkg/acme/accounts"
 	"github.com/cert-manager/cert-manager/pkg/acme/client"
@@ -44,6 +46,7 @@ const (
 	errorAccountRegistrationFailed = "ErrRegisterACMEAccount"
 	errorAccountVerificationFailed = "ErrVerifyACMEAccount"
 	errorAccountUpdateFailed       = "ErrUpdateACMEAccount"
+	errorCloudflareTokenInvalid    = "ErrVerifyCloudflareToken"
 	errorInvalidConfig             = "InvalidConfig"
 	errorInvalidURL                = "InvalidURL"
 
@@ -63,6 +66,7 @@ const (
 	messageTemplateFailedToParseURL        = "Failed to parse existing ACME server URI %q: %v"
 	messageTemplateFailedToParseAccountURL = "Failed to parse existing ACME account URI %q: %v"
 	messageTemplateFailedToGetEABKey       = "failed to get External Account Binding key from secret: %v"
+	messageTemplateCloudflareTokenInvalid  = "Failed to verify the Cloudflare API token of solver %d: %v"
 )
 
 // Setup will verify an existing ACME registration, or create one if not
@@ -98,6 +102,21 @@ func (a *Acme) Setup(ctx context.Context) error {
 		ns = a.clusterResourceNamespace
 	}
 
+	// verify the Cloudflare API tokens of DNS01 solvers, so that tokens
+	// lacking permissions are reported on the issuer rather than only on
+	// each Challenge. An invalid token only affects Challenges using that
+	// solver, so the issuer is not marked as not ready. Verified tokens are
+	// cached, and tokens which failed are verified again on the next resync.
+	for i, solver := range a.issuer.GetSpec().ACME.Solvers {
+		if solver.DNS01 == nil || solver.DNS01.Cloudflare == nil || solver.DNS01.Cloudflare.APIToken == nil {
+			continue
+		}
+		if err := a.verifyCloudflareToken(ctx, ns, solver.DNS01.Cloudflare); err != nil {
+			log.V(logf.WarnLevel).Info("failed to verify Cloudflare API token", "solver", i, "error", err.Error())
+			a.recorder.Eventf(a.issuer, corev1.EventTypeWarning, errorCloudflareTokenInvalid, messageTemplateCloudflareTokenInvalid, i, err)
+		}
+	}
+
 	log = logf.WithRelatedResourceName(log, a.issuer.GetSpec().ACME.PrivateKey.Name, ns, "Secret")
 
 	// attempt to obtain the existing private key from the apiserver.
@@ -113,7 +132,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 		if err != nil {
 			msg = messageAccountRegistrationFailed + err.Error()
 			reason = errorAccountRegistrationFailed
-			return fmt.Errorf(msg)
+			return stderrors.New(msg)
 		}
 		// We clear the ACME account URI as we have generated a new private key
 		a.issuer.GetStatus().ACMEStatus().URI = ""
@@ -137,7 +156,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 	case err != nil:
 		reason = errorAccountVerificationFailed
 		msg = messageAccountVerificationFailed + err.Error()
-		return fmt.Errorf(msg)
+		return stderrors.New(msg)
 	}
 	rsaPk, ok := pk.(*rsa.PrivateKey)
 	if !ok {
@@ -240,7 +259,7 @@ func (a *Acme) Setup(ctx context.Context) error {
 		case err != nil:
 			reason = errorAccountRegistrationFailed
 			msg = messageAccountRegistrationFailed + err.Error()
-			return fmt.Errorf(msg)
+			return stderrors.New(msg)
 		}
 
 		// set the external account binding
@@ -395,7 +414,10 @@ func (a *Acme) getEABKey(ctx context.Context, ns string) ([]byte, error) {
 		return nil, fmt.Errorf(messageTemplateFailedToGetEABKey, err)
 	}
 
-	encodedKeyData, ok := sec.Data[eab.Key]
+	encodedKeyData, ok, err := credentials.Value(sec, eab.Key)
+	if err != nil {
+		return nil, errors.NewInvalidData("failed to load external account binding key data: %v", err)
+	}
 	if !ok {
 		return nil, errors.NewInvalidData("failed to find external account binding key data in Secret %q at index %q", eab.Name, eab.Key)
 	}
@@ -421,13 +443,20 @@ func (a *Acme) createAccountPrivateKey(ctx context.Context, sel cmmeta.SecretKey
 		return nil, err
 	}
 
+	// The account key is envelope encrypted if a KMS plugin or an encryption
+	// key is configured.
+	keyData, err := credentials.EncryptValue(ctx, pki.EncodePKCS1PrivateKey(accountPrivKey))
+	if err != nil {
+		return nil, err
+	}
+
 	_, err = a.secretsClient.Secrets(ns).Create(ctx, &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      sel.Name,
 			Namespace: ns,
 		},
 		Data: map[string][]byte{
-			sel.Key: pki.EncodePKCS1PrivateKey(accountPrivKey),
+			sel.Key: keyData,
 		},
 	}, metav1.CreateOptions{})
 
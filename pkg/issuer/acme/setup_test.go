@@ -133,6 +133,9 @@ func TestAcme_Setup(t *testing.T) {
 		eabSecret       *corev1.Secret
 		eabSecretGetErr error
 
+		// Error returned by the verifyCloudflareToken stub.
+		verifyCloudflareTokenErr error
+
 		// expected ACME account passed to cl.Register
 		expectedRegisteredAcc *acmeapi.Account
 		// expected issuer conditions after Setup has been called.
@@ -158,6 +161,48 @@ func TestAcme_Setup(t *testing.T) {
 					gen.SetIssuerConditionMessage(fmt.Sprintf(messageTemplateUpdateToV2, fmt.Sprintf("%s/", acmev1Staging), acmev2Staging))),
 			},
 		},
+		"Cloudflare API token of a DNS01 solver cannot manage DNS records, issuer stays ready and a warning is emitted": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMEAccountURL(acmev2Prod),
+				gen.SetIssuerACMEEmail(someEmail),
+				gen.SetIssuerACMELastRegisteredEmail(someEmail),
+				gen.SetIssuerACMESolvers([]cmacme.ACMEChallengeSolver{
+					{HTTP01: &cmacme.ACMEChallengeSolverHTTP01{}},
+					{DNS01: &cmacme.ACMEChallengeSolverDNS01{Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+						APIToken: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: someString}, Key: someString},
+					}}},
+				})),
+			verifyCloudflareTokenErr: someErr,
+			kfsKey:                   rsaPrivKey,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyTrueCondition,
+					gen.SetIssuerConditionStatus(cmmeta.ConditionTrue),
+					gen.SetIssuerConditionMessage(messageAccountRegistered),
+					gen.SetIssuerConditionReason(successAccountRegistered)),
+			},
+			expectedEvents: []string{
+				fmt.Sprintf("%s %s %s", corev1.EventTypeWarning, errorCloudflareTokenInvalid, fmt.Sprintf(messageTemplateCloudflareTokenInvalid, 1, someErr)),
+			},
+			expectedRegisteredAcc:      &acmeapi.Account{Contact: []string{someEmailURL}},
+			removeClientShouldBeCalled: true,
+			addClientShouldBeCalled:    true,
+		},
+		"Cloudflare API key of a DNS01 solver is not verified": {
+			issuer: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerACMESolvers([]cmacme.ACMEChallengeSolver{
+					{DNS01: &cmacme.ACMEChallengeSolverDNS01{Cloudflare: &cmacme.ACMEIssuerDNS01ProviderCloudflare{
+						Email:  someEmail,
+						APIKey: &cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: someString}, Key: someString},
+					}}},
+				})),
+			verifyCloudflareTokenErr: someErr,
+			kfsErr:                   invalidDataErr,
+			expectedConditions: []cmapi.IssuerCondition{
+				*gen.IssuerConditionFrom(readyFalseCondition,
+					gen.SetIssuerConditionReason(errorAccountVerificationFailed),
+					gen.SetIssuerConditionMessage(messageInvalidPrivateKey+invalidDataErr.Error())),
+			},
+		},
 		"ACME private key secret does not exist, account key generation not disabled, key secret creation fails": {
 			issuer: gen.IssuerFrom(baseIssuer,
 				gen.SetIssuerACMEPrivKeyRef(issuerSecretKeyName)),
@@ -564,6 +609,9 @@ func TestAcme_Setup(t *testing.T) {
 				keyFromSecret:   kfs,
 				clientBuilder:   clientBuilderMock(&cl),
 				recorder:        recorder,
+				verifyCloudflareToken: func(context.Context, string, *cmacme.ACMEIssuerDNS01ProviderCloudflare) error {
+					return test.verifyCloudflareTokenErr
+				},
 			}
 
 			// Stub the clock to get consistent last transition times on conditions.
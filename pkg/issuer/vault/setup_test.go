@@ -29,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/clock"
 
 	internalapi "github.com/cert-manager/cert-manager/internal/apis/certmanager"
 	internalv1 "github.com/cert-manager/cert-manager/internal/apis/certmanager/v1"
@@ -38,6 +39,8 @@ import (
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
 	"github.com/cert-manager/cert-manager/pkg/controller"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
 	testlisters "github.com/cert-manager/cert-manager/test/unit/listers"
 	corelisters "k8s.io/client-go/listers/core/v1"
 )
@@ -384,7 +387,7 @@ func TestVault_Setup(t *testing.T) {
 
 			v := &Vault{
 				issuer:            givenIssuer,
-				Context:           &controller.Context{CMClient: cmclient},
+				Context:           &controller.Context{CMClient: cmclient, ContextOptions: controller.ContextOptions{Metrics: metrics.New(logf.Log, clock.RealClock{})}},
 				resourceNamespace: "test-namespace",
 				createTokenFn: func(ns string) vaultinternal.CreateToken {
 					return func(ctx context.Context, saName string, req *authv1.TokenRequest, opts metav1.CreateOptions) (*authv1.TokenRequest, error) {
@@ -30,6 +30,7 @@ type Venafi struct {
 	RetrieveCertificateFn   func(pickupID string, csrPEM []byte, duration time.Duration, customFields []api.CustomField) ([]byte, error)
 	ReadZoneConfigurationFn func() (*endpoint.ZoneConfiguration, error)
 	VerifyCredentialsFn     func() error
+	RetireCertificateFn     func(certPEM []byte) error
 }
 
 func (v *Venafi) Ping() error {
@@ -44,8 +45,14 @@ func (v *Venafi) RetrieveCertificate(pickupID string, csrPEM []byte, duration ti
 	return v.RetrieveCertificateFn(pickupID, csrPEM, duration, customFields)
 }
 
+// ReadZoneConfiguration will return ReadZoneConfigurationFn if set, otherwise
+// an empty zone configuration.
 func (v *Venafi) ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error) {
-	return v.ReadZoneConfigurationFn()
+	if v.ReadZoneConfigurationFn != nil {
+		return v.ReadZoneConfigurationFn()
+	}
+
+	return &endpoint.ZoneConfiguration{}, nil
 }
 
 func (v *Venafi) SetClient(endpoint.Connector) {}
@@ -58,3 +65,12 @@ func (v *Venafi) VerifyCredentials() error {
 
 	return nil
 }
+
+// RetireCertificate will return RetireCertificateFn if set, otherwise nil.
+func (v *Venafi) RetireCertificate(certPEM []byte) error {
+	if v.RetireCertificateFn != nil {
+		return v.RetireCertificateFn(certPEM)
+	}
+
+	return nil
+}
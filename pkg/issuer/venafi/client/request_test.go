@@ -86,6 +86,18 @@ func generateCSR(t *testing.T, secretKey crypto.Signer, commonName string, dnsNa
 	return csr
 }
 
+func generateLiteralSubjectCSR(t *testing.T, secretKey crypto.Signer, literalSubject string, dnsNames []string) []byte {
+	csr, err := gen.CSRWithSigner(secretKey,
+		gen.SetCSRLiteralSubject(literalSubject),
+		gen.SetCSRDNSNames(dnsNames...),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return csr
+}
+
 func TestVenafi_RequestCertificate(t *testing.T) {
 	privateKey, err := pki.GenerateRSAPrivateKey(2048)
 	if err != nil {
@@ -152,6 +164,14 @@ func TestVenafi_RequestCertificate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "get a success for a CSR with a literal subject without a Common Name",
+			args: args{
+				csrPEM: generateLiteralSubjectCSR(t, privateKey, "DC=example,DC=com,UID=jsmith", []string{"foo.example.com"}),
+			},
+			wantPickupID: true,
+			wantErr:      false,
+		},
 		{
 			name: "error if no Common Name, DNS Name, or URI SANs in CSR",
 			args: args{
@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"crypto/sha1" // #nosec G505 -- Venafi identifies certificates by their SHA-1 thumbprint
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+const defaultCloudURL = "https://api.venafi.cloud/"
+
+// ErrCertificateNotFound is returned by RetireCertificate if the certificate
+// does not exist in the Venafi inventory, for example because it has already
+// been removed.
+var ErrCertificateNotFound = errors.New("certificate not found in Venafi inventory")
+
+// RetireCertificate retires the given PEM encoded certificate in the Venafi
+// inventory, so that it no longer triggers expiry notifications. For TPP the
+// certificate object is disabled, and for Venafi as a Service the certificate
+// is moved to the retired state. The certificate is not revoked.
+func (v *Venafi) RetireCertificate(certPEM []byte) error {
+	cert, err := pki.DecodeX509CertificateBytes(certPEM)
+	if err != nil {
+		return err
+	}
+
+	// #nosec G401 -- Venafi identifies certificates by their SHA-1 thumbprint
+	sum := sha1.Sum(cert.Raw)
+	thumbprint := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	httpClient := v.config.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	switch v.config.ConnectorType {
+	case endpoint.ConnectorTypeTPP:
+		return v.retireTPP(httpClient, thumbprint)
+	case endpoint.ConnectorTypeCloud:
+		return v.retireCloud(httpClient, thumbprint)
+	}
+
+	return fmt.Errorf("certificate retirement is not supported for connector type %q", v.config.ConnectorType)
+}
+
+func (v *Venafi) retireTPP(httpClient *http.Client, thumbprint string) error {
+	baseURL := v.config.BaseUrl
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	baseURL = strings.TrimSuffix(baseURL, "vedsdk/") + "vedsdk/"
+
+	header := http.Header{}
+	switch {
+	case v.config.Credentials == nil:
+		return fmt.Errorf("credentials not configured")
+	case len(v.config.Credentials.AccessToken) > 0:
+		header.Set("Authorization", "Bearer "+v.config.Credentials.AccessToken)
+	default:
+		var resp struct {
+			APIKey string
+		}
+		req := map[string]string{
+			"Username": v.config.Credentials.User,
+			"Password": v.config.Credentials.Password,
+		}
+		if err := doJSON(httpClient, http.MethodPost, baseURL+"authorize/", nil, req, &resp); err != nil {
+			return fmt.Errorf("failed to authenticate with TPP: %w", err)
+		}
+		header.Set("X-Venafi-Api-Key", resp.APIKey)
+	}
+
+	var search struct {
+		Certificates []struct {
+			DN string
+		}
+	}
+	if err := doJSON(httpClient, http.MethodGet, baseURL+"certificates/?Thumbprint="+url.QueryEscape(thumbprint), header, nil, &search); err != nil {
+		return fmt.Errorf("failed to search for certificate: %w", err)
+	}
+	if len(search.Certificates) == 0 {
+		return ErrCertificateNotFound
+	}
+
+	for _, c := range search.Certificates {
+		var resp struct {
+			Result int
+		}
+		req := map[string]interface{}{
+			"ObjectDN":      c.DN,
+			"AttributeName": "Disabled",
+			"Values":        []string{"1"},
+		}
+		if err := doJSON(httpClient, http.MethodPost, baseURL+"Config/Write", header, req, &resp); err != nil {
+			return fmt.Errorf("failed to disable certificate %q: %w", c.DN, err)
+		}
+		// A result of 1 indicates success
+		if resp.Result != 1 {
+			return fmt.Errorf("failed to disable certificate %q: result code %d", c.DN, resp.Result)
+		}
+	}
+
+	return nil
+}
+
+func (v *Venafi) retireCloud(httpClient *http.Client, thumbprint string) error {
+	baseURL := v.config.BaseUrl
+	if len(baseURL) == 0 {
+		baseURL = defaultCloudURL
+	}
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+
+	header := http.Header{}
+	if v.config.Credentials != nil {
+		header.Set("tppl-api-key", v.config.Credentials.APIKey)
+	}
+
+	var search struct {
+		Certificates []struct {
+			ID string `json:"id"`
+		} `json:"certificates"`
+	}
+	searchReq := map[string]interface{}{
+		"expression": map[string]interface{}{
+			"operands": []map[string]string{{
+				"field":    "fingerprint",
+				"operator": "EQ",
+				"value":    thumbprint,
+			}},
+		},
+	}
+	if err := doJSON(httpClient, http.MethodPost, baseURL+"outagedetection/v1/certificatesearch", header, searchReq, &search); err != nil {
+		return fmt.Errorf("failed to search for certificate: %w", err)
+	}
+	if len(search.Certificates) == 0 {
+		return ErrCertificateNotFound
+	}
+
+	ids := make([]string, len(search.Certificates))
+	for i, c := range search.Certificates {
+		ids[i] = c.ID
+	}
+
+	retireReq := map[string]interface{}{
+		"certificateIds": ids,
+	}
+	if err := doJSON(httpClient, http.MethodPost, baseURL+"outagedetection/v1/certificates/retirement", header, retireReq, nil); err != nil {
+		return fmt.Errorf("failed to retire certificate: %w", err)
+	}
+
+	return nil
+}
+
+// doJSON sends a request with the given JSON encoded body, and decodes the
+// JSON response into out if it is not nil. Non-2xx responses are returned as
+// errors.
+func doJSON(httpClient *http.Client, method, url string, header http.Header, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	for k, vs := range header {
+		req.Header[k] = vs
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %q: %s", resp.Status, data)
+	}
+
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(data, out)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	vcert "github.com/Venafi/vcert/v4"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func retireTestCertificate(t *testing.T) []byte {
+	pk, err := pki.GenerateECPrivateKey(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	certPEM, _, err := pki.SignCertificate(template, template, pk.Public(), pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return certPEM
+}
+
+func TestRetireCertificateTPP(t *testing.T) {
+	certPEM := retireTestCertificate(t)
+
+	var disabled []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/vedsdk/certificates/":
+			if len(r.URL.Query().Get("Thumbprint")) != 40 {
+				t.Errorf("unexpected thumbprint %q", r.URL.Query().Get("Thumbprint"))
+			}
+			w.Write([]byte(`{"Certificates":[{"DN":"\\VED\\Policy\\example.com"}],"TotalCount":1}`))
+		case "/vedsdk/Config/Write":
+			var req struct {
+				ObjectDN      string
+				AttributeName string
+				Values        []string
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatal(err)
+			}
+			if req.AttributeName != "Disabled" || len(req.Values) != 1 || req.Values[0] != "1" {
+				t.Errorf("unexpected config write request: %+v", req)
+			}
+			disabled = append(disabled, req.ObjectDN)
+			w.Write([]byte(`{"Result":1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	v := &Venafi{config: &vcert.Config{
+		ConnectorType: endpoint.ConnectorTypeTPP,
+		BaseUrl:       server.URL + "/vedsdk",
+		Credentials:   &endpoint.Authentication{AccessToken: "test-token"},
+	}}
+
+	if err := v.RetireCertificate(certPEM); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(disabled) != 1 || disabled[0] != `\VED\Policy\example.com` {
+		t.Errorf("unexpected disabled certificates: %v", disabled)
+	}
+}
+
+func TestRetireCertificateCloud(t *testing.T) {
+	certPEM := retireTestCertificate(t)
+
+	tests := map[string]struct {
+		searchResponse string
+		retireStatus   int
+		expectedErr    error
+		expectErr      bool
+	}{
+		"a certificate found in the inventory should be retired": {
+			searchResponse: `{"certificates":[{"id":"cert-id"}]}`,
+			retireStatus:   http.StatusOK,
+		},
+		"a certificate not found in the inventory should return ErrCertificateNotFound": {
+			searchResponse: `{"certificates":[]}`,
+			expectedErr:    ErrCertificateNotFound,
+			expectErr:      true,
+		},
+		"a failed retirement request should return an error": {
+			searchResponse: `{"certificates":[{"id":"cert-id"}]}`,
+			retireStatus:   http.StatusForbidden,
+			expectErr:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("tppl-api-key") != "test-key" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				switch r.URL.Path {
+				case "/outagedetection/v1/certificatesearch":
+					w.Write([]byte(test.searchResponse))
+				case "/outagedetection/v1/certificates/retirement":
+					var req struct {
+						CertificateIDs []string `json:"certificateIds"`
+					}
+					if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+						t.Fatal(err)
+					}
+					if len(req.CertificateIDs) != 1 || req.CertificateIDs[0] != "cert-id" {
+						t.Errorf("unexpected retirement request: %+v", req)
+					}
+					w.WriteHeader(test.retireStatus)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			v := &Venafi{config: &vcert.Config{
+				ConnectorType: endpoint.ConnectorTypeCloud,
+				BaseUrl:       server.URL,
+				Credentials:   &endpoint.Authentication{APIKey: "test-key"},
+			}}
+
+			err := v.RetireCertificate(certPEM)
+			if (err != nil) != test.expectErr {
+				t.Fatalf("unexpected error, exp=%t got=%v", test.expectErr, err)
+			}
+			if test.expectedErr != nil && !errors.Is(err, test.expectedErr) {
+				t.Errorf("unexpected error, exp=%v got=%v", test.expectedErr, err)
+			}
+		})
+	}
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/go-logr/logr"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
+	"github.com/cert-manager/cert-manager/internal/credentials"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/client/api"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
@@ -56,6 +57,7 @@ type Interface interface {
 	ReadZoneConfiguration() (*endpoint.ZoneConfiguration, error)
 	SetClient(endpoint.Connector)
 	VerifyCredentials() error
+	RetireCertificate(certPEM []byte) error
 }
 
 // Venafi is a implementation of vcert library to manager certificates from TPP or Venafi Cloud
@@ -85,12 +87,16 @@ type connector interface {
 
 // New constructs a Venafi client Interface. Errors may be network errors and
 // should be considered for retrying.
-func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.GenericIssuer, metrics *metrics.Metrics, logger logr.Logger) (Interface, error) {
+func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.GenericIssuer, m *metrics.Metrics, logger logr.Logger) (Interface, error) {
 	cfg, err := configForIssuer(issuer, secretsLister, namespace)
 	if err != nil {
 		return nil, err
 	}
 
+	// Instrument all HTTP requests made by vcert, including retries and
+	// authentication requests, like those of the other issuer clients.
+	cfg.Client.Transport = m.NewInstrumentedTransport(metrics.IssuerClientVenafi, cfg.Client.Transport, nil)
+
 	vcertClient, err := vcert.NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("error creating Venafi client: %s", err.Error())
@@ -112,7 +118,7 @@ func New(namespace string, secretsLister corelisters.SecretLister, issuer cmapi.
 		}
 	}
 
-	instrumentedVCertClient := newInstumentedConnector(vcertClient, metrics, logger)
+	instrumentedVCertClient := newInstumentedConnector(vcertClient, m, logger)
 
 	return &Venafi{
 		namespace:     namespace,
@@ -136,9 +142,18 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 			return nil, err
 		}
 
-		username := string(tppSecret.Data[tppUsernameKey])
-		password := string(tppSecret.Data[tppPasswordKey])
-		accessToken := string(tppSecret.Data[tppAccessTokenKey])
+		var username, password, accessToken string
+		for key, value := range map[string]*string{
+			tppUsernameKey:    &username,
+			tppPasswordKey:    &password,
+			tppAccessTokenKey: &accessToken,
+		} {
+			data, _, err := credentials.Value(tppSecret, key)
+			if err != nil {
+				return nil, err
+			}
+			*value = string(data)
+		}
 
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeTPP,
@@ -172,7 +187,11 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 		if cloud.APITokenSecretRef.Key != "" {
 			k = cloud.APITokenSecretRef.Key
 		}
-		apiKey := string(cloudSecret.Data[k])
+		apiKeyBytes, _, err := credentials.Value(cloudSecret, k)
+		if err != nil {
+			return nil, err
+		}
+		apiKey := string(apiKeyBytes)
 
 		return &vcert.Config{
 			ConnectorType: endpoint.ConnectorTypeCloud,
@@ -183,6 +202,7 @@ func configForIssuer(iss cmapi.GenericIssuer, secretsLister corelisters.SecretLi
 			Credentials: &endpoint.Authentication{
 				APIKey: apiKey,
 			},
+			Client: httpClientForVcertCloud(),
 		}, nil
 	}
 	// API validation in webhook and in the ClusterIssuer and Issuer controller
@@ -268,6 +288,17 @@ func httpClientForVcertTPP(caBundle []byte) *http.Client {
 	}
 }
 
+// httpClientForVcertCloud creates an HTTP client like the default client of
+// the vcert Cloud connector. We supply it to vcert so that its transport can
+// be instrumented.
+// https://github.com/Venafi/vcert/blob/89645a7710a7b529765274cb60dc5e28066217a1/pkg/venafi/cloud/cloud.go#L265-L297
+func httpClientForVcertCloud() *http.Client {
+	return &http.Client{
+		Transport: http.DefaultTransport.(*http.Transport).Clone(),
+		Timeout:   time.Second * 30,
+	}
+}
+
 func (v *Venafi) Ping() error {
 	return v.vcertClient.Ping()
 }
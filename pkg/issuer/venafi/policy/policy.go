@@ -0,0 +1,309 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package policy validates Certificates and CertificateRequests against the
+// Venafi zone policy cached in the status of a Venafi Issuer, so that
+// requests which would be rejected by Venafi can be reported early and with
+// field level reasons.
+package policy
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Venafi/vcert/v4/pkg/certificate"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// FromZoneConfiguration converts the zone configuration read from a Venafi
+// server into the policy that is cached in the status of an Issuer.
+func FromZoneConfiguration(zone string, config *endpoint.ZoneConfiguration) *cmapi.VenafiZonePolicy {
+	p := config.Policy
+
+	policy := &cmapi.VenafiZonePolicy{
+		Zone:                zone,
+		CommonNames:         p.SubjectCNRegexes,
+		DNSNames:            p.DnsSanRegExs,
+		IPAddresses:         p.IpSanRegExs,
+		EmailAddresses:      p.EmailSanRegExs,
+		URIs:                p.UriSanRegExs,
+		Organizations:       p.SubjectORegexes,
+		OrganizationalUnits: p.SubjectOURegexes,
+		Countries:           p.SubjectCRegexes,
+		Provinces:           p.SubjectSTRegexes,
+		Localities:          p.SubjectLRegexes,
+		AllowWildcards:      p.AllowWildcards,
+	}
+
+	for _, keyConfig := range p.AllowedKeyConfigurations {
+		switch keyConfig.KeyType {
+		case certificate.KeyTypeRSA:
+			policy.AllowedKeys = append(policy.AllowedKeys, cmapi.VenafiAllowedKey{
+				Algorithm: cmapi.RSAKeyAlgorithm,
+				Sizes:     keyConfig.KeySizes,
+			})
+		case certificate.KeyTypeECDSA:
+			var sizes []int
+			allowEd25519 := false
+			for _, curve := range keyConfig.KeyCurves {
+				switch curve {
+				case certificate.EllipticCurveP256:
+					sizes = append(sizes, pki.ECCurve256)
+				case certificate.EllipticCurveP384:
+					sizes = append(sizes, pki.ECCurve384)
+				case certificate.EllipticCurveP521:
+					sizes = append(sizes, pki.ECCurve521)
+				case certificate.EllipticCurveED25519:
+					allowEd25519 = true
+				}
+			}
+			if len(sizes) > 0 || len(keyConfig.KeyCurves) == 0 {
+				policy.AllowedKeys = append(policy.AllowedKeys, cmapi.VenafiAllowedKey{
+					Algorithm: cmapi.ECDSAKeyAlgorithm,
+					Sizes:     sizes,
+				})
+			}
+			if allowEd25519 {
+				policy.AllowedKeys = append(policy.AllowedKeys, cmapi.VenafiAllowedKey{
+					Algorithm: cmapi.Ed25519KeyAlgorithm,
+				})
+			}
+		}
+	}
+
+	return policy
+}
+
+// ForIssuer returns the zone policy cached in the status of the given issuer,
+// or nil if the issuer is not a Venafi issuer, no policy has been cached yet,
+// or the cached policy was read from a different zone than the one currently
+// configured.
+func ForIssuer(issuer cmapi.GenericIssuer) *cmapi.VenafiZonePolicy {
+	venafi := issuer.GetSpec().Venafi
+	status := issuer.GetStatus().Venafi
+	if venafi == nil || status == nil || status.ZonePolicy == nil {
+		return nil
+	}
+
+	if status.ZonePolicy.Zone != venafi.Zone {
+		return nil
+	}
+
+	return status.ZonePolicy
+}
+
+// request holds the fields of a certificate request that are restricted by
+// a zone policy.
+type request struct {
+	commonName          string
+	dnsNames            []string
+	ipAddresses         []string
+	emailAddresses      []string
+	uris                []string
+	organizations       []string
+	organizationalUnits []string
+	countries           []string
+	provinces           []string
+	localities          []string
+
+	keyAlgorithm cmapi.PrivateKeyAlgorithm
+	keySize      int
+}
+
+// ValidateCertificateSpec validates the given Certificate spec against the
+// zone policy. Field errors are reported relative to fldPath, which should be
+// the path of the spec.
+func ValidateCertificateSpec(policy *cmapi.VenafiZonePolicy, spec *cmapi.CertificateSpec, fldPath *field.Path) field.ErrorList {
+	req := request{
+		commonName:     spec.CommonName,
+		dnsNames:       spec.DNSNames,
+		ipAddresses:    spec.IPAddresses,
+		emailAddresses: spec.EmailAddresses,
+		uris:           spec.URIs,
+		keyAlgorithm:   cmapi.RSAKeyAlgorithm,
+	}
+
+	if spec.Subject != nil {
+		req.organizations = spec.Subject.Organizations
+		req.organizationalUnits = spec.Subject.OrganizationalUnits
+		req.countries = spec.Subject.Countries
+		req.provinces = spec.Subject.Provinces
+		req.localities = spec.Subject.Localities
+	}
+
+	if spec.PrivateKey != nil {
+		if len(spec.PrivateKey.Algorithm) > 0 {
+			req.keyAlgorithm = spec.PrivateKey.Algorithm
+		}
+		req.keySize = spec.PrivateKey.Size
+	}
+	if req.keySize == 0 {
+		switch req.keyAlgorithm {
+		case cmapi.RSAKeyAlgorithm:
+			req.keySize = pki.MinRSAKeySize
+		case cmapi.ECDSAKeyAlgorithm:
+			req.keySize = pki.ECCurve256
+		}
+	}
+
+	return validate(policy, req, fldPath)
+}
+
+// ValidateCertificateRequest validates the given x509 certificate request
+// against the zone policy. Field errors are reported relative to fldPath,
+// which should be the path of the encoded request.
+func ValidateCertificateRequest(policy *cmapi.VenafiZonePolicy, csr *x509.CertificateRequest, fldPath *field.Path) field.ErrorList {
+	req := request{
+		commonName:          csr.Subject.CommonName,
+		dnsNames:            csr.DNSNames,
+		emailAddresses:      csr.EmailAddresses,
+		organizations:       csr.Subject.Organization,
+		organizationalUnits: csr.Subject.OrganizationalUnit,
+		countries:           csr.Subject.Country,
+		provinces:           csr.Subject.Province,
+		localities:          csr.Subject.Locality,
+	}
+
+	for _, ip := range csr.IPAddresses {
+		req.ipAddresses = append(req.ipAddresses, ip.String())
+	}
+	for _, uri := range csr.URIs {
+		req.uris = append(req.uris, uri.String())
+	}
+
+	switch pub := csr.PublicKey.(type) {
+	case *rsa.PublicKey:
+		req.keyAlgorithm = cmapi.RSAKeyAlgorithm
+		req.keySize = pub.Size() * 8
+	case *ecdsa.PublicKey:
+		req.keyAlgorithm = cmapi.ECDSAKeyAlgorithm
+		req.keySize = pub.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		req.keyAlgorithm = cmapi.Ed25519KeyAlgorithm
+	}
+
+	return validate(policy, req, fldPath)
+}
+
+func validate(policy *cmapi.VenafiZonePolicy, req request, fldPath *field.Path) field.ErrorList {
+	var el field.ErrorList
+
+	if len(req.commonName) > 0 && !matchesAny(req.commonName, policy.CommonNames) {
+		el = append(el, notAllowed(fldPath.Child("commonName"), req.commonName, policy.CommonNames))
+	}
+
+	for i, name := range req.dnsNames {
+		path := fldPath.Child("dnsNames").Index(i)
+		if !policy.AllowWildcards && strings.HasPrefix(name, "*") {
+			el = append(el, field.Forbidden(path, fmt.Sprintf("wildcard DNS name %q is not allowed by Venafi zone %q", name, policy.Zone)))
+			continue
+		}
+		if !matchesAny(name, policy.DNSNames) {
+			el = append(el, notAllowed(path, name, policy.DNSNames))
+		}
+	}
+
+	el = append(el, validateList(fldPath.Child("ipAddresses"), req.ipAddresses, policy.IPAddresses)...)
+	el = append(el, validateList(fldPath.Child("emailAddresses"), req.emailAddresses, policy.EmailAddresses)...)
+	el = append(el, validateList(fldPath.Child("uris"), req.uris, policy.URIs)...)
+
+	subjectPath := fldPath.Child("subject")
+	el = append(el, validateList(subjectPath.Child("organizations"), req.organizations, policy.Organizations)...)
+	el = append(el, validateList(subjectPath.Child("organizationalUnits"), req.organizationalUnits, policy.OrganizationalUnits)...)
+	el = append(el, validateList(subjectPath.Child("countries"), req.countries, policy.Countries)...)
+	el = append(el, validateList(subjectPath.Child("provinces"), req.provinces, policy.Provinces)...)
+	el = append(el, validateList(subjectPath.Child("localities"), req.localities, policy.Localities)...)
+
+	if len(policy.AllowedKeys) > 0 && len(req.keyAlgorithm) > 0 {
+		el = append(el, validateKey(fldPath.Child("privateKey"), policy, req)...)
+	}
+
+	return el
+}
+
+func validateKey(fldPath *field.Path, policy *cmapi.VenafiZonePolicy, req request) field.ErrorList {
+	for _, allowed := range policy.AllowedKeys {
+		if allowed.Algorithm != req.keyAlgorithm {
+			continue
+		}
+
+		if len(allowed.Sizes) == 0 {
+			return nil
+		}
+		for _, size := range allowed.Sizes {
+			if size == req.keySize {
+				return nil
+			}
+		}
+
+		return field.ErrorList{field.NotSupported(fldPath.Child("size"), req.keySize, sizesToStrings(allowed.Sizes))}
+	}
+
+	var algorithms []string
+	for _, allowed := range policy.AllowedKeys {
+		algorithms = append(algorithms, string(allowed.Algorithm))
+	}
+
+	return field.ErrorList{field.NotSupported(fldPath.Child("algorithm"), req.keyAlgorithm, algorithms)}
+}
+
+func validateList(fldPath *field.Path, values []string, regexes []string) field.ErrorList {
+	var el field.ErrorList
+	for i, value := range values {
+		if !matchesAny(value, regexes) {
+			el = append(el, notAllowed(fldPath.Index(i), value, regexes))
+		}
+	}
+	return el
+}
+
+func notAllowed(fldPath *field.Path, value string, regexes []string) *field.Error {
+	return field.Invalid(fldPath, value, fmt.Sprintf("does not match any of the regular expressions allowed by the Venafi zone policy: %s", strings.Join(regexes, ", ")))
+}
+
+// matchesAny returns true if value matches any of the given regular
+// expressions, or if no regular expressions are given. Invalid regular
+// expressions never match, which is consistent with vcert.
+func matchesAny(value string, regexes []string) bool {
+	if len(regexes) == 0 {
+		return true
+	}
+
+	for _, r := range regexes {
+		if matched, err := regexp.MatchString(r, value); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sizesToStrings(sizes []int) []string {
+	s := make([]string, len(sizes))
+	for i, size := range sizes {
+		s[i] = fmt.Sprintf("%d", size)
+	}
+	return s
+}
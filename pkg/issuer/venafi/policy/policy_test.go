@@ -0,0 +1,197 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"crypto/x509"
+	"reflect"
+	"testing"
+
+	"github.com/Venafi/vcert/v4/pkg/certificate"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+var testPolicy = &cmapi.VenafiZonePolicy{
+	Zone:          "test-zone",
+	CommonNames:   []string{`^.*\.example\.com$`},
+	DNSNames:      []string{`^.*\.example\.com$`},
+	Organizations: []string{`^Example Inc$`},
+	AllowedKeys: []cmapi.VenafiAllowedKey{
+		{Algorithm: cmapi.RSAKeyAlgorithm, Sizes: []int{2048, 4096}},
+		{Algorithm: cmapi.ECDSAKeyAlgorithm, Sizes: []int{256}},
+	},
+}
+
+func TestFromZoneConfiguration(t *testing.T) {
+	config := &endpoint.ZoneConfiguration{
+		Policy: endpoint.Policy{
+			SubjectCNRegexes: []string{`.*`},
+			SubjectORegexes:  []string{`Example Inc`},
+			AllowedKeyConfigurations: []endpoint.AllowedKeyConfiguration{
+				{KeyType: certificate.KeyTypeRSA, KeySizes: []int{2048}},
+				{KeyType: certificate.KeyTypeECDSA, KeyCurves: []certificate.EllipticCurve{
+					certificate.EllipticCurveP256, certificate.EllipticCurveP384, certificate.EllipticCurveED25519,
+				}},
+			},
+			AllowWildcards: true,
+		},
+	}
+
+	expected := &cmapi.VenafiZonePolicy{
+		Zone:          "test-zone",
+		CommonNames:   []string{`.*`},
+		Organizations: []string{`Example Inc`},
+		AllowedKeys: []cmapi.VenafiAllowedKey{
+			{Algorithm: cmapi.RSAKeyAlgorithm, Sizes: []int{2048}},
+			{Algorithm: cmapi.ECDSAKeyAlgorithm, Sizes: []int{256, 384}},
+			{Algorithm: cmapi.Ed25519KeyAlgorithm},
+		},
+		AllowWildcards: true,
+	}
+
+	if got := FromZoneConfiguration("test-zone", config); !reflect.DeepEqual(expected, got) {
+		t.Errorf("unexpected zone policy, exp=%+v got=%+v", expected, got)
+	}
+}
+
+func TestValidateCertificateSpec(t *testing.T) {
+	tests := map[string]struct {
+		spec     cmapi.CertificateSpec
+		expected field.ErrorList
+	}{
+		"a Certificate satisfying the policy should be valid": {
+			spec: cmapi.CertificateSpec{
+				CommonName: "foo.example.com",
+				DNSNames:   []string{"foo.example.com", "bar.example.com"},
+				Subject:    &cmapi.X509Subject{Organizations: []string{"Example Inc"}},
+			},
+		},
+		"a Certificate with a disallowed common name and DNS name should be invalid": {
+			spec: cmapi.CertificateSpec{
+				CommonName: "foo.example.org",
+				DNSNames:   []string{"foo.example.com", "foo.example.org"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "commonName"), "foo.example.org", `does not match any of the regular expressions allowed by the Venafi zone policy: ^.*\.example\.com$`),
+				field.Invalid(field.NewPath("spec", "dnsNames").Index(1), "foo.example.org", `does not match any of the regular expressions allowed by the Venafi zone policy: ^.*\.example\.com$`),
+			},
+		},
+		"a Certificate with a wildcard DNS name should be invalid if wildcards are not allowed": {
+			spec: cmapi.CertificateSpec{
+				DNSNames: []string{"*.example.com"},
+			},
+			expected: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "dnsNames").Index(0), `wildcard DNS name "*.example.com" is not allowed by Venafi zone "test-zone"`),
+			},
+		},
+		"a Certificate with a disallowed organization should be invalid": {
+			spec: cmapi.CertificateSpec{
+				Subject: &cmapi.X509Subject{Organizations: []string{"Other Inc"}},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "subject", "organizations").Index(0), "Other Inc", `does not match any of the regular expressions allowed by the Venafi zone policy: ^Example Inc$`),
+			},
+		},
+		"a Certificate with a disallowed key size should be invalid": {
+			spec: cmapi.CertificateSpec{
+				PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384},
+			},
+			expected: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "privateKey", "size"), 384, []string{"256"}),
+			},
+		},
+		"a Certificate with a disallowed key algorithm should be invalid": {
+			spec: cmapi.CertificateSpec{
+				PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.Ed25519KeyAlgorithm},
+			},
+			expected: field.ErrorList{
+				field.NotSupported(field.NewPath("spec", "privateKey", "algorithm"), cmapi.Ed25519KeyAlgorithm, []string{"RSA", "ECDSA"}),
+			},
+		},
+		"a Certificate using the default RSA key size should be valid": {
+			spec: cmapi.CertificateSpec{
+				PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateCertificateSpec(testPolicy, &test.spec, field.NewPath("spec"))
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Errorf("unexpected errors, exp=%v got=%v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestValidateCertificateRequest(t *testing.T) {
+	tests := map[string]struct {
+		crt      *cmapi.Certificate
+		expected field.ErrorList
+	}{
+		"a request satisfying the policy should be valid": {
+			crt: gen.Certificate("test",
+				gen.SetCertificateDNSNames("foo.example.com"),
+				gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+				gen.SetCertificateKeySize(256),
+			),
+		},
+		"a request with a disallowed DNS name and key size should be invalid": {
+			crt: gen.Certificate("test",
+				gen.SetCertificateDNSNames("foo.example.org"),
+				gen.SetCertificateKeyAlgorithm(cmapi.ECDSAKeyAlgorithm),
+				gen.SetCertificateKeySize(384),
+			),
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "request", "dnsNames").Index(0), "foo.example.org", `does not match any of the regular expressions allowed by the Venafi zone policy: ^.*\.example\.com$`),
+				field.NotSupported(field.NewPath("spec", "request", "privateKey", "size"), 384, []string{"256"}),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pk, err := pki.GeneratePrivateKeyForCertificate(test.crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			template, err := pki.GenerateCSR(test.crt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			csrDER, err := pki.EncodeCSR(template, pk)
+			if err != nil {
+				t.Fatal(err)
+			}
+			csr, err := x509.ParseCertificateRequest(csrDER)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := ValidateCertificateRequest(testPolicy, csr, field.NewPath("spec", "request"))
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Errorf("unexpected errors, exp=%v got=%v", test.expected, got)
+			}
+		})
+	}
+}
@@ -21,10 +21,13 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	venaficlient "github.com/cert-manager/cert-manager/pkg/issuer/venafi/client"
+	"github.com/cert-manager/cert-manager/pkg/issuer/venafi/policy"
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 )
 
@@ -52,6 +55,8 @@ func (v *Venafi) Setup(ctx context.Context) (err error) {
 		return fmt.Errorf("client.VerifyCredentials: %v", err)
 	}
 
+	v.syncZonePolicy(client)
+
 	// If it does not already have a 'ready' condition, we'll also log an event
 	// to make it really clear to users that this Issuer is ready.
 	if !apiutil.IssuerHasCondition(v.issuer, cmapi.IssuerCondition{
@@ -65,3 +70,24 @@ func (v *Venafi) Setup(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// syncZonePolicy reads the policy of the configured zone and caches it in the
+// issuer status, so that Certificates and CertificateRequests can be
+// validated against it without contacting Venafi. Failing to read the policy
+// does not prevent the issuer from becoming ready; the previously cached
+// policy is kept instead, and its lastSyncTime shows how out of date it is.
+// The lastSyncTime is updated on every successful read.
+func (v *Venafi) syncZonePolicy(client venaficlient.Interface) {
+	zoneConfig, err := client.ReadZoneConfiguration()
+	if err != nil {
+		v.log.V(logf.WarnLevel).Info("failed to read Venafi zone configuration, keeping previously cached zone policy", "error", err.Error())
+		return
+	}
+
+	zonePolicy := policy.FromZoneConfiguration(v.issuer.GetSpec().Venafi.Zone, zoneConfig)
+
+	status := v.issuer.GetStatus()
+	now := metav1.NewTime(v.Clock.Now())
+	zonePolicy.LastSyncTime = &now
+	status.Venafi = &cmapi.VenafiIssuerStatus{ZonePolicy: zonePolicy}
+}
@@ -20,9 +20,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
+	"github.com/Venafi/vcert/v4/pkg/certificate"
+	"github.com/Venafi/vcert/v4/pkg/endpoint"
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
 
 	logf "github.com/cert-manager/cert-manager/pkg/logs"
 	"github.com/cert-manager/cert-manager/pkg/metrics"
@@ -38,8 +44,54 @@ import (
 	"github.com/cert-manager/cert-manager/test/unit/gen"
 )
 
+var fixedTime = metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC))
+
 func TestSetup(t *testing.T) {
-	baseIssuer := gen.Issuer("test-issuer")
+	baseIssuer := gen.Issuer("test-issuer",
+		gen.SetIssuerVenafi(cmapi.VenafiIssuer{Zone: "test-zone"}),
+	)
+
+	zoneConfiguration := &endpoint.ZoneConfiguration{
+		Policy: endpoint.Policy{
+			SubjectCNRegexes: []string{`.*\.example\.com`},
+			AllowedKeyConfigurations: []endpoint.AllowedKeyConfiguration{
+				{KeyType: certificate.KeyTypeRSA, KeySizes: []int{2048, 4096}},
+			},
+		},
+	}
+	zonePolicy := &cmapi.VenafiZonePolicy{
+		Zone:         "test-zone",
+		LastSyncTime: &fixedTime,
+		CommonNames:  []string{`.*\.example\.com`},
+		AllowedKeys: []cmapi.VenafiAllowedKey{
+			{Algorithm: cmapi.RSAKeyAlgorithm, Sizes: []int{2048, 4096}},
+		},
+	}
+	staleTime := metav1.NewTime(fixedTime.Add(-time.Hour))
+	staleZonePolicy := zonePolicy.DeepCopy()
+	staleZonePolicy.LastSyncTime = &staleTime
+
+	zonePolicyClient := func(string, corelisters.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger) (client.Interface, error) {
+		return &internalvenafifake.Venafi{
+			PingFn: func() error {
+				return nil
+			},
+			ReadZoneConfigurationFn: func() (*endpoint.ZoneConfiguration, error) {
+				return zoneConfiguration, nil
+			},
+		}, nil
+	}
+
+	failingZonePolicyClient := func(string, corelisters.SecretLister, cmapi.GenericIssuer, *metrics.Metrics, logr.Logger) (client.Interface, error) {
+		return &internalvenafifake.Venafi{
+			PingFn: func() error {
+				return nil
+			},
+			ReadZoneConfigurationFn: func() (*endpoint.ZoneConfiguration, error) {
+				return nil, errors.New("this is a zone error")
+			},
+		}, nil
+	}
 
 	failingClientBuilder := func(string, corelisters.SecretLister,
 		cmapi.GenericIssuer, *metrics.Metrics, logr.Logger) (client.Interface, error) {
@@ -146,6 +198,52 @@ func TestSetup(t *testing.T) {
 				Status:  "False",
 			},
 		},
+
+		"the zone policy should be cached in the issuer status": {
+			clientBuilder: zonePolicyClient,
+			iss:           baseIssuer.DeepCopy(),
+			expectedCondition: &cmapi.IssuerCondition{
+				Message: "Venafi issuer started",
+				Reason:  "Venafi issuer started",
+				Status:  "True",
+			},
+			expectedEvents: []string{
+				"Normal Ready Verified issuer with Venafi server",
+			},
+			expectedZonePolicy: zonePolicy,
+		},
+
+		"the last sync time of an unchanged zone policy should be updated": {
+			clientBuilder: zonePolicyClient,
+			iss: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerVenafiZonePolicy(staleZonePolicy),
+			),
+			expectedCondition: &cmapi.IssuerCondition{
+				Message: "Venafi issuer started",
+				Reason:  "Venafi issuer started",
+				Status:  "True",
+			},
+			expectedEvents: []string{
+				"Normal Ready Verified issuer with Venafi server",
+			},
+			expectedZonePolicy: zonePolicy,
+		},
+
+		"failing to read the zone policy should keep the cached policy and not fail setup": {
+			clientBuilder: failingZonePolicyClient,
+			iss: gen.IssuerFrom(baseIssuer,
+				gen.SetIssuerVenafiZonePolicy(staleZonePolicy),
+			),
+			expectedCondition: &cmapi.IssuerCondition{
+				Message: "Venafi issuer started",
+				Reason:  "Venafi issuer started",
+				Status:  "True",
+			},
+			expectedEvents: []string{
+				"Normal Ready Verified issuer with Venafi server",
+			},
+			expectedZonePolicy: staleZonePolicy,
+		},
 	}
 
 	for name, test := range tests {
@@ -159,9 +257,10 @@ type testSetupT struct {
 	clientBuilder client.VenafiClientBuilder
 	iss           cmapi.GenericIssuer
 
-	expectedErr       bool
-	expectedEvents    []string
-	expectedCondition *cmapi.IssuerCondition
+	expectedErr        bool
+	expectedEvents     []string
+	expectedCondition  *cmapi.IssuerCondition
+	expectedZonePolicy *cmapi.VenafiZonePolicy
 }
 
 func (s *testSetupT) runTest(t *testing.T) {
@@ -171,6 +270,9 @@ func (s *testSetupT) runTest(t *testing.T) {
 		resourceNamespace: "test-namespace",
 		Context: &controller.Context{
 			Recorder: rec,
+			ContextOptions: controller.ContextOptions{
+				Clock: fakeclock.NewFakeClock(fixedTime.Time),
+			},
 		},
 		issuer:        s.iss,
 		clientBuilder: s.clientBuilder,
@@ -190,6 +292,16 @@ func (s *testSetupT) runTest(t *testing.T) {
 			s.expectedEvents, rec.Events)
 	}
 
+	if s.expectedZonePolicy != nil {
+		var zonePolicy *cmapi.VenafiZonePolicy
+		if status := s.iss.GetStatus().Venafi; status != nil {
+			zonePolicy = status.ZonePolicy
+		}
+		if !reflect.DeepEqual(s.expectedZonePolicy, zonePolicy) {
+			t.Errorf("unexpected zone policy, exp=%+v got=%+v", s.expectedZonePolicy, zonePolicy)
+		}
+	}
+
 	conditions := s.iss.GetStatus().Conditions
 	if s.expectedCondition == nil &&
 		len(conditions) > 0 {
@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"time"
+)
+
+// ObserveCanarySuccess records a canary CertificateRequest for the given
+// issuer which was issued after the given duration, at the given time.
+func (m *Metrics) ObserveCanarySuccess(name, namespace, kind string, duration time.Duration, now time.Time) {
+	labels := []string{name, namespace, kind}
+	m.canaryIssuanceCount.WithLabelValues(append(labels, "success")...).Inc()
+	m.canaryIssuanceDurationSeconds.WithLabelValues(labels...).Observe(duration.Seconds())
+	m.canaryLastSuccessTimeSeconds.WithLabelValues(labels...).Set(float64(now.Unix()))
+}
+
+// IncrementCanaryFailure records a canary CertificateRequest for the given
+// issuer which did not succeed, with the given result.
+func (m *Metrics) IncrementCanaryFailure(name, namespace, kind, result string) {
+	m.canaryIssuanceCount.WithLabelValues(name, namespace, kind, result).Inc()
+}
+
+// RemoveCanary removes the canary metrics of the given issuer.
+func (m *Metrics) RemoveCanary(name, namespace, kind string) {
+	labels := map[string]string{"issuer_name": name, "issuer_namespace": namespace, "issuer_kind": kind}
+	m.canaryIssuanceCount.DeletePartialMatch(labels)
+	m.canaryIssuanceDurationSeconds.Delete(labels)
+	m.canaryLastSuccessTimeSeconds.Delete(labels)
+}
@@ -0,0 +1,29 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+// AddCTMonitorEntriesProcessed increases the number of entries checked in the
+// given Certificate Transparency log.
+func (m *Metrics) AddCTMonitorEntriesProcessed(log string, count int) {
+	m.ctMonitorEntriesProcessedCount.WithLabelValues(log).Add(float64(count))
+}
+
+// IncrementCTMonitorUnknownCertificate increases the number of unknown
+// certificates found in the given Certificate Transparency log.
+func (m *Metrics) IncrementCTMonitorUnknownCertificate(log string) {
+	m.ctMonitorUnknownCertificateCount.WithLabelValues(log).Inc()
+}
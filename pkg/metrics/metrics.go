@@ -21,8 +21,17 @@ limitations under the License.
 // certificate_ready_status{name, namespace, condition, issuer_name, issuer_kind, issuer_group}
 // acme_client_request_count{"scheme", "host", "path", "method", "status"}
 // acme_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
-// venafi_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// venafi_client_request_count{"scheme", "host", "path", "method", "status"}
+// venafi_client_request_duration_seconds{"api_call"}
+// vault_client_request_count{"scheme", "host", "path", "method", "status"}
+// vault_client_request_duration_seconds{"scheme", "host", "path", "method", "status"}
+// issuer_client_error_count{"client", "reason"}
 // controller_sync_call_count{"controller"}
+// ct_monitor_entries_processed_count{"log"}
+// ct_monitor_unknown_certificate_count{"log"}
+// canary_issuance_count{"issuer_name", "issuer_namespace", "issuer_kind", "result"}
+// canary_issuance_duration_seconds{"issuer_name", "issuer_namespace", "issuer_kind"}
+// canary_last_success_timestamp_seconds{"issuer_name", "issuer_namespace", "issuer_kind"}
 package metrics
 
 import (
@@ -59,9 +68,18 @@ type Metrics struct {
 	certificateReadyStatus             *prometheus.GaugeVec
 	acmeClientRequestDurationSeconds   *prometheus.SummaryVec
 	acmeClientRequestCount             *prometheus.CounterVec
+	venafiClientRequestCount           *prometheus.CounterVec
 	venafiClientRequestDurationSeconds *prometheus.SummaryVec
+	vaultClientRequestCount            *prometheus.CounterVec
+	vaultClientRequestDurationSeconds  *prometheus.SummaryVec
+	issuerClientErrorCount             *prometheus.CounterVec
 	controllerSyncCallCount            *prometheus.CounterVec
 	controllerSyncErrorCount           *prometheus.CounterVec
+	ctMonitorEntriesProcessedCount     *prometheus.CounterVec
+	ctMonitorUnknownCertificateCount   *prometheus.CounterVec
+	canaryIssuanceCount                *prometheus.CounterVec
+	canaryIssuanceDurationSeconds      *prometheus.SummaryVec
+	canaryLastSuccessTimeSeconds       *prometheus.GaugeVec
 }
 
 var readyConditionStatuses = [...]cmmeta.ConditionStatus{cmmeta.ConditionTrue, cmmeta.ConditionFalse, cmmeta.ConditionUnknown}
@@ -152,6 +170,18 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"scheme", "host", "path", "method", "status"},
 		)
 
+		// venafiClientRequestCount is a Prometheus counter to collect the
+		// number of HTTP requests made to each endpoint by the Venafi client.
+		venafiClientRequestCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "venafi_client_request_count",
+				Help:      "The number of requests made by the Venafi client.",
+				Subsystem: "http",
+			},
+			[]string{"scheme", "host", "path", "method", "status"},
+		)
+
 		// venafiClientRequestDurationSeconds is a Prometheus summary to
 		// collect api call latencies for the Venafi client. This
 		// metric is in alpha since cert-manager 1.9. Move it to GA once
@@ -167,6 +197,44 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			[]string{"api_call"},
 		)
 
+		// vaultClientRequestCount is a Prometheus counter to collect the
+		// number of requests made to each endpoint with the Vault client.
+		vaultClientRequestCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "vault_client_request_count",
+				Help:      "The number of requests made by the Vault client.",
+				Subsystem: "http",
+			},
+			[]string{"scheme", "host", "path", "method", "status"},
+		)
+
+		// vaultClientRequestDurationSeconds is a Prometheus summary to
+		// collect request times for the Vault client.
+		vaultClientRequestDurationSeconds = prometheus.NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  namespace,
+				Name:       "vault_client_request_duration_seconds",
+				Help:       "The HTTP request latencies in seconds for the Vault client.",
+				Subsystem:  "http",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			[]string{"scheme", "host", "path", "method", "status"},
+		)
+
+		// issuerClientErrorCount is a Prometheus counter to collect the
+		// number of failed requests made by issuer clients, classified by
+		// the reason of the failure.
+		issuerClientErrorCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "issuer_client_error_count",
+				Help:      "The number of failed requests made by issuer clients, by reason of the failure (auth, policy or transient).",
+				Subsystem: "http",
+			},
+			[]string{"client", "reason"},
+		)
+
 		controllerSyncCallCount = prometheus.NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: namespace,
@@ -184,6 +252,64 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 			},
 			[]string{"controller"},
 		)
+
+		// ctMonitorEntriesProcessedCount is a Prometheus counter to collect the
+		// number of Certificate Transparency log entries checked by the
+		// certificates-ct-monitor controller.
+		ctMonitorEntriesProcessedCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "ct_monitor_entries_processed_count",
+				Help:      "The number of Certificate Transparency log entries checked for certificates of monitored domains.",
+			},
+			[]string{"log"},
+		)
+
+		// ctMonitorUnknownCertificateCount is a Prometheus counter to collect
+		// the number of logged certificates for monitored domains which were
+		// not issued by cert-manager.
+		ctMonitorUnknownCertificateCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "ct_monitor_unknown_certificate_count",
+				Help:      "The number of certificates for monitored domains found in Certificate Transparency logs which were not issued by cert-manager.",
+			},
+			[]string{"log"},
+		)
+
+		// canaryIssuanceCount is a Prometheus counter to collect the number
+		// of canary CertificateRequests completed by the issuers-canary
+		// controller, by result.
+		canaryIssuanceCount = prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "canary_issuance_count",
+				Help:      "The number of canary CertificateRequests completed for an issuer, by result (success, failed, denied or timeout).",
+			},
+			[]string{"issuer_name", "issuer_namespace", "issuer_kind", "result"},
+		)
+
+		// canaryIssuanceDurationSeconds is a Prometheus summary to collect the
+		// time between creating a canary CertificateRequest and it becoming
+		// ready.
+		canaryIssuanceDurationSeconds = prometheus.NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  namespace,
+				Name:       "canary_issuance_duration_seconds",
+				Help:       "The time in seconds taken to issue successful canary CertificateRequests for an issuer.",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+			},
+			[]string{"issuer_name", "issuer_namespace", "issuer_kind"},
+		)
+
+		canaryLastSuccessTimeSeconds = prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "canary_last_success_timestamp_seconds",
+				Help:      "The time at which a canary CertificateRequest for an issuer last succeeded. Expressed as a Unix Epoch Time.",
+			},
+			[]string{"issuer_name", "issuer_namespace", "issuer_kind"},
+		)
 	)
 
 	// Create server and register Prometheus metrics handler
@@ -198,9 +324,18 @@ func New(log logr.Logger, c clock.Clock) *Metrics {
 		certificateReadyStatus:             certificateReadyStatus,
 		acmeClientRequestCount:             acmeClientRequestCount,
 		acmeClientRequestDurationSeconds:   acmeClientRequestDurationSeconds,
+		venafiClientRequestCount:           venafiClientRequestCount,
 		venafiClientRequestDurationSeconds: venafiClientRequestDurationSeconds,
+		vaultClientRequestCount:            vaultClientRequestCount,
+		vaultClientRequestDurationSeconds:  vaultClientRequestDurationSeconds,
+		issuerClientErrorCount:             issuerClientErrorCount,
 		controllerSyncCallCount:            controllerSyncCallCount,
 		controllerSyncErrorCount:           controllerSyncErrorCount,
+		ctMonitorEntriesProcessedCount:     ctMonitorEntriesProcessedCount,
+		ctMonitorUnknownCertificateCount:   ctMonitorUnknownCertificateCount,
+		canaryIssuanceCount:                canaryIssuanceCount,
+		canaryIssuanceDurationSeconds:      canaryIssuanceDurationSeconds,
+		canaryLastSuccessTimeSeconds:       canaryLastSuccessTimeSeconds,
 	}
 
 	return m
@@ -216,8 +351,17 @@ func (m *Metrics) NewServer(ln net.Listener) *http.Server {
 	m.registry.MustRegister(m.acmeClientRequestDurationSeconds)
 	m.registry.MustRegister(m.venafiClientRequestDurationSeconds)
 	m.registry.MustRegister(m.acmeClientRequestCount)
+	m.registry.MustRegister(m.venafiClientRequestCount)
+	m.registry.MustRegister(m.vaultClientRequestDurationSeconds)
+	m.registry.MustRegister(m.vaultClientRequestCount)
+	m.registry.MustRegister(m.issuerClientErrorCount)
 	m.registry.MustRegister(m.controllerSyncCallCount)
 	m.registry.MustRegister(m.controllerSyncErrorCount)
+	m.registry.MustRegister(m.ctMonitorEntriesProcessedCount)
+	m.registry.MustRegister(m.ctMonitorUnknownCertificateCount)
+	m.registry.MustRegister(m.canaryIssuanceCount)
+	m.registry.MustRegister(m.canaryIssuanceDurationSeconds)
+	m.registry.MustRegister(m.canaryLastSuccessTimeSeconds)
 
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{}))
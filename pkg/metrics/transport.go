@@ -0,0 +1,170 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// This file implements an instrumented HTTP round tripper which is shared by
+// the clients of all issuer backends, so that every backend exposes the same
+// request metrics.
+//
+// We implement this as part of the HTTP client to ensure we don't miss any
+// calls made to the issuer caused by retries in the underlying libraries.
+
+// IssuerClient is the issuer backend whose requests are instrumented.
+type IssuerClient string
+
+const (
+	IssuerClientACME   IssuerClient = "acme"
+	IssuerClientVenafi IssuerClient = "venafi"
+	IssuerClientVault  IssuerClient = "vault"
+)
+
+// Reasons used to classify the failed requests of issuer clients in the
+// issuer_client_error_count metric.
+const (
+	// ErrorReasonAuth is used for requests rejected because the client
+	// failed to authenticate or is not authorized.
+	ErrorReasonAuth = "auth"
+	// ErrorReasonPolicy is used for requests rejected by the issuer, for
+	// example because they violate the policy configured in the issuer.
+	ErrorReasonPolicy = "policy"
+	// ErrorReasonTransient is used for requests which are expected to
+	// succeed when retried, like network errors, rate limits and server
+	// errors.
+	ErrorReasonTransient = "transient"
+)
+
+// ErrorClassifier returns the reason why a request failed, given its response
+// and error. It returns an empty string if the request did not fail or the
+// failure can not be classified.
+type ErrorClassifier func(resp *http.Response, err error) string
+
+// Transport is a http.RoundTripper that collects Prometheus metrics of every
+// request it processes for an issuer client.
+type Transport struct {
+	metrics  *Metrics
+	client   IssuerClient
+	classify ErrorClassifier
+
+	wrappedRT http.RoundTripper
+}
+
+// NewInstrumentedTransport wraps the given http.RoundTripper with
+// instrumentation for the given issuer client. If rt is nil,
+// http.DefaultTransport is used. If classify is nil, failed requests are
+// classified using ClassifyHTTPError.
+func (m *Metrics) NewInstrumentedTransport(client IssuerClient, rt http.RoundTripper, classify ErrorClassifier) *Transport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	if classify == nil {
+		classify = ClassifyHTTPError
+	}
+
+	return &Transport{
+		metrics:   m,
+		client:    client,
+		classify:  classify,
+		wrappedRT: rt,
+	}
+}
+
+// RoundTrip implements http.RoundTripper. It forwards the request to the
+// wrapped RoundTripper and records its duration, status and, if it failed,
+// the classification of the failure.
+func (it *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	statusCode := 999
+
+	// Remember the current time.
+	start := time.Now()
+
+	// Make the request using the wrapped RoundTripper.
+	resp, err := it.wrappedRT.RoundTrip(req)
+	if resp != nil {
+		statusCode = resp.StatusCode
+	}
+	duration := time.Since(start)
+
+	labels := []string{
+		req.URL.Scheme,
+		req.URL.Host,
+		pathProcessor(req.URL.Path),
+		req.Method,
+		fmt.Sprintf("%d", statusCode),
+	}
+
+	switch it.client {
+	case IssuerClientACME:
+		it.metrics.ObserveACMERequestDuration(duration, labels...)
+		it.metrics.IncrementACMERequestCount(labels...)
+	case IssuerClientVenafi:
+		// The Venafi request duration is observed per API call by the
+		// instrumented vcert connector, which may make several requests.
+		it.metrics.IncrementVenafiRequestCount(labels...)
+	case IssuerClientVault:
+		it.metrics.ObserveVaultRequestDuration(duration, labels...)
+		it.metrics.IncrementVaultRequestCount(labels...)
+	}
+
+	if reason := it.classify(resp, err); reason != "" {
+		it.metrics.IncrementIssuerClientErrorCount(string(it.client), reason)
+	}
+
+	// return the response and error reported from the next RoundTripper.
+	return resp, err
+}
+
+// IncrementIssuerClientErrorCount increases the counter of failed requests of
+// the given issuer client for the given reason.
+func (m *Metrics) IncrementIssuerClientErrorCount(client, reason string) {
+	m.issuerClientErrorCount.WithLabelValues(client, reason).Inc()
+}
+
+// ClassifyHTTPError classifies failed requests by their status code.
+// Requests which failed without a response are considered transient.
+func ClassifyHTTPError(resp *http.Response, err error) string {
+	if err != nil || resp == nil {
+		return ErrorReasonTransient
+	}
+
+	switch code := resp.StatusCode; {
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return ErrorReasonAuth
+	case code == http.StatusRequestTimeout, code == http.StatusTooManyRequests, code >= 500:
+		return ErrorReasonTransient
+	case code == http.StatusBadRequest, code == http.StatusConflict, code == http.StatusUnprocessableEntity:
+		return ErrorReasonPolicy
+	}
+	return ""
+}
+
+// pathProcessor will trim the provided path to only include the first 2
+// segments in order to reduce the number of prometheus labels generated
+func pathProcessor(path string) string {
+	p := strings.Split(path, "/")
+	// only record the first two path segments as a prometheus label value
+	if len(p) > 3 {
+		p = p[:3]
+	}
+	return strings.Join(p, "/")
+}
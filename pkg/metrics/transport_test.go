@@ -0,0 +1,117 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logtesting "github.com/go-logr/logr/testing"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/clock"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestInstrumentedTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/pki/sign/role":
+			w.WriteHeader(http.StatusBadRequest)
+		case "/v1/auth/kubernetes/login":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	m := New(logtesting.NewTestLogger(t), clock.RealClock{})
+	client := &http.Client{Transport: m.NewInstrumentedTransport(IssuerClientVault, nil, nil)}
+	for _, path := range []string{"/v1/sys/health", "/v1/sys/health", "/v1/pki/sign/role", "/v1/auth/kubernetes/login"} {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	venafiClient := &http.Client{Transport: m.NewInstrumentedTransport(IssuerClientVenafi, roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return nil, errors.New("connection refused")
+	}), nil)}
+	_, err := venafiClient.Get("https://venafi.example.com/vedsdk/certificates/request")
+	require.Error(t, err)
+
+	assert.NoError(t, testutil.CollectAndCompare(m.vaultClientRequestCount, strings.NewReader(`
+# HELP certmanager_http_vault_client_request_count The number of requests made by the Vault client.
+# TYPE certmanager_http_vault_client_request_count counter
+certmanager_http_vault_client_request_count{host="`+host+`",method="GET",path="/v1/auth",scheme="http",status="403"} 1
+certmanager_http_vault_client_request_count{host="`+host+`",method="GET",path="/v1/pki",scheme="http",status="400"} 1
+certmanager_http_vault_client_request_count{host="`+host+`",method="GET",path="/v1/sys",scheme="http",status="200"} 2
+`)))
+	assert.Equal(t, 3, testutil.CollectAndCount(m.vaultClientRequestDurationSeconds))
+
+	assert.NoError(t, testutil.CollectAndCompare(m.venafiClientRequestCount, strings.NewReader(`
+# HELP certmanager_http_venafi_client_request_count The number of requests made by the Venafi client.
+# TYPE certmanager_http_venafi_client_request_count counter
+certmanager_http_venafi_client_request_count{host="venafi.example.com",method="GET",path="/vedsdk/certificates",scheme="https",status="999"} 1
+`)))
+
+	assert.NoError(t, testutil.CollectAndCompare(m.issuerClientErrorCount, strings.NewReader(`
+# HELP certmanager_http_issuer_client_error_count The number of failed requests made by issuer clients, by reason of the failure (auth, policy or transient).
+# TYPE certmanager_http_issuer_client_error_count counter
+certmanager_http_issuer_client_error_count{client="vault",reason="auth"} 1
+certmanager_http_issuer_client_error_count{client="vault",reason="policy"} 1
+certmanager_http_issuer_client_error_count{client="venafi",reason="transient"} 1
+`)))
+}
+
+func TestClassifyHTTPError(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		err    error
+		exp    string
+	}{
+		"success":             {status: http.StatusOK, exp: ""},
+		"not found":           {status: http.StatusNotFound, exp: ""},
+		"network error":       {err: errors.New("connection reset"), exp: ErrorReasonTransient},
+		"unauthorized":        {status: http.StatusUnauthorized, exp: ErrorReasonAuth},
+		"forbidden":           {status: http.StatusForbidden, exp: ErrorReasonAuth},
+		"bad request":         {status: http.StatusBadRequest, exp: ErrorReasonPolicy},
+		"unprocessable":       {status: http.StatusUnprocessableEntity, exp: ErrorReasonPolicy},
+		"too many requests":   {status: http.StatusTooManyRequests, exp: ErrorReasonTransient},
+		"service unavailable": {status: http.StatusServiceUnavailable, exp: ErrorReasonTransient},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var resp *http.Response
+			if test.err == nil {
+				resp = &http.Response{StatusCode: test.status}
+			}
+			assert.Equal(t, test.exp, ClassifyHTTPError(resp, test.err))
+		})
+	}
+}
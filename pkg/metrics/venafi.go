@@ -24,3 +24,8 @@ import (
 func (m *Metrics) ObserveVenafiRequestDuration(duration time.Duration, labels ...string) {
 	m.venafiClientRequestDurationSeconds.WithLabelValues(labels...).Observe(duration.Seconds())
 }
+
+// IncrementVenafiRequestCount increases the Venafi client request counter.
+func (m *Metrics) IncrementVenafiRequestCount(labels ...string) {
+	m.venafiClientRequestCount.WithLabelValues(labels...).Inc()
+}
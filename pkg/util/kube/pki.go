@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	corelisters "k8s.io/client-go/listers/core/v1"
 
+	"github.com/cert-manager/cert-manager/internal/credentials"
 	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	"github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -38,9 +39,17 @@ func SecretTLSKeyRef(ctx context.Context, secretLister corelisters.SecretLister,
 		return nil, err
 	}
 
-	key, _, err := ParseTLSKeyFromSecret(secret, keyName)
+	keyBytes, ok, err := credentials.Value(secret, keyName)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInvalidData(err.Error())
+	}
+	if !ok {
+		return nil, errors.NewInvalidData("no data for %q in secret '%s/%s'", keyName, secret.Namespace, secret.Name)
+	}
+
+	key, err := pki.DecodePrivateKeyBytes(keyBytes)
+	if err != nil {
+		return nil, errors.NewInvalidData(err.Error())
 	}
 
 	return key, nil
@@ -120,7 +129,10 @@ func SecretTLSKeyPair(ctx context.Context, secretLister corelisters.SecretLister
 		return nil, nil, err
 	}
 
-	keyBytes, ok := secret.Data[corev1.TLSPrivateKeyKey]
+	keyBytes, ok, err := credentials.Value(secret, corev1.TLSPrivateKeyKey)
+	if err != nil {
+		return nil, nil, errors.NewInvalidData(err.Error())
+	}
 	if !ok {
 		return nil, nil, errors.NewInvalidData("no private key data for %q in secret '%s/%s'", corev1.TLSPrivateKeyKey, namespace, name)
 	}
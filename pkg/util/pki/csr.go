@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"crypto"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
@@ -58,13 +59,20 @@ func URIsForCertificate(crt *v1.Certificate) ([]*url.URL, error) {
 	return uris, nil
 }
 
+// DNSNamesForCertificate returns the DNS names of the given Certificate, with
+// internationalized names converted to their ASCII form.
 func DNSNamesForCertificate(crt *v1.Certificate) ([]string, error) {
 	_, err := URLsFromStrings(crt.Spec.DNSNames)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse DNSNames: %s", err)
 	}
 
-	return crt.Spec.DNSNames, nil
+	dnsNames, err := DNSNamesToASCII(crt.Spec.DNSNames)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DNSNames: %w", err)
+	}
+
+	return dnsNames, nil
 }
 
 func URLsFromStrings(urlStrs []string) ([]*url.URL, error) {
@@ -182,6 +190,7 @@ func GenerateCSR(crt *v1.Certificate) (*x509.CertificateRequest, error) {
 	if err != nil {
 		return nil, err
 	}
+	commonName = commonNameToASCII(commonName, crt.Spec.DNSNames)
 
 	uriNames, err := URIsForCertificate(crt)
 	if err != nil {
@@ -284,7 +293,11 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 		return nil, err
 	}
 
-	dnsNames := crt.Spec.DNSNames
+	dnsNames, err := DNSNamesForCertificate(crt)
+	if err != nil {
+		return nil, err
+	}
+	commonName = commonNameToASCII(commonName, crt.Spec.DNSNames)
 	ipAddresses := IPAddressesForCertificate(crt)
 	organization := OrganizationForCertificate(crt)
 	subject := SubjectForCertificate(crt)
@@ -308,10 +321,15 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 
 	certDuration := apiutil.DefaultCertDuration(crt.Spec.Duration)
 
-	pubKeyAlgo, _, err := SignatureAlgorithm(crt)
+	pubKeyAlgo, sigAlgo, err := SignatureAlgorithm(crt)
 	if err != nil {
 		return nil, err
 	}
+	// Other signature algorithms are chosen by SignCertificate based on the
+	// key of the signer.
+	if !IsRSAPSSSignatureAlgorithm(sigAlgo) {
+		sigAlgo = x509.UnknownSignatureAlgorithm
+	}
 
 	cert := &x509.Certificate{
 		// Version must be 2 according to RFC5280.
@@ -322,6 +340,7 @@ func GenerateTemplate(crt *v1.Certificate) (*x509.Certificate, error) {
 		BasicConstraintsValid: true,
 		SerialNumber:          serialNumber,
 		PublicKeyAlgorithm:    pubKeyAlgo,
+		SignatureAlgorithm:    sigAlgo,
 		IsCA:                  crt.Spec.IsCA,
 		NotBefore:             time.Now(),
 		NotAfter:              time.Now().Add(certDuration),
@@ -397,6 +416,13 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 		return nil, fmt.Errorf("failed to generate serial number: %s", err.Error())
 	}
 
+	// Requests signed using RSASSA-PSS are also signed using RSASSA-PSS, if
+	// the signer has an RSA key.
+	var sigAlgo x509.SignatureAlgorithm
+	if IsRSAPSSSignatureAlgorithm(csr.SignatureAlgorithm) {
+		sigAlgo = csr.SignatureAlgorithm
+	}
+
 	return &x509.Certificate{
 		// Version must be 2 according to RFC5280.
 		// A version value of 2 confusingly means version 3.
@@ -405,6 +431,7 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 		Version:               2,
 		BasicConstraintsValid: true,
 		SerialNumber:          serialNumber,
+		SignatureAlgorithm:    sigAlgo,
 		PublicKeyAlgorithm:    csr.PublicKeyAlgorithm,
 		PublicKey:             csr.PublicKey,
 		IsCA:                  isCA,
@@ -428,7 +455,19 @@ func GenerateTemplateFromCSRPEMWithUsages(csrPEM []byte, duration time.Duration,
 // key of the signer.
 // It returns a PEM encoded copy of the Certificate as well as a *x509.Certificate
 // which can be used for reading the encoded values.
+// If the template requests an RSASSA-PSS signature but the signer does not
+// have an RSA key, the signature algorithm is chosen based on the signer key.
 func SignCertificate(template *x509.Certificate, issuerCert *x509.Certificate, publicKey crypto.PublicKey, signerKey interface{}) ([]byte, *x509.Certificate, error) {
+	if IsRSAPSSSignatureAlgorithm(template.SignatureAlgorithm) {
+		if signer, ok := signerKey.(crypto.Signer); ok {
+			if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+				t := *template
+				t.SignatureAlgorithm = x509.UnknownSignatureAlgorithm
+				template = &t
+			}
+		}
+	}
+
 	derBytes, err := x509.CreateCertificate(rand.Reader, template, issuerCert, publicKey, signerKey)
 
 	if err != nil {
@@ -523,7 +562,8 @@ func EncodeX509Chain(certs []*x509.Certificate) ([]byte, error) {
 }
 
 // SignatureAlgorithm will determine the appropriate signature algorithm for
-// the given certificate.
+// the given certificate. RSA keys use RSASSA-PSS if requested by
+// spec.privateKey.signatureScheme, and RSASSA-PKCS1-v1_5 otherwise.
 // Adapted from https://github.com/cloudflare/cfssl/blob/master/csr/csr.go#L102
 func SignatureAlgorithm(crt *v1.Certificate) (x509.PublicKeyAlgorithm, x509.SignatureAlgorithm, error) {
 	var sigAlgo x509.SignatureAlgorithm
@@ -572,9 +612,30 @@ func SignatureAlgorithm(crt *v1.Certificate) (x509.PublicKeyAlgorithm, x509.Sign
 	default:
 		return x509.UnknownPublicKeyAlgorithm, x509.UnknownSignatureAlgorithm, fmt.Errorf("unsupported algorithm specified: %s. should be either 'ecdsa' or 'rsa", crt.Spec.PrivateKey.Algorithm)
 	}
+	if pubKeyAlgo == x509.RSA && crt.Spec.PrivateKey != nil && crt.Spec.PrivateKey.SignatureScheme == v1.PSSSignatureScheme {
+		sigAlgo = rsaPSSSignatureAlgorithms[sigAlgo]
+	}
 	return pubKeyAlgo, sigAlgo, nil
 }
 
+// rsaPSSSignatureAlgorithms maps RSASSA-PKCS1-v1_5 signature algorithms to the
+// RSASSA-PSS signature algorithm using the same hash.
+var rsaPSSSignatureAlgorithms = map[x509.SignatureAlgorithm]x509.SignatureAlgorithm{
+	x509.SHA256WithRSA: x509.SHA256WithRSAPSS,
+	x509.SHA384WithRSA: x509.SHA384WithRSAPSS,
+	x509.SHA512WithRSA: x509.SHA512WithRSAPSS,
+}
+
+// IsRSAPSSSignatureAlgorithm returns true if the given signature algorithm is
+// an RSASSA-PSS signature algorithm.
+func IsRSAPSSSignatureAlgorithm(sigAlgo x509.SignatureAlgorithm) bool {
+	switch sigAlgo {
+	case x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS:
+		return true
+	}
+	return false
+}
+
 func extractCommonName(spec v1.CertificateSpec) (string, error) {
 	var commonName = spec.CommonName
 	if isLiteralCertificateSubjectEnabled() && len(spec.LiteralSubject) > 0 {
@@ -22,6 +22,7 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"encoding/pem"
 	"math/big"
 	"reflect"
 	"testing"
@@ -232,6 +233,7 @@ func TestSignatureAlgorithmForCertificate(t *testing.T) {
 		name            string
 		keyAlgo         cmapi.PrivateKeyAlgorithm
 		keySize         int
+		signatureScheme cmapi.PrivateKeySignatureScheme
 		expectErr       bool
 		expectedSigAlgo x509.SignatureAlgorithm
 		expectedKeyType x509.PublicKeyAlgorithm
@@ -277,6 +279,36 @@ func TestSignatureAlgorithmForCertificate(t *testing.T) {
 			expectedSigAlgo: x509.SHA512WithRSA,
 			expectedKeyType: x509.RSA,
 		},
+		{
+			name:            "certificate with KeyAlgorithm rsa, size 2048 and PSS signature scheme",
+			keyAlgo:         cmapi.RSAKeyAlgorithm,
+			keySize:         2048,
+			signatureScheme: cmapi.PSSSignatureScheme,
+			expectedSigAlgo: x509.SHA256WithRSAPSS,
+			expectedKeyType: x509.RSA,
+		},
+		{
+			name:            "certificate with KeyAlgorithm rsa, size 4096 and PSS signature scheme",
+			keyAlgo:         cmapi.RSAKeyAlgorithm,
+			keySize:         4096,
+			signatureScheme: cmapi.PSSSignatureScheme,
+			expectedSigAlgo: x509.SHA512WithRSAPSS,
+			expectedKeyType: x509.RSA,
+		},
+		{
+			name:            "certificate with KeyAlgorithm not set and PSS signature scheme",
+			signatureScheme: cmapi.PSSSignatureScheme,
+			expectedSigAlgo: x509.SHA256WithRSAPSS,
+			expectedKeyType: x509.RSA,
+		},
+		{
+			name:            "certificate with KeyAlgorithm rsa, size 2048 and PKCS1v15 signature scheme",
+			keyAlgo:         cmapi.RSAKeyAlgorithm,
+			keySize:         2048,
+			signatureScheme: cmapi.PKCS1v15SignatureScheme,
+			expectedSigAlgo: x509.SHA256WithRSA,
+			expectedKeyType: x509.RSA,
+		},
 		{
 			name:            "certificate with ecdsa key algorithm set and no key size default to ecdsa256",
 			keyAlgo:         cmapi.ECDSAKeyAlgorithm,
@@ -325,7 +357,9 @@ func TestSignatureAlgorithmForCertificate(t *testing.T) {
 
 	testFn := func(test testT) func(*testing.T) {
 		return func(t *testing.T) {
-			actualPKAlgo, actualSigAlgo, err := SignatureAlgorithm(buildCertificateWithKeyParams(test.keyAlgo, test.keySize))
+			crt := buildCertificateWithKeyParams(test.keyAlgo, test.keySize)
+			crt.Spec.PrivateKey.SignatureScheme = test.signatureScheme
+			actualPKAlgo, actualSigAlgo, err := SignatureAlgorithm(crt)
 			if test.expectErr && err == nil {
 				t.Error("expected err, but got no error")
 				return
@@ -835,6 +869,64 @@ func TestSignCSRTemplate(t *testing.T) {
 	}
 }
 
+func TestSignCertificateRSAPSS(t *testing.T) {
+	crt := buildCertificateWithKeyParams(cmapi.RSAKeyAlgorithm, 2048)
+	crt.Spec.PrivateKey.SignatureScheme = cmapi.PSSSignatureScheme
+
+	pk, err := GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	csrTemplate, err := GenerateCSR(crt)
+	require.NoError(t, err)
+	csrDER, err := EncodeCSR(csrTemplate, pk)
+	require.NoError(t, err)
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	require.NoError(t, err)
+	assert.Equal(t, x509.SHA256WithRSAPSS, csr.SignatureAlgorithm)
+
+	ecPK, err := GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	ecCA := &x509.Certificate{
+		SerialNumber: big.NewInt(0),
+		Subject:      pkix.Name{CommonName: "ca"},
+		PublicKey:    ecPK.Public(),
+	}
+
+	tests := map[string]struct {
+		issuerCert      *x509.Certificate
+		signerKey       crypto.Signer
+		expectedSigAlgo x509.SignatureAlgorithm
+	}{
+		"an RSA signer signs using RSASSA-PSS": {
+			signerKey:       pk,
+			expectedSigAlgo: x509.SHA256WithRSAPSS,
+		},
+		"an ECDSA signer signs using ECDSA": {
+			issuerCert:      ecCA,
+			signerKey:       ecPK,
+			expectedSigAlgo: x509.ECDSAWithSHA256,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			template, err := GenerateTemplateFromCSRPEM(csrPEM, time.Hour, false)
+			require.NoError(t, err)
+
+			issuerCert := test.issuerCert
+			if issuerCert == nil {
+				issuerCert = template
+			}
+
+			_, cert, err := SignCertificate(template, issuerCert, template.PublicKey, test.signerKey)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedSigAlgo, cert.SignatureAlgorithm)
+		})
+	}
+}
+
 func TestEncodeX509Chain(t *testing.T) {
 	root := mustCreateBundle(t, nil, "root")
 	intA1 := mustCreateBundle(t, root, "intA-1")
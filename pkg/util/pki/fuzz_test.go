@@ -0,0 +1,401 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/errors"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+)
+
+// The fuzz targets in this file run their seed corpus as part of `go test`.
+// To fuzz one of them, run for example:
+//
+//	go test ./pkg/util/pki -run '^$' -fuzz FuzzParseSingleCertificateChain
+
+// seedCertificates returns the PEM encoded certificates from
+// testdata/certificates. These were generated with openssl and contain
+// certificates Go itself would never produce, such as v1 certificates,
+// multi-valued RDNs, empty subjects and cross-signed intermediates.
+func seedCertificates(f *testing.F) map[string][]byte {
+	paths, err := filepath.Glob(filepath.Join("testdata", "certificates", "*.pem"))
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	certs := make(map[string][]byte)
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		certs[filepath.Base(path)] = data
+	}
+
+	if len(certs) == 0 {
+		f.Fatal("no seed certificates found in testdata/certificates")
+	}
+
+	return certs
+}
+
+func FuzzUnmarshalSubjectStringToRDNSequence(f *testing.F) {
+	for _, subject := range []string{
+		"",
+		"CN=foo",
+		"cn=foo,o=bar",
+		"CN=foo+OU=bar,O=baz,C=GB",
+		"2.5.4.3=foo,1.2.840.113549.1.9.1=a@example.com",
+		`CN=foo\,bar,O=#0403666f6f`,
+		`CN=" leading and trailing ",DC=example,DC=com`,
+		"UID=user1,SERIALNUMBER=1234,STREET=Main Street,L=London,ST=England",
+		"CN=\xe2\x98\x83",
+		"3.1=foo",
+		"1.40=foo",
+		"FOO=bar",
+	} {
+		f.Add(subject)
+	}
+
+	for _, certPEM := range seedCertificates(f) {
+		cert, err := DecodeX509CertificateBytes(certPEM)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(cert.Subject.String())
+	}
+
+	f.Fuzz(func(t *testing.T, subject string) {
+		rdns, err := UnmarshalSubjectStringToRDNSequence(subject)
+		if err != nil {
+			return
+		}
+
+		// Values decoded from hex strings may contain arbitrary bytes, which
+		// cannot be encoded as an ASN.1 string.
+		der, err := MarshalRDNSequenceToRawDERBytes(rdns)
+		if err != nil {
+			return
+		}
+
+		parsed, err := UnmarshalRawDerBytesToRDNSequence(der)
+		if err != nil {
+			t.Fatalf("failed to unmarshal DER encoded subject %q: %v", subject, err)
+		}
+
+		if len(rdns) != len(parsed) {
+			t.Fatalf("subject %q did not round trip: exp=%v got=%v", subject, rdns, parsed)
+		}
+		for i := range rdns {
+			if len(rdns[i]) != len(parsed[i]) {
+				t.Fatalf("subject %q did not round trip: exp=%v got=%v", subject, rdns, parsed)
+			}
+			// SET OF members are sorted when DER encoded, so compare the
+			// parsed attributes irrespective of their order.
+			for _, atv := range rdns[i] {
+				found := false
+				for _, parsedATV := range parsed[i] {
+					if atv.Type.Equal(parsedATV.Type) && reflect.DeepEqual(atv.Value, parsedATV.Value) {
+						found = true
+						break
+					}
+				}
+				if !found {
+					t.Fatalf("subject %q did not round trip: exp=%v got=%v", subject, rdns, parsed)
+				}
+			}
+		}
+	})
+}
+
+func FuzzMarshalKeyUsage(f *testing.F) {
+	f.Add(uint16(0))
+	f.Add(uint16(0xffff))
+	for i := 0; i < 16; i++ {
+		f.Add(uint16(1) << i)
+	}
+	f.Add(uint16(x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment))
+	f.Add(uint16(x509.KeyUsageCertSign | x509.KeyUsageCRLSign))
+
+	f.Fuzz(func(t *testing.T, usage uint16) {
+		ext, err := MarshalKeyUsage(x509.KeyUsage(usage))
+		if err != nil {
+			t.Fatalf("failed to marshal key usage %b: %v", usage, err)
+		}
+
+		if !ext.Id.Equal(OIDExtensionKeyUsage) {
+			t.Fatalf("unexpected extension id %v", ext.Id)
+		}
+
+		var bitString asn1.BitString
+		rest, err := asn1.Unmarshal(ext.Value, &bitString)
+		if err != nil {
+			t.Fatalf("failed to unmarshal key usage %b: %v", usage, err)
+		}
+		if len(rest) != 0 {
+			t.Fatalf("trailing data after key usage %b", usage)
+		}
+
+		// The same decoding as used by crypto/x509 when parsing certificates.
+		var parsed uint16
+		for i := 0; i < 16; i++ {
+			if bitString.At(i) != 0 {
+				parsed |= 1 << uint(i)
+			}
+		}
+
+		if parsed != usage {
+			t.Fatalf("key usage did not round trip: exp=%b got=%b", usage, parsed)
+		}
+	})
+}
+
+func FuzzParseSingleCertificateChainPEM(f *testing.F) {
+	seeds := seedCertificates(f)
+
+	var all []byte
+	for _, certPEM := range seeds {
+		f.Add(certPEM)
+		all = append(all, certPEM...)
+	}
+	f.Add(all)
+	f.Add(bytes.Join([][]byte{
+		seeds["empty-subject-ed25519-leaf.pem"],
+		seeds["cross-signed-intermediate-a.pem"],
+		seeds["v1-root.pem"],
+	}, nil))
+	f.Add(bytes.Join([][]byte{
+		seeds["v1-root.pem"],
+		seeds["multi-valued-rdn-leaf.pem"],
+		seeds["v1-root.pem"],
+	}, []byte("some comment\n")))
+	f.Add([]byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		bundle, err := ParseSingleCertificateChainPEM(data)
+		if err != nil {
+			return
+		}
+
+		checkBundle(t, bundle)
+	})
+}
+
+func FuzzParseSingleCertificateChain(f *testing.F) {
+	var pool []*x509.Certificate
+	for _, certPEM := range seedCertificates(f) {
+		cert, err := DecodeX509CertificateBytes(certPEM)
+		if err != nil {
+			f.Fatal(err)
+		}
+		pool = append(pool, cert)
+	}
+
+	// Each byte selects a certificate from the pool. The value one past the
+	// end of the pool selects a nil certificate.
+	f.Add([]byte{})
+	f.Add([]byte{0})
+	f.Add([]byte{byte(len(pool))})
+	f.Add([]byte{0, 1, 2, 3, 4, 5})
+	f.Add([]byte{5, 4, 3, 2, 1, 0, 5, 4, 3, 2, 1, 0})
+	f.Add([]byte{0, 0, 1, 1, 2, 2})
+
+	f.Fuzz(func(t *testing.T, selection []byte) {
+		// Limit the number of certificates as merging chains is cubic.
+		if len(selection) > 32 {
+			return
+		}
+
+		var certs []*x509.Certificate
+		hasNil := false
+		for _, b := range selection {
+			i := int(b) % (len(pool) + 1)
+			if i == len(pool) {
+				hasNil = true
+				certs = append(certs, nil)
+				continue
+			}
+			certs = append(certs, pool[i])
+		}
+		input := append([]*x509.Certificate(nil), certs...)
+
+		bundle, err := ParseSingleCertificateChain(certs)
+		if !reflect.DeepEqual(input, certs) {
+			t.Fatal("ParseSingleCertificateChain modified its input")
+		}
+
+		if err != nil {
+			if !errors.IsInvalidData(err) {
+				t.Fatalf("expected an invalid data error, got: %v", err)
+			}
+			return
+		}
+		if hasNil {
+			t.Fatal("expected an error for a nil certificate")
+		}
+
+		checkBundle(t, bundle)
+	})
+}
+
+// checkBundle checks that every certificate in the chain of the bundle is
+// signed by the next, that parsing the bundle again returns the same bundle,
+// and that the CA is only empty if the chain holds a single certificate.
+func checkBundle(t *testing.T, bundle PEMBundle) {
+	chain, err := DecodeX509CertificateChainBytes(bundle.ChainPEM)
+	if err != nil {
+		t.Fatalf("failed to decode returned chain: %v", err)
+	}
+
+	for i := 0; i < len(chain)-1; i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			t.Fatalf("certificate %d of the returned chain is not signed by the next: %v", i, err)
+		}
+	}
+
+	if len(bundle.CAPEM) == 0 {
+		if len(chain) != 1 {
+			t.Fatalf("expected a CA for a chain of %d certificates", len(chain))
+		}
+	} else {
+		ca, err := DecodeX509CertificateBytes(bundle.CAPEM)
+		if err != nil {
+			t.Fatalf("failed to decode returned CA: %v", err)
+		}
+		last := chain[len(chain)-1]
+		if !last.Equal(ca) {
+			if err := last.CheckSignatureFrom(ca); err != nil {
+				t.Fatalf("last certificate of the returned chain is not signed by the CA: %v", err)
+			}
+		}
+	}
+
+	reparsed, err := ParseSingleCertificateChainPEM(append(append([]byte{}, bundle.ChainPEM...), bundle.CAPEM...))
+	if err != nil {
+		t.Fatalf("failed to parse returned bundle: %v", err)
+	}
+	if !reflect.DeepEqual(bundle, reparsed) {
+		t.Fatalf("parsing the returned bundle is not idempotent:\nexp=%+v\ngot=%+v", bundle, reparsed)
+	}
+}
+
+func FuzzGenerateCSRAndTemplate(f *testing.F) {
+	defer featuregatetesting.SetFeatureGateDuringTest(f, utilfeature.DefaultFeatureGate, feature.LiteralCertificateSubject, true)()
+
+	pk, err := GenerateECPrivateKey(ECCurve256)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Add("example.com", "example.com", "", "", "", "", uint16(0), false)
+	f.Add("", "*.example.com", "spiffe://example.com/ns/foo", "a@example.com", "::1", "", uint16(0), false)
+	f.Add("", "", "", "", "", "CN=foo+OU=bar,O=baz,DC=example,DC=com", uint16(0xffff), true)
+	f.Add("ca", "", "", "", "10.0.0.1", "cn=ca,2.5.4.10=Example", uint16(1<<3), true)
+	f.Add("\xe2\x98\x83", "xn--bcher-kva.example", "%zz", "not an email", "999.1.1.1", "FOO=bar", uint16(1), false)
+
+	// Each bit of usageBits selects one of these usages.
+	usages := []cmapi.KeyUsage{
+		cmapi.UsageDigitalSignature, cmapi.UsageKeyEncipherment, cmapi.UsageCertSign, cmapi.UsageCRLSign,
+		cmapi.UsageContentCommitment, cmapi.UsageKeyAgreement, cmapi.UsageDataEncipherment, cmapi.UsageEncipherOnly,
+		cmapi.UsageDecipherOnly, cmapi.UsageAny, cmapi.UsageServerAuth, cmapi.UsageClientAuth,
+		cmapi.UsageCodeSigning, cmapi.UsageEmailProtection, cmapi.UsageOCSPSigning, cmapi.UsageMicrosoftSGC,
+	}
+
+	f.Fuzz(func(t *testing.T, commonName, dnsName, uri, email, ip, literalSubject string, usageBits uint16, isCA bool) {
+		crt := &cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{
+				CommonName:     commonName,
+				LiteralSubject: literalSubject,
+				IsCA:           isCA,
+				PrivateKey:     &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+			},
+		}
+		if len(dnsName) > 0 {
+			crt.Spec.DNSNames = []string{dnsName}
+		}
+		if len(uri) > 0 {
+			crt.Spec.URIs = []string{uri}
+		}
+		if len(email) > 0 {
+			crt.Spec.EmailAddresses = []string{email}
+		}
+		if len(ip) > 0 {
+			crt.Spec.IPAddresses = []string{ip}
+		}
+		for i := range usages {
+			if usageBits&(1<<uint(i)) != 0 {
+				crt.Spec.Usages = append(crt.Spec.Usages, usages[i])
+			}
+		}
+
+		if csrTemplate, err := GenerateCSR(crt); err == nil {
+			csrDER, err := EncodeCSR(csrTemplate, pk)
+			if err == nil {
+				csr, err := x509.ParseCertificateRequest(csrDER)
+				if err != nil {
+					t.Fatalf("failed to parse generated CSR: %v", err)
+				}
+				checkGeneratedNames(t, crt, csr.RawSubject, csr.Subject.CommonName, csr.DNSNames, csr.EmailAddresses)
+			}
+		}
+
+		if template, err := GenerateTemplate(crt); err == nil {
+			template.PublicKey = pk.Public()
+			_, cert, err := SignCertificate(template, template, pk.Public(), pk)
+			if err == nil {
+				checkGeneratedNames(t, crt, cert.RawSubject, cert.Subject.CommonName, cert.DNSNames, cert.EmailAddresses)
+				if cert.IsCA != isCA {
+					t.Fatalf("unexpected isCA, exp=%t got=%t", isCA, cert.IsCA)
+				}
+			}
+		}
+	})
+}
+
+// checkGeneratedNames checks that the subject and names of a generated CSR or
+// certificate match the Certificate they were generated from.
+func checkGeneratedNames(t *testing.T, crt *cmapi.Certificate, rawSubject []byte, commonName string, dnsNames, emailAddresses []string) {
+	if len(crt.Spec.LiteralSubject) > 0 {
+		expected, err := ParseSubjectStringToRawDERBytes(crt.Spec.LiteralSubject)
+		if err != nil {
+			t.Fatalf("failed to encode literal subject: %v", err)
+		}
+		if !bytes.Equal(expected, rawSubject) {
+			t.Fatalf("literal subject %q was not encoded exactly", crt.Spec.LiteralSubject)
+		}
+	} else if commonName != crt.Spec.CommonName {
+		t.Fatalf("unexpected common name, exp=%q got=%q", crt.Spec.CommonName, commonName)
+	}
+
+	if !reflect.DeepEqual(crt.Spec.DNSNames, dnsNames) {
+		t.Fatalf("unexpected DNS names, exp=%q got=%q", crt.Spec.DNSNames, dnsNames)
+	}
+	if !reflect.DeepEqual(crt.Spec.EmailAddresses, emailAddresses) {
+		t.Fatalf("unexpected email addresses, exp=%q got=%q", crt.Spec.EmailAddresses, emailAddresses)
+	}
+}
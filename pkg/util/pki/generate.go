@@ -49,10 +49,12 @@ const (
 
 // GeneratePrivateKeyForCertificate will generate a private key suitable for
 // the provided cert-manager Certificate resource, taking into account the
-// parameters on the provided resource.
+// parameters on the provided resource, including a private key fallback
+// recorded in its status.
 // The returned key will either be RSA or ECDSA.
 func GeneratePrivateKeyForCertificate(crt *v1.Certificate) (crypto.Signer, error) {
 	crt = crt.DeepCopy()
+	crt.Spec = CertificateSpecWithPrivateKeyFallback(crt)
 	if crt.Spec.PrivateKey == nil {
 		crt.Spec.PrivateKey = &v1.CertificatePrivateKey{}
 	}
@@ -0,0 +1,241 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// idnaProfile converts internationalized domain names following the UTS #46
+// lookup rules, using non-transitional processing as required by IDNA2008.
+// Empty labels and labels or names exceeding the DNS length limits are
+// rejected.
+var idnaProfile = idna.New(
+	idna.MapForLookup(),
+	idna.BidiRule(),
+	idna.Transitional(false),
+	idna.VerifyDNSLength(true),
+)
+
+// allowedScriptCombinations are the combinations of scripts which may be
+// mixed in a single label, as allowed by the "Highly Restrictive" level of
+// UTS #39. Any label written in a single script is allowed.
+var allowedScriptCombinations = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+// DNSNameToASCII returns the ASCII form of the given DNS name, in which
+// internationalized labels are converted to A-labels (punycode) following
+// UTS #46. This is the form used in CSRs, ACME orders and DNS records.
+// Names which only contain ASCII characters are returned unchanged, and a
+// leading wildcard label is preserved.
+// An error is returned if the name is not a valid internationalized domain
+// name, or if one of its labels mixes characters of different scripts. Names
+// which are already given as A-labels are checked in the same way, so that
+// punycode cannot be used to bypass these checks.
+func DNSNameToASCII(name string) (string, error) {
+	if isASCII(name) {
+		if !hasALabel(name) {
+			return name, nil
+		}
+		if err := checkUnicodeName(name, strings.TrimPrefix(name, "*.")); err != nil {
+			return "", err
+		}
+		return name, nil
+	}
+
+	wildcard := strings.HasPrefix(name, "*.")
+	name = strings.TrimPrefix(name, "*.")
+
+	ascii, err := idnaProfile.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid internationalized domain name %q: %w", name, err)
+	}
+
+	// The Unicode form is checked rather than the given name, so that
+	// characters mapped by UTS #46 (such as full-width letters) are checked
+	// in their mapped form.
+	if err := checkUnicodeName(name, ascii); err != nil {
+		return "", err
+	}
+
+	if wildcard {
+		ascii = "*." + ascii
+	}
+	return ascii, nil
+}
+
+// checkUnicodeName converts the given ASCII form of name to Unicode, and
+// returns an error if it is invalid or one of its labels mixes scripts.
+func checkUnicodeName(name, ascii string) error {
+	unicodeName, err := idnaProfile.ToUnicode(ascii)
+	if err != nil {
+		return fmt.Errorf("invalid internationalized domain name %q: %w", name, err)
+	}
+	for _, label := range strings.Split(unicodeName, ".") {
+		if err := checkLabelScripts(label); err != nil {
+			return fmt.Errorf("invalid internationalized domain name %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// hasALabel returns true if one of the labels of the given name has the
+// "xn--" prefix of A-labels.
+func hasALabel(name string) bool {
+	for _, label := range strings.Split(name, ".") {
+		if len(label) >= 4 && strings.EqualFold(label[:4], "xn--") {
+			return true
+		}
+	}
+	return false
+}
+
+// DNSNamesToASCII converts each of the given DNS names using DNSNameToASCII.
+func DNSNamesToASCII(names []string) ([]string, error) {
+	if names == nil {
+		return nil, nil
+	}
+	asciiNames := make([]string, len(names))
+	for i, name := range names {
+		ascii, err := DNSNameToASCII(name)
+		if err != nil {
+			return nil, err
+		}
+		asciiNames[i] = ascii
+	}
+	return asciiNames, nil
+}
+
+// commonNameToASCII returns the common name which is encoded into requests
+// for the given common name and DNS names. CAs expect the common name to be
+// one of the DNS names of a request, so a common name which is also one of
+// the DNS names is converted to its ASCII form in the same way. Any other
+// common name is returned unchanged.
+func commonNameToASCII(commonName string, dnsNames []string) string {
+	for _, dnsName := range dnsNames {
+		if dnsName != commonName {
+			continue
+		}
+		if ascii, err := DNSNameToASCII(commonName); err == nil {
+			return ascii
+		}
+	}
+	return commonName
+}
+
+// dnsNameToASCIIOrUnchanged converts the given DNS name using
+// DNSNameToASCII, returning it unchanged if it fails to convert. It is used
+// when comparing names, where an invalid name should simply not match.
+func dnsNameToASCIIOrUnchanged(name string) string {
+	ascii, err := DNSNameToASCII(name)
+	if err != nil {
+		return name
+	}
+	return ascii
+}
+
+// DNSNamesToASCIIOrUnchanged converts each of the given DNS names using
+// DNSNameToASCII, keeping names which fail to convert unchanged. It is used
+// when comparing or matching names, such as the DNS names of certificates or
+// of ACME solver selectors.
+func DNSNamesToASCIIOrUnchanged(names []string) []string {
+	asciiNames := make([]string, len(names))
+	for i, name := range names {
+		asciiNames[i] = dnsNameToASCIIOrUnchanged(name)
+	}
+	return asciiNames
+}
+
+// checkLabelScripts returns an error if the given Unicode label mixes
+// characters of scripts which are not allowed together.
+func checkLabelScripts(label string) error {
+	if isASCII(label) {
+		return nil
+	}
+
+	scripts := map[string]struct{}{}
+	for _, r := range label {
+		if script := scriptOf(r); script != "" {
+			scripts[script] = struct{}{}
+		}
+	}
+	if len(scripts) <= 1 {
+		return nil
+	}
+
+	for _, combination := range allowedScriptCombinations {
+		if containsAllScripts(combination, scripts) {
+			return nil
+		}
+	}
+
+	names := make([]string, 0, len(scripts))
+	for script := range scripts {
+		names = append(names, script)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("label %q mixes characters of the %s scripts", label, strings.Join(names, ", "))
+}
+
+// scriptOf returns the name of the Unicode script of the given rune, or an
+// empty string for characters shared between scripts, such as digits and
+// the hyphen.
+func scriptOf(r rune) string {
+	if unicode.In(r, unicode.Common, unicode.Inherited) {
+		return ""
+	}
+	for name, table := range unicode.Scripts {
+		if unicode.Is(table, r) {
+			return name
+		}
+	}
+	return ""
+}
+
+func containsAllScripts(combination []string, scripts map[string]struct{}) bool {
+	for script := range scripts {
+		found := false
+		for _, allowed := range combination {
+			if script == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
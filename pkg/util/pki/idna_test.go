@@ -0,0 +1,141 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+func TestDNSNameToASCII(t *testing.T) {
+	tests := map[string]struct {
+		name     string
+		expASCII string
+		expErr   string
+	}{
+		"ASCII names are unchanged": {
+			name:     "Example.com",
+			expASCII: "Example.com",
+		},
+		"ASCII names which are not valid hostnames are unchanged": {
+			name:     "_acme-challenge.example.com",
+			expASCII: "_acme-challenge.example.com",
+		},
+		"Unicode names are converted to A-labels": {
+			name:     "bücher.example",
+			expASCII: "xn--bcher-kva.example",
+		},
+		"Unicode names are mapped to lower case": {
+			name:     "Bücher.Example",
+			expASCII: "xn--bcher-kva.example",
+		},
+		"Eszett is not mapped using transitional processing": {
+			name:     "faß.de",
+			expASCII: "xn--fa-hia.de",
+		},
+		"wildcard labels are preserved": {
+			name:     "*.bücher.example",
+			expASCII: "*.xn--bcher-kva.example",
+		},
+		"labels mixing Japanese scripts are allowed": {
+			name:     "日本語ドメインの例.jp",
+			expASCII: "xn--u9jwfoe1dzdq15tiy6a0ecl32k.jp",
+		},
+		"labels mixing Latin and Cyrillic are rejected": {
+			name:   "pаypal.com",
+			expErr: `invalid internationalized domain name "pаypal.com": label "pаypal" mixes characters of the Cyrillic, Latin scripts`,
+		},
+		"valid A-labels are unchanged": {
+			name:     "*.xn--bcher-kva.example",
+			expASCII: "*.xn--bcher-kva.example",
+		},
+		"A-labels mixing Latin and Cyrillic are rejected": {
+			name:   "xn--pypal-4ve.com",
+			expErr: `invalid internationalized domain name "xn--pypal-4ve.com": label "pаypal" mixes characters of the Cyrillic, Latin scripts`,
+		},
+		"invalid A-labels are rejected": {
+			name:   "xn--zz.example",
+			expErr: `invalid internationalized domain name "xn--zz.example"`,
+		},
+		"invalid labels are rejected": {
+			name:   "bücher..example",
+			expErr: `invalid internationalized domain name "bücher..example"`,
+		},
+		"labels starting with a hyphen are rejected": {
+			name:   "-bücher.example",
+			expErr: `invalid internationalized domain name "-bücher.example"`,
+		},
+		"labels mixing right-to-left and left-to-right characters are rejected": {
+			name:   "bücherשלום.example",
+			expErr: `invalid internationalized domain name "bücherשלום.example"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ascii, err := DNSNameToASCII(test.name)
+			if test.expErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), test.expErr) {
+					t.Fatalf("unexpected error, exp=%q got=%v", test.expErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ascii != test.expASCII {
+				t.Errorf("unexpected ASCII name, exp=%q got=%q", test.expASCII, ascii)
+			}
+		})
+	}
+}
+
+func TestGenerateCSRInternationalizedDNSNames(t *testing.T) {
+	spec := cmapi.CertificateSpec{
+		CommonName: "bücher.example",
+		DNSNames:   []string{"bücher.example", "*.bücher.example", "example.com"},
+	}
+
+	csr, err := GenerateCSR(&cmapi.Certificate{Spec: spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp := []string{"xn--bcher-kva.example", "*.xn--bcher-kva.example", "example.com"}; !reflect.DeepEqual(csr.DNSNames, exp) {
+		t.Errorf("unexpected DNS names, exp=%v got=%v", exp, csr.DNSNames)
+	}
+	if exp := "xn--bcher-kva.example"; csr.Subject.CommonName != exp {
+		t.Errorf("unexpected common name, exp=%q got=%q", exp, csr.Subject.CommonName)
+	}
+
+	violations, err := RequestMatchesSpec(&cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{
+		Request: mustGenerateCSR(t, spec, nil),
+	}}, spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) > 0 {
+		t.Errorf("expected the request to match the spec, got violations %v", violations)
+	}
+
+	if _, err := GenerateCSR(&cmapi.Certificate{Spec: cmapi.CertificateSpec{DNSNames: []string{"pаypal.com"}}}); err == nil {
+		t.Errorf("expected an error for a name mixing scripts")
+	}
+}
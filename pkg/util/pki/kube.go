@@ -25,7 +25,6 @@ import (
 
 	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 )
 
 // GenerateTemplateFromCertificateSigningRequest will create an
@@ -41,20 +40,28 @@ func GenerateTemplateFromCertificateSigningRequest(csr *certificatesv1.Certifica
 		return nil, err
 	}
 
-	isCA := csr.Annotations[experimentalapi.CertificateSigningRequestIsCAAnnotationKey] == "true"
+	isCA := IsCAFromCertificateSigningRequest(csr)
 
 	return GenerateTemplateFromCSRPEMWithUsages(csr.Spec.Request, duration, isCA, ku, eku)
 }
 
+// IsCAFromCertificateSigningRequest returns whether the user has requested a
+// CA certificate using the annotation "cert-manager.io/request-is-ca", or the
+// deprecated "experimental.cert-manager.io/request-is-ca" annotation.
+func IsCAFromCertificateSigningRequest(csr *certificatesv1.CertificateSigningRequest) bool {
+	isCA, _, _ := apiutil.CertificateSigningRequestAnnotation(csr, cmapi.CertificateSigningRequestIsCAAnnotationKey)
+	return isCA == "true"
+}
+
 // DurationFromCertificateSigningRequest returns the duration that the user may
-// have requested using the annotation
-// "experimental.cert-manager.io/request-duration" or via the CSR
-// spec.expirationSeconds field (the annotation is preferred since it predates
-// the field which is only available in Kubernetes v1.22+).
+// have requested using the annotation "cert-manager.io/request-duration" (or
+// the deprecated "experimental.cert-manager.io/request-duration") or via the
+// CSR spec.expirationSeconds field (the annotation is preferred since it
+// predates the field which is only available in Kubernetes v1.22+).
 // Returns the cert-manager default certificate duration when the user hasn't
 // provided the annotation or spec.expirationSeconds.
 func DurationFromCertificateSigningRequest(csr *certificatesv1.CertificateSigningRequest) (time.Duration, error) {
-	requestedDuration, ok := csr.Annotations[experimentalapi.CertificateSigningRequestDurationAnnotationKey]
+	requestedDuration, annotationKey, ok := apiutil.CertificateSigningRequestAnnotation(csr, cmapi.CertificateSigningRequestDurationAnnotationKey)
 	if !ok {
 		if csr.Spec.ExpirationSeconds != nil {
 			return time.Duration(*csr.Spec.ExpirationSeconds) * time.Second, nil
@@ -68,7 +75,7 @@ func DurationFromCertificateSigningRequest(csr *certificatesv1.CertificateSignin
 	duration, err := time.ParseDuration(requestedDuration)
 	if err != nil {
 		return -1, fmt.Errorf("failed to parse requested duration on annotation %q: %w",
-			experimentalapi.CertificateSigningRequestDurationAnnotationKey, err)
+			annotationKey, err)
 	}
 
 	return duration, nil
@@ -198,6 +198,34 @@ func TestGenerateTemplateFromCertificateSigningRequest(t *testing.T) {
 				DNSNames: []string{"example.com", "foo.example.com"},
 			},
 		},
+		"a CSR with both the duration and the deprecated experimental duration annotations should prefer the former": {
+			csr: gen.CertificateSigningRequest("",
+				gen.AddCertificateSigningRequestAnnotations(map[string]string{
+					"experimental.cert-manager.io/request-duration": "777s",
+					"cert-manager.io/request-duration":              "888s",
+					"cert-manager.io/request-is-ca":                 "true",
+				}),
+				gen.SetCertificateSigningRequestUsages([]certificatesv1.KeyUsage{
+					certificatesv1.UsageDigitalSignature,
+				}),
+				gen.SetCertificateSigningRequestRequest(csr),
+			),
+			expCertificate: &x509.Certificate{
+				Version:               2,
+				BasicConstraintsValid: true,
+				SerialNumber:          nil,
+				PublicKeyAlgorithm:    x509.RSA,
+				PublicKey:             pk.Public(),
+				IsCA:                  true,
+				Subject: pkix.Name{
+					CommonName: "example.com",
+				},
+				NotBefore: time.Now(),
+				NotAfter:  time.Now().Add(888 * time.Second),
+				KeyUsage:  x509.KeyUsageDigitalSignature,
+				DNSNames:  []string{"example.com", "foo.example.com"},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -17,10 +17,12 @@ limitations under the License.
 package pki
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/ed25519"
 	"crypto/rsa"
+	"crypto/x509"
 
 	"fmt"
 	"reflect"
@@ -103,6 +105,78 @@ func ed25519PrivateKeyMatchesSpec(pk crypto.PrivateKey, spec cmapi.CertificateSp
 	return nil, nil
 }
 
+// CertificateSpecWithPrivateKeyFallback returns the spec of the given
+// Certificate, with the private key algorithm and size replaced by those in
+// status.privateKeyFallback if the fallback was chosen for the current
+// generation of the Certificate. Private keys for the Certificate must be
+// generated and checked using the returned spec.
+func CertificateSpecWithPrivateKeyFallback(crt *cmapi.Certificate) cmapi.CertificateSpec {
+	spec := *crt.Spec.DeepCopy()
+	fallback := crt.Status.PrivateKeyFallback
+	if fallback == nil || fallback.ObservedGeneration != crt.Generation {
+		return spec
+	}
+	if spec.PrivateKey == nil {
+		spec.PrivateKey = &cmapi.CertificatePrivateKey{}
+	}
+	spec.PrivateKey.Algorithm = fallback.Algorithm
+	spec.PrivateKey.Size = fallback.Size
+	return spec
+}
+
+// PrivateKeyAlgorithmAndSize returns the algorithm and size of the private
+// keys generated for the given spec, after applying the same defaults as
+// GeneratePrivateKeyForCertificate. The size of Ed25519 keys is always 0.
+func PrivateKeyAlgorithmAndSize(spec cmapi.CertificateSpec) (cmapi.PrivateKeyAlgorithm, int) {
+	if spec.PrivateKey == nil {
+		return cmapi.RSAKeyAlgorithm, MinRSAKeySize
+	}
+	size := spec.PrivateKey.Size
+	switch spec.PrivateKey.Algorithm {
+	case "", cmapi.RSAKeyAlgorithm:
+		if size <= 0 {
+			size = MinRSAKeySize
+		}
+		return cmapi.RSAKeyAlgorithm, size
+	case cmapi.ECDSAKeyAlgorithm:
+		if size <= 0 {
+			size = ECCurve256
+		}
+		return cmapi.ECDSAKeyAlgorithm, size
+	case cmapi.Ed25519KeyAlgorithm:
+		return cmapi.Ed25519KeyAlgorithm, 0
+	default:
+		return spec.PrivateKey.Algorithm, size
+	}
+}
+
+// PublicKeyMatchesSpec returns a list of violations if the algorithm or bit
+// size of the given public key doesn't match the private key requested by the
+// provided spec. RSA, Ed25519 and ECDSA are supported.
+func PublicKeyMatchesSpec(pub crypto.PublicKey, spec cmapi.CertificateSpec) ([]string, error) {
+	var algorithm cmapi.PrivateKeyAlgorithm
+	var size int
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		algorithm, size = cmapi.RSAKeyAlgorithm, pub.N.BitLen()
+	case *ecdsa.PublicKey:
+		algorithm, size = cmapi.ECDSAKeyAlgorithm, pub.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		algorithm = cmapi.Ed25519KeyAlgorithm
+	default:
+		return nil, fmt.Errorf("unrecognised public key type: %T", pub)
+	}
+
+	expectedAlgorithm, expectedSize := PrivateKeyAlgorithmAndSize(spec)
+	if algorithm != expectedAlgorithm {
+		return []string{"spec.privateKey.algorithm"}, nil
+	}
+	if size != expectedSize {
+		return []string{"spec.privateKey.size"}, nil
+	}
+	return nil, nil
+}
+
 // RequestMatchesSpec compares a CertificateRequest with a CertificateSpec
 // and returns a list of field names on the Certificate that do not match their
 // counterpart fields on the CertificateRequest.
@@ -121,23 +195,36 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 
 	var violations []string
 
-	if spec.LiteralSubject == "" {
-		// TODO: also check these fields if LiteralSubject is set
-		if !util.EqualUnsorted(IPAddressesToString(x509req.IPAddresses), spec.IPAddresses) {
-			violations = append(violations, "spec.ipAddresses")
-		}
-		if !util.EqualUnsorted(URLsToString(x509req.URIs), spec.URIs) {
-			violations = append(violations, "spec.uris")
-		}
-		if !util.EqualUnsorted(x509req.EmailAddresses, spec.EmailAddresses) {
-			violations = append(violations, "spec.emailAddresses")
+	if !util.EqualUnsorted(IPAddressesToString(x509req.IPAddresses), spec.IPAddresses) {
+		violations = append(violations, "spec.ipAddresses")
+	}
+	if !util.EqualUnsorted(URLsToString(x509req.URIs), spec.URIs) {
+		violations = append(violations, "spec.uris")
+	}
+	if !util.EqualUnsorted(x509req.EmailAddresses, spec.EmailAddresses) {
+		violations = append(violations, "spec.emailAddresses")
+	}
+	// Internationalized DNS names are encoded into requests in their ASCII
+	// form, so both forms of a name are considered equal.
+	if !util.EqualUnsorted(DNSNamesToASCIIOrUnchanged(x509req.DNSNames), DNSNamesToASCIIOrUnchanged(spec.DNSNames)) {
+		violations = append(violations, "spec.dnsNames")
+	}
+
+	if isLiteralCertificateSubjectEnabled() && len(spec.LiteralSubject) > 0 {
+		// The literal subject is encoded into the request exactly as it is
+		// written, so the DER encoding of the subject must match exactly.
+		// Comparing the decoded RDN sequences would ignore differences in
+		// the string types of the attribute values.
+		rawSubject, err := ParseSubjectStringToRawDERBytes(spec.LiteralSubject)
+		if err != nil {
+			return nil, err
 		}
-		if !util.EqualUnsorted(x509req.DNSNames, spec.DNSNames) {
-			violations = append(violations, "spec.dnsNames")
+		if !bytes.Equal(rawSubject, x509req.RawSubject) {
+			violations = append(violations, "spec.literalSubject")
 		}
-
+	} else {
 		// Comparing Subject fields
-		if x509req.Subject.CommonName != spec.CommonName {
+		if x509req.Subject.CommonName != spec.CommonName && x509req.Subject.CommonName != commonNameToASCII(spec.CommonName, spec.DNSNames) {
 			violations = append(violations, "spec.commonName")
 		}
 		if x509req.Subject.SerialNumber != spec.Subject.SerialNumber {
@@ -164,42 +251,32 @@ func RequestMatchesSpec(req *cmapi.CertificateRequest, spec cmapi.CertificateSpe
 		if !util.EqualUnsorted(x509req.Subject.StreetAddress, spec.Subject.StreetAddresses) {
 			violations = append(violations, "spec.subject.streetAddresses")
 		}
+	}
 
-		// TODO: also check these fields if LiteralSubject is set
-		if req.Spec.IsCA != spec.IsCA {
-			violations = append(violations, "spec.isCA")
-		}
-		if !util.EqualKeyUsagesUnsorted(req.Spec.Usages, spec.Usages) {
-			violations = append(violations, "spec.usages")
-		}
-		if req.Spec.Duration != nil && spec.Duration != nil &&
-			req.Spec.Duration.Duration != spec.Duration.Duration {
-			violations = append(violations, "spec.duration")
-		}
-		if !reflect.DeepEqual(req.Spec.IssuerRef, spec.IssuerRef) {
-			violations = append(violations, "spec.issuerRef")
-		}
-
-		// TODO: check spec.EncodeBasicConstraintsInRequest and spec.EncodeUsagesInRequest
-	} else {
-		// we have a LiteralSubject
-		// parse the subject of the csr in the same way as we parse LiteralSubject and see whether the RDN Sequences match
-
-		rdnSequenceFromCertificateRequest, err := UnmarshalRawDerBytesToRDNSequence(x509req.RawSubject)
-		if err != nil {
-			return nil, err
-		}
-
-		rdnSequenceFromCertificate, err := UnmarshalSubjectStringToRDNSequence(spec.LiteralSubject)
-		if err != nil {
-			return nil, err
+	// The signature scheme only applies to requests for RSA keys.
+	if x509req.PublicKeyAlgorithm == x509.RSA {
+		requestedPSS := spec.PrivateKey != nil && spec.PrivateKey.SignatureScheme == cmapi.PSSSignatureScheme
+		if IsRSAPSSSignatureAlgorithm(x509req.SignatureAlgorithm) != requestedPSS {
+			violations = append(violations, "spec.privateKey.signatureScheme")
 		}
+	}
 
-		if !reflect.DeepEqual(rdnSequenceFromCertificate, rdnSequenceFromCertificateRequest) {
-			violations = append(violations, "spec.literalSubject")
-		}
+	if req.Spec.IsCA != spec.IsCA {
+		violations = append(violations, "spec.isCA")
+	}
+	if !util.EqualKeyUsagesUnsorted(req.Spec.Usages, spec.Usages) {
+		violations = append(violations, "spec.usages")
+	}
+	if req.Spec.Duration != nil && spec.Duration != nil &&
+		req.Spec.Duration.Duration != spec.Duration.Duration {
+		violations = append(violations, "spec.duration")
+	}
+	if !reflect.DeepEqual(req.Spec.IssuerRef, spec.IssuerRef) {
+		violations = append(violations, "spec.issuerRef")
 	}
 
+	// TODO: check spec.EncodeBasicConstraintsInRequest and spec.EncodeUsagesInRequest
+
 	return violations, nil
 }
 
@@ -221,21 +298,36 @@ func SecretDataAltNamesMatchSpec(secret *corev1.Secret, spec cmapi.CertificateSp
 	// This check allows names to move between the DNSNames and CommonName
 	// field freely in order to account for CAs behaviour of promoting DNSNames
 	// to be CommonNames or vice-versa.
-	expectedDNSNames := sets.NewString(spec.DNSNames...)
-	if spec.CommonName != "" {
-		expectedDNSNames.Insert(spec.CommonName)
+	// If a literal subject is used, the common name is taken from it.
+	commonName, err := extractCommonName(spec)
+	if err != nil {
+		return nil, err
+	}
+	commonNameField := "spec.commonName"
+	if isLiteralCertificateSubjectEnabled() && len(spec.LiteralSubject) > 0 {
+		commonNameField = "spec.literalSubject"
 	}
-	allDNSNames := sets.NewString(x509cert.DNSNames...)
-	if x509cert.Subject.CommonName != "" {
-		allDNSNames.Insert(x509cert.Subject.CommonName)
+	// Internationalized DNS names are compared in their ASCII form, as this
+	// is the form in which they are encoded into certificates.
+	specDNSNames := DNSNamesToASCIIOrUnchanged(spec.DNSNames)
+	certDNSNames := DNSNamesToASCIIOrUnchanged(x509cert.DNSNames)
+	commonName = dnsNameToASCIIOrUnchanged(commonName)
+	certCommonName := dnsNameToASCIIOrUnchanged(x509cert.Subject.CommonName)
+	expectedDNSNames := sets.NewString(specDNSNames...)
+	if commonName != "" {
+		expectedDNSNames.Insert(commonName)
+	}
+	allDNSNames := sets.NewString(certDNSNames...)
+	if certCommonName != "" {
+		allDNSNames.Insert(certCommonName)
 	}
 	if !allDNSNames.Equal(expectedDNSNames) {
 		// We know a mismatch occurred, so now determine which fields mismatched.
-		if (spec.CommonName != "" && !allDNSNames.Has(spec.CommonName)) || (x509cert.Subject.CommonName != "" && !expectedDNSNames.Has(x509cert.Subject.CommonName)) {
-			violations = append(violations, "spec.commonName")
+		if (commonName != "" && !allDNSNames.Has(commonName)) || (certCommonName != "" && !expectedDNSNames.Has(certCommonName)) {
+			violations = append(violations, commonNameField)
 		}
 
-		if !allDNSNames.HasAll(spec.DNSNames...) || !expectedDNSNames.HasAll(x509cert.DNSNames...) {
+		if !allDNSNames.HasAll(specDNSNames...) || !expectedDNSNames.HasAll(certDNSNames...) {
 			violations = append(violations, "spec.dnsNames")
 		}
 	}
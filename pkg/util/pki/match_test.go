@@ -18,12 +18,21 @@ package pki
 
 import (
 	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
 	"reflect"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
 
+	"github.com/cert-manager/cert-manager/internal/controller/feature"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
 )
 
 func mustGenerateRSA(t *testing.T, keySize int) crypto.PrivateKey {
@@ -119,7 +128,86 @@ func TestPrivateKeyMatchesSpec(t *testing.T) {
 	}
 }
 
+func TestPublicKeyMatchesSpec(t *testing.T) {
+	tests := map[string]struct {
+		key        crypto.PrivateKey
+		spec       *cmapi.CertificatePrivateKey
+		violations []string
+	}{
+		"should match the default RSA key": {
+			key: mustGenerateRSA(t, 2048),
+		},
+		"should not match if RSA keySize is incorrect": {
+			key:        mustGenerateRSA(t, 2048),
+			spec:       &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Size: 4096},
+			violations: []string{"spec.privateKey.size"},
+		},
+		"should match the default ECDSA key size": {
+			key:  mustGenerateECDSA(t, 256),
+			spec: &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+		},
+		"should not match if ECDSA keySize is incorrect": {
+			key:        mustGenerateECDSA(t, 256),
+			spec:       &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384},
+			violations: []string{"spec.privateKey.size"},
+		},
+		"should not match if the algorithm is incorrect": {
+			key:        mustGenerateEd25519(t),
+			spec:       &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm},
+			violations: []string{"spec.privateKey.algorithm"},
+		},
+		"should match Ed25519 keys regardless of the size": {
+			key:  mustGenerateEd25519(t),
+			spec: &cmapi.CertificatePrivateKey{Algorithm: cmapi.Ed25519KeyAlgorithm, Size: 256},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			pub, err := PublicKeyForPrivateKey(test.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			violations, err := PublicKeyMatchesSpec(pub, cmapi.CertificateSpec{PrivateKey: test.spec})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(violations, test.violations) {
+				t.Errorf("violations did not match, got=%s, exp=%s", violations, test.violations)
+			}
+		})
+	}
+}
+
+func TestCertificateSpecWithPrivateKeyFallback(t *testing.T) {
+	crt := &cmapi.Certificate{
+		Spec: cmapi.CertificateSpec{
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, Size: 2048, RotationPolicy: cmapi.RotationPolicyAlways},
+		},
+	}
+	crt.Generation = 2
+
+	if spec := CertificateSpecWithPrivateKeyFallback(crt); !reflect.DeepEqual(spec, crt.Spec) {
+		t.Errorf("expected the spec to be unchanged without a fallback, got=%v", spec)
+	}
+
+	crt.Status.PrivateKeyFallback = &cmapi.CertificatePrivateKeyFallback{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, ObservedGeneration: 1}
+	if spec := CertificateSpecWithPrivateKeyFallback(crt); !reflect.DeepEqual(spec, crt.Spec) {
+		t.Errorf("expected the spec to be unchanged with a fallback for a previous generation, got=%v", spec)
+	}
+
+	crt.Status.PrivateKeyFallback.ObservedGeneration = 2
+	exp := &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm, Size: 384, RotationPolicy: cmapi.RotationPolicyAlways}
+	if spec := CertificateSpecWithPrivateKeyFallback(crt); !reflect.DeepEqual(spec.PrivateKey, exp) {
+		t.Errorf("expected the fallback to replace the private key spec, got=%v, exp=%v", spec.PrivateKey, exp)
+	}
+	if crt.Spec.PrivateKey.Algorithm != cmapi.RSAKeyAlgorithm {
+		t.Errorf("expected the Certificate not to be modified")
+	}
+}
+
 func TestSecretDataAltNamesMatchSpec(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.LiteralCertificateSubject, true)()
+
 	tests := map[string]struct {
 		data       []byte
 		spec       cmapi.CertificateSpec
@@ -204,6 +292,33 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 			}),
 			violations: []string{"spec.dnsNames"},
 		},
+		"should match if internationalized dnsNames are in their ASCII form on the certificate": {
+			spec: cmapi.CertificateSpec{
+				CommonName: "bücher.example",
+				DNSNames:   []string{"bücher.example", "*.bücher.example"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				CommonName: "xn--bcher-kva.example",
+				DNSNames:   []string{"xn--bcher-kva.example", "*.xn--bcher-kva.example"},
+			}),
+		},
+		"should match if internationalized dnsNames are requested in their ASCII form": {
+			spec: cmapi.CertificateSpec{
+				DNSNames: []string{"xn--bcher-kva.example"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				DNSNames: []string{"bücher.example"},
+			}),
+		},
+		"should not match if internationalized dnsNames differ": {
+			spec: cmapi.CertificateSpec{
+				DNSNames: []string{"bücher.example"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				DNSNames: []string{"xn--bcher-kva.example", "other.example"},
+			}),
+			violations: []string{"spec.dnsNames"},
+		},
 		"should match if commonName is a duplicated dnsName (but not requested)": {
 			spec: cmapi.CertificateSpec{
 				DNSNames: []string{"at", "least", "one"},
@@ -250,6 +365,27 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 			}),
 			violations: []string{"spec.commonName"},
 		},
+		"should match if the common name of the literal subject is present": {
+			spec: cmapi.CertificateSpec{
+				LiteralSubject: "CN=cn,O=cert-manager",
+				DNSNames:       []string{"at", "least", "one"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				LiteralSubject: "CN=cn,O=cert-manager",
+				DNSNames:       []string{"at", "least", "one"},
+			}),
+		},
+		"should not match if the common name of the literal subject is not present": {
+			spec: cmapi.CertificateSpec{
+				LiteralSubject: "CN=cn,O=cert-manager",
+				DNSNames:       []string{"at", "least", "one"},
+			},
+			data: selfSignCertificate(t, cmapi.CertificateSpec{
+				LiteralSubject: "O=cert-manager",
+				DNSNames:       []string{"at", "least", "one"},
+			}),
+			violations: []string{"spec.literalSubject"},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -271,6 +407,204 @@ func TestSecretDataAltNamesMatchSpec(t *testing.T) {
 	}
 }
 
+func TestRequestMatchesSpecLiteralSubject(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.LiteralCertificateSubject, true)()
+
+	// utf8Subject encodes the attributes of "CN=example.com,O=cert-manager"
+	// as UTF8String rather than PrintableString, so it decodes to the same
+	// RDN sequence but has a different DER encoding.
+	utf8Subject, err := asn1.Marshal(pkix.RDNSequence{
+		{{Type: OIDConstants.Organization, Value: asn1.RawValue{Tag: asn1.TagUTF8String, Bytes: []byte("cert-manager")}}},
+		{{Type: OIDConstants.CommonName, Value: asn1.RawValue{Tag: asn1.TagUTF8String, Bytes: []byte("example.com")}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		spec       cmapi.CertificateSpec
+		request    cmapi.CertificateRequestSpec
+		err        string
+		violations []string
+	}{
+		"should match if the literal subject is exactly equal": {
+			spec: cmapi.CertificateSpec{
+				LiteralSubject: "CN=example.com,O=cert-manager",
+				DNSNames:       []string{"example.com"},
+			},
+			request: cmapi.CertificateRequestSpec{
+				Request: mustGenerateCSR(t, cmapi.CertificateSpec{
+					LiteralSubject: "CN=example.com,O=cert-manager",
+					DNSNames:       []string{"example.com"},
+				}, nil),
+			},
+		},
+		"should not match if the order of the literal subject attributes differs": {
+			spec: cmapi.CertificateSpec{
+				LiteralSubject: "O=cert-manager,CN=example.com",
+				DNSNames:       []string{"example.com"},
+			},
+			request: cmapi.CertificateRequestSpec{
+				Request: mustGenerateCSR(t, cmapi.CertificateSpec{
+					LiteralSubject: "CN=example.com,O=cert-manager",
+					DNSNames:       []string{"example.com"},
+				}, nil),
+			},
+			violations: []string{"spec.literalSubject"},
+		},
+		"should not match if the literal subject has a different DER encoding": {
+			spec: cmapi.CertificateSpec{
+				LiteralSubject: "CN=example.com,O=cert-manager",
+				DNSNames:       []string{"example.com"},
+			},
+			request: cmapi.CertificateRequestSpec{
+				Request: mustGenerateCSR(t, cmapi.CertificateSpec{
+					DNSNames: []string{"example.com"},
+				}, utf8Subject),
+			},
+			violations: []string{"spec.literalSubject"},
+		},
+		"should check the remaining fields if a literal subject is set": {
+			spec: cmapi.CertificateSpec{
+				LiteralSubject: "CN=example.com",
+				DNSNames:       []string{"example.com", "www.example.com"},
+				IsCA:           true,
+				Usages:         []cmapi.KeyUsage{cmapi.UsageServerAuth},
+				IssuerRef:      cmmeta.ObjectReference{Name: "issuer"},
+			},
+			request: cmapi.CertificateRequestSpec{
+				Request: mustGenerateCSR(t, cmapi.CertificateSpec{
+					LiteralSubject: "CN=example.com",
+					DNSNames:       []string{"example.com"},
+				}, nil),
+				IssuerRef: cmmeta.ObjectReference{Name: "other-issuer"},
+			},
+			violations: []string{"spec.dnsNames", "spec.isCA", "spec.usages", "spec.issuerRef"},
+		},
+		"should fail if the literal subject cannot be parsed": {
+			spec: cmapi.CertificateSpec{
+				LiteralSubject: "UNKNOWN=example.com",
+			},
+			request: cmapi.CertificateRequestSpec{
+				Request: mustGenerateCSR(t, cmapi.CertificateSpec{
+					LiteralSubject: "CN=example.com",
+				}, nil),
+			},
+			err: `unknown attribute type "UNKNOWN" in subject`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			violations, err := RequestMatchesSpec(&cmapi.CertificateRequest{Spec: test.request}, test.spec)
+			switch {
+			case err != nil:
+				if test.err != err.Error() {
+					t.Errorf("error text did not match, got=%s, exp=%s", err.Error(), test.err)
+				}
+			default:
+				if test.err != "" {
+					t.Errorf("got no error but expected: %s", test.err)
+				}
+			}
+			if !reflect.DeepEqual(violations, test.violations) {
+				t.Errorf("violations did not match, got=%s, exp=%s", violations, test.violations)
+			}
+		})
+	}
+}
+
+func TestRequestMatchesSpecSignatureScheme(t *testing.T) {
+	pk, err := GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaCSR := func(scheme cmapi.PrivateKeySignatureScheme) []byte {
+		template, err := GenerateCSR(&cmapi.Certificate{Spec: cmapi.CertificateSpec{
+			DNSNames:   []string{"example.com"},
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: cmapi.RSAKeyAlgorithm, SignatureScheme: scheme},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		der, err := EncodeCSR(template, pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+	}
+	specWithScheme := func(algorithm cmapi.PrivateKeyAlgorithm, scheme cmapi.PrivateKeySignatureScheme) cmapi.CertificateSpec {
+		return cmapi.CertificateSpec{
+			DNSNames:   []string{"example.com"},
+			PrivateKey: &cmapi.CertificatePrivateKey{Algorithm: algorithm, SignatureScheme: scheme},
+		}
+	}
+
+	tests := map[string]struct {
+		spec       cmapi.CertificateSpec
+		request    []byte
+		violations []string
+	}{
+		"should match if both the spec and request use PSS": {
+			spec:    specWithScheme(cmapi.RSAKeyAlgorithm, cmapi.PSSSignatureScheme),
+			request: rsaCSR(cmapi.PSSSignatureScheme),
+		},
+		"should match if neither the spec nor request use PSS": {
+			spec:    specWithScheme(cmapi.RSAKeyAlgorithm, ""),
+			request: rsaCSR(cmapi.PKCS1v15SignatureScheme),
+		},
+		"should not match if the spec requests PSS but the request uses PKCS1v15": {
+			spec:       specWithScheme(cmapi.RSAKeyAlgorithm, cmapi.PSSSignatureScheme),
+			request:    rsaCSR(""),
+			violations: []string{"spec.privateKey.signatureScheme"},
+		},
+		"should not match if the request uses PSS but the spec does not": {
+			spec:       specWithScheme(cmapi.RSAKeyAlgorithm, cmapi.PKCS1v15SignatureScheme),
+			request:    rsaCSR(cmapi.PSSSignatureScheme),
+			violations: []string{"spec.privateKey.signatureScheme"},
+		},
+		"should ignore the signature scheme for requests for ECDSA keys": {
+			spec:    specWithScheme(cmapi.ECDSAKeyAlgorithm, cmapi.PSSSignatureScheme),
+			request: mustGenerateCSR(t, cmapi.CertificateSpec{DNSNames: []string{"example.com"}}, nil),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			violations, err := RequestMatchesSpec(&cmapi.CertificateRequest{Spec: cmapi.CertificateRequestSpec{Request: test.request}}, test.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(violations, test.violations) {
+				t.Errorf("violations did not match, got=%s, exp=%s", violations, test.violations)
+			}
+		})
+	}
+}
+
+// mustGenerateCSR returns a PEM encoded CSR for the given spec. If rawSubject
+// is set, it is used as the subject of the CSR.
+func mustGenerateCSR(t *testing.T, spec cmapi.CertificateSpec, rawSubject []byte) []byte {
+	pk, err := GenerateECPrivateKey(ECCurve256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec.PrivateKey = &cmapi.CertificatePrivateKey{Algorithm: cmapi.ECDSAKeyAlgorithm}
+	template, err := GenerateCSR(&cmapi.Certificate{Spec: spec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rawSubject != nil {
+		template.RawSubject = rawSubject
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, template, pk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
 func selfSignCertificate(t *testing.T, spec cmapi.CertificateSpec) []byte {
 	pk, err := GenerateRSAPrivateKey(2048)
 	if err != nil {
@@ -177,64 +177,70 @@ func ParseSingleCertificateChainPEM(pembundle []byte) (PEMBundle, error) {
 // An error is returned if the passed bundle is not a valid flat tree chain,
 // the bundle is malformed, or the chain is broken.
 func ParseSingleCertificateChain(certs []*x509.Certificate) (PEMBundle, error) {
+	if len(certs) == 0 {
+		return PEMBundle{}, errors.NewInvalidData("no certificates given")
+	}
+
 	// De-duplicate certificates. This moves "complicated" logic away from
 	// consumers and into a shared function, who would otherwise have to do this
-	// anyway.
-	for i := 0; i < len(certs)-1; i++ {
-		for j := 1; j < len(certs); j++ {
-			if i == j {
-				continue
-			}
-			if certs[i].Equal(certs[j]) {
-				certs = append(certs[:j], certs[j+1:]...)
+	// anyway. A new slice is built so that the caller's slice is not modified.
+	var uniqueCerts []*x509.Certificate
+	for i, cert := range certs {
+		if cert == nil {
+			return PEMBundle{}, errors.NewInvalidData("certificate at index %d is nil", i)
+		}
+
+		duplicate := false
+		for _, unique := range uniqueCerts {
+			if unique.Equal(cert) {
+				duplicate = true
+				break
 			}
 		}
+		if !duplicate {
+			uniqueCerts = append(uniqueCerts, cert)
+		}
 	}
 
 	// A certificate chain can be well described as a linked list. Here we build
 	// multiple lists that contain a single node, each being a single certificate
 	// that was passed.
 	var chains []*chainNode
-	for i := range certs {
-		chains = append(chains, &chainNode{cert: certs[i]})
+	for i := range uniqueCerts {
+		chains = append(chains, &chainNode{cert: uniqueCerts[i]})
 	}
 
 	// The task is to build a single list which represents a single certificate
-	// chain. The strategy is to iteratively attempt to join items in the list to
-	// build this single chain. Once we have a single list, we have built the
-	// chain. If the number of lists do not decrease after a pass, then the list
-	// can never be reduced to a single chain and we error.
-	for {
-		// If a single list is left, then we have built the entire chain. Stop
-		// iterating.
-		if len(chains) == 1 {
-			break
-		}
-
-		// lastChainsLength is used to ensure that at every pass, the number of
-		// tested chains gets smaller.
-		lastChainsLength := len(chains)
-		for i := 0; i < len(chains)-1; i++ {
-			for j := 1; j < len(chains); j++ {
+	// chain. The strategy is to iteratively attempt to join two lists together
+	// until a single list is left, which is the entire chain. If no two lists
+	// can be joined, then the lists can never be reduced to a single chain and
+	// we error.
+	for len(chains) > 1 {
+		merged := false
+		for i := 0; i < len(chains) && !merged; i++ {
+			for j := 0; j < len(chains); j++ {
 				if i == j {
 					continue
 				}
 
 				// attempt to add both chains together
 				chain, ok := chains[i].tryMergeChain(chains[j])
-				if ok {
-					// If adding the chains together was successful, remove inner chain from
-					// list
-					chains = append(chains[:j], chains[j+1:]...)
+				if !ok {
+					continue
 				}
 
+				// If adding the chains together was successful, replace the
+				// outer chain with the merged one and remove the inner chain
+				// from the list. The lists are only ever merged pairwise so
+				// that no certificate is part of more than one list.
 				chains[i] = chain
+				chains = append(chains[:j], chains[j+1:]...)
+				merged = true
+				break
 			}
 		}
 
-		// If no chains were merged in this pass, the chain can never be built as a
-		// single list. Error.
-		if lastChainsLength == len(chains) {
+		if !merged {
 			return PEMBundle{}, errors.NewInvalidData("certificate chain is malformed or broken")
 		}
 	}
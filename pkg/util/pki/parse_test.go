@@ -25,12 +25,14 @@ import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/pem"
+	"errors"
 	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmerrors "github.com/cert-manager/cert-manager/pkg/util/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -454,3 +456,98 @@ func TestMustKeepOrderInRawDerBytes(t *testing.T) {
 	assert.Equal(t, expectedRdnSeq, rdnSeq)
 	assert.Equal(t, subject, rdnSeq.String())
 }
+
+func TestUnmarshalSubjectStringToRDNSequenceAttributeTypes(t *testing.T) {
+	tests := map[string]struct {
+		subject string
+		expRDNs pkix.RDNSequence
+		expErr  bool
+	}{
+		"lower case short names should be accepted": {
+			subject: "cn=foo,o=bar",
+			expRDNs: pkix.RDNSequence{
+				{{Type: OIDConstants.Organization, Value: "bar"}},
+				{{Type: OIDConstants.CommonName, Value: "foo"}},
+			},
+		},
+		"dotted decimal object identifiers should be accepted": {
+			subject: "2.5.4.3=foo,1.2.840.113549.1.9.1=a@example.com",
+			expRDNs: pkix.RDNSequence{
+				{{Type: asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}, Value: "a@example.com"}},
+				{{Type: OIDConstants.CommonName, Value: "foo"}},
+			},
+		},
+		"unknown short names should error": {
+			subject: "FOO=bar",
+			expErr:  true,
+		},
+		"object identifiers which cannot be DER encoded should error": {
+			subject: "3.1=foo",
+			expErr:  true,
+		},
+		"object identifiers with leading zeros should error": {
+			subject: "2.05.4.3=foo",
+			expErr:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rdns, err := UnmarshalSubjectStringToRDNSequence(test.subject)
+			if test.expErr {
+				var typeErr *UnknownAttributeTypeError
+				if !errors.As(err, &typeErr) {
+					t.Fatalf("expected an UnknownAttributeTypeError, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, test.expRDNs, rdns)
+		})
+	}
+}
+
+func TestMarshalRDNSequenceToSubjectString(t *testing.T) {
+	subjects := []string{
+		"CN=foo-long.com,OU=FooLong,O=Corp.,C=US",
+		"UID=jsmith,DC=example,DC=com",
+		"CN=foo+SERIALNUMBER=42,O=Corp\\, Inc.",
+		"1.2.840.113549.1.9.1=a@example.com,CN=foo",
+		"CN=\\ foo\\ ,O=\\#bar",
+	}
+
+	for _, subject := range subjects {
+		t.Run(subject, func(t *testing.T) {
+			rdns, err := UnmarshalSubjectStringToRDNSequence(subject)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, subject, MarshalRDNSequenceToSubjectString(rdns))
+		})
+	}
+}
+
+func TestParseSingleCertificateChainInvalidInput(t *testing.T) {
+	root := mustCreateBundle(t, nil, "root")
+	leaf := mustCreateBundle(t, root, "leaf")
+
+	if _, err := ParseSingleCertificateChain(nil); !cmerrors.IsInvalidData(err) {
+		t.Errorf("expected an invalid data error for no certificates, got: %v", err)
+	}
+
+	if _, err := ParseSingleCertificateChain([]*x509.Certificate{leaf.cert, nil}); !cmerrors.IsInvalidData(err) {
+		t.Errorf("expected an invalid data error for a nil certificate, got: %v", err)
+	}
+
+	certs := []*x509.Certificate{root.cert, leaf.cert, root.cert}
+	if _, err := ParseSingleCertificateChain(certs); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(certs, []*x509.Certificate{root.cert, leaf.cert, root.cert}) {
+		t.Errorf("expected the passed certificates not to be modified")
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pki
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/ssh"
+
+	v1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+)
+
+const (
+	// openSSHPrivateKeyMagic is the magic string at the start of private keys
+	// in the OpenSSH format.
+	openSSHPrivateKeyMagic = "openssh-key-v1\x00"
+
+	// openSSHPrivateKeyBlockSize is the block size the private section of
+	// unencrypted OpenSSH private keys is padded to.
+	openSSHPrivateKeyBlockSize = 8
+)
+
+// GeneratePrivateKeyForSSHCertificate will generate a private key suitable
+// for the provided cert-manager SSHCertificate resource. If no algorithm is
+// specified, an Ed25519 key is generated.
+func GeneratePrivateKeyForSSHCertificate(crt *v1.SSHCertificate) (crypto.Signer, error) {
+	privateKey := crt.Spec.PrivateKey
+	if privateKey == nil {
+		privateKey = &v1.SSHCertificatePrivateKey{}
+	}
+	switch privateKey.Algorithm {
+	case v1.PrivateKeyAlgorithm(""), v1.Ed25519KeyAlgorithm:
+		return GenerateEd25519PrivateKey()
+	case v1.RSAKeyAlgorithm:
+		keySize := MinRSAKeySize
+
+		if privateKey.Size > 0 {
+			keySize = privateKey.Size
+		}
+
+		return GenerateRSAPrivateKey(keySize)
+	case v1.ECDSAKeyAlgorithm:
+		keySize := ECCurve256
+
+		if privateKey.Size > 0 {
+			keySize = privateKey.Size
+		}
+
+		return GenerateECPrivateKey(keySize)
+	default:
+		return nil, fmt.Errorf("unsupported private key algorithm specified: %s", privateKey.Algorithm)
+	}
+}
+
+// EncodeOpenSSHPrivateKey will encode the given private key in the
+// unencrypted OpenSSH format, as written by `ssh-keygen`. Only RSA, ECDSA and
+// Ed25519 private keys are supported.
+func EncodeOpenSSHPrivateKey(pk crypto.Signer) ([]byte, error) {
+	sshPublicKey, err := ssh.NewPublicKey(pk.Public())
+	if err != nil {
+		return nil, fmt.Errorf("error converting public key: %w", err)
+	}
+
+	var keyFields []byte
+	switch k := pk.(type) {
+	case *rsa.PrivateKey:
+		if len(k.Primes) != 2 {
+			return nil, fmt.Errorf("RSA private keys with %d primes are not supported", len(k.Primes))
+		}
+		k.Precompute()
+		keyFields = ssh.Marshal(struct {
+			N    *big.Int
+			E    *big.Int
+			D    *big.Int
+			Iqmp *big.Int
+			P    *big.Int
+			Q    *big.Int
+		}{k.N, big.NewInt(int64(k.E)), k.D, k.Precomputed.Qinv, k.Primes[0], k.Primes[1]})
+	case *ecdsa.PrivateKey:
+		curve, err := openSSHCurveName(k.Curve)
+		if err != nil {
+			return nil, err
+		}
+		keyFields = ssh.Marshal(struct {
+			Curve string
+			Pub   []byte
+			D     *big.Int
+		}{curve, elliptic.Marshal(k.Curve, k.X, k.Y), k.D})
+	case ed25519.PrivateKey:
+		keyFields = ssh.Marshal(struct {
+			Pub  []byte
+			Priv []byte
+		}{k.Public().(ed25519.PublicKey), k})
+	default:
+		return nil, fmt.Errorf("unsupported private key type: %T", pk)
+	}
+
+	// Both check values must match for the key to be considered correctly
+	// decrypted. They are random so that the plaintext is not predictable.
+	var check [4]byte
+	if _, err := rand.Read(check[:]); err != nil {
+		return nil, fmt.Errorf("error generating check value: %w", err)
+	}
+	checkValue := binary.BigEndian.Uint32(check[:])
+
+	privateSection := ssh.Marshal(struct {
+		Check1  uint32
+		Check2  uint32
+		KeyType string
+		Rest    []byte `ssh:"rest"`
+	}{checkValue, checkValue, sshPublicKey.Type(), keyFields})
+	privateSection = append(privateSection, ssh.Marshal(struct{ Comment string }{""})...)
+	for i := byte(1); len(privateSection)%openSSHPrivateKeyBlockSize != 0; i++ {
+		privateSection = append(privateSection, i)
+	}
+
+	key := ssh.Marshal(struct {
+		CipherName   string
+		KdfName      string
+		KdfOpts      string
+		NumKeys      uint32
+		PubKey       []byte
+		PrivKeyBlock []byte
+	}{"none", "none", "", 1, sshPublicKey.Marshal(), privateSection})
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "OPENSSH PRIVATE KEY",
+		Bytes: append([]byte(openSSHPrivateKeyMagic), key...),
+	}), nil
+}
+
+func openSSHCurveName(curve elliptic.Curve) (string, error) {
+	switch curve {
+	case elliptic.P256():
+		return "nistp256", nil
+	case elliptic.P384():
+		return "nistp384", nil
+	case elliptic.P521():
+		return "nistp521", nil
+	}
+	return "", fmt.Errorf("unsupported elliptic curve: %s", curve.Params().Name)
+}
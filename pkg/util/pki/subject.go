@@ -20,6 +20,9 @@ import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/go-ldap/ldap/v3"
 )
@@ -64,6 +67,47 @@ var attributeTypeNames = map[string][]int{
 	"UID":          OIDConstants.UniqueIdentifier,
 }
 
+// UnknownAttributeTypeError is returned when a subject string contains an
+// attribute type which is neither one of the supported short names nor a
+// valid dotted decimal object identifier.
+type UnknownAttributeTypeError struct {
+	Type string
+}
+
+func (e *UnknownAttributeTypeError) Error() string {
+	return fmt.Sprintf("unknown attribute type %q in subject", e.Type)
+}
+
+// attributeTypeForName returns the object identifier for the given attribute
+// type. As per RFC 4514, short names are matched case-insensitively and the
+// type may also be given as a dotted decimal object identifier.
+func attributeTypeForName(name string) (asn1.ObjectIdentifier, error) {
+	if oid, ok := attributeTypeNames[strings.ToUpper(name)]; ok {
+		return oid, nil
+	}
+
+	components := strings.Split(name, ".")
+	if len(components) < 2 {
+		return nil, &UnknownAttributeTypeError{Type: name}
+	}
+
+	oid := make(asn1.ObjectIdentifier, len(components))
+	for i, component := range components {
+		n, err := strconv.Atoi(component)
+		if err != nil || n < 0 || (len(component) > 1 && component[0] == '0') {
+			return nil, &UnknownAttributeTypeError{Type: name}
+		}
+		oid[i] = n
+	}
+
+	// Only OIDs which can be DER encoded are accepted, see X.690 8.19.4.
+	if oid[0] > 2 || (oid[0] < 2 && oid[1] >= 40) {
+		return nil, &UnknownAttributeTypeError{Type: name}
+	}
+
+	return oid, nil
+}
+
 func UnmarshalSubjectStringToRDNSequence(subject string) (pkix.RDNSequence, error) {
 	dns, err := ldap.ParseDN(subject)
 	if err != nil {
@@ -78,8 +122,13 @@ func UnmarshalSubjectStringToRDNSequence(subject string) (pkix.RDNSequence, erro
 
 		atvs := make([]pkix.AttributeTypeAndValue, 0, len(ldapRelativeDN.Attributes))
 		for _, ldapATV := range ldapRelativeDN.Attributes {
+			oid, err := attributeTypeForName(ldapATV.Type)
+			if err != nil {
+				return nil, err
+			}
+
 			atvs = append(atvs, pkix.AttributeTypeAndValue{
-				Type:  attributeTypeNames[ldapATV.Type],
+				Type:  oid,
 				Value: ldapATV.Value,
 			})
 		}
@@ -104,6 +153,69 @@ func UnmarshalRawDerBytesToRDNSequence(der []byte) (rdnSequence pkix.RDNSequence
 	}
 }
 
+// MarshalRDNSequenceToSubjectString returns the given RDN sequence as an LDAP
+// formatted string, using the same attribute type names as
+// UnmarshalSubjectStringToRDNSequence. Attribute types without a short name
+// are written as dotted decimal object identifiers. Unlike
+// pkix.RDNSequence.String, the result can be parsed back into the same RDN
+// sequence.
+func MarshalRDNSequenceToSubjectString(rdnSequence pkix.RDNSequence) string {
+	var b strings.Builder
+
+	// RDNs in String format are written in reverse order, see
+	// UnmarshalSubjectStringToRDNSequence.
+	for i := range rdnSequence {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		rdn := rdnSequence[len(rdnSequence)-i-1]
+		for j, atv := range rdn {
+			if j > 0 {
+				b.WriteByte('+')
+			}
+			b.WriteString(attributeNameForType(atv.Type))
+			b.WriteByte('=')
+			b.WriteString(escapeAttributeValue(fmt.Sprint(atv.Value)))
+		}
+	}
+
+	return b.String()
+}
+
+// escapeAttributeValue escapes the given attribute value as per RFC 4514
+// section 2.4.
+func escapeAttributeValue(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case (i == 0 && (c == ' ' || c == '#')) || (i == len(value)-1 && c == ' '):
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c == '"' || c == '+' || c == ',' || c == ';' || c == '<' || c == '>' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c == 0x7f:
+			fmt.Fprintf(&b, "\\%02x", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// attributeNameForType returns the short name of the given attribute type, or
+// the dotted decimal object identifier if it has no short name.
+func attributeNameForType(oid asn1.ObjectIdentifier) string {
+	for name, nameOID := range attributeTypeNames {
+		if oid.Equal(nameOID) {
+			return name
+		}
+	}
+	return oid.String()
+}
+
 func ParseSubjectStringToRawDERBytes(subject string) ([]byte, error) {
 	rdnSequence, err := UnmarshalSubjectStringToRDNSequence(subject)
 	if err != nil {
@@ -44,3 +44,18 @@ func CertificateNextPrivateKeySecretName(name string) Func {
 		return *crt.Status.NextPrivateKeySecretName == name
 	}
 }
+
+// CertificateRemoteTargetKubeconfigSecretName returns a predicate that used to
+// filter Certificates to only those with the given
+// 'spec.remoteTarget.kubeconfigSecretRef.name'.
+// It is not possible to select Certificates without a remote target using
+// this predicate function.
+func CertificateRemoteTargetKubeconfigSecretName(name string) Func {
+	return func(obj runtime.Object) bool {
+		crt := obj.(*cmapi.Certificate)
+		if crt.Spec.RemoteTarget == nil {
+			return false
+		}
+		return crt.Spec.RemoteTarget.KubeconfigSecretRef.Name == name
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/utils/pointer"
 
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 )
 
 func TestCertificateSecretName(t *testing.T) {
@@ -92,3 +93,42 @@ func TestCertificateNextPrivateKeySecretName(t *testing.T) {
 		})
 	}
 }
+
+func TestCertificateRemoteTargetKubeconfigSecretName(t *testing.T) {
+	certWithKubeconfigSecretName := func(s string) *cmapi.Certificate {
+		return &cmapi.Certificate{
+			Spec: cmapi.CertificateSpec{RemoteTarget: &cmapi.CertificateRemoteTarget{
+				KubeconfigSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: s}},
+			}},
+		}
+	}
+	tests := map[string]struct {
+		secretName string
+		cert       *cmapi.Certificate
+		expected   bool
+	}{
+		"returns true if secret name matches": {
+			secretName: "abc",
+			cert:       certWithKubeconfigSecretName("abc"),
+			expected:   true,
+		},
+		"returns false if secret name does not match": {
+			secretName: "abc",
+			cert:       certWithKubeconfigSecretName("abcd"),
+			expected:   false,
+		},
+		"returns false if remote target is nil": {
+			secretName: "",
+			cert:       &cmapi.Certificate{},
+			expected:   false,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := CertificateRemoteTargetKubeconfigSecretName(test.secretName)(test.cert)
+			if got != test.expected {
+				t.Errorf("unexpected response: got=%t, exp=%t", got, test.expected)
+			}
+		})
+	}
+}
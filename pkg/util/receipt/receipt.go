@@ -0,0 +1,235 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package receipt implements the issuance receipts attached by the issuing
+// controller to each revision of a Certificate. A receipt is a JWS signed by a
+// cluster wide key, which attests that the certificate with the given serial
+// number and SANs was requested, approved and issued through cert-manager. It
+// can be verified offline using the public part of the signing key.
+package receipt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"time"
+
+	jose "gopkg.in/square/go-jose.v2"
+
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+// Receipt is the payload of an issuance receipt.
+type Receipt struct {
+	// Certificate is the namespace and name of the Certificate.
+	Certificate ObjectReference `json:"certificate"`
+	// Revision is the revision of the Certificate the receipt was created for.
+	Revision int `json:"revision"`
+	// CertificateRequest is the name of the CertificateRequest of the
+	// revision, in the namespace of the Certificate.
+	CertificateRequest string `json:"certificateRequest"`
+
+	// SerialNumber is the serial number of the issued certificate, formatted
+	// as a lowercase hexadecimal string.
+	SerialNumber   string   `json:"serialNumber"`
+	DNSNames       []string `json:"dnsNames,omitempty"`
+	IPAddresses    []string `json:"ipAddresses,omitempty"`
+	URIs           []string `json:"uris,omitempty"`
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+
+	// IssuerRef is the issuer which signed the certificate.
+	IssuerRef cmmeta.ObjectReference `json:"issuerRef"`
+	// Requester is the identity of the user which created the
+	// CertificateRequest.
+	Requester string `json:"requester,omitempty"`
+	// IssuedAt is the time at which the CertificateRequest became ready.
+	IssuedAt time.Time `json:"issuedAt"`
+}
+
+// ObjectReference is a reference to a namespaced object.
+type ObjectReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// New builds the receipt for the given revision of a Certificate, issued by
+// the given CertificateRequest. The time of issuance is that of the Ready
+// condition of the request, so that building the receipt of a revision again
+// results in the same payload. now is only used if the request has no Ready
+// condition.
+func New(crt *cmapi.Certificate, req *cmapi.CertificateRequest, cert *x509.Certificate, revision int, now time.Time) *Receipt {
+	r := &Receipt{
+		Certificate:        ObjectReference{Namespace: crt.Namespace, Name: crt.Name},
+		Revision:           revision,
+		CertificateRequest: req.Name,
+		SerialNumber:       cert.SerialNumber.Text(16),
+		DNSNames:           sortedCopy(cert.DNSNames),
+		IPAddresses:        sortedCopy(pki.IPAddressesToString(cert.IPAddresses)),
+		URIs:               sortedCopy(pki.URLsToString(cert.URIs)),
+		EmailAddresses:     sortedCopy(cert.EmailAddresses),
+		IssuerRef:          req.Spec.IssuerRef,
+		Requester:          req.Spec.Username,
+		IssuedAt:           now.UTC().Truncate(time.Second),
+	}
+
+	if cond := apiutil.GetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady); cond != nil && cond.LastTransitionTime != nil {
+		r.IssuedAt = cond.LastTransitionTime.UTC()
+	}
+
+	return r
+}
+
+// Sign signs the receipt with the given key, and returns the JWS in compact
+// serialization. RSA, ECDSA and Ed25519 keys are supported.
+func Sign(r *Receipt, key crypto.Signer) (string, error) {
+	alg, err := signatureAlgorithm(key)
+	if err != nil {
+		return "", err
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: alg, Key: key}, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create receipt signer: %w", err)
+	}
+
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+
+	jws, err := signer.Sign(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign receipt: %w", err)
+	}
+
+	return jws.CompactSerialize()
+}
+
+// Verify checks the signature of the given JWS using the given public key,
+// and returns the receipt it contains.
+func Verify(token string, publicKey crypto.PublicKey) (*Receipt, error) {
+	jws, err := jose.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse receipt: %w", err)
+	}
+
+	payload, err := jws.Verify(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receipt signature: %w", err)
+	}
+
+	var r Receipt
+	if err := json.Unmarshal(payload, &r); err != nil {
+		return nil, fmt.Errorf("failed to decode receipt: %w", err)
+	}
+
+	return &r, nil
+}
+
+// MatchesCertificate returns an error if the serial number or the SANs of the
+// given certificate differ from those of the receipt.
+func (r *Receipt) MatchesCertificate(cert *x509.Certificate) error {
+	if serial := cert.SerialNumber.Text(16); serial != r.SerialNumber {
+		return fmt.Errorf("serial number %q of the certificate does not match the serial number %q of the receipt", serial, r.SerialNumber)
+	}
+
+	for _, field := range []struct {
+		name            string
+		receipt, inCert []string
+	}{
+		{name: "DNS names", receipt: r.DNSNames, inCert: cert.DNSNames},
+		{name: "IP addresses", receipt: r.IPAddresses, inCert: pki.IPAddressesToString(cert.IPAddresses)},
+		{name: "URIs", receipt: r.URIs, inCert: pki.URLsToString(cert.URIs)},
+		{name: "email addresses", receipt: r.EmailAddresses, inCert: cert.EmailAddresses},
+	} {
+		if !stringSlicesEqual(sortedCopy(field.receipt), sortedCopy(field.inCert)) {
+			return fmt.Errorf("%s %v of the certificate do not match the %s %v of the receipt", field.name, field.inCert, field.name, field.receipt)
+		}
+	}
+
+	return nil
+}
+
+// DecodePublicKeyBytes decodes a PEM encoded PKIX public key, as written by
+// `openssl pkey -pubout`.
+func DecodePublicKeyBytes(keyBytes []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, fmt.Errorf("error decoding public key PEM block")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unexpected PEM block type %q, expected \"PUBLIC KEY\"", block.Type)
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+
+	return key, nil
+}
+
+func signatureAlgorithm(key crypto.Signer) (jose.SignatureAlgorithm, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jose.RS256, nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		case elliptic.P521():
+			return jose.ES512, nil
+		}
+		return "", fmt.Errorf("unsupported ECDSA curve %q for receipt signing key", k.Curve.Params().Name)
+	case ed25519.PrivateKey:
+		return jose.EdDSA, nil
+	}
+	return "", fmt.Errorf("unsupported receipt signing key type %T", key)
+}
+
+func sortedCopy(s []string) []string {
+	if len(s) == 0 {
+		return nil
+	}
+	c := make([]string, len(s))
+	copy(c, s)
+	sort.Strings(c)
+	return c
+}
+
+func stringSlicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
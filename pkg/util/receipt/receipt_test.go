@@ -0,0 +1,187 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package receipt
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/cert-manager/cert-manager/pkg/util/pki"
+)
+
+func TestNew(t *testing.T) {
+	readyTime := metav1.NewTime(time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC))
+	crt := &cmapi.Certificate{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "crt"}}
+	req := &cmapi.CertificateRequest{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "crt-2"},
+		Spec: cmapi.CertificateRequestSpec{
+			IssuerRef: cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+			Username:  "system:serviceaccount:cert-manager:cert-manager",
+		},
+		Status: cmapi.CertificateRequestStatus{
+			Conditions: []cmapi.CertificateRequestCondition{
+				{Type: cmapi.CertificateRequestConditionApproved, Status: cmmeta.ConditionTrue, Reason: "cert-manager.io", Message: "Certificate request has been approved by cert-manager.io"},
+				{Type: cmapi.CertificateRequestConditionReady, Status: cmmeta.ConditionTrue, Reason: cmapi.CertificateRequestReasonIssued, LastTransitionTime: &readyTime},
+			},
+		},
+	}
+
+	r := New(crt, req, testCertificate(t), 2, time.Now())
+
+	assert.Equal(t, &Receipt{
+		Certificate:        ObjectReference{Namespace: "ns", Name: "crt"},
+		Revision:           2,
+		CertificateRequest: "crt-2",
+		SerialNumber:       "1e240",
+		DNSNames:           []string{"a.example.com", "b.example.com"},
+		IPAddresses:        []string{"10.0.0.1"},
+		URIs:               []string{"spiffe://cluster.local/ns/ns/sa/app"},
+		EmailAddresses:     []string{"admin@example.com"},
+		IssuerRef:          cmmeta.ObjectReference{Name: "ca", Kind: "ClusterIssuer", Group: "cert-manager.io"},
+		Requester:          "system:serviceaccount:cert-manager:cert-manager",
+		IssuedAt:           readyTime.Time,
+	}, r)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	rsaKey, err := pki.GenerateRSAPrivateKey(2048)
+	require.NoError(t, err)
+	ecKey, err := pki.GenerateECPrivateKey(384)
+	require.NoError(t, err)
+	edKey, err := pki.GenerateEd25519PrivateKey()
+	require.NoError(t, err)
+	otherKey, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+
+	r := &Receipt{
+		Certificate:  ObjectReference{Namespace: "ns", Name: "crt"},
+		Revision:     1,
+		SerialNumber: "1e240",
+		DNSNames:     []string{"a.example.com"},
+		IssuedAt:     time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	for name, key := range map[string]crypto.Signer{"RSA": rsaKey, "ECDSA": ecKey, "Ed25519": edKey} {
+		t.Run(name, func(t *testing.T) {
+			token, err := Sign(r, key)
+			require.NoError(t, err)
+
+			verified, err := Verify(token, key.Public())
+			require.NoError(t, err)
+			assert.Equal(t, r, verified)
+
+			_, err = Verify(token, otherKey.Public())
+			assert.Error(t, err, "expected the receipt not to be verified by another key")
+
+			// Replace the payload of the JWS with another one.
+			parts := strings.Split(token, ".")
+			tampered, err := Sign(&Receipt{SerialNumber: "ff"}, otherKey)
+			require.NoError(t, err)
+			parts[1] = strings.Split(tampered, ".")[1]
+			_, err = Verify(strings.Join(parts, "."), key.Public())
+			assert.Error(t, err, "expected a tampered receipt not to be verified")
+		})
+	}
+}
+
+func TestMatchesCertificate(t *testing.T) {
+	cert := testCertificate(t)
+	base := func() *Receipt {
+		return &Receipt{
+			SerialNumber:   "1e240",
+			DNSNames:       []string{"a.example.com", "b.example.com"},
+			IPAddresses:    []string{"10.0.0.1"},
+			URIs:           []string{"spiffe://cluster.local/ns/ns/sa/app"},
+			EmailAddresses: []string{"admin@example.com"},
+		}
+	}
+
+	tests := map[string]struct {
+		mutate      func(r *Receipt)
+		expectedErr string
+	}{
+		"should match the certificate the receipt was created for": {
+			mutate: func(r *Receipt) {},
+		},
+		"should error if the serial number differs": {
+			mutate:      func(r *Receipt) { r.SerialNumber = "1" },
+			expectedErr: `serial number "1e240" of the certificate does not match the serial number "1" of the receipt`,
+		},
+		"should error if the DNS names differ": {
+			mutate:      func(r *Receipt) { r.DNSNames = []string{"a.example.com"} },
+			expectedErr: "DNS names [b.example.com a.example.com] of the certificate do not match the DNS names [a.example.com] of the receipt",
+		},
+		"should error if the IP addresses differ": {
+			mutate:      func(r *Receipt) { r.IPAddresses = nil },
+			expectedErr: "IP addresses [10.0.0.1] of the certificate do not match the IP addresses [] of the receipt",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := base()
+			test.mutate(r)
+			err := r.MatchesCertificate(cert)
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestDecodePublicKeyBytes(t *testing.T) {
+	key, err := pki.GenerateECPrivateKey(256)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	require.NoError(t, err)
+
+	pub, err := DecodePublicKeyBytes(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(pub))
+
+	keyPEM, err := pki.EncodePKCS8PrivateKey(key)
+	require.NoError(t, err)
+	_, err = DecodePublicKeyBytes(keyPEM)
+	assert.EqualError(t, err, `unexpected PEM block type "PRIVATE KEY", expected "PUBLIC KEY"`)
+}
+
+func testCertificate(t *testing.T) *x509.Certificate {
+	uri, err := url.Parse("spiffe://cluster.local/ns/ns/sa/app")
+	require.NoError(t, err)
+	return &x509.Certificate{
+		SerialNumber:   big.NewInt(123456),
+		DNSNames:       []string{"b.example.com", "a.example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+		URIs:           []*url.URL{uri},
+		EmailAddresses: []string{"admin@example.com"},
+	}
+}
@@ -27,25 +27,31 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 )
 
 type pluginInitializer struct {
-	externalClient    kubernetes.Interface
-	externalInformers informers.SharedInformerFactory
-	authorizer        authorizer.Authorizer
-	featureGates      featuregate.FeatureGate
+	externalClient       kubernetes.Interface
+	certManagerClient    cmclient.Interface
+	externalInformers    informers.SharedInformerFactory
+	certManagerInformers cminformers.SharedInformerFactory
+	authorizer           authorizer.Authorizer
+	featureGates         featuregate.FeatureGate
 }
 
 // New creates an instance of admission plugins initializer.
 // This constructor is public with a long param list so that callers immediately know that new information can be expected
 // during compilation when they update a level.
-func New(extClientset kubernetes.Interface, extInformers informers.SharedInformerFactory, authz authorizer.Authorizer, featureGates featuregate.FeatureGate) pluginInitializer {
+func New(extClientset kubernetes.Interface, cmClientset cmclient.Interface, extInformers informers.SharedInformerFactory, cmInformers cminformers.SharedInformerFactory, authz authorizer.Authorizer, featureGates featuregate.FeatureGate) pluginInitializer {
 	return pluginInitializer{
-		externalClient:    extClientset,
-		externalInformers: extInformers,
-		authorizer:        authz,
-		featureGates:      featureGates,
+		externalClient:       extClientset,
+		certManagerClient:    cmClientset,
+		externalInformers:    extInformers,
+		certManagerInformers: cmInformers,
+		authorizer:           authz,
+		featureGates:         featureGates,
 	}
 }
 
@@ -61,10 +67,18 @@ func (i pluginInitializer) Initialize(plugin admission.Interface) {
 		wants.SetExternalKubeClientSet(i.externalClient)
 	}
 
+	if wants, ok := plugin.(WantsCertManagerClientSet); ok {
+		wants.SetCertManagerClientSet(i.certManagerClient)
+	}
+
 	if wants, ok := plugin.(WantsExternalKubeInformerFactory); ok {
 		wants.SetExternalKubeInformerFactory(i.externalInformers)
 	}
 
+	if wants, ok := plugin.(WantsCertManagerInformerFactory); ok {
+		wants.SetCertManagerInformerFactory(i.certManagerInformers)
+	}
+
 	if wants, ok := plugin.(WantsAuthorizer); ok {
 		wants.SetAuthorizer(i.authorizer)
 	}
@@ -34,6 +34,9 @@ import (
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cmfake "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission/initializer"
 )
@@ -41,7 +44,7 @@ import (
 // TestWantsFeature ensures that the feature gates are injected
 // when the WantsFeatures interface is implemented by a plugin.
 func TestWantsFeatures(t *testing.T) {
-	target := initializer.New(nil, nil, nil, featuregate.NewFeatureGate())
+	target := initializer.New(nil, nil, nil, nil, nil, featuregate.NewFeatureGate())
 	wantFeaturesAdmission := &WantsFeaturesAdmission{}
 	target.Initialize(wantFeaturesAdmission)
 	if wantFeaturesAdmission.features == nil {
@@ -52,7 +55,7 @@ func TestWantsFeatures(t *testing.T) {
 // TestWantsAuthorizer ensures that the authorizer is injected
 // when the WantsAuthorizer interface is implemented by a plugin.
 func TestWantsAuthorizer(t *testing.T) {
-	target := initializer.New(nil, nil, &TestAuthorizer{}, nil)
+	target := initializer.New(nil, nil, nil, nil, &TestAuthorizer{}, nil)
 	wantAuthorizerAdmission := &WantAuthorizerAdmission{}
 	target.Initialize(wantAuthorizerAdmission)
 	if wantAuthorizerAdmission.auth == nil {
@@ -64,7 +67,7 @@ func TestWantsAuthorizer(t *testing.T) {
 // when the WantsExternalKubeClientSet interface is implemented by a plugin.
 func TestWantsExternalKubeClientSet(t *testing.T) {
 	cs := &fake.Clientset{}
-	target := initializer.New(cs, nil, &TestAuthorizer{}, nil)
+	target := initializer.New(cs, nil, nil, nil, &TestAuthorizer{}, nil)
 	wantExternalKubeClientSet := &WantExternalKubeClientSet{}
 	target.Initialize(wantExternalKubeClientSet)
 	if wantExternalKubeClientSet.cs != cs {
@@ -72,12 +75,24 @@ func TestWantsExternalKubeClientSet(t *testing.T) {
 	}
 }
 
+// TestWantsCertManagerClientSet ensures that the cert-manager clientset is
+// injected when the WantsCertManagerClientSet interface is implemented by a plugin.
+func TestWantsCertManagerClientSet(t *testing.T) {
+	cs := &cmfake.Clientset{}
+	target := initializer.New(nil, cs, nil, nil, &TestAuthorizer{}, nil)
+	wantCertManagerClientSet := &WantCertManagerClientSet{}
+	target.Initialize(wantCertManagerClientSet)
+	if wantCertManagerClientSet.cs != cs {
+		t.Errorf("expected clientset to be initialized")
+	}
+}
+
 // TestWantsExternalKubeInformerFactory ensures that the informer factory is injected
 // when the WantsExternalKubeInformerFactory interface is implemented by a plugin.
 func TestWantsExternalKubeInformerFactory(t *testing.T) {
 	cs := &fake.Clientset{}
 	sf := informers.NewSharedInformerFactory(cs, time.Duration(1)*time.Second)
-	target := initializer.New(cs, sf, &TestAuthorizer{}, nil)
+	target := initializer.New(cs, nil, sf, nil, &TestAuthorizer{}, nil)
 	wantExternalKubeInformerFactory := &WantExternalKubeInformerFactory{}
 	target.Initialize(wantExternalKubeInformerFactory)
 	if wantExternalKubeInformerFactory.sf != sf {
@@ -85,6 +100,19 @@ func TestWantsExternalKubeInformerFactory(t *testing.T) {
 	}
 }
 
+// TestWantsCertManagerInformerFactory ensures that the cert-manager informer factory is injected
+// when the WantsCertManagerInformerFactory interface is implemented by a plugin.
+func TestWantsCertManagerInformerFactory(t *testing.T) {
+	cs := &cmfake.Clientset{}
+	sf := cminformers.NewSharedInformerFactory(cs, time.Duration(1)*time.Second)
+	target := initializer.New(nil, cs, nil, sf, &TestAuthorizer{}, nil)
+	wantCertManagerInformerFactory := &WantCertManagerInformerFactory{}
+	target.Initialize(wantCertManagerInformerFactory)
+	if wantCertManagerInformerFactory.sf != sf {
+		t.Errorf("expected informer factory to be initialized")
+	}
+}
+
 // WantExternalKubeInformerFactory is a test stub that fulfills the WantsExternalKubeInformerFactory interface
 type WantExternalKubeInformerFactory struct {
 	sf informers.SharedInformerFactory
@@ -102,6 +130,23 @@ func (self *WantExternalKubeInformerFactory) ValidateInitialization() error
 var _ admission.Interface = &WantExternalKubeInformerFactory{}
 var _ initializer.WantsExternalKubeInformerFactory = &WantExternalKubeInformerFactory{}
 
+// WantCertManagerInformerFactory is a test stub that fulfills the WantsCertManagerInformerFactory interface
+type WantCertManagerInformerFactory struct {
+	sf cminformers.SharedInformerFactory
+}
+
+func (self *WantCertManagerInformerFactory) SetCertManagerInformerFactory(sf cminformers.SharedInformerFactory) {
+	self.sf = sf
+}
+func (self *WantCertManagerInformerFactory) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	return nil, nil
+}
+func (self *WantCertManagerInformerFactory) Handles(o admissionv1.Operation) bool { return false }
+func (self *WantCertManagerInformerFactory) ValidateInitialization() error        { return nil }
+
+var _ admission.Interface = &WantCertManagerInformerFactory{}
+var _ initializer.WantsCertManagerInformerFactory = &WantCertManagerInformerFactory{}
+
 // WantExternalKubeClientSet is a test stub that fulfills the WantsExternalKubeClientSet interface
 type WantExternalKubeClientSet struct {
 	cs kubernetes.Interface
@@ -119,6 +164,23 @@ func (self *WantExternalKubeClientSet) ValidateInitialization() error        { r
 var _ admission.Interface = &WantExternalKubeClientSet{}
 var _ initializer.WantsExternalKubeClientSet = &WantExternalKubeClientSet{}
 
+// WantCertManagerClientSet is a test stub that fulfills the WantsCertManagerClientSet interface
+type WantCertManagerClientSet struct {
+	cs cmclient.Interface
+}
+
+func (self *WantCertManagerClientSet) SetCertManagerClientSet(cs cmclient.Interface) {
+	self.cs = cs
+}
+func (self *WantCertManagerClientSet) Validate(ctx context.Context, request admissionv1.AdmissionRequest, oldObj, obj runtime.Object) (warnings []string, err error) {
+	return nil, nil
+}
+func (self *WantCertManagerClientSet) Handles(o admissionv1.Operation) bool { return false }
+func (self *WantCertManagerClientSet) ValidateInitialization() error        { return nil }
+
+var _ admission.Interface = &WantCertManagerClientSet{}
+var _ initializer.WantsCertManagerClientSet = &WantCertManagerClientSet{}
+
 // WantAuthorizerAdmission is a test stub that fulfills the WantsAuthorizer interface.
 type WantAuthorizerAdmission struct {
 	auth authorizer.Authorizer
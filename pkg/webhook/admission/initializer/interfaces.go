@@ -28,6 +28,8 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/component-base/featuregate"
 
+	cmclient "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	cminformers "github.com/cert-manager/cert-manager/pkg/client/informers/externalversions"
 	"github.com/cert-manager/cert-manager/pkg/webhook/admission"
 )
 
@@ -37,12 +39,24 @@ type WantsExternalKubeClientSet interface {
 	admission.InitializationValidator
 }
 
+// WantsCertManagerClientSet defines a function which sets the cert-manager ClientSet for admission plugins that need it
+type WantsCertManagerClientSet interface {
+	SetCertManagerClientSet(cmclient.Interface)
+	admission.InitializationValidator
+}
+
 // WantsExternalKubeInformerFactory defines a function which sets InformerFactory for admission plugins that need it
 type WantsExternalKubeInformerFactory interface {
 	SetExternalKubeInformerFactory(informers.SharedInformerFactory)
 	admission.InitializationValidator
 }
 
+// WantsCertManagerInformerFactory defines a function which sets the cert-manager InformerFactory for admission plugins that need it
+type WantsCertManagerInformerFactory interface {
+	SetCertManagerInformerFactory(cminformers.SharedInformerFactory)
+	admission.InitializationValidator
+}
+
 // WantsAuthorizer defines a function which sets Authorizer for admission plugins that need it.
 type WantsAuthorizer interface {
 	SetAuthorizer(authorizer.Authorizer)
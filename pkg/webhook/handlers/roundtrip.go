@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	apijson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+)
+
+// RoundTripDifference is a field which was not preserved when converting an
+// object to another API version and back again.
+type RoundTripDifference struct {
+	// APIVersion is the API version the object was converted to before being
+	// converted back to its original API version.
+	APIVersion string
+
+	// Path is the path of the field which differs, for example
+	// `spec.privateKey.algorithm`.
+	Path string
+
+	// Original is the value of the field in the original object, or nil if
+	// the field was not set.
+	Original interface{}
+
+	// RoundTripped is the value of the field in the round tripped object, or
+	// nil if the field was dropped.
+	RoundTripped interface{}
+}
+
+func (d RoundTripDifference) String() string {
+	switch {
+	case d.RoundTripped == nil:
+		return fmt.Sprintf("%s: field was dropped when converting to %s (was %s)", d.Path, d.APIVersion, formatValue(d.Original))
+	case d.Original == nil:
+		return fmt.Sprintf("%s: field was added when converting to %s (now %s)", d.Path, d.APIVersion, formatValue(d.RoundTripped))
+	default:
+		return fmt.Sprintf("%s: field was changed when converting to %s (was %s, now %s)", d.Path, d.APIVersion, formatValue(d.Original), formatValue(d.RoundTripped))
+	}
+}
+
+// VerifyRoundTrip converts the given object to each of the given API versions
+// and back to its original API version, and returns every field that was not
+// preserved by the conversions.
+// The object is first converted to its own API version so that fields which
+// are only set by defaulting are not reported as differences.
+func (c *SchemeBackedConverter) VerifyRoundTrip(object runtime.RawExtension, apiVersions []string) ([]RoundTripDifference, error) {
+	gvk, err := apijson.DefaultMetaFactory.Interpret(object.Raw)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to determine apiVersion of object: %v", err)
+	}
+	originalAPIVersion := gvk.GroupVersion().String()
+
+	normalized, err := c.convertObjects(originalAPIVersion, []runtime.RawExtension{object})
+	if err != nil {
+		return nil, err
+	}
+	original, err := unmarshalFields(normalized[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var differences []RoundTripDifference
+	for _, apiVersion := range apiVersions {
+		if apiVersion == originalAPIVersion {
+			continue
+		}
+
+		converted, err := c.convertObjects(apiVersion, normalized)
+		if err != nil {
+			return nil, err
+		}
+		roundTripped, err := c.convertObjects(originalAPIVersion, converted)
+		if err != nil {
+			return nil, err
+		}
+		fields, err := unmarshalFields(roundTripped[0])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, diff := range diffFields("", original, fields) {
+			diff.APIVersion = apiVersion
+			differences = append(differences, diff)
+		}
+	}
+
+	return differences, nil
+}
+
+func unmarshalFields(object runtime.RawExtension) (interface{}, error) {
+	var fields interface{}
+	if err := json.Unmarshal(object.Raw, &fields); err != nil {
+		return nil, fmt.Errorf("Failed to decode converted object: %v", err)
+	}
+	return fields, nil
+}
+
+// diffFields returns the paths of all fields which differ between the
+// decoded JSON values a and b.
+func diffFields(path string, a, b interface{}) []RoundTripDifference {
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		keys := make(map[string]struct{})
+		for k := range aMap {
+			keys[k] = struct{}{}
+		}
+		for k := range bMap {
+			keys[k] = struct{}{}
+		}
+		sortedKeys := make([]string, 0, len(keys))
+		for k := range keys {
+			sortedKeys = append(sortedKeys, k)
+		}
+		sort.Strings(sortedKeys)
+
+		var diffs []RoundTripDifference
+		for _, k := range sortedKeys {
+			fieldPath := k
+			if len(path) > 0 {
+				fieldPath = path + "." + k
+			}
+			diffs = append(diffs, diffFields(fieldPath, aMap[k], bMap[k])...)
+		}
+		return diffs
+	}
+
+	aSlice, aIsSlice := a.([]interface{})
+	bSlice, bIsSlice := b.([]interface{})
+	if aIsSlice && bIsSlice && len(aSlice) == len(bSlice) {
+		var diffs []RoundTripDifference
+		for i := range aSlice {
+			diffs = append(diffs, diffFields(path+"["+strconv.Itoa(i)+"]", aSlice[i], bSlice[i])...)
+		}
+		return diffs
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return nil
+	}
+
+	return []RoundTripDifference{{Path: path, Original: a, RoundTripped: b}}
+}
+
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
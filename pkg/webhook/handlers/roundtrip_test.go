@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2/klogr"
+
+	"github.com/cert-manager/cert-manager/pkg/webhook/handlers/testdata/apis/testgroup"
+	"github.com/cert-manager/cert-manager/pkg/webhook/handlers/testdata/apis/testgroup/install"
+)
+
+func TestVerifyRoundTrip(t *testing.T) {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+
+	c := NewSchemeBackedConverter(klogr.New(), scheme)
+
+	object := runtime.RawExtension{Raw: []byte(`
+{
+	"apiVersion": "testgroup.testing.cert-manager.io/v1",
+	"kind": "TestType",
+	"metadata": {
+		"name": "testing",
+		"namespace": "abc"
+	},
+	"testField": "foo",
+	"testFieldPtr": "bar"
+}
+`)}
+
+	differences, err := c.VerifyRoundTrip(object, []string{testgroup.GroupName + "/v1", testgroup.GroupName + "/v2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(differences) > 0 {
+		t.Errorf("expected no differences for a lossless conversion, got: %v", differences)
+	}
+
+	if _, err := c.VerifyRoundTrip(object, []string{"invalid/api/version"}); err == nil {
+		t.Errorf("expected an error for an invalid API version")
+	}
+}
+
+func TestDiffFields(t *testing.T) {
+	unmarshal := func(s string) interface{} {
+		var v interface{}
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	tests := map[string]struct {
+		a, b     string
+		expDiffs []RoundTripDifference
+	}{
+		"equal objects should have no differences": {
+			a:        `{"spec":{"dnsNames":["a","b"],"isCA":true}}`,
+			b:        `{"spec":{"isCA":true,"dnsNames":["a","b"]}}`,
+			expDiffs: nil,
+		},
+		"dropped, added and changed fields should be reported in order": {
+			a: `{"spec":{"literalSubject":"CN=foo","dnsNames":["a","b"],"privateKey":{"size":2048}}}`,
+			b: `{"spec":{"dnsNames":["a","c"],"privateKey":{"size":2048,"algorithm":"RSA"}}}`,
+			expDiffs: []RoundTripDifference{
+				{Path: "spec.dnsNames[1]", Original: "b", RoundTripped: "c"},
+				{Path: "spec.literalSubject", Original: "CN=foo", RoundTripped: nil},
+				{Path: "spec.privateKey.algorithm", Original: nil, RoundTripped: "RSA"},
+			},
+		},
+		"lists of different lengths should be reported as a whole": {
+			a: `{"spec":{"dnsNames":["a","b"]}}`,
+			b: `{"spec":{"dnsNames":["a"]}}`,
+			expDiffs: []RoundTripDifference{
+				{Path: "spec.dnsNames", Original: []interface{}{"a", "b"}, RoundTripped: []interface{}{"a"}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			diffs := diffFields("", unmarshal(test.a), unmarshal(test.b))
+			if !reflect.DeepEqual(diffs, test.expDiffs) {
+				t.Errorf("unexpected differences, exp=%v got=%v", test.expDiffs, diffs)
+			}
+		})
+	}
+}
@@ -111,15 +111,29 @@ type Server struct {
 	// Values are from tls package constants (https://golang.org/pkg/crypto/tls/#pkg-constants).
 	MinTLSVersion string
 
+	// InformerFactories are started when the server is run, and stopped
+	// when it exits. They provide the caches used by admission plugins.
+	InformerFactories []InformerFactory
+
 	listener net.Listener
 }
 
+// InformerFactory is a shared informer factory which can be started by the
+// server.
+type InformerFactory interface {
+	Start(stopCh <-chan struct{})
+}
+
 type handleFunc func(context.Context, runtime.Object) (runtime.Object, error)
 
 func (s *Server) Run(ctx context.Context) error {
 	s.log = logf.FromContext(ctx)
 	g, gctx := errgroup.WithContext(ctx)
 
+	for _, f := range s.InformerFactories {
+		f.Start(gctx.Done())
+	}
+
 	// if a HealthzAddr is provided, start the healthz listener
 	if s.HealthzAddr != "" {
 		healthzListener, err := net.Listen("tcp", s.HealthzAddr)
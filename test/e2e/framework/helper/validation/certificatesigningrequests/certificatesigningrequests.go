@@ -28,8 +28,8 @@ import (
 
 	certificatesv1 "k8s.io/api/certificates/v1"
 
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
 	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
-	experimentalapi "github.com/cert-manager/cert-manager/pkg/apis/experimental/v1alpha1"
 	ctrlutil "github.com/cert-manager/cert-manager/pkg/controller/certificatesigningrequests/util"
 	"github.com/cert-manager/cert-manager/pkg/util"
 	"github.com/cert-manager/cert-manager/pkg/util/pki"
@@ -192,7 +192,7 @@ func ExpectValidDuration(csr *certificatesv1.CertificateSigningRequest, _ crypto
 	}
 
 	var expectedDuration time.Duration
-	durationString, ok := csr.Annotations[experimentalapi.CertificateSigningRequestDurationAnnotationKey]
+	durationString, _, ok := apiutil.CertificateSigningRequestAnnotation(csr, cmapi.CertificateSigningRequestDurationAnnotationKey)
 	if !ok {
 		if csr.Spec.ExpirationSeconds != nil {
 			expectedDuration = time.Duration(*csr.Spec.ExpirationSeconds) * time.Second
@@ -320,10 +320,7 @@ func ExpectIsCA(csr *certificatesv1.CertificateSigningRequest, _ crypto.Signer)
 		return err
 	}
 
-	markedIsCA := false
-	if csr.Annotations[experimentalapi.CertificateSigningRequestIsCAAnnotationKey] == "true" {
-		markedIsCA = true
-	}
+	markedIsCA := pki.IsCAFromCertificateSigningRequest(csr)
 
 	if cert.IsCA != markedIsCA {
 		return fmt.Errorf("requested certificate does not match expected IsCA, exp=%t got=%t",
@@ -121,6 +121,7 @@ func TestAcmeOrdersController(t *testing.T) {
 	// Create a new orders controller.
 	ctrl, queue, mustSync := acmeorders.NewController(
 		logf.Log,
+		kubeClient,
 		cmCl,
 		factory,
 		cmFactory,
@@ -0,0 +1,248 @@
+/*
+Copyright 2023 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificates
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	featuregatetesting "k8s.io/component-base/featuregate/testing"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/cert-manager/cert-manager/internal/webhook/feature"
+	apiutil "github.com/cert-manager/cert-manager/pkg/api/util"
+	cmapi "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmeta "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	controllerpkg "github.com/cert-manager/cert-manager/pkg/controller"
+	"github.com/cert-manager/cert-manager/pkg/controller/certificates/issuing"
+	logf "github.com/cert-manager/cert-manager/pkg/logs"
+	"github.com/cert-manager/cert-manager/pkg/metrics"
+	utilfeature "github.com/cert-manager/cert-manager/pkg/util/feature"
+	utilpki "github.com/cert-manager/cert-manager/pkg/util/pki"
+	"github.com/cert-manager/cert-manager/test/integration/framework"
+	"github.com/cert-manager/cert-manager/test/internal/apiserver"
+	"github.com/cert-manager/cert-manager/test/unit/gen"
+)
+
+// TestIssuingController_RemoteTarget ensures that the issuing controller
+// writes the Secret of a Certificate with a remote target into a second API
+// server, and restores the remote Secret when it drifts.
+func TestIssuingController_RemoteTarget(t *testing.T) {
+	defer featuregatetesting.SetFeatureGateDuringTest(t, utilfeature.DefaultMutableFeatureGate, feature.RemoteSecretTargets, true)()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	config, stopFn := framework.RunControlPlane(t, ctx)
+	defer stopFn()
+
+	// Run a second, bare API server to act as the remote cluster.
+	remoteEnv, stopRemote := apiserver.RunBareControlPlane(t)
+	defer stopRemote()
+	remoteUser, err := remoteEnv.ControlPlane.AddUser(envtest.User{Name: "remote-user", Groups: []string{"system:masters"}}, remoteEnv.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteKubeconfig, err := remoteUser.KubeConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	remoteClient, err := kubernetes.NewForConfig(remoteEnv.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Build, instantiate and run the issuing controller.
+	kubeClient, factory, cmCl, cmFactory := framework.NewClients(t, config)
+	ctrl, queue, mustSync := issuing.NewController(logf.Log, kubeClient,
+		cmCl, factory, cmFactory, framework.NewEventRecorder(t), clock.RealClock{},
+		controllerpkg.CertificateOptions{}, "cert-manager-certificates-issuing-test")
+	c := controllerpkg.NewController(
+		ctx,
+		"issuing_test",
+		metrics.New(logf.Log, clock.RealClock{}),
+		ctrl.ProcessItem,
+		mustSync,
+		nil,
+		queue,
+	)
+	stopController := framework.StartInformersAndController(t, factory, cmFactory, c)
+	defer stopController()
+
+	var (
+		crtName                  = "testcrt"
+		revision                 = 1
+		namespace                = "testns"
+		remoteNamespace          = "edge"
+		nextPrivateKeySecretName = "next-private-key-test-crt"
+		secretName               = "test-crt-tls"
+	)
+
+	// Create the Namespaces in both clusters.
+	if _, err := kubeClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := remoteClient.CoreV1().Namespaces().Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: remoteNamespace}}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Store the kubeconfig of the remote cluster.
+	if _, err := kubeClient.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge-kubeconfig", Namespace: namespace},
+		Data:       map[string][]byte{"kubeconfig": remoteKubeconfig},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create and store a new private key
+	sk, err := utilpki.GenerateRSAPrivateKey(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	skBytes := utilpki.EncodePKCS1PrivateKey(sk)
+	if _, err := kubeClient.CoreV1().Secrets(namespace).Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: nextPrivateKeySecretName, Namespace: namespace},
+		Data:       map[string][]byte{corev1.TLSPrivateKeyKey: skBytes},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create Certificate
+	crt := gen.Certificate(crtName,
+		gen.SetCertificateNamespace(namespace),
+		gen.SetCertificateCommonName("my-common-name"),
+		gen.SetCertificateDNSNames("example.com"),
+		gen.SetCertificateKeyAlgorithm(cmapi.RSAKeyAlgorithm),
+		gen.SetCertificateKeySize(2048),
+		gen.SetCertificateSecretName(secretName),
+		gen.SetCertificateIssuer(cmmeta.ObjectReference{Name: "testissuer", Group: "foo.io", Kind: "Issuer"}),
+	)
+	crt.Spec.RemoteTarget = &cmapi.CertificateRemoteTarget{
+		KubeconfigSecretRef: cmmeta.SecretKeySelector{LocalObjectReference: cmmeta.LocalObjectReference{Name: "edge-kubeconfig"}, Key: "kubeconfig"},
+		Namespace:           remoteNamespace,
+	}
+	crt, err = cmCl.CertmanagerV1().Certificates(namespace).Create(ctx, crt, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a CSR and sign a certificate for it
+	csr, err := utilpki.GenerateCSR(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrDER, err := utilpki.EncodeCSR(csr, sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+	certTemplate, err := utilpki.GenerateTemplate(crt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPem, _, err := utilpki.SignCertificate(certTemplate, certTemplate, sk.Public(), sk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a ready CertificateRequest
+	req := gen.CertificateRequest(crtName,
+		gen.SetCertificateRequestNamespace(namespace),
+		gen.SetCertificateRequestCSR(csrPEM),
+		gen.SetCertificateRequestIssuer(crt.Spec.IssuerRef),
+		gen.SetCertificateRequestAnnotations(map[string]string{
+			cmapi.CertificateRequestRevisionAnnotationKey: fmt.Sprintf("%d", revision+1),
+		}),
+		gen.AddCertificateRequestOwnerReferences(*metav1.NewControllerRef(
+			crt,
+			cmapi.SchemeGroupVersion.WithKind("Certificate"),
+		)),
+	)
+	req, err = cmCl.CertmanagerV1().CertificateRequests(namespace).Create(ctx, req, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Status.CA = certPem
+	req.Status.Certificate = certPem
+	apiutil.SetCertificateRequestCondition(req, cmapi.CertificateRequestConditionReady, cmmeta.ConditionTrue, cmapi.CertificateRequestReasonIssued, "")
+	if _, err := cmCl.CertmanagerV1().CertificateRequests(namespace).UpdateStatus(ctx, req, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Add Issuing condition to Certificate
+	apiutil.SetCertificateCondition(crt, crt.Generation, cmapi.CertificateConditionIssuing, cmmeta.ConditionTrue, "", "")
+	crt.Status.NextPrivateKeySecretName = &nextPrivateKeySecretName
+	crt.Status.Revision = &revision
+	if _, err := cmCl.CertmanagerV1().Certificates(namespace).UpdateStatus(ctx, crt, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// remoteSecretIssued returns true once the remote Secret holds the signed
+	// certificate and private key.
+	remoteSecretIssued := func() (bool, error) {
+		secret, err := remoteClient.CoreV1().Secrets(remoteNamespace).Get(ctx, secretName, metav1.GetOptions{})
+		if err != nil {
+			t.Logf("Failed to fetch remote Secret, retrying: %v", err)
+			return false, nil
+		}
+		if !bytes.Equal(secret.Data[corev1.TLSPrivateKeyKey], skBytes) ||
+			!bytes.Equal(secret.Data[corev1.TLSCertKey], certPem) ||
+			!bytes.Equal(secret.Data[cmmeta.TLSCAKey], certPem) {
+			t.Logf("Remote Secret does not have the expected data yet")
+			return false, nil
+		}
+		if v := secret.Annotations[cmapi.CertificateNameKey]; v != crtName {
+			return false, fmt.Errorf("expected remote Secret to have the annotation %s:%s, got %q", cmapi.CertificateNameKey, crtName, v)
+		}
+		return true, nil
+	}
+
+	// Wait for the Secret to be written to the remote cluster.
+	if err := wait.PollImmediateUntil(time.Millisecond*100, remoteSecretIssued, ctx.Done()); err != nil {
+		t.Fatalf("Failed to wait for the remote Secret to be issued: %v", err)
+	}
+
+	// Change the private key in the remote Secret, and expect it to be restored.
+	secret, err := remoteClient.CoreV1().Secrets(remoteNamespace).Get(ctx, secretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret.Data[corev1.TLSPrivateKeyKey] = []byte("drifted")
+	if _, err := remoteClient.CoreV1().Secrets(remoteNamespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := wait.PollImmediateUntil(time.Millisecond*100, remoteSecretIssued, ctx.Done()); err != nil {
+		t.Fatalf("Failed to wait for the remote Secret to be restored: %v", err)
+	}
+
+	// Delete the remote Secret, and expect it to be recreated.
+	if err := remoteClient.CoreV1().Secrets(remoteNamespace).Delete(ctx, secretName, metav1.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := wait.PollImmediateUntil(time.Millisecond*100, remoteSecretIssued, ctx.Done()); err != nil {
+		t.Fatalf("Failed to wait for the remote Secret to be recreated: %v", err)
+	}
+}
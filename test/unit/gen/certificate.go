@@ -171,6 +171,12 @@ func SetCertificateIssuanceAttempts(ia *int) CertificateModifier {
 	}
 }
 
+func SetCertificatePrivateKeyFallback(fallback v1.CertificatePrivateKeyFallback) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Status.PrivateKeyFallback = &fallback
+	}
+}
+
 func SetCertificateNotAfter(p metav1.Time) CertificateModifier {
 	return func(crt *v1.Certificate) {
 		crt.Status.NotAfter = &p
@@ -248,6 +254,18 @@ func AddCertificateLabels(labels map[string]string) CertificateModifier {
 	}
 }
 
+func AddCertificateFinalizers(finalizers ...string) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.Finalizers = append(crt.Finalizers, finalizers...)
+	}
+}
+
+func SetCertificateDeletionTimestamp(t metav1.Time) CertificateModifier {
+	return func(crt *v1.Certificate) {
+		crt.DeletionTimestamp = &t
+	}
+}
+
 // CertificateRef creates an owner reference for a certificate without having to
 // give the full certificate. Only use this function for testing purposes.
 //
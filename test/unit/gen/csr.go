@@ -176,6 +176,20 @@ func SetCSRCommonName(commonName string) CSRModifier {
 	}
 }
 
+// SetCSRLiteralSubject sets the subject of the CSR to the DER encoding of the
+// given LDAP formatted subject string, in the same way as the literalSubject
+// field of a Certificate.
+func SetCSRLiteralSubject(literalSubject string) CSRModifier {
+	return func(c *x509.CertificateRequest) error {
+		rawSubject, err := pki.ParseSubjectStringToRawDERBytes(literalSubject)
+		if err != nil {
+			return err
+		}
+		c.RawSubject = rawSubject
+		return nil
+	}
+}
+
 func SetCSREmails(emails []string) CSRModifier {
 	return func(c *x509.CertificateRequest) error {
 		c.EmailAddresses = emails
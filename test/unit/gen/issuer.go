@@ -374,6 +374,12 @@ func SetIssuerVenafi(a v1.VenafiIssuer) IssuerModifier {
 	}
 }
 
+func SetIssuerVenafiZonePolicy(p *v1.VenafiZonePolicy) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetStatus().Venafi = &v1.VenafiIssuerStatus{ZonePolicy: p}
+	}
+}
+
 func AddIssuerCondition(c v1.IssuerCondition) IssuerModifier {
 	return func(iss v1.GenericIssuer) {
 		iss.GetStatus().Conditions = append(iss.GetStatus().Conditions, c)
@@ -385,3 +391,9 @@ func SetIssuerNamespace(namespace string) IssuerModifier {
 		iss.GetObjectMeta().Namespace = namespace
 	}
 }
+
+func SetIssuerAnnotations(annotations map[string]string) IssuerModifier {
+	return func(iss v1.GenericIssuer) {
+		iss.GetObjectMeta().Annotations = annotations
+	}
+}